@@ -17,15 +17,36 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	ErrNoNewBlocks         = errors.New("no new blocks since the previous archive")
+	ErrPreviousArchiveFork = errors.New("the chain of the node doesn't contain the latest block of the previous archive")
+)
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
-// and save this data as binary archive to given path
+// and save this data as binary archive to given path.
+// If the path of a previous archive is given, the backup is incremental:
+// it starts right after the latest block of the previous archive
 func CreateBackup(
 	conn *grpc.ClientConn,
 	logger hclog.Logger,
 	from uint64,
 	to *uint64,
 	outPath string,
+	compression Compression,
+	previousPath string,
 ) (uint64, uint64, error) {
+	var previous *Metadata
+
+	if previousPath != "" {
+		metadata, err := ReadMetadata(previousPath)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to read previous archive, %w", err)
+		}
+
+		previous = metadata
+		from = previous.Latest + 1
+	}
+
 	// always create new file, throw error if the file exists
 	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
@@ -73,6 +94,30 @@ func CreateBackup(
 		return 0, 0, err
 	}
 
+	if reqTo < from {
+		closeAndRemoveFile()
+
+		if previous != nil {
+			return 0, 0, ErrNoNewBlocks
+		}
+
+		return 0, 0, fmt.Errorf("the node has no blocks from %d", from)
+	}
+
+	parentHash, err := determineParentHash(ctx, clt, from)
+	if err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	if previous != nil && parentHash != previous.LatestHash {
+		closeAndRemoveFile()
+
+		return 0, 0, fmt.Errorf("%w: expected %s at block %d, but got %s",
+			ErrPreviousArchiveFork, previous.LatestHash, previous.Latest, parentHash)
+	}
+
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
 		From: from,
 		To:   reqTo,
@@ -83,19 +128,50 @@ func CreateBackup(
 		return 0, 0, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
+	metadata := &Metadata{
+		Latest:      reqTo,
+		LatestHash:  reqToHash,
+		Version:     VersionChunked,
+		Compression: compression,
+		First:       from,
+		ParentHash:  parentHash,
+	}
+
+	if err := writeMetadata(fs, logger, metadata); err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	// the blocks are compressed, and the compressed data is checksummed in chunks
+	chunks := newChunkWriter(fs)
+
+	compressor, err := newCompressor(chunks, compression)
+	if err != nil {
 		closeAndRemoveFile()
 
 		return 0, 0, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
+	resFrom, resTo, err := processExportStream(stream, logger, compressor, from, reqTo)
 	if err != nil {
 		closeAndRemoveFile()
 
 		return 0, 0, err
 	}
 
+	if err := compressor.Close(); err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	if err := chunks.Close(); err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
 	if err := closeFile(); err != nil {
 		removeFile()
 
@@ -127,19 +203,38 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
+// determineParentHash returns the hash of the block preceding the first block of the backup
+func determineParentHash(ctx context.Context, clt proto.SystemClient, from uint64) (types.Hash, error) {
+	if from == 0 {
+		return types.ZeroHash, nil
+	}
+
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: from - 1})
+	if err != nil {
+		return types.ZeroHash, err
 	}
 
+	block := types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return block.Hash(), nil
+}
+
+// writeMetadata writes the metadata of the backup to the writer
+func writeMetadata(writer io.Writer, logger hclog.Logger, metadata *Metadata) error {
 	_, err := writer.Write(metadata.MarshalRLP())
 	if err != nil {
 		return err
 	}
 
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
+	logger.Info(
+		"Wrote metadata to backup",
+		"latest", metadata.Latest,
+		"hash", metadata.LatestHash,
+		"compression", metadata.Compression,
+	)
 
 	return err
 }
@@ -172,7 +267,11 @@ func processExportStream(
 		}
 
 		expectedTotal := event.Latest - targetFrom
-		progress := 100 * (float64(event.To) - float64(targetFrom)) / float64(expectedTotal)
+		progress := float64(100)
+
+		if expectedTotal > 0 {
+			progress = 100 * (float64(event.To) - float64(targetFrom)) / float64(expectedTotal)
+		}
 
 		logger.Info(
 			fmt.Sprintf("%d blocks are written", num),
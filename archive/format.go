@@ -0,0 +1,343 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// archiveChunkSize is the size of the (compressed) block data covered by a single checksum
+	archiveChunkSize = 1024 * 1024
+
+	// maxChunkSize is the biggest chunk accepted when reading an archive
+	maxChunkSize = 16 * archiveChunkSize
+
+	chunkSizeLength     = 4
+	chunkChecksumLength = sha256.Size
+)
+
+var (
+	ErrArchiveTruncated         = errors.New("archive is truncated")
+	ErrChunkChecksumMismatch    = errors.New("chunk checksum mismatch")
+	ErrMetadataChecksumMismatch = errors.New("metadata checksum mismatch")
+	ErrChunkTooBig              = errors.New("chunk is too big")
+	ErrUnsupportedVersion       = errors.New("unsupported archive version")
+	ErrMissingMetadata          = errors.New("expected metadata in archive but doesn't exist")
+)
+
+// chunkWriter splits the written data into chunks, each one followed by its SHA-256 checksum.
+// A chunk is laid out as <4 bytes big endian size><data><checksum>,
+// and the end of the data is marked by an empty chunk
+type chunkWriter struct {
+	output io.Writer
+	buffer []byte
+	chunks uint64
+}
+
+func newChunkWriter(output io.Writer) *chunkWriter {
+	return &chunkWriter{
+		output: output,
+		buffer: make([]byte, 0, archiveChunkSize),
+	}
+}
+
+// Write buffers the data and writes the full chunks to the output
+func (c *chunkWriter) Write(data []byte) (int, error) {
+	written := len(data)
+
+	for len(data) > 0 {
+		size := archiveChunkSize - len(c.buffer)
+		if size > len(data) {
+			size = len(data)
+		}
+
+		c.buffer = append(c.buffer, data[:size]...)
+		data = data[size:]
+
+		if len(c.buffer) == archiveChunkSize {
+			if err := c.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// flush writes the buffered data as a chunk
+func (c *chunkWriter) flush() error {
+	if len(c.buffer) == 0 {
+		return nil
+	}
+
+	if err := writeChunk(c.output, c.buffer); err != nil {
+		return err
+	}
+
+	c.buffer = c.buffer[:0]
+	c.chunks++
+
+	return nil
+}
+
+// Close writes the remaining data and the end marker
+func (c *chunkWriter) Close() error {
+	if err := c.flush(); err != nil {
+		return err
+	}
+
+	return writeChunk(c.output, nil)
+}
+
+func writeChunk(output io.Writer, data []byte) error {
+	header := make([]byte, chunkSizeLength)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+
+	if _, err := output.Write(header); err != nil {
+		return err
+	}
+
+	// the end marker has no checksum
+	if len(data) == 0 {
+		return nil
+	}
+
+	if _, err := output.Write(data); err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(data)
+	_, err := output.Write(checksum[:])
+
+	return err
+}
+
+// chunkReader reads the data written by chunkWriter, verifying the checksum of every chunk
+type chunkReader struct {
+	input  io.Reader
+	chunk  []byte
+	offset int
+	done   bool
+	chunks uint64
+}
+
+func newChunkReader(input io.Reader) *chunkReader {
+	return &chunkReader{
+		input: input,
+	}
+}
+
+// Read implements the io.Reader interface
+func (c *chunkReader) Read(data []byte) (int, error) {
+	for c.offset == len(c.chunk) {
+		if c.done {
+			return 0, io.EOF
+		}
+
+		if err := c.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(data, c.chunk[c.offset:])
+	c.offset += n
+
+	return n, nil
+}
+
+// nextChunk loads and verifies the next chunk from input
+func (c *chunkReader) nextChunk() error {
+	header := make([]byte, chunkSizeLength)
+	if err := readFull(c.input, header); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size == 0 {
+		c.done = true
+		c.chunk, c.offset = c.chunk[:0], 0
+
+		return nil
+	}
+
+	if size > maxChunkSize {
+		return fmt.Errorf("%w: chunk %d has %d bytes", ErrChunkTooBig, c.chunks, size)
+	}
+
+	if cap(c.chunk) < int(size)+chunkChecksumLength {
+		c.chunk = make([]byte, int(size)+chunkChecksumLength)
+	}
+
+	buf := c.chunk[:int(size)+chunkChecksumLength]
+	if err := readFull(c.input, buf); err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(buf[:size])
+	if !bytes.Equal(checksum[:], buf[size:]) {
+		return fmt.Errorf("%w: chunk %d", ErrChunkChecksumMismatch, c.chunks)
+	}
+
+	c.chunk, c.offset = buf[:size], 0
+	c.chunks++
+
+	return nil
+}
+
+// readFull reads exactly len(buf) bytes, reporting a missing end as truncation
+func readFull(input io.Reader, buf []byte) error {
+	_, err := io.ReadFull(input, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrArchiveTruncated
+	}
+
+	return err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// newCompressor returns the writer compressing the data to output
+func newCompressor(output io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return nopWriteCloser{output}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(output), nil
+	case CompressionZstd:
+		return zstd.NewWriter(output)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCompression, compression)
+	}
+}
+
+// newDecompressor returns the reader decompressing the data from input
+func newDecompressor(input io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(input), nil
+	case CompressionGzip:
+		return gzip.NewReader(input)
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(input)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCompression, compression)
+	}
+}
+
+// archiveReader reads the metadata and the blocks of an archive file of any version
+type archiveReader struct {
+	metadata     *Metadata
+	blocks       *blockStream
+	chunks       *chunkReader // nil for legacy archives
+	decompressor io.ReadCloser
+	file         *os.File
+}
+
+// openArchive opens the archive file and reads its metadata
+func openArchive(filePath string) (*archiveReader, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := newArchiveReader(bufio.NewReader(fp))
+	if err != nil {
+		fp.Close()
+
+		return nil, err
+	}
+
+	reader.file = fp
+
+	return reader, nil
+}
+
+func newArchiveReader(input io.Reader) (*archiveReader, error) {
+	blocks := newBlockStream(input)
+
+	metadata, err := blocks.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, ErrMissingMetadata
+	}
+
+	reader := &archiveReader{
+		metadata: metadata,
+		blocks:   blocks,
+	}
+
+	switch metadata.GetVersion() {
+	case VersionLegacy:
+		return reader, nil
+	case VersionChunked:
+		reader.chunks = newChunkReader(input)
+
+		if reader.decompressor, err = newDecompressor(reader.chunks, metadata.Compression); err != nil {
+			return nil, err
+		}
+
+		// the blocks follow the metadata in the checksummed chunks
+		blocks.input = reader.decompressor
+
+		return reader, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, metadata.Version)
+	}
+}
+
+// chunkCount returns the number of chunks read so far
+func (r *archiveReader) chunkCount() uint64 {
+	if r.chunks == nil {
+		return 0
+	}
+
+	return r.chunks.chunks
+}
+
+// Close closes the decompressor and the archive file
+func (r *archiveReader) Close() error {
+	if r.decompressor != nil {
+		r.decompressor.Close()
+	}
+
+	if r.file != nil {
+		return r.file.Close()
+	}
+
+	return nil
+}
+
+// ReadMetadata reads the metadata of the archive file
+func ReadMetadata(filePath string) (*Metadata, error) {
+	reader, err := openArchive(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return reader.metadata, nil
+}
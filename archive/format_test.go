@@ -0,0 +1,202 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLinkedBlocks creates the blocks following the parent block
+func newLinkedBlocks(parent *types.Block, count int) []*types.Block {
+	linked := make([]*types.Block, count)
+
+	for i := range linked {
+		linked[i] = &types.Block{
+			Header: &types.Header{
+				Number:     parent.Number() + 1,
+				ParentHash: parent.Hash(),
+				ExtraData:  []byte{byte(i)},
+			},
+		}
+		linked[i].Header.ComputeHash()
+
+		parent = linked[i]
+	}
+
+	return linked
+}
+
+// encodeArchive encodes the blocks as an archive of the latest version
+func encodeArchive(t *testing.T, compression Compression, parentHash types.Hash, archiveBlocks []*types.Block) []byte {
+	t.Helper()
+
+	latest := archiveBlocks[len(archiveBlocks)-1]
+
+	return encodeArchiveWithMetadata(t, &Metadata{
+		Latest:      latest.Number(),
+		LatestHash:  latest.Hash(),
+		Version:     VersionChunked,
+		Compression: compression,
+		First:       archiveBlocks[0].Number(),
+		ParentHash:  parentHash,
+	}, archiveBlocks)
+}
+
+func encodeArchiveWithMetadata(t *testing.T, metadata *Metadata, archiveBlocks []*types.Block) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	buf.Write(metadata.MarshalRLP())
+
+	chunks := newChunkWriter(&buf)
+
+	compressor, err := newCompressor(chunks, metadata.Compression)
+	assert.NoError(t, err)
+
+	for _, block := range archiveBlocks {
+		_, err := compressor.Write(block.MarshalRLP())
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, compressor.Close())
+	assert.NoError(t, chunks.Close())
+
+	return buf.Bytes()
+}
+
+func writeArchiveFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Unable to write archive, %v", err)
+	}
+
+	return path
+}
+
+// readAllBlocks reads the encoded archive until the end
+func readAllBlocks(encoded []byte) error {
+	reader, err := newArchiveReader(bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+
+	for {
+		block, err := reader.blocks.nextBlock()
+		if err != nil || block == nil {
+			return err
+		}
+	}
+}
+
+func TestMetadata_LegacyEncoding(t *testing.T) {
+	t.Parallel()
+
+	legacy := &Metadata{
+		Latest:     blocks[2].Number(),
+		LatestHash: blocks[2].Hash(),
+	}
+
+	decoded := &Metadata{}
+	assert.NoError(t, decoded.UnmarshalRLP(legacy.MarshalRLP()))
+	assert.Equal(t, legacy, decoded)
+	assert.Equal(t, VersionLegacy, decoded.GetVersion())
+
+	chunked := &Metadata{
+		Latest:      blocks[2].Number(),
+		LatestHash:  blocks[2].Hash(),
+		Version:     VersionChunked,
+		Compression: CompressionZstd,
+		First:       blocks[0].Number(),
+		ParentHash:  genesis.Hash(),
+	}
+
+	decoded = &Metadata{}
+	assert.NoError(t, decoded.UnmarshalRLP(chunked.MarshalRLP()))
+	assert.Equal(t, chunked, decoded)
+}
+
+func TestChunkReader(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 2*archiveChunkSize+archiveChunkSize/2)
+	_, _ = rand.Read(data)
+
+	var buf bytes.Buffer
+
+	writer := newChunkWriter(&buf)
+	_, err := writer.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	encoded := buf.Bytes()
+
+	t.Run("should read all chunks", func(t *testing.T) {
+		t.Parallel()
+
+		reader := newChunkReader(bytes.NewReader(encoded))
+
+		read, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, data, read)
+		assert.Equal(t, uint64(3), reader.chunks)
+	})
+
+	t.Run("should detect corrupted chunk", func(t *testing.T) {
+		t.Parallel()
+
+		corrupted := append([]byte{}, encoded...)
+		corrupted[chunkSizeLength+archiveChunkSize+100]++
+
+		_, err := io.ReadAll(newChunkReader(bytes.NewReader(corrupted)))
+		assert.ErrorIs(t, err, ErrChunkChecksumMismatch)
+	})
+
+	t.Run("should detect missing end marker", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := io.ReadAll(newChunkReader(bytes.NewReader(encoded[:len(encoded)-chunkSizeLength])))
+		assert.ErrorIs(t, err, ErrArchiveTruncated)
+	})
+}
+
+func TestArchiveReader_Compression(t *testing.T) {
+	t.Parallel()
+
+	archiveBlocks := append([]*types.Block{genesis}, newLinkedBlocks(genesis, 100)...)
+
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		compression := compression
+
+		t.Run(compression.String(), func(t *testing.T) {
+			t.Parallel()
+
+			encoded := encodeArchive(t, compression, types.ZeroHash, archiveBlocks)
+
+			reader, err := newArchiveReader(bytes.NewReader(encoded))
+			assert.NoError(t, err)
+			assert.Equal(t, compression, reader.metadata.Compression)
+
+			for _, expected := range archiveBlocks {
+				block, err := reader.blocks.nextBlock()
+				assert.NoError(t, err)
+				assert.Equal(t, expected.Hash(), block.Hash())
+			}
+
+			block, err := reader.blocks.nextBlock()
+			assert.NoError(t, err)
+			assert.Nil(t, block)
+
+			// the archive is cut in the middle of the blocks
+			assert.ErrorIs(t, readAllBlocks(encoded[:len(encoded)/2]), ErrArchiveTruncated)
+		})
+	}
+}
@@ -22,31 +22,84 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) error
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
-func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
-	fp, err := os.Open(filePath)
+var (
+	ErrArchiveNotContinuous = errors.New("archive doesn't continue the previous archive")
+)
+
+// RestoreChain reads blocks from the archives and write to the chain.
+// Incremental archives are given after the archive they continue
+func RestoreChain(chain blockchainInterface, filePaths []string, progression *progress.ProgressionWrapper) error {
+	var previous *Metadata
+
+	for _, filePath := range filePaths {
+		metadata, err := restoreArchive(chain, filePath, previous, progression)
+		if err != nil {
+			return fmt.Errorf("unable to restore %s, %w", filePath, err)
+		}
+
+		previous = metadata
+	}
+
+	return nil
+}
+
+// restoreArchive writes the blocks of a single archive to the chain, and returns its metadata
+func restoreArchive(
+	chain blockchainInterface,
+	filePath string,
+	previous *Metadata,
+	progression *progress.ProgressionWrapper,
+) (*Metadata, error) {
+	reader, err := openArchive(filePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	blockStream := newBlockStream(fp)
+	defer reader.Close()
 
-	return importBlocks(chain, blockStream, progression)
-}
+	if previous != nil {
+		if err := checkContinuity(previous, reader.metadata); err != nil {
+			return nil, err
+		}
+	}
 
-// import blocks scans all blocks from stream and write them to chain
-func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
-	shutdownCh := common.GetTerminationSignalCh()
+	if err := importBlocks(chain, reader.metadata, reader.blocks, progression); err != nil {
+		return nil, err
+	}
 
-	metadata, err := blockStream.getMetadata()
-	if err != nil {
-		return err
+	return reader.metadata, nil
+}
+
+// checkContinuity checks the archive starts right after the previous archive.
+// Legacy archives don't store their first block, so only the chunked ones are checked
+func checkContinuity(previous, next *Metadata) error {
+	if next.GetVersion() == VersionLegacy {
+		return nil
 	}
 
-	if metadata == nil {
-		return errors.New("expected metadata in archive but doesn't exist")
+	if next.First != previous.Latest+1 || next.ParentHash != previous.LatestHash {
+		return fmt.Errorf(
+			"%w: expected block %d with parent %s, but archive starts at block %d with parent %s",
+			ErrArchiveNotContinuous,
+			previous.Latest+1,
+			previous.LatestHash,
+			next.First,
+			next.ParentHash,
+		)
 	}
 
+	return nil
+}
+
+// import blocks scans all blocks from stream and write them to chain
+func importBlocks(
+	chain blockchainInterface,
+	metadata *Metadata,
+	blockStream *blockStream,
+	progression *progress.ProgressionWrapper,
+) error {
+	shutdownCh := common.GetTerminationSignalCh()
+
 	// check whether the local chain has the latest block already
 	latestBlock, ok := chain.GetBlockByNumber(metadata.Latest, false)
 	if ok && latestBlock.Hash() == metadata.LatestHash {
@@ -215,7 +268,7 @@ func (b *blockStream) loadRLPArray() (uint64, error) {
 // loadRLPPrefix loads first byte of RLP encoded data from input
 func (b *blockStream) loadRLPPrefix() (byte, error) {
 	buf := b.buffer[:1]
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return 0, err
 	}
 
@@ -237,15 +290,13 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
+		_, err := io.ReadFull(b.input, payloadSizeBytes)
 
-		if err != nil {
-			return 0, 0, err
-		}
-
-		if uint64(n) < payloadSizeSize {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
 			// couldn't load required amount of bytes
 			return 0, 0, io.EOF
+		} else if err != nil {
+			return 0, 0, err
 		}
 
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
@@ -261,7 +312,8 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	// decompressors may return less than requested
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
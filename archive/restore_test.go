@@ -116,7 +116,10 @@ func Test_importBlocks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
 			blockStream := newTestBlockStream(tt.metadata, tt.archiveBlocks...)
-			err := importBlocks(tt.chain, blockStream, progression)
+			metadata, err := blockStream.getMetadata()
+			assert.NoError(t, err)
+
+			err = importBlocks(tt.chain, metadata, blockStream, progression)
 
 			assert.Equal(t, tt.err, err)
 			latestBlock := getLatestBlockFromMockChain(tt.chain)
@@ -125,6 +128,35 @@ func Test_importBlocks(t *testing.T) {
 	}
 }
 
+func TestRestoreChain_Incremental(t *testing.T) {
+	chainBlocks := newLinkedBlocks(genesis, 6)
+	full := writeArchiveFile(t, "full",
+		encodeArchive(t, CompressionZstd, types.ZeroHash, append([]*types.Block{genesis}, chainBlocks[:3]...)))
+	incremental := writeArchiveFile(t, "incremental",
+		encodeArchive(t, CompressionZstd, chainBlocks[2].Hash(), chainBlocks[3:]))
+
+	t.Run("should restore a chain of archives", func(t *testing.T) {
+		chain := &mockChain{
+			genesis: genesis,
+			blocks:  []*types.Block{},
+		}
+
+		err := RestoreChain(chain, []string{full, incremental}, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+		assert.NoError(t, err)
+		assert.Equal(t, chainBlocks, chain.blocks)
+	})
+
+	t.Run("should refuse archives out of order", func(t *testing.T) {
+		chain := &mockChain{
+			genesis: genesis,
+			blocks:  []*types.Block{},
+		}
+
+		err := RestoreChain(chain, []string{incremental, full}, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+		assert.ErrorIs(t, err, ErrArchiveNotContinuous)
+	})
+}
+
 func Test_consumeCommonBlocks(t *testing.T) {
 	newTestArchiveStream := func(blocks ...*types.Block) *blockStream {
 		var buf bytes.Buffer
@@ -0,0 +1,194 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// gzipSuffix is the file extension of the gzip compressed RLP files, as in geth
+const gzipSuffix = ".gz"
+
+var (
+	ErrNoBlocksInRange = errors.New("no blocks in the range")
+	ErrBlockNotFound   = errors.New("block not found")
+)
+
+type exportChain interface {
+	Header() *types.Header
+	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+}
+
+// ExportRLPFile writes the blocks in the range to the file as plain concatenated RLP blocks,
+// the format of geth export. The file is gzip compressed if its name ends with .gz.
+// The range ends at the latest block if to is nil
+func ExportRLPFile(chain exportChain, outPath string, from uint64, to *uint64) (uint64, uint64, error) {
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	resFrom, resTo, err := exportRLPToFile(chain, fs, outPath, from, to)
+	if closeErr := fs.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(outPath)
+
+		return 0, 0, err
+	}
+
+	return resFrom, resTo, nil
+}
+
+func exportRLPToFile(chain exportChain, fs *os.File, outPath string, from uint64, to *uint64) (uint64, uint64, error) {
+	buffered := bufio.NewWriter(fs)
+
+	var output io.WriteCloser = nopWriteCloser{buffered}
+	if strings.HasSuffix(outPath, gzipSuffix) {
+		output = gzip.NewWriter(buffered)
+	}
+
+	resFrom, resTo, err := ExportRLP(chain, output, from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := output.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return 0, 0, err
+	}
+
+	return resFrom, resTo, nil
+}
+
+// ExportRLP writes the blocks in the range to the writer as plain concatenated RLP blocks
+func ExportRLP(chain exportChain, output io.Writer, from uint64, to *uint64) (uint64, uint64, error) {
+	latest := chain.Header().Number
+	if to == nil || *to > latest {
+		to = &latest
+	}
+
+	if from > *to {
+		return 0, 0, fmt.Errorf("%w: %d-%d, latest block is %d", ErrNoBlocksInRange, from, *to, latest)
+	}
+
+	for number := from; number <= *to; number++ {
+		block, ok := chain.GetBlockByNumber(number, true)
+		if !ok {
+			return 0, 0, fmt.Errorf("%w: %d", ErrBlockNotFound, number)
+		}
+
+		if _, err := output.Write(block.MarshalRLP()); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return from, *to, nil
+}
+
+// ImportRLPFile writes the blocks of the RLP file to the chain, see ImportRLP.
+// The file is gzip decompressed if its name ends with .gz
+func ImportRLPFile(chain blockchainInterface, filePath string) (*ImportResult, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fp.Close()
+
+	var input io.Reader = bufio.NewReader(fp)
+
+	if strings.HasSuffix(filePath, gzipSuffix) {
+		decompressor, err := gzip.NewReader(input)
+		if err != nil {
+			return nil, err
+		}
+
+		defer decompressor.Close()
+
+		input = decompressor
+	}
+
+	return ImportRLP(chain, input)
+}
+
+// ImportResult is the outcome of an RLP import
+type ImportResult struct {
+	Skipped  uint64 // the blocks the chain already had
+	Imported uint64
+	First    uint64 // the first imported block
+	Last     uint64 // the last imported block
+}
+
+// ImportRLP writes the plain concatenated RLP blocks to the chain, skipping the blocks
+// the chain already has. The imported blocks are verified and executed the same way as
+// the blocks restored from an archive
+func ImportRLP(chain blockchainInterface, input io.Reader) (*ImportResult, error) {
+	shutdownCh := common.GetTerminationSignalCh()
+	blockStream := newBlockStream(input)
+	result := &ImportResult{}
+
+	for {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return result, fmt.Errorf("unable to read block, %w", err)
+		}
+
+		if block == nil {
+			return result, nil
+		}
+
+		if block.Number() == 0 {
+			if block.Hash() != chain.Genesis() {
+				return result, fmt.Errorf(
+					"the hash of genesis block (%s) does not match blockchain genesis (%s)",
+					block.Hash(),
+					chain.Genesis(),
+				)
+			}
+
+			result.Skipped++
+
+			continue
+		}
+
+		if chain.GetHashByNumber(block.Number()) == block.Hash() {
+			result.Skipped++
+
+			continue
+		}
+
+		if err := chain.VerifyFinalizedBlock(block); err != nil {
+			return result, fmt.Errorf("unable to verify block %d, %w", block.Number(), err)
+		}
+
+		if err := chain.WriteBlock(block); err != nil {
+			return result, fmt.Errorf("unable to write block %d, %w", block.Number(), err)
+		}
+
+		if result.Imported == 0 {
+			result.First = block.Number()
+		}
+
+		result.Last = block.Number()
+		result.Imported++
+
+		select {
+		case <-shutdownCh:
+			return result, nil
+		default:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package archive
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func (m *mockChain) Header() *types.Header {
+	if latest := getLatestBlockFromMockChain(m); latest != nil {
+		return latest.Header
+	}
+
+	return m.genesis.Header
+}
+
+func TestExportImportRLP(t *testing.T) {
+	t.Parallel()
+
+	chainBlocks := newLinkedBlocks(genesis, 10)
+	source := &mockChain{
+		genesis: genesis,
+		blocks:  append([]*types.Block{genesis}, chainBlocks...),
+	}
+
+	t.Run("should export plain concatenated blocks", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		to := uint64(3)
+		from, exportedTo, err := ExportRLP(source, &buf, 2, &to)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), from)
+		assert.Equal(t, uint64(3), exportedTo)
+		assert.Equal(t, append(chainBlocks[1].MarshalRLP(), chainBlocks[2].MarshalRLP()...), buf.Bytes())
+	})
+
+	t.Run("should refuse an empty range", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := ExportRLP(source, &bytes.Buffer{}, 11, nil)
+		assert.ErrorIs(t, err, ErrNoBlocksInRange)
+	})
+
+	t.Run("should import the missing blocks", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		_, _, err := ExportRLP(source, &buf, 0, nil)
+		assert.NoError(t, err)
+
+		target := &mockChain{
+			genesis: genesis,
+			blocks:  []*types.Block{genesis, chainBlocks[0], chainBlocks[1]},
+		}
+
+		result, err := ImportRLP(target, &buf)
+		assert.NoError(t, err)
+		assert.Equal(t, &ImportResult{Skipped: 3, Imported: 8, First: 3, Last: 10}, result)
+		assert.Equal(t, source.blocks, target.blocks)
+	})
+
+	t.Run("should refuse another genesis", func(t *testing.T) {
+		t.Parallel()
+
+		otherGenesis := &types.Block{
+			Header: &types.Header{
+				ExtraData: []byte("other"),
+			},
+		}
+		otherGenesis.Header.ComputeHash()
+
+		target := &mockChain{
+			genesis: otherGenesis,
+			blocks:  []*types.Block{otherGenesis},
+		}
+
+		_, err := ImportRLP(target, bytes.NewReader(genesis.MarshalRLP()))
+		assert.Error(t, err)
+	})
+
+	t.Run("should round trip a gzip file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "blocks.rlp.gz")
+
+		_, _, err := ExportRLPFile(source, path, 0, nil)
+		assert.NoError(t, err)
+
+		// the file is not overwritten
+		_, _, err = ExportRLPFile(source, path, 0, nil)
+		assert.Error(t, err)
+
+		target := &mockChain{
+			genesis: genesis,
+			blocks:  []*types.Block{},
+		}
+
+		result, err := ImportRLPFile(target, path)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), result.Imported)
+		assert.Equal(t, chainBlocks, target.blocks)
+	})
+}
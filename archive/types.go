@@ -1,16 +1,81 @@
 package archive
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
 )
 
+const (
+	// VersionLegacy is the version of the uncompressed archives without checksums
+	VersionLegacy uint64 = 1
+
+	// VersionChunked is the version of the archives whose blocks are
+	// stored in checksummed chunks, optionally compressed
+	VersionChunked uint64 = 2
+)
+
+// Compression is the compression algorithm of the archive blocks
+type Compression uint64
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZstd
+)
+
+var (
+	ErrUnknownCompression = errors.New("unknown compression")
+)
+
+var compressionNames = map[Compression]string{
+	CompressionNone: "none",
+	CompressionGzip: "gzip",
+	CompressionZstd: "zstd",
+}
+
+func (c Compression) String() string {
+	if name, ok := compressionNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint64(c))
+}
+
+// ParseCompression parses the name of the compression algorithm
+func ParseCompression(name string) (Compression, error) {
+	for compression, compressionName := range compressionNames {
+		if compressionName == name {
+			return compression, nil
+		}
+	}
+
+	return CompressionNone, fmt.Errorf("%w: %s", ErrUnknownCompression, name)
+}
+
 // Metadata is the data stored in the beginning of backup
 type Metadata struct {
 	Latest     uint64
 	LatestHash types.Hash
+
+	// The fields below are present from the VersionChunked archives
+	Version     uint64
+	Compression Compression
+	First       uint64     // the number of the first block in the archive
+	ParentHash  types.Hash // the hash of the block preceding the first block
+}
+
+// GetVersion returns the version of the archive format
+func (m *Metadata) GetVersion() uint64 {
+	if m.Version == 0 {
+		return VersionLegacy
+	}
+
+	return m.Version
 }
 
 // MarshalRLP returns RLP encoded bytes
@@ -23,16 +88,45 @@ func (m *Metadata) MarshalRLPTo(dst []byte) []byte {
 	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
 }
 
-// MarshalRLPWith appends own field into arena for encode
+// MarshalRLPWith appends own field into arena for encode.
+// The fields of chunked archives are followed by their checksum
 func (m *Metadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := m.marshalFieldsWith(arena)
+
+	if m.GetVersion() != VersionLegacy {
+		vv.Set(arena.NewBytes(m.checksum()))
+	}
+
+	return vv
+}
+
+// marshalFieldsWith appends the fields covered by the checksum into arena
+func (m *Metadata) marshalFieldsWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv := arena.NewArray()
 
 	vv.Set(arena.NewUint(m.Latest))
 	vv.Set(arena.NewBytes(m.LatestHash.Bytes()))
 
+	if m.GetVersion() == VersionLegacy {
+		return vv
+	}
+
+	vv.Set(arena.NewUint(m.Version))
+	vv.Set(arena.NewUint(uint64(m.Compression)))
+	vv.Set(arena.NewUint(m.First))
+	vv.Set(arena.NewBytes(m.ParentHash.Bytes()))
+
 	return vv
 }
 
+// checksum returns the SHA-256 checksum of the RLP encoded fields
+func (m *Metadata) checksum() []byte {
+	arena := &fastrlp.Arena{}
+	checksum := sha256.Sum256(m.marshalFieldsWith(arena).MarshalTo(nil))
+
+	return checksum[:]
+}
+
 // UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
 func (m *Metadata) UnmarshalRLP(input []byte) error {
 	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
@@ -57,5 +151,42 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	// legacy archives have no other fields
+	if len(elems) == 2 {
+		return nil
+	}
+
+	if len(elems) < 7 {
+		return fmt.Errorf("incorrect number of elements to decode Metadata, expected 7 but found %d", len(elems))
+	}
+
+	if m.Version, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	compression, err := elems[3].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	m.Compression = Compression(compression)
+
+	if m.First, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[5].GetHash(m.ParentHash[:]); err != nil {
+		return err
+	}
+
+	checksum, err := elems[6].Bytes()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(checksum, m.checksum()) {
+		return ErrMetadataChecksumMismatch
+	}
+
 	return nil
 }
@@ -0,0 +1,162 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrArchiveEmpty          = errors.New("archive has no blocks")
+	ErrUnexpectedBlockNumber = errors.New("unexpected block number")
+	ErrBrokenParentHash      = errors.New("block doesn't reference the previous block")
+	ErrLatestBlockMismatch   = errors.New("last block doesn't match the archive metadata")
+)
+
+// ArchiveSummary is the description of a verified archive
+type ArchiveSummary struct {
+	Path        string
+	Version     uint64
+	Compression Compression
+	From        uint64
+	To          uint64
+	ParentHash  types.Hash // the parent hash of the first block
+	LatestHash  types.Hash
+	Blocks      uint64
+	Chunks      uint64
+}
+
+// VerifyArchives checks the integrity of the archives without a running node:
+// the chunk checksums, the linkage of the blocks, the metadata,
+// and that every archive continues the previous one
+func VerifyArchives(filePaths []string) ([]ArchiveSummary, error) {
+	summaries := make([]ArchiveSummary, 0, len(filePaths))
+
+	for i, filePath := range filePaths {
+		summary, err := verifyArchive(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		if i > 0 {
+			previous := summaries[i-1]
+
+			if summary.From != previous.To+1 || summary.ParentHash != previous.LatestHash {
+				return nil, fmt.Errorf(
+					"%s: %w: expected block %d with parent %s, but archive starts at block %d with parent %s",
+					filePath,
+					ErrArchiveNotContinuous,
+					previous.To+1,
+					previous.LatestHash,
+					summary.From,
+					summary.ParentHash,
+				)
+			}
+		}
+
+		summaries = append(summaries, *summary)
+	}
+
+	return summaries, nil
+}
+
+// verifyArchive reads all the blocks of the archive and checks them against each other and the metadata
+func verifyArchive(filePath string) (*ArchiveSummary, error) {
+	reader, err := openArchive(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	metadata := reader.metadata
+	summary := &ArchiveSummary{
+		Path:        filePath,
+		Version:     metadata.GetVersion(),
+		Compression: metadata.Compression,
+	}
+
+	var last *types.Block
+
+	for {
+		block, err := reader.blocks.nextBlock()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read block %d, %w", summary.Blocks, err)
+		}
+
+		if block == nil {
+			break
+		}
+
+		if last == nil {
+			summary.ParentHash = block.ParentHash()
+			summary.From = block.Number()
+
+			if err := verifyFirstBlock(metadata, block); err != nil {
+				return nil, err
+			}
+		} else if err := verifyNextBlock(last, block); err != nil {
+			return nil, err
+		}
+
+		last = block
+		summary.Blocks++
+	}
+
+	if last == nil {
+		return nil, ErrArchiveEmpty
+	}
+
+	if last.Number() != metadata.Latest || last.Hash() != metadata.LatestHash {
+		return nil, fmt.Errorf(
+			"%w: expected block %d (%s), but got block %d (%s)",
+			ErrLatestBlockMismatch,
+			metadata.Latest,
+			metadata.LatestHash,
+			last.Number(),
+			last.Hash(),
+		)
+	}
+
+	summary.To = last.Number()
+	summary.LatestHash = last.Hash()
+	summary.Chunks = reader.chunkCount()
+
+	return summary, nil
+}
+
+// verifyFirstBlock checks the first block against the metadata of chunked archives
+func verifyFirstBlock(metadata *Metadata, block *types.Block) error {
+	if metadata.GetVersion() == VersionLegacy {
+		return nil
+	}
+
+	if block.Number() != metadata.First {
+		return fmt.Errorf("%w: expected first block %d, but got %d", ErrUnexpectedBlockNumber, metadata.First, block.Number())
+	}
+
+	if block.Number() > 0 && block.ParentHash() != metadata.ParentHash {
+		return fmt.Errorf("%w: first block %d", ErrBrokenParentHash, block.Number())
+	}
+
+	return nil
+}
+
+// verifyNextBlock checks the block follows the previous block
+func verifyNextBlock(previous, block *types.Block) error {
+	if block.Number() != previous.Number()+1 {
+		return fmt.Errorf(
+			"%w: expected block %d, but got %d",
+			ErrUnexpectedBlockNumber,
+			previous.Number()+1,
+			block.Number(),
+		)
+	}
+
+	if block.ParentHash() != previous.Hash() {
+		return fmt.Errorf("%w: block %d", ErrBrokenParentHash, block.Number())
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package archive
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyArchives(t *testing.T) {
+	t.Parallel()
+
+	chainBlocks := newLinkedBlocks(genesis, 20)
+	full := writeArchiveFile(t, "full",
+		encodeArchive(t, CompressionZstd, types.ZeroHash, append([]*types.Block{genesis}, chainBlocks[:10]...)))
+	incremental := writeArchiveFile(t, "incremental",
+		encodeArchive(t, CompressionGzip, chainBlocks[9].Hash(), chainBlocks[10:]))
+
+	t.Run("should verify a chain of archives", func(t *testing.T) {
+		t.Parallel()
+
+		summaries, err := VerifyArchives([]string{full, incremental})
+		assert.NoError(t, err)
+
+		if assert.Len(t, summaries, 2) {
+			assert.Equal(t, uint64(0), summaries[0].From)
+			assert.Equal(t, uint64(10), summaries[0].To)
+			assert.Equal(t, uint64(11), summaries[0].Blocks)
+			assert.Equal(t, uint64(11), summaries[1].From)
+			assert.Equal(t, uint64(20), summaries[1].To)
+			assert.Equal(t, chainBlocks[19].Hash(), summaries[1].LatestHash)
+			assert.Equal(t, CompressionGzip, summaries[1].Compression)
+		}
+	})
+
+	t.Run("should verify a legacy archive", func(t *testing.T) {
+		t.Parallel()
+
+		metadata := &Metadata{Latest: chainBlocks[1].Number(), LatestHash: chainBlocks[1].Hash()}
+		data := metadata.MarshalRLP()
+
+		for _, block := range []*types.Block{genesis, chainBlocks[0], chainBlocks[1]} {
+			data = append(data, block.MarshalRLP()...)
+		}
+
+		summaries, err := VerifyArchives([]string{writeArchiveFile(t, "legacy", data)})
+		assert.NoError(t, err)
+
+		if assert.Len(t, summaries, 1) {
+			assert.Equal(t, VersionLegacy, summaries[0].Version)
+			assert.Equal(t, uint64(3), summaries[0].Blocks)
+		}
+	})
+
+	t.Run("should refuse archives out of order", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifyArchives([]string{incremental, full})
+		assert.ErrorIs(t, err, ErrArchiveNotContinuous)
+	})
+
+	t.Run("should refuse a gap between archives", func(t *testing.T) {
+		t.Parallel()
+
+		gap := writeArchiveFile(t, "gap", encodeArchive(t, CompressionNone, chainBlocks[10].Hash(), chainBlocks[11:]))
+
+		_, err := VerifyArchives([]string{full, gap})
+		assert.ErrorIs(t, err, ErrArchiveNotContinuous)
+	})
+
+	t.Run("should refuse unlinked blocks", func(t *testing.T) {
+		t.Parallel()
+
+		unlinked := []*types.Block{chainBlocks[0], chainBlocks[2]}
+		path := writeArchiveFile(t, "unlinked", encodeArchive(t, CompressionNone, genesis.Hash(), unlinked))
+
+		_, err := VerifyArchives([]string{path})
+		assert.ErrorIs(t, err, ErrUnexpectedBlockNumber)
+	})
+
+	t.Run("should refuse a wrong latest block", func(t *testing.T) {
+		t.Parallel()
+
+		// the metadata references a block missing in the archive
+		metadata := &Metadata{
+			Latest:      chainBlocks[2].Number(),
+			LatestHash:  chainBlocks[2].Hash(),
+			Version:     VersionChunked,
+			Compression: CompressionNone,
+			First:       chainBlocks[0].Number(),
+			ParentHash:  genesis.Hash(),
+		}
+		path := writeArchiveFile(t, "latest", encodeArchiveWithMetadata(t, metadata, chainBlocks[:2]))
+
+		_, err := VerifyArchives([]string{path})
+		assert.ErrorIs(t, err, ErrLatestBlockMismatch)
+	})
+
+	t.Run("should refuse a corrupted metadata", func(t *testing.T) {
+		t.Parallel()
+
+		data := encodeArchive(t, CompressionNone, genesis.Hash(), chainBlocks[:2])
+
+		// the latest block of the metadata is changed
+		index := bytes.Index(data, chainBlocks[1].Hash().Bytes())
+		if assert.GreaterOrEqual(t, index, 0) {
+			data[index] ^= 0xff
+		}
+
+		_, err := VerifyArchives([]string{writeArchiveFile(t, "corrupted", data)})
+		assert.ErrorIs(t, err, ErrMetadataChecksumMismatch)
+	})
+}
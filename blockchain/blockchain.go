@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -38,6 +39,7 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidRewindTarget  = errors.New("rewind target is above the chain head")
 )
 
 // Blockchain is a blockchain reference
@@ -71,6 +73,8 @@ type Blockchain struct {
 	stream *eventStream // Event subscriptions
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
+
+	writeLock sync.Mutex // Serializes the writes of the blocks with the rewinds of the chain
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -84,6 +88,7 @@ type gasPriceAverage struct {
 type Verifier interface {
 	VerifyHeader(header *types.Header) error
 	ProcessHeaders(headers []*types.Header) error
+	RewindHeaders(number uint64) error
 	GetBlockCreator(header *types.Header) (types.Address, error)
 	PreStateCommit(header *types.Header, txn *state.Transition) error
 }
@@ -180,6 +185,7 @@ func (b *Blockchain) GetAvgGasPrice() *big.Int {
 func NewBlockchain(
 	logger hclog.Logger,
 	dataDir string,
+	backend storage.Backend,
 	config *chain.Chain,
 	consensus Verifier,
 	executor Executor,
@@ -206,8 +212,9 @@ func NewBlockchain(
 			return nil, err
 		}
 	} else {
-		if db, err = leveldb.NewLevelDBStorage(
+		if db, err = openStorage(
 			filepath.Join(dataDir, "blockchain"),
+			backend,
 			logger,
 		); err != nil {
 			return nil, err
@@ -226,6 +233,18 @@ func NewBlockchain(
 	return b, nil
 }
 
+// openStorage opens the blockchain database at the path with the backend
+func openStorage(path string, backend storage.Backend, logger hclog.Logger) (storage.Storage, error) {
+	switch backend {
+	case storage.LevelDB:
+		return leveldb.NewLevelDBStorage(path, logger)
+	case storage.Pebble:
+		return pebble.NewPebbleStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", storage.ErrUnknownBackend, backend)
+	}
+}
+
 // initCaches initializes the blockchain caches with the specified size
 func (b *Blockchain) initCaches(size int) error {
 	var err error
@@ -620,6 +639,9 @@ func (b *Blockchain) WriteHeaders(headers []*types.Header) error {
 
 // WriteHeadersWithBodies writes a batch of headers
 func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
 	// Check the size
 	if len(headers) == 0 {
 		return fmt.Errorf("passed in headers array is empty")
@@ -860,6 +882,9 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 // WriteBlock writes a single block to the local blockchain.
 // It doesn't do any kind of verification, only commits the block to the DB
 func (b *Blockchain) WriteBlock(block *types.Block) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
 	// Log the information
 	b.logger.Info(
 		"write block",
@@ -987,6 +1012,41 @@ func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return v, ok
 }
 
+// WriteValidatorHistory writes the validator history of the epoch kept by the consensus
+func (b *Blockchain) WriteValidatorHistory(epoch uint64, blob []byte) error {
+	return b.db.WriteValidatorHistory(epoch, blob)
+}
+
+// ReadValidatorHistory reads the validator history of the epoch kept by the consensus
+func (b *Blockchain) ReadValidatorHistory(epoch uint64) ([]byte, bool) {
+	return b.db.ReadValidatorHistory(epoch)
+}
+
+// ReadValidatorHistoryEpoch reads the latest epoch of the validator history
+func (b *Blockchain) ReadValidatorHistoryEpoch() (uint64, bool) {
+	return b.db.ReadValidatorHistoryEpoch()
+}
+
+// WriteValidatorVote writes the vote of the validator history of the epoch at the index
+func (b *Blockchain) WriteValidatorVote(epoch, index uint64, blob []byte) error {
+	return b.db.WriteValidatorVote(epoch, index, blob)
+}
+
+// ReadValidatorVote reads the vote of the validator history of the epoch at the index
+func (b *Blockchain) ReadValidatorVote(epoch, index uint64) ([]byte, bool) {
+	return b.db.ReadValidatorVote(epoch, index)
+}
+
+// WriteValidatorSetChange writes the validator set change of the validator history of the epoch at the index
+func (b *Blockchain) WriteValidatorSetChange(epoch, index uint64, blob []byte) error {
+	return b.db.WriteValidatorSetChange(epoch, index, blob)
+}
+
+// ReadValidatorSetChange reads the validator set change of the validator history of the epoch at the index
+func (b *Blockchain) ReadValidatorSetChange(epoch, index uint64) ([]byte, bool) {
+	return b.db.ReadValidatorSetChange(epoch, index)
+}
+
 // verifyGasLimit is a helper function for validating a gas limit in a header
 func (b *Blockchain) verifyGasLimit(header *types.Header, parentHeader *types.Header) error {
 	if header.GasUsed > header.GasLimit {
@@ -1235,6 +1295,117 @@ func (b *Blockchain) handleReorg(
 	return nil
 }
 
+// SetHead rewinds the chain head to the block with the given number.
+// The canonical mappings, the bodies, the receipts and the transaction lookups
+// of the blocks above it are deleted, the consensus rolls back its snapshots,
+// and the subscribers are notified with a reorg event holding the removed blocks
+func (b *Blockchain) SetHead(number uint64) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	currentHeader := b.Header()
+	if number > currentHeader.Number {
+		return fmt.Errorf("%w: %d > %d", ErrInvalidRewindTarget, number, currentHeader.Number)
+	}
+
+	if number == currentHeader.Number {
+		return nil
+	}
+
+	target, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return fmt.Errorf("header %d not found", number)
+	}
+
+	evnt := &Event{}
+
+	// the removed headers, from the current head down to the child of the target
+	removed := make([]*types.Header, 0, currentHeader.Number-number)
+
+	for header := currentHeader; header.Number > number; {
+		removed = append(removed, header)
+		evnt.AddOldHeader(header)
+
+		// the subscribers get the transactions and the logs of the removed blocks
+		// from the event, as their data is deleted below
+		evnt.RemovedBlocks = append(evnt.RemovedBlocks, b.readRemovedBlock(header))
+
+		if header, ok = b.readHeader(header.ParentHash); !ok {
+			return fmt.Errorf("parent of block %d not found", removed[len(removed)-1].Number)
+		}
+	}
+
+	diff, err := b.advanceHead(target)
+	if err != nil {
+		return err
+	}
+
+	if err := b.consensus.RewindHeaders(number); err != nil {
+		return err
+	}
+
+	evnt.AddNewHeader(target)
+	evnt.Type = EventReorg
+	evnt.SetDifficulty(diff)
+
+	b.dispatchEvent(evnt)
+
+	for _, header := range removed {
+		if err := b.deleteBlockData(header); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info("chain rewound", "number", number, "hash", target.Hash, "removed", len(removed))
+
+	return nil
+}
+
+// readRemovedBlock reads the body and the receipts of the block to be removed by a rewind.
+// They are empty for the headers written without a body
+func (b *Blockchain) readRemovedBlock(header *types.Header) *RemovedBlock {
+	removed := &RemovedBlock{
+		Block: &types.Block{
+			Header: header.Copy(),
+		},
+	}
+
+	if body, err := b.db.ReadBody(header.Hash); err == nil {
+		removed.Block.Transactions = body.Transactions
+		removed.Block.Uncles = body.Uncles
+	}
+
+	if receipts, err := b.db.ReadReceipts(header.Hash); err == nil {
+		removed.Receipts = receipts
+	}
+
+	return removed
+}
+
+// deleteBlockData removes the block from the canonical chain and deletes its body,
+// receipts and transaction lookups. The header is kept, as for the forks
+func (b *Blockchain) deleteBlockData(header *types.Header) error {
+	if body, ok := b.readBody(header.Hash); ok {
+		for _, txn := range body.Transactions {
+			if err := b.db.DeleteTxLookup(txn.Hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := b.db.DeleteBody(header.Hash); err != nil {
+		return err
+	}
+
+	if err := b.db.DeleteReceipts(header.Hash); err != nil {
+		return err
+	}
+
+	b.receiptsCache.Remove(header.Hash)
+
+	return b.db.DeleteCanonicalHash(header.Number)
+}
+
 // GetForks returns the forks
 func (b *Blockchain) GetForks() ([]types.Hash, error) {
 	return b.db.ReadForks()
@@ -997,3 +997,79 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	// the block 7 has a transaction and receipts
+	txn := &types.Transaction{
+		Nonce: 1,
+		Value: big.NewInt(10),
+		V:     big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	block := &types.Block{
+		Header:       headers[7],
+		Transactions: []*types.Transaction{txn},
+	}
+
+	assert.NoError(t, b.writeBody(block))
+	assert.NoError(t, b.db.WriteReceipts(headers[7].Hash, []*types.Receipt{{TxHash: txn.Hash}}))
+
+	var rewoundTo *uint64
+
+	verifier, _ := b.consensus.(*MockVerifier)
+	verifier.HookRewindHeaders(func(number uint64) error {
+		rewoundTo = &number
+
+		return nil
+	})
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	assert.ErrorIs(t, b.SetHead(10), ErrInvalidRewindTarget)
+	assert.NoError(t, b.SetHead(5))
+
+	assert.Equal(t, headers[5].Hash, b.Header().Hash)
+	assert.Equal(t, uint64(5), *rewoundTo)
+
+	for number := uint64(6); number < 10; number++ {
+		_, ok := b.GetHeaderByNumber(number)
+		assert.False(t, ok)
+	}
+
+	_, ok := b.ReadTxLookup(txn.Hash)
+	assert.False(t, ok)
+
+	_, ok = b.GetBodyByHash(headers[7].Hash)
+	assert.False(t, ok)
+
+	_, err := b.GetReceiptsByHash(headers[7].Hash)
+	assert.Error(t, err)
+
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Len(t, evnt.OldChain, 4)
+	assert.Equal(t, headers[9].Hash, evnt.OldChain[0].Hash)
+	assert.Equal(t, headers[6].Hash, evnt.OldChain[3].Hash)
+	assert.Equal(t, headers[5].Hash, evnt.Header().Hash)
+
+	// the deleted transactions and receipts are carried by the event
+	assert.Len(t, evnt.RemovedBlocks, 4)
+
+	removed, ok := evnt.GetRemovedBlock(headers[7].Hash)
+	if assert.True(t, ok) {
+		assert.Len(t, removed.Block.Transactions, 1)
+		assert.Equal(t, txn.Hash, removed.Block.Transactions[0].Hash)
+		assert.Len(t, removed.Receipts, 1)
+	}
+
+	// the chain grows again from the new head
+	assert.NoError(t, b.WriteHeaders(headers[6:8]))
+	assert.Equal(t, headers[7].Hash, b.Header().Hash)
+}
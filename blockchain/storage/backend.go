@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Backend is the key-value database holding the data of a data directory
+type Backend string
+
+const (
+	LevelDB Backend = "leveldb"
+	Pebble  Backend = "pebble"
+
+	// pebbleOptionsPrefix is the prefix of the options file, written only by Pebble
+	pebbleOptionsPrefix = "OPTIONS-"
+
+	// currentFile is the file pointing to the manifest, written by both LevelDB and Pebble
+	currentFile = "CURRENT"
+)
+
+var (
+	ErrUnknownBackend  = errors.New("unknown database backend")
+	ErrBackendMismatch = errors.New("database backend mismatch")
+)
+
+// ParseBackend returns the backend by its name
+func ParseBackend(name string) (Backend, error) {
+	switch backend := Backend(strings.ToLower(name)); backend {
+	case LevelDB, Pebble:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownBackend, name)
+	}
+}
+
+// DetectBackend returns the backend of the database at the path,
+// and false if there is no database
+func DetectBackend(path string) (Backend, bool, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	hasCurrent := false
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), pebbleOptionsPrefix) {
+			return Pebble, true, nil
+		}
+
+		if entry.Name() == currentFile {
+			hasCurrent = true
+		}
+	}
+
+	if hasCurrent {
+		return LevelDB, true, nil
+	}
+
+	return "", false, nil
+}
+
+// ResolveBackend returns the backend to open the database at the path with.
+// A new database is created with the configured backend, LevelDB if not set,
+// and an existing database must use the configured backend, if set
+func ResolveBackend(path string, configured Backend) (Backend, error) {
+	detected, exists, err := DetectBackend(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case !exists && configured == "":
+		return LevelDB, nil
+	case !exists:
+		return configured, nil
+	case configured == "" || configured == detected:
+		return detected, nil
+	default:
+		return "", fmt.Errorf(
+			"%w: %s uses %s, but %s is configured",
+			ErrBackendMismatch,
+			path,
+			detected,
+			configured,
+		)
+	}
+}
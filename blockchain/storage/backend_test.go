@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveBackend(t *testing.T) {
+	t.Parallel()
+
+	// writeFiles creates a database directory with the files
+	writeFiles := func(t *testing.T, names ...string) string {
+		t.Helper()
+
+		path := t.TempDir()
+
+		for _, name := range names {
+			assert.NoError(t, os.WriteFile(filepath.Join(path, name), nil, 0600))
+		}
+
+		return path
+	}
+
+	levelDBPath := writeFiles(t, "CURRENT", "LOG", "000001.log")
+	pebblePath := writeFiles(t, "CURRENT", "OPTIONS-000003", "000002.log")
+	missingPath := filepath.Join(t.TempDir(), "blockchain")
+
+	cases := []struct {
+		name       string
+		path       string
+		configured Backend
+		expected   Backend
+		err        error
+	}{
+		{"new database with default backend", missingPath, "", LevelDB, nil},
+		{"new database with configured backend", missingPath, Pebble, Pebble, nil},
+		{"leveldb database", levelDBPath, "", LevelDB, nil},
+		{"pebble database", pebblePath, "", Pebble, nil},
+		{"pebble database with matching backend", pebblePath, Pebble, Pebble, nil},
+		{"leveldb database with other backend", levelDBPath, Pebble, "", ErrBackendMismatch},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend, err := ResolveBackend(c.path, c.configured)
+
+			assert.ErrorIs(t, err, c.err)
+			assert.Equal(t, c.expected, backend)
+		})
+	}
+}
+
+func TestParseBackend(t *testing.T) {
+	t.Parallel()
+
+	backend, err := ParseBackend("Pebble")
+	assert.NoError(t, err)
+	assert.Equal(t, Pebble, backend)
+
+	_, err = ParseBackend("rocksdb")
+	assert.ErrorIs(t, err, ErrUnknownBackend)
+}
@@ -39,6 +39,9 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// VALIDATOR_HISTORY is the prefix for the IBFT validator history
+	VALIDATOR_HISTORY = []byte("v")
 )
 
 // Sub-prefixes
@@ -46,6 +49,8 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+	VOTE   = []byte("vote")
+	CHANGE = []byte("change")
 )
 
 // KV is a key value storage interface.
@@ -55,6 +60,7 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	Delete(p []byte) error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -96,6 +102,11 @@ func (s *KeyValueStorage) WriteCanonicalHash(n uint64, hash types.Hash) error {
 	return s.set(CANONICAL, s.encodeUint(n), hash.Bytes())
 }
 
+// DeleteCanonicalHash removes the number block from the canonical chain
+func (s *KeyValueStorage) DeleteCanonicalHash(n uint64) error {
+	return s.delete(CANONICAL, s.encodeUint(n))
+}
+
 // HEAD //
 
 // ReadHeadHash returns the hash of the head
@@ -221,6 +232,11 @@ func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 	return body, err
 }
 
+// DeleteBody deletes the body
+func (s *KeyValueStorage) DeleteBody(hash types.Hash) error {
+	return s.delete(BODY, hash.Bytes())
+}
+
 // SNAPSHOTS //
 
 // WriteSnapshot writes the snapshot to the DB
@@ -238,6 +254,63 @@ func (s *KeyValueStorage) ReadSnapshot(hash types.Hash) ([]byte, bool) {
 	return data, true
 }
 
+// VALIDATOR HISTORY //
+
+// WriteValidatorHistory writes the validator history of the epoch, without its votes
+// and validator set changes, and marks the epoch as the latest one of the history
+func (s *KeyValueStorage) WriteValidatorHistory(epoch uint64, blob []byte) error {
+	if err := s.set(VALIDATOR_HISTORY, s.encodeUint(epoch), blob); err != nil {
+		return err
+	}
+
+	return s.set(VALIDATOR_HISTORY, NUMBER, s.encodeUint(epoch))
+}
+
+// ReadValidatorHistory reads the validator history of the epoch
+func (s *KeyValueStorage) ReadValidatorHistory(epoch uint64) ([]byte, bool) {
+	return s.get(VALIDATOR_HISTORY, s.encodeUint(epoch))
+}
+
+// ReadValidatorHistoryEpoch reads the latest epoch of the validator history
+func (s *KeyValueStorage) ReadValidatorHistoryEpoch() (uint64, bool) {
+	data, ok := s.get(VALIDATOR_HISTORY, NUMBER)
+	if !ok {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteValidatorVote writes the vote of the epoch at the index, the votes are appended
+// to the history of the epoch as they're cast
+func (s *KeyValueStorage) WriteValidatorVote(epoch, index uint64, blob []byte) error {
+	return s.set(VALIDATOR_HISTORY, s.historyRecordKey(VOTE, epoch, index), blob)
+}
+
+// ReadValidatorVote reads the vote of the epoch at the index
+func (s *KeyValueStorage) ReadValidatorVote(epoch, index uint64) ([]byte, bool) {
+	return s.get(VALIDATOR_HISTORY, s.historyRecordKey(VOTE, epoch, index))
+}
+
+// WriteValidatorSetChange writes the validator set change of the epoch at the index,
+// the changes are appended to the history of the epoch as they're done
+func (s *KeyValueStorage) WriteValidatorSetChange(epoch, index uint64, blob []byte) error {
+	return s.set(VALIDATOR_HISTORY, s.historyRecordKey(CHANGE, epoch, index), blob)
+}
+
+// ReadValidatorSetChange reads the validator set change of the epoch at the index
+func (s *KeyValueStorage) ReadValidatorSetChange(epoch, index uint64) ([]byte, bool) {
+	return s.get(VALIDATOR_HISTORY, s.historyRecordKey(CHANGE, epoch, index))
+}
+
+// historyRecordKey returns the key of a record of the validator history of the epoch
+func (s *KeyValueStorage) historyRecordKey(kind []byte, epoch, index uint64) []byte {
+	key := append([]byte{}, kind...)
+	key = append(key, s.encodeUint(epoch)...)
+
+	return append(key, s.encodeUint(index)...)
+}
+
 // RECEIPTS //
 
 // WriteReceipts writes the receipts
@@ -255,6 +328,11 @@ func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error
 	return *receipts, err
 }
 
+// DeleteReceipts deletes the receipts
+func (s *KeyValueStorage) DeleteReceipts(hash types.Hash) error {
+	return s.delete(RECEIPTS, hash.Bytes())
+}
+
 // TX LOOKUP //
 
 // WriteTxLookup maps the transaction hash to the block hash
@@ -284,6 +362,11 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// DeleteTxLookup deletes the mapping of the transaction hash to the block hash
+func (s *KeyValueStorage) DeleteTxLookup(hash types.Hash) error {
+	return s.delete(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
@@ -363,6 +446,12 @@ func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 	return data, ok
 }
 
+func (s *KeyValueStorage) delete(p []byte, k []byte) error {
+	p = append(p, k...)
+
+	return s.db.Delete(p)
+}
+
 // Close closes the connection with the db
 func (s *KeyValueStorage) Close() error {
 	return s.db.Close()
@@ -59,6 +59,11 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Delete deletes the key-value pair in leveldb storage
+func (l *levelDBKV) Delete(p []byte) error {
+	return l.db.Delete(p, nil)
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
@@ -0,0 +1,77 @@
+package pebble
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// migrateBatchSize is the size of the written data after which the batch is committed
+const migrateBatchSize = 16 * 1024 * 1024
+
+// MigrateLevelDB copies all the key-value pairs of the LevelDB database into
+// a new Pebble database, and returns the number of copied pairs.
+// It works on the raw keys, so it's used for both the blockchain and the trie databases
+func MigrateLevelDB(srcPath, dstPath string) (uint64, error) {
+	src, err := leveldb.OpenFile(srcPath, &opt.Options{ErrorIfMissing: true, ReadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("unable to open %s, %w", srcPath, err)
+	}
+
+	defer src.Close()
+
+	dst, err := pebble.Open(dstPath, &pebble.Options{ErrorIfExists: true})
+	if err != nil {
+		return 0, fmt.Errorf("unable to create %s, %w", dstPath, err)
+	}
+
+	copied, err := copyPairs(src, dst)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	return copied, err
+}
+
+func copyPairs(src *leveldb.DB, dst *pebble.DB) (uint64, error) {
+	iter := src.NewIterator(nil, nil)
+	defer iter.Release()
+
+	var copied uint64
+
+	batch := dst.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+
+	for iter.Next() {
+		// the batch copies the key and the value
+		if err := batch.Set(iter.Key(), iter.Value(), nil); err != nil {
+			return copied, err
+		}
+
+		copied++
+
+		if len(batch.Repr()) >= migrateBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return copied, err
+			}
+
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return copied, err
+	}
+
+	// the last commit is synced, making the whole copy durable
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return copied, err
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,55 @@
+package pebble
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateLevelDB(t *testing.T) {
+	t.Parallel()
+
+	srcPath := filepath.Join(t.TempDir(), "blockchain")
+	dstPath := filepath.Join(t.TempDir(), "blockchain")
+
+	src, err := leveldb.NewLevelDBStorage(srcPath, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	header := &types.Header{Number: 5, ExtraData: []byte{0x1}}
+	header.ComputeHash()
+
+	assert.NoError(t, src.WriteHeader(header))
+	assert.NoError(t, src.WriteCanonicalHash(header.Number, header.Hash))
+	assert.NoError(t, src.WriteHeadHash(header.Hash))
+	assert.NoError(t, src.Close())
+
+	copied, err := MigrateLevelDB(srcPath, dstPath)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), copied)
+
+	backend, _, err := storage.DetectBackend(dstPath)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.Pebble, backend)
+
+	dst, err := NewPebbleStorage(dstPath, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	defer dst.Close()
+
+	hash, ok := dst.ReadCanonicalHash(header.Number)
+	assert.True(t, ok)
+	assert.Equal(t, header.Hash, hash)
+
+	readHeader, err := dst.ReadHeader(header.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, header.ExtraData, readHeader.ExtraData)
+
+	// the target must be a new database
+	_, err = MigrateLevelDB(srcPath, dstPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	return NewPebbleStorage(pathStr, logger)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	kv := &pebbleKV{db}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.NoSync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// the data is only valid until the closer is closed
+	value := make([]byte, len(data))
+	copy(value, data)
+
+	if err := closer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Delete deletes the key-value pair in pebble storage
+func (p *pebbleKV) Delete(k []byte) error {
+	return p.db.Delete(k, pebble.NoSync)
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
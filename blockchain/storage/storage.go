@@ -11,6 +11,7 @@ import (
 type Storage interface {
 	ReadCanonicalHash(n uint64) (types.Hash, bool)
 	WriteCanonicalHash(n uint64, hash types.Hash) error
+	DeleteCanonicalHash(n uint64) error
 
 	ReadHeadHash() (types.Hash, bool)
 	ReadHeadNumber() (uint64, bool)
@@ -30,15 +31,26 @@ type Storage interface {
 
 	WriteBody(hash types.Hash, body *types.Body) error
 	ReadBody(hash types.Hash) (*types.Body, error)
+	DeleteBody(hash types.Hash) error
 
 	WriteSnapshot(hash types.Hash, blob []byte) error
 	ReadSnapshot(hash types.Hash) ([]byte, bool)
 
+	WriteValidatorHistory(epoch uint64, blob []byte) error
+	ReadValidatorHistory(epoch uint64) ([]byte, bool)
+	ReadValidatorHistoryEpoch() (uint64, bool)
+	WriteValidatorVote(epoch, index uint64, blob []byte) error
+	ReadValidatorVote(epoch, index uint64) ([]byte, bool)
+	WriteValidatorSetChange(epoch, index uint64, blob []byte) error
+	ReadValidatorSetChange(epoch, index uint64) ([]byte, bool)
+
 	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
 	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
+	DeleteReceipts(hash types.Hash) error
 
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
+	DeleteTxLookup(hash types.Hash) error
 
 	Close() error
 }
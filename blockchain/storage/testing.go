@@ -49,6 +49,47 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testDelete(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testValidatorHistory(t, m)
+	})
+}
+
+func testDelete(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	blockHash := types.StringToHash("1")
+	txHash := types.StringToHash("2")
+
+	assert.NoError(t, s.WriteCanonicalHash(1, blockHash))
+	assert.NoError(t, s.WriteBody(blockHash, &types.Body{}))
+	assert.NoError(t, s.WriteReceipts(blockHash, []*types.Receipt{}))
+	assert.NoError(t, s.WriteTxLookup(txHash, blockHash))
+
+	assert.NoError(t, s.DeleteCanonicalHash(1))
+	assert.NoError(t, s.DeleteBody(blockHash))
+	assert.NoError(t, s.DeleteReceipts(blockHash))
+	assert.NoError(t, s.DeleteTxLookup(txHash))
+
+	_, ok := s.ReadCanonicalHash(1)
+	assert.False(t, ok)
+
+	_, err := s.ReadBody(blockHash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.ReadReceipts(blockHash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, ok = s.ReadTxLookup(txHash)
+	assert.False(t, ok)
+
+	// deleting missing data is not an error
+	assert.NoError(t, s.DeleteBody(blockHash))
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -223,6 +264,54 @@ func testForks(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testValidatorHistory(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadValidatorHistoryEpoch()
+	assert.False(t, ok)
+
+	assert.NoError(t, s.WriteValidatorHistory(1, []byte{0x1}))
+	assert.NoError(t, s.WriteValidatorHistory(2, []byte{0x2}))
+
+	epoch, ok := s.ReadValidatorHistoryEpoch()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), epoch)
+
+	// the epoch is rewritten after a rewind
+	assert.NoError(t, s.WriteValidatorHistory(1, []byte{0x3}))
+
+	epoch, _ = s.ReadValidatorHistoryEpoch()
+	assert.Equal(t, uint64(1), epoch)
+
+	blob, ok := s.ReadValidatorHistory(1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x3}, blob)
+
+	_, ok = s.ReadValidatorHistory(3)
+	assert.False(t, ok)
+
+	// the votes and the changes are kept apart from the history of the epoch
+	assert.NoError(t, s.WriteValidatorVote(1, 0, []byte{0x4}))
+	assert.NoError(t, s.WriteValidatorSetChange(1, 0, []byte{0x5}))
+
+	blob, ok = s.ReadValidatorVote(1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x4}, blob)
+
+	blob, ok = s.ReadValidatorSetChange(1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x5}, blob)
+
+	_, ok = s.ReadValidatorVote(1, 1)
+	assert.False(t, ok)
+
+	_, ok = s.ReadValidatorSetChange(2, 0)
+	assert.False(t, ok)
+}
+
 func testHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -471,35 +560,55 @@ type writeBodyDelegate func(types.Hash, *types.Body) error
 type readBodyDelegate func(types.Hash) (*types.Body, error)
 type writeSnapshotDelegate func(types.Hash, []byte) error
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
+type writeValidatorHistoryDelegate func(uint64, []byte) error
+type readValidatorHistoryDelegate func(uint64) ([]byte, bool)
+type readValidatorHistoryEpochDelegate func() (uint64, bool)
+type writeValidatorRecordDelegate func(uint64, uint64, []byte) error
+type readValidatorRecordDelegate func(uint64, uint64) ([]byte, bool)
 type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type deleteCanonicalHashDelegate func(uint64) error
+type deleteBodyDelegate func(types.Hash) error
+type deleteReceiptsDelegate func(types.Hash) error
+type deleteTxLookupDelegate func(types.Hash) error
 type closeDelegate func() error
 
 type MockStorage struct {
-	readCanonicalHashFn    readCanonicalHashDelegate
-	writeCanonicalHashFn   writeCanonicalHashDelegate
-	readHeadHashFn         readHeadHashDelegate
-	readHeadNumberFn       readHeadNumberDelegate
-	writeHeadHashFn        writeHeadHashDelegate
-	writeHeadNumberFn      writeHeadNumberDelegate
-	writeForksFn           writeForksDelegate
-	readForksFn            readForksDelegate
-	writeTotalDifficultyFn writeTotalDifficultyDelegate
-	readTotalDifficultyFn  readTotalDifficultyDelegate
-	writeHeaderFn          writeHeaderDelegate
-	readHeaderFn           readHeaderDelegate
-	writeCanonicalHeaderFn writeCanonicalHeaderDelegate
-	writeBodyFn            writeBodyDelegate
-	readBodyFn             readBodyDelegate
-	writeSnapshotFn        writeSnapshotDelegate
-	readSnapshotFn         readSnapshotDelegate
-	writeReceiptsFn        writeReceiptsDelegate
-	readReceiptsFn         readReceiptsDelegate
-	writeTxLookupFn        writeTxLookupDelegate
-	readTxLookupFn         readTxLookupDelegate
-	closeFn                closeDelegate
+	readCanonicalHashFn         readCanonicalHashDelegate
+	writeCanonicalHashFn        writeCanonicalHashDelegate
+	readHeadHashFn              readHeadHashDelegate
+	readHeadNumberFn            readHeadNumberDelegate
+	writeHeadHashFn             writeHeadHashDelegate
+	writeHeadNumberFn           writeHeadNumberDelegate
+	writeForksFn                writeForksDelegate
+	readForksFn                 readForksDelegate
+	writeTotalDifficultyFn      writeTotalDifficultyDelegate
+	readTotalDifficultyFn       readTotalDifficultyDelegate
+	writeHeaderFn               writeHeaderDelegate
+	readHeaderFn                readHeaderDelegate
+	writeCanonicalHeaderFn      writeCanonicalHeaderDelegate
+	writeBodyFn                 writeBodyDelegate
+	readBodyFn                  readBodyDelegate
+	writeSnapshotFn             writeSnapshotDelegate
+	readSnapshotFn              readSnapshotDelegate
+	writeValidatorHistoryFn     writeValidatorHistoryDelegate
+	readValidatorHistoryFn      readValidatorHistoryDelegate
+	readValidatorHistoryEpochFn readValidatorHistoryEpochDelegate
+	writeValidatorVoteFn        writeValidatorRecordDelegate
+	readValidatorVoteFn         readValidatorRecordDelegate
+	writeValidatorSetChangeFn   writeValidatorRecordDelegate
+	readValidatorSetChangeFn    readValidatorRecordDelegate
+	writeReceiptsFn             writeReceiptsDelegate
+	readReceiptsFn              readReceiptsDelegate
+	writeTxLookupFn             writeTxLookupDelegate
+	readTxLookupFn              readTxLookupDelegate
+	deleteCanonicalHashFn       deleteCanonicalHashDelegate
+	deleteBodyFn                deleteBodyDelegate
+	deleteReceiptsFn            deleteReceiptsDelegate
+	deleteTxLookupFn            deleteTxLookupDelegate
+	closeFn                     closeDelegate
 }
 
 func NewMockStorage() *MockStorage {
@@ -710,6 +819,90 @@ func (m *MockStorage) HookReadSnapshot(fn readSnapshotDelegate) {
 	m.readSnapshotFn = fn
 }
 
+func (m *MockStorage) WriteValidatorHistory(epoch uint64, blob []byte) error {
+	if m.writeValidatorHistoryFn != nil {
+		return m.writeValidatorHistoryFn(epoch, blob)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteValidatorHistory(fn writeValidatorHistoryDelegate) {
+	m.writeValidatorHistoryFn = fn
+}
+
+func (m *MockStorage) ReadValidatorHistory(epoch uint64) ([]byte, bool) {
+	if m.readValidatorHistoryFn != nil {
+		return m.readValidatorHistoryFn(epoch)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadValidatorHistory(fn readValidatorHistoryDelegate) {
+	m.readValidatorHistoryFn = fn
+}
+
+func (m *MockStorage) ReadValidatorHistoryEpoch() (uint64, bool) {
+	if m.readValidatorHistoryEpochFn != nil {
+		return m.readValidatorHistoryEpochFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadValidatorHistoryEpoch(fn readValidatorHistoryEpochDelegate) {
+	m.readValidatorHistoryEpochFn = fn
+}
+
+func (m *MockStorage) WriteValidatorVote(epoch, index uint64, blob []byte) error {
+	if m.writeValidatorVoteFn != nil {
+		return m.writeValidatorVoteFn(epoch, index, blob)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteValidatorVote(fn writeValidatorRecordDelegate) {
+	m.writeValidatorVoteFn = fn
+}
+
+func (m *MockStorage) ReadValidatorVote(epoch, index uint64) ([]byte, bool) {
+	if m.readValidatorVoteFn != nil {
+		return m.readValidatorVoteFn(epoch, index)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadValidatorVote(fn readValidatorRecordDelegate) {
+	m.readValidatorVoteFn = fn
+}
+
+func (m *MockStorage) WriteValidatorSetChange(epoch, index uint64, blob []byte) error {
+	if m.writeValidatorSetChangeFn != nil {
+		return m.writeValidatorSetChangeFn(epoch, index, blob)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteValidatorSetChange(fn writeValidatorRecordDelegate) {
+	m.writeValidatorSetChangeFn = fn
+}
+
+func (m *MockStorage) ReadValidatorSetChange(epoch, index uint64) ([]byte, bool) {
+	if m.readValidatorSetChangeFn != nil {
+		return m.readValidatorSetChangeFn(epoch, index)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadValidatorSetChange(fn readValidatorRecordDelegate) {
+	m.readValidatorSetChangeFn = fn
+}
+
 func (m *MockStorage) WriteReceipts(hash types.Hash, receipts []*types.Receipt) error {
 	if m.writeReceiptsFn != nil {
 		return m.writeReceiptsFn(hash, receipts)
@@ -758,6 +951,54 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) DeleteCanonicalHash(n uint64) error {
+	if m.deleteCanonicalHashFn != nil {
+		return m.deleteCanonicalHashFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteCanonicalHash(fn deleteCanonicalHashDelegate) {
+	m.deleteCanonicalHashFn = fn
+}
+
+func (m *MockStorage) DeleteBody(hash types.Hash) error {
+	if m.deleteBodyFn != nil {
+		return m.deleteBodyFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteBody(fn deleteBodyDelegate) {
+	m.deleteBodyFn = fn
+}
+
+func (m *MockStorage) DeleteReceipts(hash types.Hash) error {
+	if m.deleteReceiptsFn != nil {
+		return m.deleteReceiptsFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteReceipts(fn deleteReceiptsDelegate) {
+	m.deleteReceiptsFn = fn
+}
+
+func (m *MockStorage) DeleteTxLookup(hash types.Hash) error {
+	if m.deleteTxLookupFn != nil {
+		return m.deleteTxLookupFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteTxLookup(fn deleteTxLookupDelegate) {
+	m.deleteTxLookupFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
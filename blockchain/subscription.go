@@ -119,6 +119,27 @@ type Event struct {
 	// Source is the source that generated the blocks for the event
 	// right now it can be either the Sealer or the Syncer. TODO
 	Source string
+
+	// RemovedBlocks are the blocks of the old chain deleted by a rewind of the chain,
+	// their bodies and receipts are no longer in the storage
+	RemovedBlocks []*RemovedBlock
+}
+
+// RemovedBlock is a block deleted by a rewind of the chain, with its receipts
+type RemovedBlock struct {
+	Block    *types.Block
+	Receipts []*types.Receipt
+}
+
+// GetRemovedBlock returns the removed block with the given hash, if any
+func (e *Event) GetRemovedBlock(hash types.Hash) (*RemovedBlock, bool) {
+	for _, removed := range e.RemovedBlocks {
+		if removed.Block.Hash() == hash {
+			return removed, true
+		}
+	}
+
+	return nil, false
 }
 
 // Header returns the latest block header for the event
@@ -224,12 +224,14 @@ type verifyHeaderDelegate func(*types.Header) error
 type processHeadersDelegate func([]*types.Header) error
 type getBlockCreatorDelegate func(*types.Header) (types.Address, error)
 type preStateCommitDelegate func(*types.Header, *state.Transition) error
+type rewindHeadersDelegate func(uint64) error
 
 type MockVerifier struct {
 	verifyHeaderFn    verifyHeaderDelegate
 	processHeadersFn  processHeadersDelegate
 	getBlockCreatorFn getBlockCreatorDelegate
 	preStateCommitFn  preStateCommitDelegate
+	rewindHeadersFn   rewindHeadersDelegate
 }
 
 func (m *MockVerifier) VerifyHeader(header *types.Header) error {
@@ -256,6 +258,18 @@ func (m *MockVerifier) HookProcessHeaders(fn processHeadersDelegate) {
 	m.processHeadersFn = fn
 }
 
+func (m *MockVerifier) RewindHeaders(number uint64) error {
+	if m.rewindHeadersFn != nil {
+		return m.rewindHeadersFn(number)
+	}
+
+	return nil
+}
+
+func (m *MockVerifier) HookRewindHeaders(fn rewindHeadersDelegate) {
+	m.rewindHeadersFn = fn
+}
+
 func (m *MockVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
 	if m.getBlockCreatorFn != nil {
 		return m.getBlockCreatorFn(header)
@@ -329,7 +343,7 @@ func newBlockChain(config *chain.Chain, executor Executor) (*Blockchain, error)
 		executor = &mockExecutor{}
 	}
 
-	b, err := NewBlockchain(hclog.NewNullLogger(), "", config, &MockVerifier{}, executor)
+	b, err := NewBlockchain(hclog.NewNullLogger(), "", "", config, &MockVerifier{}, executor)
 	if err != nil {
 		return nil, err
 	}
@@ -374,5 +374,9 @@ func importChain(content []byte) (*Chain, error) {
 		return nil, fmt.Errorf("expected one consensus engine but found %d", len(engines))
 	}
 
+	if err := chain.Params.Validate(); err != nil {
+		return nil, err
+	}
+
 	return chain, nil
 }
@@ -1,15 +1,62 @@
 package chain
 
 import (
-	"math/big"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrInvalidForkOrder is returned when a fork is activated before the fork it follows
+var ErrInvalidForkOrder = errors.New("invalid fork order")
+
 // Params are all the set of params for the chain
 type Params struct {
 	Forks          *Forks                 `json:"forks"`
 	ChainID        int                    `json:"chainID"`
 	Engine         map[string]interface{} `json:"engine"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+
+	// Precompiles are the stateful precompiled contracts enabled on the chain,
+	// keyed by their registered name
+	Precompiles map[string]*PrecompileConfig `json:"precompiles,omitempty"`
+}
+
+// PrecompileConfig is the genesis configuration of a stateful precompiled contract
+type PrecompileConfig struct {
+	// Block is the height at which the contract is activated
+	Block Fork `json:"block"`
+
+	// AdminAddresses are the addresses granted the admin role on activation
+	AdminAddresses []types.Address `json:"adminAddresses,omitempty"`
+
+	// EnabledAddresses are the addresses granted the enabled role on activation
+	EnabledAddresses []types.Address `json:"enabledAddresses,omitempty"`
+}
+
+// Active returns true if the contract is active at the given block
+func (p *PrecompileConfig) Active(block uint64) bool {
+	return p != nil && !p.Block.IsTimestamp() && p.Block.Active(block, 0)
+}
+
+// Validate checks the forks are in order, and the precompiles are activated by block,
+// as their storage is configured at the activation block
+func (p *Params) Validate() error {
+	if p.Forks != nil {
+		if err := p.Forks.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for name, precompile := range p.Precompiles {
+		if precompile.Block.IsTimestamp() {
+			return fmt.Errorf("precompile %s must be activated by block", name)
+		}
+	}
+
+	return nil
 }
 
 func (p *Params) GetEngine() string {
@@ -31,71 +78,202 @@ type Forks struct {
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
+	EIP2537        *Fork `json:"EIP2537,omitempty"`
 }
 
-func (f *Forks) active(ff *Fork, block uint64) bool {
+func (f *Forks) active(ff *Fork, block, timestamp uint64) bool {
 	if ff == nil {
 		return false
 	}
 
-	return ff.Active(block)
+	return ff.Active(block, timestamp)
 }
 
-func (f *Forks) IsHomestead(block uint64) bool {
-	return f.active(f.Homestead, block)
+func (f *Forks) IsHomestead(block, timestamp uint64) bool {
+	return f.active(f.Homestead, block, timestamp)
 }
 
-func (f *Forks) IsByzantium(block uint64) bool {
-	return f.active(f.Byzantium, block)
+func (f *Forks) IsByzantium(block, timestamp uint64) bool {
+	return f.active(f.Byzantium, block, timestamp)
 }
 
-func (f *Forks) IsConstantinople(block uint64) bool {
-	return f.active(f.Constantinople, block)
+func (f *Forks) IsConstantinople(block, timestamp uint64) bool {
+	return f.active(f.Constantinople, block, timestamp)
 }
 
-func (f *Forks) IsPetersburg(block uint64) bool {
-	return f.active(f.Petersburg, block)
+func (f *Forks) IsPetersburg(block, timestamp uint64) bool {
+	return f.active(f.Petersburg, block, timestamp)
 }
 
-func (f *Forks) IsEIP150(block uint64) bool {
-	return f.active(f.EIP150, block)
+func (f *Forks) IsEIP150(block, timestamp uint64) bool {
+	return f.active(f.EIP150, block, timestamp)
 }
 
-func (f *Forks) IsEIP158(block uint64) bool {
-	return f.active(f.EIP158, block)
+func (f *Forks) IsEIP158(block, timestamp uint64) bool {
+	return f.active(f.EIP158, block, timestamp)
 }
 
-func (f *Forks) IsEIP155(block uint64) bool {
-	return f.active(f.EIP155, block)
+func (f *Forks) IsEIP155(block, timestamp uint64) bool {
+	return f.active(f.EIP155, block, timestamp)
 }
 
-func (f *Forks) At(block uint64) ForksInTime {
+func (f *Forks) IsEIP2537(block, timestamp uint64) bool {
+	return f.active(f.EIP2537, block, timestamp)
+}
+
+// At returns the forks active in the block with the given number and timestamp
+func (f *Forks) At(block, timestamp uint64) ForksInTime {
 	return ForksInTime{
-		Homestead:      f.active(f.Homestead, block),
-		Byzantium:      f.active(f.Byzantium, block),
-		Constantinople: f.active(f.Constantinople, block),
-		Petersburg:     f.active(f.Petersburg, block),
-		Istanbul:       f.active(f.Istanbul, block),
-		EIP150:         f.active(f.EIP150, block),
-		EIP158:         f.active(f.EIP158, block),
-		EIP155:         f.active(f.EIP155, block),
+		Homestead:      f.active(f.Homestead, block, timestamp),
+		Byzantium:      f.active(f.Byzantium, block, timestamp),
+		Constantinople: f.active(f.Constantinople, block, timestamp),
+		Petersburg:     f.active(f.Petersburg, block, timestamp),
+		Istanbul:       f.active(f.Istanbul, block, timestamp),
+		EIP150:         f.active(f.EIP150, block, timestamp),
+		EIP158:         f.active(f.EIP158, block, timestamp),
+		EIP155:         f.active(f.EIP155, block, timestamp),
+		EIP2537:        f.active(f.EIP2537, block, timestamp),
+	}
+}
+
+// ordered returns the forks in their activation order, with their names
+func (f *Forks) ordered() []namedFork {
+	return []namedFork{
+		{"homestead", f.Homestead},
+		{"EIP150", f.EIP150},
+		{"EIP155", f.EIP155},
+		{"EIP158", f.EIP158},
+		{"byzantium", f.Byzantium},
+		{"constantinople", f.Constantinople},
+		{"petersburg", f.Petersburg},
+		{"istanbul", f.Istanbul},
+		{"EIP2537", f.EIP2537},
+	}
+}
+
+type namedFork struct {
+	name string
+	fork *Fork
+}
+
+// Validate checks the forks are activated in order. The block forks have to come
+// before the timestamp forks, as a block number can't be compared to a timestamp
+func (f *Forks) Validate() error {
+	var prev *namedFork
+
+	for _, next := range f.ordered() {
+		next := next
+
+		if next.fork == nil {
+			continue
+		}
+
+		if prev != nil {
+			if prev.fork.IsTimestamp() && !next.fork.IsTimestamp() {
+				return fmt.Errorf(
+					"%w: %s is activated by block after %s is activated by timestamp",
+					ErrInvalidForkOrder, next.name, prev.name,
+				)
+			}
+
+			if prev.fork.IsTimestamp() == next.fork.IsTimestamp() && next.fork.value() < prev.fork.value() {
+				return fmt.Errorf(
+					"%w: %s is activated at %s, before %s at %s",
+					ErrInvalidForkOrder, next.name, next.fork, prev.name, prev.fork,
+				)
+			}
+		}
+
+		prev = &next
 	}
+
+	return nil
 }
 
-type Fork uint64
+// Fork is the activation point of a fork, either a block number or a block timestamp.
+// A block fork is encoded in JSON as the block number, a timestamp fork as {"timestamp": n}
+type Fork struct {
+	// Block is the number of the first block of the fork
+	Block uint64
+
+	// Timestamp is the unix time from which the blocks are part of the fork, it takes
+	// precedence over the block number when set
+	Timestamp *uint64
+}
 
+// NewFork returns a fork activated at the given block
 func NewFork(n uint64) *Fork {
-	f := Fork(n)
+	return &Fork{Block: n}
+}
 
-	return &f
+// NewTimestampFork returns a fork activated by the blocks with a timestamp equal or after the given one
+func NewTimestampFork(timestamp uint64) *Fork {
+	return &Fork{Timestamp: &timestamp}
 }
 
-func (f Fork) Active(block uint64) bool {
-	return block >= uint64(f)
+// IsTimestamp returns true if the fork is activated by timestamp
+func (f Fork) IsTimestamp() bool {
+	return f.Timestamp != nil
 }
 
-func (f Fork) Int() *big.Int {
-	return big.NewInt(int64(f))
+// Active returns true if the block with the given number and timestamp is part of the fork
+func (f Fork) Active(block, timestamp uint64) bool {
+	if f.Timestamp != nil {
+		return timestamp >= *f.Timestamp
+	}
+
+	return block >= f.Block
+}
+
+func (f Fork) value() uint64 {
+	if f.Timestamp != nil {
+		return *f.Timestamp
+	}
+
+	return f.Block
+}
+
+func (f Fork) String() string {
+	if f.Timestamp != nil {
+		return fmt.Sprintf("timestamp %d", *f.Timestamp)
+	}
+
+	return fmt.Sprintf("block %d", f.Block)
+}
+
+type forkTimestamp struct {
+	Timestamp *uint64 `json:"timestamp"`
+}
+
+// MarshalJSON implements the json interface
+func (f Fork) MarshalJSON() ([]byte, error) {
+	if f.Timestamp != nil {
+		return json.Marshal(&forkTimestamp{Timestamp: f.Timestamp})
+	}
+
+	return json.Marshal(f.Block)
+}
+
+// UnmarshalJSON implements the json interface
+func (f *Fork) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var dec forkTimestamp
+		if err := json.Unmarshal(data, &dec); err != nil {
+			return err
+		}
+
+		if dec.Timestamp == nil {
+			return errors.New("fork without a timestamp")
+		}
+
+		*f = Fork{Timestamp: dec.Timestamp}
+
+		return nil
+	}
+
+	*f = Fork{}
+
+	return json.Unmarshal(data, &f.Block)
 }
 
 type ForksInTime struct {
@@ -106,7 +284,8 @@ type ForksInTime struct {
 	Istanbul,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	EIP2537 bool
 }
 
 var AllForksEnabled = &Forks{
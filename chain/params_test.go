@@ -2,9 +2,12 @@ package chain
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestValidateChainID(t *testing.T) {
@@ -31,6 +34,22 @@ func TestParamsForks(t *testing.T) {
 				Homestead: NewFork(1000),
 			},
 		},
+		{
+			input: `{
+				"homestead": 0,
+				"istanbul": {"timestamp": 1700000000}
+			}`,
+			output: &Forks{
+				Homestead: NewFork(0),
+				Istanbul:  NewTimestampFork(1700000000),
+			},
+		},
+		{
+			input: `{
+				"istanbul": {"block": 10}
+			}`,
+			output: nil,
+		},
 	}
 
 	for _, c := range cases {
@@ -53,7 +72,7 @@ func TestParamsForksInTime(t *testing.T) {
 		EIP150:         NewFork(2000),
 	}
 
-	ff := f.At(1000)
+	ff := f.At(1000, 0)
 
 	expect := func(name string, found bool, expect bool) {
 		if expect != found {
@@ -66,3 +85,111 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestParamsForksInTime_Timestamp(t *testing.T) {
+	f := Forks{
+		Homestead: NewFork(0),
+		Byzantium: NewFork(10),
+		Istanbul:  NewTimestampFork(1000),
+	}
+
+	// the block forks are evaluated by number, the timestamp forks by timestamp
+	ff := f.At(5, 2000)
+	assert.True(t, ff.Homestead)
+	assert.False(t, ff.Byzantium)
+	assert.True(t, ff.Istanbul)
+
+	ff = f.At(20, 999)
+	assert.True(t, ff.Byzantium)
+	assert.False(t, ff.Istanbul)
+
+	ff = f.At(20, 1000)
+	assert.True(t, ff.Istanbul)
+}
+
+func TestForkJSON(t *testing.T) {
+	forks := &Forks{
+		Homestead: NewFork(5),
+		Istanbul:  NewTimestampFork(1000),
+	}
+
+	raw, err := json.Marshal(forks)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"homestead": 5, "istanbul": {"timestamp": 1000}}`, string(raw))
+
+	var dec *Forks
+
+	assert.NoError(t, json.Unmarshal(raw, &dec))
+	assert.Equal(t, forks, dec)
+}
+
+func TestForksValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		forks *Forks
+		valid bool
+	}{
+		{
+			name:  "all forks enabled",
+			forks: AllForksEnabled,
+			valid: true,
+		},
+		{
+			name: "block forks in order, skipping unset forks",
+			forks: &Forks{
+				Homestead: NewFork(0),
+				EIP155:    NewFork(10),
+				Istanbul:  NewFork(10),
+			},
+			valid: true,
+		},
+		{
+			name: "block forks out of order",
+			forks: &Forks{
+				Homestead: NewFork(10),
+				Byzantium: NewFork(5),
+			},
+			valid: false,
+		},
+		{
+			name: "timestamp forks after block forks",
+			forks: &Forks{
+				Homestead:  NewFork(0),
+				Byzantium:  NewTimestampFork(1000),
+				Petersburg: NewTimestampFork(1000),
+				Istanbul:   NewTimestampFork(2000),
+			},
+			valid: true,
+		},
+		{
+			name: "timestamp forks out of order",
+			forks: &Forks{
+				Byzantium: NewTimestampFork(2000),
+				Istanbul:  NewTimestampFork(1000),
+			},
+			valid: false,
+		},
+		{
+			name: "block fork after a timestamp fork",
+			forks: &Forks{
+				Byzantium: NewTimestampFork(1000),
+				Istanbul:  NewFork(5),
+			},
+			valid: false,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			err := c.forks.Validate()
+
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrInvalidForkOrder))
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package backup
 
 import (
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/backup/verify"
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
@@ -20,6 +22,11 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	setRequiredFlags(backupCmd)
 
+	backupCmd.AddCommand(
+		// backup verify
+		verify.GetCommand(),
+	)
+
 	return backupCmd
 }
 
@@ -44,6 +51,21 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().StringVar(
+		&params.compressionRaw,
+		compressionFlag,
+		archive.CompressionZstd.String(),
+		"the compression of the blocks in backup (none, gzip or zstd)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.incremental,
+		incrementalFlag,
+		"",
+		"the path of the previous backup to continue from. "+
+			"The backup starts after the latest block of the previous backup",
+	)
 }
 
 func setRequiredFlags(cmd *cobra.Command) {
@@ -10,9 +10,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag         = "out"
+	fromFlag        = "from"
+	toFlag          = "to"
+	compressionFlag = "compression"
+	incrementalFlag = "incremental"
 )
 
 var (
@@ -20,8 +22,9 @@ var (
 )
 
 var (
-	errDecodeRange  = errors.New("unable to decode range value")
-	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errDecodeRange     = errors.New("unable to decode range value")
+	errInvalidRange    = errors.New(`invalid "to" value; must be >= "from"`)
+	errIncrementalFrom = errors.New(`"from" can't be set for incremental backups`)
 )
 
 type backupParams struct {
@@ -33,6 +36,11 @@ type backupParams struct {
 	from uint64
 	to   *uint64
 
+	compressionRaw string
+	compression    archive.Compression
+
+	incremental string
+
 	resFrom uint64
 	resTo   uint64
 }
@@ -58,6 +66,14 @@ func (p *backupParams) validateFlags() error {
 		p.to = &parsedTo
 	}
 
+	if p.incremental != "" && p.from != 0 {
+		return errIncrementalFrom
+	}
+
+	if p.compression, parseErr = archive.ParseCompression(p.compressionRaw); parseErr != nil {
+		return parseErr
+	}
+
 	return nil
 }
 
@@ -85,6 +101,8 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		p.from,
 		p.to,
 		p.out,
+		p.compression,
+		p.incremental,
 	)
 	if err != nil {
 		return err
@@ -98,8 +116,10 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+		From:        p.resFrom,
+		To:          p.resTo,
+		Out:         p.out,
+		Compression: p.compression.String(),
+		Incremental: p.incremental,
 	}
 }
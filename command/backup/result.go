@@ -7,9 +7,11 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From        uint64 `json:"from"`
+	To          uint64 `json:"to"`
+	Out         string `json:"out"`
+	Compression string `json:"compression"`
+	Incremental string `json:"incremental,omitempty"`
 }
 
 func (r *BackupResult) GetOutput() string {
@@ -17,11 +19,18 @@ func (r *BackupResult) GetOutput() string {
 
 	buffer.WriteString("\n[BACKUP]\n")
 	buffer.WriteString("Exported backup file successfully:\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	vals := []string{
 		fmt.Sprintf("File|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
-	}))
+		fmt.Sprintf("Compression|%s", r.Compression),
+	}
+
+	if r.Incremental != "" {
+		vals = append(vals, fmt.Sprintf("Continues|%s", r.Incremental))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 
 	return buffer.String()
 }
@@ -0,0 +1,58 @@
+package verify
+
+import (
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	fileFlag = "file"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	files []string
+
+	summaries []archive.ArchiveSummary
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *verifyParams) verifyArchives() error {
+	summaries, err := archive.VerifyArchives(p.files)
+	if err != nil {
+		return err
+	}
+
+	p.summaries = summaries
+
+	return nil
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	result := &VerifyResult{
+		Archives: make([]ArchiveResult, len(p.summaries)),
+	}
+
+	for i, summary := range p.summaries {
+		result.Archives[i] = ArchiveResult{
+			File:        summary.Path,
+			Version:     summary.Version,
+			Compression: summary.Compression.String(),
+			From:        summary.From,
+			To:          summary.To,
+			LatestHash:  summary.LatestHash.String(),
+			Blocks:      summary.Blocks,
+			Chunks:      summary.Chunks,
+		}
+	}
+
+	return result
+}
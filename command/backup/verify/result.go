@@ -0,0 +1,51 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ArchiveResult struct {
+	File        string `json:"file"`
+	Version     uint64 `json:"version"`
+	Compression string `json:"compression"`
+	From        uint64 `json:"from"`
+	To          uint64 `json:"to"`
+	LatestHash  string `json:"latest_hash"`
+	Blocks      uint64 `json:"blocks"`
+	Chunks      uint64 `json:"chunks"`
+}
+
+type VerifyResult struct {
+	Archives []ArchiveResult `json:"archives"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP VERIFY]\n")
+	buffer.WriteString("Verified backup files successfully:\n")
+
+	rows := make([]string, len(r.Archives)+1)
+	rows[0] = "File|Version|Compression|From|To|Blocks|Chunks|Latest Hash"
+
+	for i, archive := range r.Archives {
+		rows[i+1] = fmt.Sprintf("%s|%d|%s|%d|%d|%d|%d|%s",
+			archive.File,
+			archive.Version,
+			archive.Compression,
+			archive.From,
+			archive.To,
+			archive.Blocks,
+			archive.Chunks,
+			archive.LatestHash,
+		)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,48 @@
+package verify
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the checksums and the block linkage of backup files without a running node. " +
+			"Incremental backups are given after the backup they continue",
+		Run: runCommand,
+	}
+
+	setFlags(verifyCmd)
+	setRequiredFlags(verifyCmd)
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&params.files,
+		fileFlag,
+		[]string{},
+		"the path of the backup file to verify. Can be set multiple times for a chain of incremental backups",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verifyArchives(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
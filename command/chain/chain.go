@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/chain/export"
+	"github.com/0xPolygon/polygon-edge/command/chain/importer"
+	"github.com/0xPolygon/polygon-edge/command/chain/rewind"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	chainCmd := &cobra.Command{
+		Use: "chain",
+		Short: "Top level command for working on the chain data of a stopped node. " +
+			"Only accepts subcommands.",
+	}
+
+	helper.RegisterOfflineChainFlags(chainCmd)
+
+	registerSubcommands(chainCmd)
+
+	return chainCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// chain export
+		export.GetCommand(),
+		// chain import
+		importer.GetCommand(),
+		// chain rewind
+		rewind.GetCommand(),
+	)
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the blocks of the chain as concatenated RLP blocks, the format of geth export. " +
+			"The file is gzip compressed if its name ends with .gz",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportCmd)
+	setRequiredFlags(exportCmd)
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the export path for the blocks",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to export (default latest)",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	offlineChain, err := helper.OpenOfflineChain(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer offlineChain.Close()
+
+	if err := params.exportBlocks(offlineChain); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	outFlag  = "out"
+	fromFlag = "from"
+	toFlag   = "to"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+)
+
+type exportParams struct {
+	out string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	resFrom uint64
+	resTo   uint64
+}
+
+func (p *exportParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *exportParams) exportBlocks(offlineChain *server.OfflineChain) error {
+	resFrom, resTo, err := archive.ExportRLPFile(offlineChain.Blockchain(), p.out, p.from, p.to)
+	if err != nil {
+		return err
+	}
+
+	p.resFrom = resFrom
+	p.resTo = resTo
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		From: p.resFrom,
+		To:   p.resTo,
+		Out:  p.out,
+	}
+}
@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use: "import",
+		Short: "Imports concatenated RLP blocks, the format of geth export, verifying and executing every block. " +
+			"The file is gzip decompressed if its name ends with .gz",
+		Run: runCommand,
+	}
+
+	setFlags(importCmd)
+	setRequiredFlags(importCmd)
+
+	return importCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path of the RLP blocks file to import",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	offlineChain, err := helper.OpenOfflineChain(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer offlineChain.Close()
+
+	if err := params.importBlocks(offlineChain); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+const (
+	fileFlag = "file"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	file string
+
+	result *archive.ImportResult
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *importParams) importBlocks(offlineChain *server.OfflineChain) error {
+	result, err := archive.ImportRLPFile(offlineChain.Blockchain(), p.file)
+	if err != nil {
+		if result != nil && result.Imported > 0 {
+			return fmt.Errorf("imported blocks %d-%d, then failed: %w", result.First, result.Last, err)
+		}
+
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		File:     p.file,
+		Imported: p.result.Imported,
+		Skipped:  p.result.Skipped,
+		First:    p.result.First,
+		Last:     p.result.Last,
+	}
+}
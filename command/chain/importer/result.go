@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ImportResult struct {
+	File     string `json:"file"`
+	Imported uint64 `json:"imported"`
+	Skipped  uint64 `json:"skipped"`
+	First    uint64 `json:"first,omitempty"`
+	Last     uint64 `json:"last,omitempty"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN IMPORT]\n")
+	buffer.WriteString("Imported blocks successfully:\n")
+
+	vals := []string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Imported blocks|%d", r.Imported),
+		fmt.Sprintf("Skipped blocks|%d", r.Skipped),
+	}
+
+	if r.Imported > 0 {
+		vals = append(vals,
+			fmt.Sprintf("First imported|%d", r.First),
+			fmt.Sprintf("Last imported|%d", r.Last),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,51 @@
+package rewind
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	toFlag = "to"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+type rewindParams struct {
+	to uint64
+
+	previousHead uint64
+	head         *types.Header
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return []string{
+		toFlag,
+	}
+}
+
+func (p *rewindParams) rewindChain(offlineChain *server.OfflineChain) error {
+	chain := offlineChain.Blockchain()
+
+	p.previousHead = chain.Header().Number
+
+	if err := chain.SetHead(p.to); err != nil {
+		return err
+	}
+
+	p.head = chain.Header()
+
+	return nil
+}
+
+func (p *rewindParams) getResult() command.CommandResult {
+	return &RewindResult{
+		PreviousHead: p.previousHead,
+		Head:         p.head.Number,
+		HeadHash:     p.head.Hash.String(),
+		Removed:      p.previousHead - p.head.Number,
+	}
+}
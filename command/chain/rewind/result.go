@@ -0,0 +1,31 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RewindResult struct {
+	PreviousHead uint64 `json:"previous_head"`
+	Head         uint64 `json:"head"`
+	HeadHash     string `json:"head_hash"`
+	Removed      uint64 `json:"removed"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN REWIND]\n")
+	buffer.WriteString("Rewound chain successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Previous head|%d", r.PreviousHead),
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("Removed blocks|%d", r.Removed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,58 @@
+package rewind
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	rewindCmd := &cobra.Command{
+		Use: "rewind",
+		Short: "Rewinds the chain head to the block, deleting the canonical mappings, bodies, receipts " +
+			"and transaction lookups of the blocks above it. Used to recover from a bad block",
+		Run: runCommand,
+	}
+
+	setFlags(rewindCmd)
+	setRequiredFlags(rewindCmd)
+
+	return rewindCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.to,
+		toFlag,
+		0,
+		"the number of the block to become the chain head",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	offlineChain, err := helper.OpenOfflineChain(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer offlineChain.Close()
+
+	if err := params.rewindChain(offlineChain); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
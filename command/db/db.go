@@ -0,0 +1,24 @@
+package db
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/db/migrate"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for working on the databases of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db migrate
+		migrate.GetCommand(),
+	)
+}
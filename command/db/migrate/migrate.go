@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Copies the LevelDB blockchain and trie databases of the data directory into Pebble databases. " +
+			"The LevelDB databases are kept with the .leveldb suffix",
+		Run: runCommand,
+	}
+
+	setFlags(migrateCmd)
+	setRequiredFlags(migrateCmd)
+
+	return migrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		command.DataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrateDatabases(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
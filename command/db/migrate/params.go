@@ -0,0 +1,115 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	// migrationSuffix is the suffix of the Pebble databases being written
+	migrationSuffix = ".migration"
+
+	// backupSuffix is the suffix of the migrated LevelDB databases
+	backupSuffix = ".leveldb"
+)
+
+var (
+	params = &migrateParams{}
+
+	// databases are the directories of the migrated databases in the data directory
+	databases = []string{"blockchain", "trie"}
+)
+
+var (
+	errNotLevelDB    = errors.New("database doesn't use leveldb")
+	errPathExists    = errors.New("path already exists, remove it first")
+	errNoDatabase    = errors.New("database not found")
+	errNotMigratable = errors.New("unable to migrate the data directory")
+)
+
+type migrateParams struct {
+	dataDir string
+
+	migrated []MigratedDatabase
+}
+
+func (p *migrateParams) getRequiredFlags() []string {
+	return []string{
+		command.DataDirFlag,
+	}
+}
+
+// migrateDatabases copies every database to a new Pebble database,
+// and swaps them once all of them are copied
+func (p *migrateParams) migrateDatabases() error {
+	for _, name := range databases {
+		if err := checkDatabase(filepath.Join(p.dataDir, name)); err != nil {
+			return fmt.Errorf("%w: %v", errNotMigratable, err)
+		}
+	}
+
+	for _, name := range databases {
+		path := filepath.Join(p.dataDir, name)
+
+		copied, err := pebble.MigrateLevelDB(path, path+migrationSuffix)
+		if err != nil {
+			_ = os.RemoveAll(path + migrationSuffix)
+
+			return err
+		}
+
+		p.migrated = append(p.migrated, MigratedDatabase{
+			Path:   path,
+			Backup: path + backupSuffix,
+			Keys:   copied,
+		})
+	}
+
+	for _, database := range p.migrated {
+		if err := os.Rename(database.Path, database.Backup); err != nil {
+			return err
+		}
+
+		if err := os.Rename(database.Path+migrationSuffix, database.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDatabase makes sure the database uses LevelDB and the migration paths are free
+func checkDatabase(path string) error {
+	backend, exists, err := storage.DetectBackend(path)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: %s", errNoDatabase, path)
+	}
+
+	if backend != storage.LevelDB {
+		return fmt.Errorf("%w: %s uses %s", errNotLevelDB, path, backend)
+	}
+
+	for _, target := range []string{path + migrationSuffix, path + backupSuffix} {
+		if _, err := os.Stat(target); err == nil {
+			return fmt.Errorf("%w: %s", errPathExists, target)
+		}
+	}
+
+	return nil
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	return &MigrateResult{
+		Databases: p.migrated,
+	}
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type MigratedDatabase struct {
+	Path   string `json:"path"`
+	Backup string `json:"backup"`
+	Keys   uint64 `json:"keys"`
+}
+
+type MigrateResult struct {
+	Databases []MigratedDatabase `json:"databases"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB MIGRATE]\n")
+	buffer.WriteString("Migrated the databases to pebble successfully:\n")
+
+	rows := make([]string, len(r.Databases)+1)
+	rows[0] = "Database|LevelDB backup|Keys"
+
+	for i, database := range r.Databases {
+		rows[i+1] = fmt.Sprintf("%s|%s|%d", database.Path, database.Backup, database.Keys)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -16,6 +16,8 @@ const (
 	JSONOutputFlag  = "json"
 	GRPCAddressFlag = "grpc-address"
 	JSONRPCFlag     = "jsonrpc"
+	DataDirFlag     = "data-dir"
+	ChainFlag       = "chain"
 )
 
 // GRPCAddressFlagLEGACY Legacy flag that needs to be present to preserve backwards
@@ -0,0 +1,80 @@
+package dnstree
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dnsTreeCmd := &cobra.Command{
+		Use:     "dnstree",
+		Short:   "Generates and signs the DNS tree of node records (EIP-1459), used for node discovery",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dnsTreeCmd)
+	setRequiredFlags(dnsTreeCmd)
+
+	return dnsTreeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.domain,
+		domainFlag,
+		"",
+		"the domain the tree is published on",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyPath,
+		keyFlag,
+		"",
+		"the path to the tree signing key. A new key is generated if the file doesn't exist",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.seq,
+		seqFlag,
+		0,
+		"the sequence number of the tree, which must increase with every update. Defaults to the current UNIX time",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.nodes,
+		nodeFlag,
+		[]string{},
+		"the libp2p address of a node, including the peer ID, or a signed node record (enr:...)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.links,
+		linkFlag,
+		[]string{},
+		"the enrtree:// URL of another tree to link",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.signTree(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,103 @@
+package dnstree
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/discovery/dnsdisc"
+)
+
+const (
+	domainFlag = "domain"
+	keyFlag    = "key"
+	seqFlag    = "seq"
+	nodeFlag   = "node"
+	linkFlag   = "link"
+)
+
+var (
+	params = &dnsTreeParams{}
+)
+
+var (
+	errNoNodes = errors.New("at least one node or link is required")
+)
+
+type dnsTreeParams struct {
+	domain  string
+	keyPath string
+	seq     uint64
+	nodes   []string
+	links   []string
+
+	url     string
+	records map[string]string
+}
+
+func (p *dnsTreeParams) validateFlags() error {
+	if len(p.nodes) == 0 && len(p.links) == 0 {
+		return errNoNodes
+	}
+
+	return nil
+}
+
+func (p *dnsTreeParams) getRequiredFlags() []string {
+	return []string{
+		domainFlag,
+		keyFlag,
+	}
+}
+
+func (p *dnsTreeParams) signTree() error {
+	key, err := crypto.GenerateOrReadPrivateKey(p.keyPath)
+	if err != nil {
+		return err
+	}
+
+	if p.seq == 0 {
+		p.seq = uint64(time.Now().Unix())
+	}
+
+	tree, err := dnsdisc.MakeTree(p.seq, p.nodes, p.links)
+	if err != nil {
+		return err
+	}
+
+	if p.url, err = tree.Sign(key, p.domain); err != nil {
+		return err
+	}
+
+	p.records = tree.Records(p.domain)
+
+	return nil
+}
+
+func (p *dnsTreeParams) getResult() command.CommandResult {
+	records := make([]DNSRecord, 0, len(p.records))
+
+	for name, txt := range p.records {
+		records = append(records, DNSRecord{
+			Name: name,
+			TXT:  txt,
+		})
+	}
+
+	// the root record comes first, the rest are ordered by name
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name == p.domain || records[j].Name == p.domain {
+			return records[i].Name == p.domain
+		}
+
+		return records[i].Name < records[j].Name
+	})
+
+	return &DNSTreeResult{
+		URL:     p.url,
+		Seq:     p.seq,
+		Records: records,
+	}
+}
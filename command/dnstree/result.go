@@ -0,0 +1,37 @@
+package dnstree
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DNSRecord struct {
+	Name string `json:"name"`
+	TXT  string `json:"txt"`
+}
+
+type DNSTreeResult struct {
+	URL     string      `json:"url"`
+	Seq     uint64      `json:"seq"`
+	Records []DNSRecord `json:"records"`
+}
+
+func (r *DNSTreeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DNS TREE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("URL|%s", r.URL),
+		fmt.Sprintf("Sequence|%d", r.Seq),
+	}))
+
+	buffer.WriteString("\n\n[TXT RECORDS]\n")
+
+	for _, record := range r.Records {
+		buffer.WriteString(fmt.Sprintf("%s\n\t%s\n", record.Name, record.TXT))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,189 @@
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/state/dump"
+)
+
+// initAllocFromDump fills the genesis allocation with the state dump, and carries the fork,
+// precompile and consensus configuration of the dumped chain over to the new chain
+func (p *genesisParams) initAllocFromDump(chainConfig *chain.Chain) error {
+	file, err := os.Open(p.allocFromPath)
+	if err != nil {
+		return fmt.Errorf("unable to open state dump: %w", err)
+	}
+
+	defer file.Close()
+
+	header, alloc, err := dump.ReadGenesisAlloc(file)
+	if err != nil {
+		return fmt.Errorf("unable to read state dump: %w", err)
+	}
+
+	chainConfig.Genesis.Alloc = alloc
+
+	if header.Params == nil {
+		return nil
+	}
+
+	return rebaseParams(chainConfig.Params, header.Params, header.Block)
+}
+
+// rebaseParams sets the forks of the dumped chain on the new chain, which starts at the dump block.
+// Block n of the new chain is block dumpBlock+n of the dumped chain, so the forks active at the
+// dump block are active from the genesis, and the later forks are moved back by the dump block
+func rebaseParams(params *chain.Params, dumped *chain.Params, dumpBlock uint64) error {
+	if dumped.Forks != nil {
+		params.Forks = rebaseForks(dumped.Forks, dumpBlock)
+	}
+
+	params.BlockGasTarget = dumped.BlockGasTarget
+
+	for name, precompile := range dumped.Precompiles {
+		// the allow lists set by flag take precedence
+		if _, ok := params.Precompiles[name]; ok {
+			continue
+		}
+
+		if params.Precompiles == nil {
+			params.Precompiles = map[string]*chain.PrecompileConfig{}
+		}
+
+		rebased := *precompile
+		rebased.Block = rebaseFork(precompile.Block, dumpBlock)
+
+		params.Precompiles[name] = &rebased
+	}
+
+	engine := params.GetEngine()
+
+	dumpedEngine, ok := dumped.Engine[engine].(map[string]interface{})
+	if !ok {
+		// the consensus changed, the engine config set by flag is kept
+		return nil
+	}
+
+	if server.ConsensusType(engine) == server.IBFTConsensus {
+		var err error
+		if dumpedEngine, err = rebaseIBFTConfig(dumpedEngine, dumpBlock); err != nil {
+			return err
+		}
+	}
+
+	params.Engine = map[string]interface{}{
+		engine: dumpedEngine,
+	}
+
+	return nil
+}
+
+// rebaseFork moves the block fork back by the dump block, the timestamp forks stay as they are
+func rebaseFork(fork chain.Fork, dumpBlock uint64) chain.Fork {
+	if fork.IsTimestamp() {
+		return fork
+	}
+
+	return chain.Fork{Block: rebaseBlock(fork.Block, dumpBlock)}
+}
+
+func rebaseBlock(block, dumpBlock uint64) uint64 {
+	if block <= dumpBlock {
+		return 0
+	}
+
+	return block - dumpBlock
+}
+
+func rebaseForks(forks *chain.Forks, dumpBlock uint64) *chain.Forks {
+	rebase := func(fork *chain.Fork) *chain.Fork {
+		if fork == nil {
+			return nil
+		}
+
+		rebased := rebaseFork(*fork, dumpBlock)
+
+		return &rebased
+	}
+
+	return &chain.Forks{
+		Homestead:      rebase(forks.Homestead),
+		Byzantium:      rebase(forks.Byzantium),
+		Constantinople: rebase(forks.Constantinople),
+		Petersburg:     rebase(forks.Petersburg),
+		Istanbul:       rebase(forks.Istanbul),
+		EIP150:         rebase(forks.EIP150),
+		EIP158:         rebase(forks.EIP158),
+		EIP155:         rebase(forks.EIP155),
+		EIP2537:        rebase(forks.EIP2537),
+	}
+}
+
+// rebaseIBFTConfig rebases the IBFT mechanism forks. The forks ended by the dump block are dropped,
+// and the staking contract deployments already done are not repeated
+func rebaseIBFTConfig(config map[string]interface{}, dumpBlock uint64) (map[string]interface{}, error) {
+	forks, err := ibft.GetIBFTForks(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rebased := make([]ibft.IBFTFork, 0, len(forks))
+
+	for _, fork := range forks {
+		if fork.To != nil && fork.To.Value <= dumpBlock {
+			continue
+		}
+
+		fork.From = common.JSONNumber{Value: rebaseBlock(fork.From.Value, dumpBlock)}
+
+		if fork.To != nil {
+			fork.To = &common.JSONNumber{Value: fork.To.Value - dumpBlock}
+		}
+
+		if fork.Deployment != nil {
+			if fork.Deployment.Value <= dumpBlock {
+				fork.Deployment = nil
+			} else {
+				fork.Deployment = &common.JSONNumber{Value: fork.Deployment.Value - dumpBlock}
+			}
+		}
+
+		rebased = append(rebased, fork)
+	}
+
+	// the first remaining fork covers the genesis
+	if len(rebased) > 0 {
+		rebased[0].From = common.JSONNumber{Value: 0}
+	}
+
+	// the config is round-tripped through JSON, the way it's read from the genesis file
+	raw, err := json.Marshal(rebased)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []interface{}
+	if err := json.Unmarshal(raw, &types); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]interface{}, len(config))
+
+	for key, value := range config {
+		if key == "type" || key == "types" {
+			continue
+		}
+
+		res[key] = value
+	}
+
+	res["types"] = types
+
+	return res, nil
+}
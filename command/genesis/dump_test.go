@@ -0,0 +1,75 @@
+package genesis
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebaseForks(t *testing.T) {
+	t.Parallel()
+
+	forks := rebaseForks(&chain.Forks{
+		Homestead: chain.NewFork(0),
+		Istanbul:  chain.NewFork(100),
+		EIP2537:   chain.NewFork(150),
+	}, 100)
+
+	assert.Equal(t, chain.NewFork(0), forks.Homestead)
+	assert.Equal(t, chain.NewFork(0), forks.Istanbul)
+	assert.Equal(t, chain.NewFork(50), forks.EIP2537)
+	assert.Nil(t, forks.Byzantium)
+}
+
+func TestRebaseIBFTConfig(t *testing.T) {
+	t.Parallel()
+
+	config := map[string]interface{}{
+		"epochSize": float64(10),
+		"types": []interface{}{
+			map[string]interface{}{
+				"type": "PoA",
+				"from": "0x0",
+				"to":   "0x31",
+			},
+			map[string]interface{}{
+				"type":       "PoS",
+				"deployment": "0x32",
+				"from":       "0x3c",
+				"to":         "0xc7",
+			},
+			map[string]interface{}{
+				"type":              "PoS",
+				"deployment":        "0x12c",
+				"from":              "0xc8",
+				"maxValidatorCount": "0x5",
+			},
+		},
+	}
+
+	// dumped at block 100, in the first PoS fork, after its staking contract deployment
+	rebased, err := rebaseIBFTConfig(config, 100)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(10), rebased["epochSize"])
+
+	forks, err := ibft.GetIBFTForks(rebased)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ibft.IBFTFork{
+		{
+			Type: ibft.PoS,
+			From: common.JSONNumber{Value: 0},
+			To:   &common.JSONNumber{Value: 99},
+		},
+		{
+			Type:              ibft.PoS,
+			Deployment:        &common.JSONNumber{Value: 200},
+			From:              common.JSONNumber{Value: 100},
+			MaxValidatorCount: &common.JSONNumber{Value: 5},
+		},
+	}, forks)
+}
@@ -65,6 +65,14 @@ func setFlags(cmd *cobra.Command) {
 			"Needs to be present if ibft-validator is omitted",
 	)
 
+	cmd.Flags().StringVar(
+		&params.allocFromPath,
+		allocFromFlag,
+		"",
+		"the path to a state dump (see state dump). The accounts, code and storage of the dump are "+
+			"allocated in the genesis, and the forks and consensus configuration of the dumped chain are kept",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.premine,
 		premineFlag,
@@ -139,6 +147,38 @@ func setFlags(cmd *cobra.Command) {
 		common.MaxSafeJSInt,
 		"the maximum number of validators in the validator set for PoS",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&params.transactionAllowListAdminRaw,
+		transactionAllowListAdminFlag,
+		[]string{},
+		"addresses allowed to send transactions and to manage the transaction allow list, "+
+			"can be used multiple times. Enables the transaction allow list from genesis",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.transactionAllowListEnabledRaw,
+		transactionAllowListEnabledFlag,
+		[]string{},
+		"addresses allowed to send transactions, can be used multiple times. "+
+			"Enables the transaction allow list from genesis",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.contractDeployerAllowListAdminRaw,
+		contractDeployerAllowListAdminFlag,
+		[]string{},
+		"addresses allowed to deploy contracts and to manage the contract deployer allow list, "+
+			"can be used multiple times. Enables the contract deployer allow list from genesis",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.contractDeployerAllowListEnabledRaw,
+		contractDeployerAllowListEnabledFlag,
+		[]string{},
+		"addresses allowed to deploy contracts, can be used multiple times. "+
+			"Enables the contract deployer allow list from genesis",
+	)
 }
 
 // setLegacyFlags sets the legacy flags to preserve backwards compatibility
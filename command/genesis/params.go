@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -26,6 +27,12 @@ const (
 	posFlag                 = "pos"
 	minValidatorCount       = "min-validator-count"
 	maxValidatorCount       = "max-validator-count"
+	allocFromFlag           = "alloc-from"
+
+	transactionAllowListAdminFlag        = "transaction-allowlist-admin"
+	transactionAllowListEnabledFlag      = "transaction-allowlist-enabled"
+	contractDeployerAllowListAdminFlag   = "contract-deployer-allowlist-admin"
+	contractDeployerAllowListEnabledFlag = "contract-deployer-allowlist-enabled"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -51,12 +58,18 @@ type genesisParams struct {
 	name                string
 	consensusRaw        string
 	validatorPrefixPath string
+	allocFromPath       string
 	premine             []string
 	bootnodes           []string
 	ibftValidators      []types.Address
 
 	ibftValidatorsRaw []string
 
+	transactionAllowListAdminRaw        []string
+	transactionAllowListEnabledRaw      []string
+	contractDeployerAllowListAdminRaw   []string
+	contractDeployerAllowListEnabledRaw []string
+
 	chainID       uint64
 	epochSize     uint64
 	blockGasLimit uint64
@@ -275,8 +288,16 @@ func (p *genesisParams) initGenesisConfig() error {
 		Bootnodes: p.bootnodes,
 	}
 
-	// Predeploy staking smart contract if needed
-	if p.shouldPredeployStakingSC() {
+	// Carry the state of the dumped chain over
+	if p.allocFromPath != "" {
+		if err := p.initAllocFromDump(chainConfig); err != nil {
+			return err
+		}
+	}
+
+	// Predeploy staking smart contract if needed,
+	// unless it's already in the dumped state
+	if _, ok := chainConfig.Genesis.Alloc[staking.AddrStakingContract]; !ok && p.shouldPredeployStakingSC() {
 		stakingAccount, err := p.predeployStakingSC()
 		if err != nil {
 			return err
@@ -290,11 +311,61 @@ func (p *genesisParams) initGenesisConfig() error {
 		return err
 	}
 
+	// Seed the allow lists
+	p.initAllowLists(chainConfig.Params)
+
 	p.genesisConfig = chainConfig
 
 	return nil
 }
 
+// initAllowLists enables the allow lists for which
+// at least one address is specified from the genesis block
+func (p *genesisParams) initAllowLists(params *chain.Params) {
+	lists := []struct {
+		name    string
+		admin   []string
+		enabled []string
+	}{
+		{
+			name:    allowlist.TransactionAllowListName,
+			admin:   p.transactionAllowListAdminRaw,
+			enabled: p.transactionAllowListEnabledRaw,
+		},
+		{
+			name:    allowlist.ContractDeployerAllowListName,
+			admin:   p.contractDeployerAllowListAdminRaw,
+			enabled: p.contractDeployerAllowListEnabledRaw,
+		},
+	}
+
+	for _, list := range lists {
+		if len(list.admin) == 0 && len(list.enabled) == 0 {
+			continue
+		}
+
+		if params.Precompiles == nil {
+			params.Precompiles = map[string]*chain.PrecompileConfig{}
+		}
+
+		params.Precompiles[list.name] = &chain.PrecompileConfig{
+			Block:            *chain.NewFork(0),
+			AdminAddresses:   stringsToAddresses(list.admin),
+			EnabledAddresses: stringsToAddresses(list.enabled),
+		}
+	}
+}
+
+func stringsToAddresses(raw []string) []types.Address {
+	addresses := make([]types.Address, 0, len(raw))
+
+	for _, addr := range raw {
+		addresses = append(addresses, types.StringToAddress(addr))
+	}
+
+	return addresses
+}
+
 func (p *genesisParams) shouldPredeployStakingSC() bool {
 	// If the consensus selected is IBFT / Dev and the mechanism is Proof of Stake,
 	// deploy the Staking SC
@@ -77,6 +77,14 @@ func fillPremineMap(
 			return fmt.Errorf("failed to parse amount %s: %w", val, err)
 		}
 
+		// the balance of an account of the dumped state is overridden,
+		// its code and storage are kept
+		if account, ok := premineMap[addr]; ok {
+			account.Balance = amount
+
+			continue
+		}
+
 		premineMap[addr] = &chain.GenesisAccount{
 			Balance: amount,
 		}
@@ -15,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/hashicorp/go-hclog"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -193,6 +194,38 @@ func RegisterLegacyGRPCAddressFlag(cmd *cobra.Command) {
 	_ = cmd.PersistentFlags().MarkHidden(command.GRPCAddressFlagLEGACY)
 }
 
+// RegisterOfflineChainFlags registers the flags locating the chain data of a stopped node
+// for all child commands
+func RegisterOfflineChainFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		command.DataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.PersistentFlags().String(
+		command.ChainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain",
+	)
+
+	_ = cmd.MarkPersistentFlagRequired(command.DataDirFlag)
+}
+
+// OpenOfflineChain opens the chain data of a stopped node, located by the offline chain flags
+func OpenOfflineChain(cmd *cobra.Command) (*server.OfflineChain, error) {
+	genesis, err := chain.Import(cmd.Flag(command.ChainFlag).Value.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read genesis file, %w", err)
+	}
+
+	return server.NewOfflineChain(&server.Config{
+		Chain:    genesis,
+		DataDir:  cmd.Flag(command.DataDirFlag).Value.String(),
+		LogLevel: hclog.Info,
+	})
+}
+
 // ParseGRPCAddress parses the passed in GRPC address
 func ParseGRPCAddress(grpcAddress string) (*net.TCPAddr, error) {
 	return net.ResolveTCPAddr("tcp", grpcAddress)
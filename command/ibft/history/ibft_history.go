@@ -0,0 +1,65 @@
+package history
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftHistoryCmd := &cobra.Command{
+		Use: "history",
+		Short: "Returns the history of the validator set changes and votes, and the statistics of the validators. " +
+			"All the epochs are returned, unless an epoch range is specified",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(ibftHistoryCmd)
+
+	return ibftHistoryCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.fromEpoch,
+		fromEpochFlag,
+		0,
+		"the first epoch of the history",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.toEpoch,
+		toEpochFlag,
+		0,
+		"the last epoch of the history, 0 for the latest epoch",
+	)
+
+	cmd.Flags().StringVar(
+		&params.validatorRaw,
+		validatorFlag,
+		"",
+		"the address of the validator to return the history of",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initHistory(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
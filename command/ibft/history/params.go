@@ -0,0 +1,96 @@
+package history
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	fromEpochFlag = "from-epoch"
+	toEpochFlag   = "to-epoch"
+	validatorFlag = "validator"
+)
+
+var (
+	errInvalidEpochRange    = errors.New("the last epoch must be greater than or equal to the first epoch")
+	errInvalidAddressFormat = errors.New("invalid address format")
+)
+
+var (
+	params = &historyParams{}
+)
+
+type historyParams struct {
+	fromEpoch    uint64
+	toEpoch      uint64
+	validatorRaw string
+
+	validator *types.Address
+
+	history *ibftOp.ValidatorHistoryResp
+	stats   *ibftOp.ValidatorStatsResp
+}
+
+func (p *historyParams) validateFlags() error {
+	if p.toEpoch != 0 && p.toEpoch < p.fromEpoch {
+		return errInvalidEpochRange
+	}
+
+	return nil
+}
+
+func (p *historyParams) initRawParams() error {
+	if p.validatorRaw == "" {
+		return nil
+	}
+
+	validator := types.Address{}
+	if err := validator.UnmarshalText([]byte(p.validatorRaw)); err != nil {
+		return errInvalidAddressFormat
+	}
+
+	p.validator = &validator
+
+	return nil
+}
+
+func (p *historyParams) initHistory(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	historyReq := &ibftOp.ValidatorHistoryReq{
+		FromEpoch: p.fromEpoch,
+		ToEpoch:   p.toEpoch,
+	}
+
+	if p.validator != nil {
+		historyReq.Validator = p.validator.String()
+	}
+
+	if p.history, err = ibftClient.GetValidatorHistory(context.Background(), historyReq); err != nil {
+		return err
+	}
+
+	if p.stats, err = ibftClient.GetValidatorStats(
+		context.Background(),
+		&ibftOp.ValidatorStatsReq{
+			FromEpoch: p.fromEpoch,
+			ToEpoch:   p.toEpoch,
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *historyParams) getResult() command.CommandResult {
+	return newIBFTHistoryResult(p.history, p.stats, p.validator)
+}
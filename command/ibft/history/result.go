@@ -0,0 +1,193 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftHelper "github.com/0xPolygon/polygon-edge/command/ibft/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type IBFTSetChange struct {
+	Number  uint64   `json:"number"`
+	Hash    string   `json:"hash"`
+	Epoch   uint64   `json:"epoch"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Cause   string   `json:"cause"`
+}
+
+type IBFTHistoryVote struct {
+	Number   uint64          `json:"number"`
+	Epoch    uint64          `json:"epoch"`
+	Proposer string          `json:"proposer"`
+	Address  string          `json:"address"`
+	Vote     ibftHelper.Vote `json:"vote"`
+}
+
+type IBFTValidatorStats struct {
+	Address        string `json:"address"`
+	ProposedBlocks uint64 `json:"proposed_blocks"`
+	CommittedSeals uint64 `json:"committed_seals"`
+	MissedRounds   uint64 `json:"missed_rounds"`
+}
+
+type IBFTHistoryResult struct {
+	Changes []IBFTSetChange      `json:"changes"`
+	Votes   []IBFTHistoryVote    `json:"votes"`
+	Stats   []IBFTValidatorStats `json:"stats"`
+	Partial bool                 `json:"partial"`
+}
+
+func newIBFTHistoryResult(
+	history *ibftOp.ValidatorHistoryResp,
+	stats *ibftOp.ValidatorStatsResp,
+	validator *types.Address,
+) *IBFTHistoryResult {
+	res := &IBFTHistoryResult{
+		Changes: make([]IBFTSetChange, len(history.Changes)),
+		Votes:   make([]IBFTHistoryVote, len(history.Votes)),
+		Stats:   []IBFTValidatorStats{},
+		Partial: history.Partial || stats.Partial,
+	}
+
+	for i, c := range history.Changes {
+		res.Changes[i] = IBFTSetChange{
+			Number:  c.Number,
+			Hash:    c.Hash,
+			Epoch:   c.Epoch,
+			Added:   c.Added,
+			Removed: c.Removed,
+			Cause:   c.Cause,
+		}
+	}
+
+	for i, v := range history.Votes {
+		res.Votes[i] = IBFTHistoryVote{
+			Number:   v.Number,
+			Epoch:    v.Epoch,
+			Proposer: v.Validator,
+			Address:  v.Proposed,
+			Vote:     ibftHelper.BoolToVote(v.Auth),
+		}
+	}
+
+	for _, s := range stats.Stats {
+		if validator != nil && s.Address != validator.String() {
+			continue
+		}
+
+		res.Stats = append(res.Stats, IBFTValidatorStats{
+			Address:        s.Address,
+			ProposedBlocks: s.ProposedBlocks,
+			CommittedSeals: s.CommittedSeals,
+			MissedRounds:   s.MissedRounds,
+		})
+	}
+
+	return res
+}
+
+func (r *IBFTHistoryResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[IBFT HISTORY]\n")
+
+	if r.Partial {
+		buffer.WriteString("\nThe history is partial, the past blocks are still being recorded\n")
+	}
+
+	r.writeChangeData(&buffer)
+	r.writeVoteData(&buffer)
+	r.writeStatsData(&buffer)
+
+	return buffer.String()
+}
+
+func (r *IBFTHistoryResult) writeChangeData(buffer *bytes.Buffer) {
+	numChanges := len(r.Changes)
+	changes := make([]string, numChanges+1)
+
+	changes[0] = "No validator set changes found"
+
+	if numChanges > 0 {
+		changes[0] = "BLOCK|EPOCH|CAUSE|ADDED|REMOVED"
+
+		for i, c := range r.Changes {
+			changes[i+1] = fmt.Sprintf(
+				"%d|%d|%s|%s|%s",
+				c.Number,
+				c.Epoch,
+				c.Cause,
+				formatAddresses(c.Added),
+				formatAddresses(c.Removed),
+			)
+		}
+	}
+
+	buffer.WriteString("\n[VALIDATOR SET CHANGES]\n")
+	buffer.WriteString(helper.FormatList(changes))
+	buffer.WriteString("\n")
+}
+
+func (r *IBFTHistoryResult) writeVoteData(buffer *bytes.Buffer) {
+	numVotes := len(r.Votes)
+	votes := make([]string, numVotes+1)
+
+	votes[0] = "No votes found"
+
+	if numVotes > 0 {
+		votes[0] = "BLOCK|EPOCH|PROPOSER|ADDRESS|VOTE TO ADD"
+
+		for i, v := range r.Votes {
+			votes[i+1] = fmt.Sprintf(
+				"%d|%d|%s|%s|%s",
+				v.Number,
+				v.Epoch,
+				v.Proposer,
+				v.Address,
+				ibftHelper.VoteToString(v.Vote),
+			)
+		}
+	}
+
+	buffer.WriteString("\n[VOTES]\n")
+	buffer.WriteString(helper.FormatList(votes))
+	buffer.WriteString("\n")
+}
+
+func (r *IBFTHistoryResult) writeStatsData(buffer *bytes.Buffer) {
+	numStats := len(r.Stats)
+	stats := make([]string, numStats+1)
+
+	stats[0] = "No validator statistics found"
+
+	if numStats > 0 {
+		stats[0] = "ADDRESS|PROPOSED BLOCKS|COMMITTED SEALS|MISSED ROUNDS"
+
+		for i, s := range r.Stats {
+			stats[i+1] = fmt.Sprintf(
+				"%s|%d|%d|%d",
+				s.Address,
+				s.ProposedBlocks,
+				s.CommittedSeals,
+				s.MissedRounds,
+			)
+		}
+	}
+
+	buffer.WriteString("\n[VALIDATOR STATISTICS]\n")
+	buffer.WriteString(helper.FormatList(stats))
+	buffer.WriteString("\n")
+}
+
+func formatAddresses(addrs []string) string {
+	if len(addrs) == 0 {
+		return "-"
+	}
+
+	return strings.Join(addrs, ",")
+}
@@ -3,6 +3,7 @@ package ibft
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft/candidates"
+	"github.com/0xPolygon/polygon-edge/command/ibft/history"
 	"github.com/0xPolygon/polygon-edge/command/ibft/propose"
 	"github.com/0xPolygon/polygon-edge/command/ibft/quorum"
 	"github.com/0xPolygon/polygon-edge/command/ibft/snapshot"
@@ -38,5 +39,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		_switch.GetCommand(),
 		// ibft quorum
 		quorum.GetCommand(),
+		// ibft history
+		history.GetCommand(),
 	)
 }
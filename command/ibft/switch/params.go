@@ -278,6 +278,10 @@ func appendIBFTForks(
 		return errors.New(`cannot specify same IBFT type to the last fork`)
 	}
 
+	if lastFork.IsTimestampBased() {
+		return errors.New(`cannot switch by height after a fork beginning by timestamp`)
+	}
+
 	if from <= lastFork.From.Value {
 		return errors.New(`"from" must be greater than the beginning height of last fork`)
 	}
@@ -0,0 +1,165 @@
+package loadbot
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// hardenedKeyStart is the first index of the hardened BIP-32 child keys
+	hardenedKeyStart = 0x80000000
+
+	// the number of PBKDF2 rounds of the BIP-39 seed
+	seedIterations = 2048
+	seedLength     = 64
+)
+
+var (
+	errInvalidChildKey = errors.New("derived key is invalid")
+
+	// ethereumDerivationPath is m/44'/60'/0'/0, the parent of the account keys
+	ethereumDerivationPath = []uint32{
+		hardenedKeyStart + 44,
+		hardenedKeyStart + 60,
+		hardenedKeyStart,
+		0,
+	}
+)
+
+// extendedKey is a BIP-32 private key with its chain code
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// deriveAccounts derives the accounts m/44'/60'/0'/0/{0..count-1} from the BIP-39 mnemonic,
+// the same accounts as the wallets using the mnemonic. The words are not checked against
+// the BIP-39 word list
+func deriveAccounts(mnemonic string, count uint64) ([]*Account, error) {
+	seed := pbkdf2.Key(
+		[]byte(strings.Join(strings.Fields(mnemonic), " ")),
+		[]byte("mnemonic"),
+		seedIterations,
+		seedLength,
+		sha512.New,
+	)
+
+	parent, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range ethereumDerivationPath {
+		if parent, err = parent.child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	accounts := make([]*Account, count)
+
+	for i := range accounts {
+		child, err := parent.child(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := crypto.ParsePrivateKey(child.key)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[i] = &Account{
+			Address:    crypto.PubKeyToAddress(&privateKey.PublicKey),
+			PrivateKey: privateKey,
+		}
+	}
+
+	return accounts, nil
+}
+
+// generateAccounts creates accounts with random keys
+func generateAccounts(count uint64) ([]*Account, error) {
+	accounts := make([]*Account, count)
+
+	for i := range accounts {
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[i] = &Account{
+			Address:    crypto.PubKeyToAddress(&privateKey.PublicKey),
+			PrivateKey: privateKey,
+		}
+	}
+
+	return accounts, nil
+}
+
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	master := &extendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}
+
+	if !isValidKey(new(big.Int).SetBytes(master.key)) {
+		return nil, errInvalidChildKey
+	}
+
+	return master, nil
+}
+
+// child derives the private child key with the index, hardened from hardenedKeyStart
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	data := make([]byte, 0, 37)
+
+	if index >= hardenedKeyStart {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		_, publicKey := btcec.PrivKeyFromBytes(crypto.S256, k.key)
+		data = append(data, publicKey.SerializeCompressed()...)
+	}
+
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(crypto.S256.Params().N) >= 0 {
+		return nil, fmt.Errorf("%w: index %d", errInvalidChildKey, index)
+	}
+
+	childKey := tweak.Add(tweak, new(big.Int).SetBytes(k.key))
+	childKey.Mod(childKey, crypto.S256.Params().N)
+
+	if !isValidKey(childKey) {
+		return nil, fmt.Errorf("%w: index %d", errInvalidChildKey, index)
+	}
+
+	return &extendedKey{
+		key:       childKey.FillBytes(make([]byte, 32)),
+		chainCode: sum[32:],
+	}, nil
+}
+
+func isValidKey(key *big.Int) bool {
+	return key.Sign() > 0 && key.Cmp(crypto.S256.Params().N) < 0
+}
@@ -0,0 +1,34 @@
+package loadbot
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveAccounts(t *testing.T) {
+	t.Parallel()
+
+	// the default development mnemonic of Hardhat and Foundry
+	mnemonic := "test test test test test test test test test test test junk"
+
+	accounts, err := deriveAccounts(mnemonic, 3)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 3)
+
+	expected := []types.Address{
+		types.StringToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		types.StringToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		types.StringToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+	}
+
+	for i, account := range accounts {
+		assert.Equal(t, expected[i], account.Address)
+	}
+
+	// the extra whitespace doesn't change the seed
+	spaced, err := deriveAccounts("  test test test test test test test test test test test\tjunk ", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[0], spaced[0].Address)
+}
@@ -26,7 +26,10 @@ func (l *Loadbot) deployContract(
 	}
 
 	// deploy SC
-	txHash, err := l.executeTxn(grpcClient)
+	txHash, err := l.executeTxn(
+		&grpcSubmitter{client: grpcClient},
+		&receiptConfirmer{client: jsonClient},
+	)
 	if err != nil {
 		//nolint:forcetypeassert
 		l.generator.(generator.ContractTxnGenerator).MarkFailedContractTxn(&generator.FailedContractTxnInfo{
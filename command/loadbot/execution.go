@@ -11,9 +11,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/loadbot/generator"
-	"github.com/0xPolygon/polygon-edge/helper/tests"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/umbracle/ethgo/jsonrpc"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -59,6 +57,8 @@ type Configuration struct {
 	ContractArtifact *generator.ContractArtifact
 	ConstructorArgs  []byte // smart contract constructor args
 	MaxWait          uint64 // max wait time for receipts in minutes
+	SubmitPaths      []SubmitPath
+	Confirmation     ConfirmMode
 }
 
 type metadata struct {
@@ -96,12 +96,20 @@ type ContractMetricsData struct {
 	ContractGasMetrics              *BlockGasMetrics
 }
 
+// PathMetrics are the metrics of the transactions sent through a single submission path
+type PathMetrics struct {
+	TotalTransactionsSentCount uint64
+	FailedTransactionsCount    uint64
+	TransactionDuration        ExecDuration
+}
+
 type Metrics struct {
 	TotalTransactionsSentCount uint64
 	FailedTransactionsCount    uint64
 	TransactionDuration        ExecDuration
 	ContractMetrics            *ContractMetricsData
 	GasMetrics                 *BlockGasMetrics
+	PathMetrics                map[SubmitPath]*PathMetrics
 }
 
 type Loadbot struct {
@@ -122,9 +130,26 @@ func NewLoadbot(cfg *Configuration) *Loadbot {
 			GasMetrics: &BlockGasMetrics{
 				Blocks: make(map[uint64]GasMetrics),
 			},
+			PathMetrics: make(map[SubmitPath]*PathMetrics),
 		},
 	}
 
+	if len(cfg.SubmitPaths) == 0 {
+		cfg.SubmitPaths = []SubmitPath{grpcPath}
+	}
+
+	if cfg.Confirmation == "" {
+		cfg.Confirmation = receiptConfirm
+	}
+
+	for _, path := range cfg.SubmitPaths {
+		loadbot.metrics.PathMetrics[path] = &PathMetrics{
+			TransactionDuration: ExecDuration{
+				blockTransactions: make(map[uint64]uint64),
+			},
+		}
+	}
+
 	// Attempt to initialize contract metrics if needed
 	loadbot.initContractMetricsIfNeeded()
 
@@ -262,6 +287,41 @@ func (l *Loadbot) Run() error {
 		}
 	}
 
+	var wsConn *wsClient
+
+	if l.needsWebSocket() {
+		wsAddress, err := toWSAddress(l.cfg.JSONRPC)
+		if err != nil {
+			return fmt.Errorf("invalid WebSocket address: %w", err)
+		}
+
+		if wsConn, err = newWSClient(wsAddress); err != nil {
+			return fmt.Errorf("an error has occurred while creating WebSocket client: %w", err)
+		}
+
+		defer wsConn.close()
+	}
+
+	submitters := make([]submitter, len(l.cfg.SubmitPaths))
+
+	for i, path := range l.cfg.SubmitPaths {
+		switch path {
+		case httpPath:
+			submitters[i] = &httpSubmitter{client: jsonClient}
+		case wsPath:
+			submitters[i] = &wsSubmitter{client: wsConn}
+		default:
+			submitters[i] = &grpcSubmitter{client: grpcClient}
+		}
+	}
+
+	confirm, err := l.newConfirmer(jsonClient, grpcClient, wsConn)
+	if err != nil {
+		return fmt.Errorf("unable to set up transaction confirmation: %w", err)
+	}
+
+	defer confirm.close()
+
 	var (
 		seenBlockNums     = make(map[uint64]struct{})
 		seenBlockNumsLock sync.Mutex
@@ -280,16 +340,21 @@ func (l *Loadbot) Run() error {
 
 		l.metrics.TotalTransactionsSentCount += 1
 
+		// the transactions are spread evenly across the submission paths
+		path := l.cfg.SubmitPaths[i%uint64(len(submitters))]
+		pathMetrics := l.metrics.PathMetrics[path]
+		pathMetrics.TotalTransactionsSentCount += 1
+
 		wg.Add(1)
 
-		go func(index uint64) {
+		go func(index uint64, submit submitter) {
 			defer wg.Done()
 
 			// Start the performance timer
 			start := time.Now()
 
 			// Execute the transaction
-			txHash, err := l.executeTxn(grpcClient)
+			txHash, err := l.executeTxn(submit, confirm)
 			if err != nil {
 				l.generator.MarkFailedTxn(&generator.FailedTxnInfo{
 					Index:  index,
@@ -300,6 +365,7 @@ func (l *Loadbot) Run() error {
 					},
 				})
 				atomic.AddUint64(&l.metrics.FailedTransactionsCount, 1)
+				atomic.AddUint64(&pathMetrics.FailedTransactionsCount, 1)
 
 				return
 			}
@@ -307,7 +373,7 @@ func (l *Loadbot) Run() error {
 			ctx, cancel := context.WithTimeout(context.Background(), receiptTimeout)
 			defer cancel()
 
-			receipt, err := tests.WaitForReceipt(ctx, jsonClient.Eth(), txHash)
+			included, err := confirm.wait(ctx, txHash)
 			if err != nil {
 				l.generator.MarkFailedTxn(&generator.FailedTxnInfo{
 					Index:  index,
@@ -318,25 +384,24 @@ func (l *Loadbot) Run() error {
 					},
 				})
 				atomic.AddUint64(&l.metrics.FailedTransactionsCount, 1)
+				atomic.AddUint64(&pathMetrics.FailedTransactionsCount, 1)
 
 				return
 			}
 
 			// Mark the block as seen so data on it
 			// is gathered later
-			markSeenBlock(receipt.BlockNumber)
-
-			// Stop the performance timer
-			end := time.Now()
-
-			l.metrics.TransactionDuration.reportTurnAroundTime(
-				txHash,
-				&metadata{
-					turnAroundTime: end.Sub(start),
-					blockNumber:    receipt.BlockNumber,
-				},
-			)
-		}(i)
+			markSeenBlock(included.blockNumber)
+
+			// Stop the performance timer at the time the inclusion was learned
+			txMetadata := &metadata{
+				turnAroundTime: included.time.Sub(start),
+				blockNumber:    included.blockNumber,
+			}
+
+			l.metrics.TransactionDuration.reportTurnAroundTime(txHash, txMetadata)
+			pathMetrics.TransactionDuration.reportTurnAroundTime(txHash, txMetadata)
+		}(i, submitters[i%uint64(len(submitters))])
 	}
 
 	wg.Wait()
@@ -353,28 +418,54 @@ func (l *Loadbot) Run() error {
 	l.metrics.TransactionDuration.calcTurnAroundMetrics()
 	l.metrics.TransactionDuration.TotalExecTime = endTime.Sub(startTime)
 
+	for _, pathMetrics := range l.metrics.PathMetrics {
+		pathMetrics.TransactionDuration.calcTurnAroundMetrics()
+		pathMetrics.TransactionDuration.TotalExecTime = endTime.Sub(startTime)
+	}
+
 	return nil
 }
 
+// needsWebSocket checks if the transactions are submitted or confirmed over WebSocket
+func (l *Loadbot) needsWebSocket() bool {
+	return l.cfg.Confirmation == newHeadsConfirm || containsSubmitPath(l.cfg.SubmitPaths, wsPath)
+}
+
+func (l *Loadbot) newConfirmer(
+	jsonClient *jsonrpc.Client,
+	grpcClient txpoolOp.TxnPoolOperatorClient,
+	wsConn *wsClient,
+) (confirmer, error) {
+	switch l.cfg.Confirmation {
+	case newHeadsConfirm:
+		return newNewHeadsConfirmer(wsConn, jsonClient)
+	case txpoolConfirm:
+		return newTxPoolConfirmer(grpcClient)
+	default:
+		return &receiptConfirmer{client: jsonClient}, nil
+	}
+}
+
 func (l *Loadbot) executeTxn(
-	client txpoolOp.TxnPoolOperatorClient,
+	submit submitter,
+	confirm confirmer,
 ) (ethgo.Hash, error) {
 	txn, err := l.generator.GenerateTransaction()
 	if err != nil {
 		return ethgo.Hash{}, err
 	}
 
-	addReq := &txpoolOp.AddTxnReq{
-		Raw: &any.Any{
-			Value: txn.MarshalRLP(),
-		},
-		From: types.ZeroAddress.String(),
-	}
+	// the hash is expected before the submission,
+	// so an inclusion notified before the submission returns isn't missed
+	expectedHash := ethgo.Hash(txn.ComputeHash().Hash)
+	confirm.expect(expectedHash)
 
-	addRes, addErr := client.AddTxn(context.Background(), addReq)
+	txHash, addErr := submit.submit(txn)
 	if addErr != nil {
+		confirm.forget(expectedHash)
+
 		return ethgo.Hash{}, fmt.Errorf("unable to add transaction, %w", addErr)
 	}
 
-	return ethgo.Hash(types.StringToHash(addRes.TxHash)), nil
+	return txHash, nil
 }
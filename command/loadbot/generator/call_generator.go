@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errMissingABI    = errors.New("contract ABI not specified")
+	errUnknownMethod = errors.New("method not found in contract ABI")
+)
+
+// CallGenerator generates transactions calling a method of a deployed contract
+type CallGenerator struct {
+	BaseGenerator
+
+	contractAddress types.Address
+	encodedParams   []byte
+}
+
+// NewCallGenerator creates a generator calling the method of the contract at
+// params.ContractAddress with the arguments, encoded using the ABI of params.ContractArtifact
+func NewCallGenerator(params *GeneratorParams, method string, args []interface{}) (*CallGenerator, error) {
+	gen := &CallGenerator{
+		contractAddress: types.Address(params.ContractAddress),
+	}
+
+	gen.BaseGenerator = BaseGenerator{
+		failedTxns: make([]*FailedTxnInfo, 0),
+		params:     params,
+		signer:     crypto.NewEIP155Signer(params.ChainID),
+	}
+
+	if params.ContractArtifact == nil || params.ContractArtifact.ABI == nil {
+		return nil, errMissingABI
+	}
+
+	abiMethod, ok := params.ContractArtifact.ABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownMethod, method)
+	}
+
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	var err error
+	if gen.encodedParams, err = abiMethod.Encode(args); err != nil {
+		return nil, fmt.Errorf("cannot encode %s method params: %w", method, err)
+	}
+
+	return gen, nil
+}
+
+func (gen *CallGenerator) GetExampleTransaction() (*types.Transaction, error) {
+	return gen.signer.SignTx(&types.Transaction{
+		From:     gen.params.SenderAddress,
+		To:       &gen.contractAddress,
+		Value:    gen.value(),
+		GasPrice: gen.params.GasPrice,
+		Input:    gen.encodedParams,
+		V:        big.NewInt(1), // it is necessary to encode in rlp
+	}, gen.params.SenderKey)
+}
+
+func (gen *CallGenerator) GenerateTransaction() (*types.Transaction, error) {
+	nonce := gen.params.nextNonce()
+
+	txn, err := gen.signer.SignTx(&types.Transaction{
+		From:     gen.params.SenderAddress,
+		To:       &gen.contractAddress,
+		Gas:      gen.estimatedGas,
+		Value:    gen.value(),
+		GasPrice: gen.params.GasPrice,
+		Nonce:    nonce,
+		Input:    gen.encodedParams,
+		V:        big.NewInt(1), // it is necessary to encode in rlp
+	}, gen.params.SenderKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return txn, nil
+}
+
+func (gen *CallGenerator) value() *big.Int {
+	if gen.params.Value == nil {
+		return big.NewInt(0)
+	}
+
+	return gen.params.Value
+}
@@ -3,7 +3,6 @@ package generator
 import (
 	"math/big"
 	"sync"
-	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -46,7 +45,7 @@ func (gen *ContractTxnsGenerator) GetExampleTransaction() (*types.Transaction, e
 }
 
 func (gen *ContractTxnsGenerator) GenerateTransaction() (*types.Transaction, error) {
-	newNextNonce := atomic.AddUint64(&gen.params.Nonce, 1)
+	nonce := gen.params.nextNonce()
 
 	if gen.contractAddress == nil {
 		//	contract not deployed yet
@@ -56,7 +55,7 @@ func (gen *ContractTxnsGenerator) GenerateTransaction() (*types.Transaction, err
 			Value:    big.NewInt(0),
 			Gas:      gen.estimatedGas,
 			GasPrice: gen.params.GasPrice,
-			Nonce:    newNextNonce - 1,
+			Nonce:    nonce,
 			Input:    gen.contractBytecode,
 			V:        big.NewInt(1), // it is necessary to encode in rlp
 		}, gen.params.SenderKey)
@@ -69,7 +68,7 @@ func (gen *ContractTxnsGenerator) GenerateTransaction() (*types.Transaction, err
 		Value:    big.NewInt(0),
 		Gas:      gen.estimatedGas,
 		GasPrice: gen.params.GasPrice,
-		Nonce:    newNextNonce - 1,
+		Nonce:    nonce,
 		Input:    gen.encodedParams,
 		V:        big.NewInt(1), // it is necessary to encode in rlp
 	}, gen.params.SenderKey)
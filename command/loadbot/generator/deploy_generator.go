@@ -4,7 +4,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -40,20 +39,20 @@ func NewDeployGenerator(params *GeneratorParams) (*DeployGenerator, error) {
 		return nil, fmt.Errorf("unable to decode bytecode, %w", err)
 	}
 
-	deployGenerator.contractBytecode = buf
+	deployGenerator.contractBytecode = append(buf, params.ConstructorArgs...)
 
 	return deployGenerator, nil
 }
 
 func (dg *DeployGenerator) GenerateTransaction() (*types.Transaction, error) {
-	newNextNonce := atomic.AddUint64(&dg.params.Nonce, 1)
+	nonce := dg.params.nextNonce()
 
 	txn, err := dg.signer.SignTx(&types.Transaction{
 		From:     dg.params.SenderAddress,
 		Gas:      dg.estimatedGas,
 		Value:    big.NewInt(0),
 		GasPrice: dg.params.GasPrice,
-		Nonce:    newNextNonce - 1,
+		Nonce:    nonce,
 		Input:    dg.contractBytecode,
 		V:        big.NewInt(1), // it is necessary to encode in rlp
 	}, dg.params.SenderKey)
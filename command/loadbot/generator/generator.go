@@ -6,6 +6,7 @@ import (
 	"github.com/umbracle/ethgo"
 	"io/ioutil"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/ethgo/abi"
@@ -70,6 +71,20 @@ type GeneratorParams struct {
 	ContractArtifact *ContractArtifact
 	ConstructorArgs  []byte // smart contract constructor arguments
 	ContractAddress  ethgo.Address
+
+	// SharedNonce is the nonce counter used instead of Nonce, so the generators
+	// of the same sender can send transactions concurrently
+	SharedNonce *uint64
+}
+
+// nextNonce returns the nonce for the next transaction of the sender
+func (p *GeneratorParams) nextNonce() uint64 {
+	counter := &p.Nonce
+	if p.SharedNonce != nil {
+		counter = p.SharedNonce
+	}
+
+	return atomic.AddUint64(counter, 1) - 1
 }
 
 // ReadContractArtifact reads the contract bytecode from the specified path
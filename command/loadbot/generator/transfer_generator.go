@@ -3,7 +3,6 @@ package generator
 import (
 	"fmt"
 	"math/big"
-	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -53,7 +52,7 @@ func (tg *TransferGenerator) generateReceiver() error {
 }
 
 func (tg *TransferGenerator) GenerateTransaction() (*types.Transaction, error) {
-	newNextNonce := atomic.AddUint64(&tg.params.Nonce, 1)
+	nonce := tg.params.nextNonce()
 
 	txn, err := tg.signer.SignTx(&types.Transaction{
 		From:     tg.params.SenderAddress,
@@ -61,7 +60,7 @@ func (tg *TransferGenerator) GenerateTransaction() (*types.Transaction, error) {
 		Gas:      tg.estimatedGas,
 		Value:    tg.params.Value,
 		GasPrice: tg.params.GasPrice,
-		Nonce:    newNextNonce - 1,
+		Nonce:    nonce,
 		V:        big.NewInt(1), // it is necessary to encode in rlp
 	}, tg.params.SenderKey)
 
@@ -121,6 +121,45 @@ func setFlags(cmd *cobra.Command) {
 		0,
 		"sets the maximum wait time for transactions receipts in minutes.",
 	)
+
+	cmd.Flags().StringVar(
+		&params.submitRaw,
+		submitFlag,
+		string(grpcPath),
+		"the comma separated paths the transactions are submitted through [grpc, http, ws]. "+
+			"Transactions are spread evenly across the paths, and the results are reported per path",
+	)
+
+	cmd.Flags().StringVar(
+		&params.confirmRaw,
+		confirmFlag,
+		string(receiptConfirm),
+		"the way transaction inclusion is confirmed [receipt, newheads, txpool]. newheads uses the "+
+			"eth_subscribe WebSocket subscription, txpool uses the txpool gRPC event stream",
+	)
+
+	cmd.Flags().StringVar(
+		&params.scenarioPath,
+		scenarioFlag,
+		"",
+		"the path to the YAML scenario file. If set, the scenario workloads and TPS profile are used "+
+			"instead of the mode, tps and count flags",
+	)
+
+	cmd.Flags().StringVar(
+		&params.reportPath,
+		reportFlag,
+		"",
+		"the path of the file the scenario report is written to",
+	)
+
+	cmd.Flags().StringVar(
+		&params.formatRaw,
+		formatFlag,
+		"",
+		"the format of the scenario report [json, csv]. If omitted, it's taken from the report file extension, "+
+			"JSON by default",
+	)
 }
 
 func setRequiredFlags(cmd *cobra.Command) {
@@ -139,6 +178,10 @@ func runPreRun(cmd *cobra.Command, _ []string) error {
 		return errInvalidValues
 	}
 
+	if err := params.initScenario(); err != nil {
+		return err
+	}
+
 	if _, err := helper.ParseGRPCAddress(
 		helper.GetGRPCAddress(cmd),
 	); err != nil {
@@ -158,6 +201,26 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
+	if params.scenario != nil {
+		scenarioResult, err := runScenario(
+			params.generateScenarioConfig(
+				helper.GetJSONRPCAddress(cmd),
+				helper.GetGRPCAddress(cmd),
+			),
+			params.reportPath,
+			params.reportFormat,
+		)
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		outputter.SetCommandResult(scenarioResult)
+
+		return
+	}
+
 	config := params.generateConfig(
 		helper.GetJSONRPCAddress(cmd),
 		helper.GetGRPCAddress(cmd),
@@ -187,6 +250,7 @@ func runLoadbot(config *Configuration, detailed bool) (*LoadbotResult, error) {
 		loadbot.GetMetrics(),
 		config.GeneratorMode,
 	)
+	result.initPathData(loadbot.GetMetrics(), config.SubmitPaths, config.Confirmation)
 
 	if detailed {
 		result.initDetailedErrors(loadbot.GetGenerator())
@@ -194,3 +258,21 @@ func runLoadbot(config *Configuration, detailed bool) (*LoadbotResult, error) {
 
 	return result, nil
 }
+
+func runScenario(config *ScenarioConfiguration, reportPath string, format ReportFormat) (*ScenarioResult, error) {
+	result, err := NewScenarioLoadbot(config).Run()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"an error occurred while running the loadbot scenario: %w",
+			err,
+		)
+	}
+
+	if reportPath != "" {
+		if err := result.WriteReport(reportPath, format); err != nil {
+			return nil, fmt.Errorf("unable to write the report: %w", err)
+		}
+	}
+
+	return result, nil
+}
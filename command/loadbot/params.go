@@ -47,6 +47,11 @@ const (
 	gasLimitFlag = "gas-limit"
 	contractFlag = "contract"
 	maxWaitFlag  = "max-wait"
+	scenarioFlag = "scenario"
+	reportFlag   = "report"
+	formatFlag   = "report-format"
+	submitFlag   = "submit"
+	confirmFlag  = "confirm"
 )
 
 type loadbotParams struct {
@@ -57,6 +62,8 @@ type loadbotParams struct {
 	maxWait  uint64
 
 	contractPath string
+	scenarioPath string
+	reportPath   string
 
 	detailed bool
 
@@ -66,6 +73,9 @@ type loadbotParams struct {
 	valueRaw    string
 	gasPriceRaw string
 	gasLimitRaw string
+	formatRaw   string
+	submitRaw   string
+	confirmRaw  string
 
 	mode             Mode
 	sender           types.Address
@@ -75,6 +85,10 @@ type loadbotParams struct {
 	gasLimit         *big.Int
 	contractArtifact *generator.ContractArtifact
 	constructorArgs  []byte
+	scenario         *Scenario
+	reportFormat     ReportFormat
+	submitPaths      []SubmitPath
+	confirmMode      ConfirmMode
 }
 
 func (p *loadbotParams) validateFlags() error {
@@ -88,6 +102,89 @@ func (p *loadbotParams) validateFlags() error {
 		return err
 	}
 
+	if err := p.initSubmitPaths(); err != nil {
+		return err
+	}
+
+	if err := p.initConfirmMode(); err != nil {
+		return err
+	}
+
+	return p.initReportFormat()
+}
+
+func (p *loadbotParams) initSubmitPaths() error {
+	p.submitPaths = make([]SubmitPath, 0)
+
+	for _, raw := range strings.Split(p.submitRaw, ",") {
+		path := SubmitPath(strings.ToLower(strings.TrimSpace(raw)))
+
+		switch path {
+		case grpcPath, httpPath, wsPath:
+		default:
+			return fmt.Errorf("%w: %s", errInvalidSubmitPath, raw)
+		}
+
+		if containsSubmitPath(p.submitPaths, path) {
+			continue
+		}
+
+		p.submitPaths = append(p.submitPaths, path)
+	}
+
+	return nil
+}
+
+func containsSubmitPath(paths []SubmitPath, path SubmitPath) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *loadbotParams) initConfirmMode() error {
+	p.confirmMode = ConfirmMode(strings.ToLower(p.confirmRaw))
+
+	switch p.confirmMode {
+	case receiptConfirm, newHeadsConfirm, txpoolConfirm:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidConfirmMode, p.confirmRaw)
+	}
+}
+
+func (p *loadbotParams) initReportFormat() error {
+	if p.formatRaw == "" {
+		p.reportFormat = reportFormatFromPath(p.reportPath)
+
+		return nil
+	}
+
+	p.reportFormat = ReportFormat(strings.ToLower(p.formatRaw))
+
+	switch p.reportFormat {
+	case jsonReport, csvReport:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidReportFormat, p.formatRaw)
+	}
+}
+
+func (p *loadbotParams) initScenario() error {
+	if p.scenarioPath == "" {
+		return nil
+	}
+
+	scenario, err := ReadScenario(p.scenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	p.scenario = scenario
+
 	return nil
 }
 
@@ -217,6 +314,23 @@ func (p *loadbotParams) generateConfig(
 		ContractArtifact: p.contractArtifact,
 		ConstructorArgs:  p.constructorArgs,
 		MaxWait:          p.maxWait,
+		SubmitPaths:      p.submitPaths,
+		Confirmation:     p.confirmMode,
+	}
+}
+
+func (p *loadbotParams) generateScenarioConfig(
+	jsonRPCAddress string,
+	grpcAddress string,
+) *ScenarioConfiguration {
+	return &ScenarioConfiguration{
+		Scenario: p.scenario,
+		Sender:   p.sender,
+		JSONRPC:  jsonRPCAddress,
+		GRPC:     grpcAddress,
+		MaxConns: int(p.maxConns),
+		ChainID:  p.chainID,
+		GasPrice: p.gasPrice,
 	}
 }
 
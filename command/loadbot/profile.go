@@ -0,0 +1,109 @@
+package loadbot
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProfileType is the shape of the transaction rate over the run
+type ProfileType string
+
+const (
+	constantProfile ProfileType = "constant"
+	rampProfile     ProfileType = "ramp"
+	stepProfile     ProfileType = "step"
+	spikeProfile    ProfileType = "spike"
+)
+
+// ProfileConfig describes the TPS of the scenario over time:
+//   - constant: TPS for the whole run
+//   - ramp: linear change from StartTPS to TPS over RampDuration, then TPS
+//   - step: the TPS of every step for its duration, then the TPS of the last step
+//   - spike: TPS, except SpikeTPS from SpikeAt for SpikeDuration
+type ProfileConfig struct {
+	Type ProfileType `yaml:"type"`
+	TPS  uint64      `yaml:"tps"`
+
+	StartTPS     uint64        `yaml:"start_tps"`
+	RampDuration time.Duration `yaml:"ramp_duration"`
+
+	Steps []ProfileStep `yaml:"steps"`
+
+	SpikeTPS      uint64        `yaml:"spike_tps"`
+	SpikeAt       time.Duration `yaml:"spike_at"`
+	SpikeDuration time.Duration `yaml:"spike_duration"`
+}
+
+// ProfileStep is a period of the step profile
+type ProfileStep struct {
+	TPS      uint64        `yaml:"tps"`
+	Duration time.Duration `yaml:"duration"`
+}
+
+func (p *ProfileConfig) validate() error {
+	if p.Type == "" {
+		p.Type = constantProfile
+	}
+
+	switch p.Type {
+	case constantProfile:
+		if p.TPS == 0 {
+			return fmt.Errorf("%w: constant profile needs tps", errInvalidProfileParams)
+		}
+	case rampProfile:
+		if p.TPS == 0 || p.RampDuration == 0 {
+			return fmt.Errorf("%w: ramp profile needs tps and ramp_duration", errInvalidProfileParams)
+		}
+	case stepProfile:
+		if len(p.Steps) == 0 {
+			return fmt.Errorf("%w: step profile needs steps", errInvalidProfileParams)
+		}
+
+		for i, step := range p.Steps {
+			if step.Duration == 0 {
+				return fmt.Errorf("%w: step %d has no duration", errInvalidProfileParams, i)
+			}
+		}
+	case spikeProfile:
+		if p.SpikeTPS == 0 || p.SpikeDuration == 0 {
+			return fmt.Errorf("%w: spike profile needs spike_tps and spike_duration", errInvalidProfileParams)
+		}
+	default:
+		return fmt.Errorf("%w: %s", errInvalidProfileType, p.Type)
+	}
+
+	return nil
+}
+
+// tpsAt returns the transaction rate at the elapsed time of the run
+func (p *ProfileConfig) tpsAt(elapsed time.Duration) uint64 {
+	switch p.Type {
+	case rampProfile:
+		if elapsed >= p.RampDuration {
+			return p.TPS
+		}
+
+		progress := float64(elapsed) / float64(p.RampDuration)
+		start, end := float64(p.StartTPS), float64(p.TPS)
+
+		return uint64(start + (end-start)*progress)
+	case stepProfile:
+		for _, step := range p.Steps {
+			if elapsed < step.Duration {
+				return step.TPS
+			}
+
+			elapsed -= step.Duration
+		}
+
+		return p.Steps[len(p.Steps)-1].TPS
+	case spikeProfile:
+		if elapsed >= p.SpikeAt && elapsed < p.SpikeAt+p.SpikeDuration {
+			return p.SpikeTPS
+		}
+
+		return p.TPS
+	default:
+		return p.TPS
+	}
+}
@@ -0,0 +1,444 @@
+package loadbot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+// ReportFormat is the file format of the scenario report
+type ReportFormat string
+
+const (
+	jsonReport ReportFormat = "json"
+	csvReport  ReportFormat = "csv"
+)
+
+var errInvalidReportFormat = errors.New("invalid report format")
+
+// txnStatus is the outcome of a scenario transaction
+type txnStatus int
+
+const (
+	txnConfirmed txnStatus = iota
+	txnReverted
+	txnRejected
+	txnTimedOut
+)
+
+// txnRecord is the outcome of a single scenario transaction
+type txnRecord struct {
+	workload int
+	status   txnStatus
+	latency  time.Duration
+	block    uint64
+	reason   string // the txpool rejection reason
+}
+
+type ScenarioCounts struct {
+	Submitted uint64 `json:"submitted"`
+	Confirmed uint64 `json:"confirmed"`
+	Reverted  uint64 `json:"reverted"`
+	Rejected  uint64 `json:"rejected"`
+	TimedOut  uint64 `json:"timed_out"`
+}
+
+func (c *ScenarioCounts) add(record *txnRecord) {
+	c.Submitted++
+
+	switch record.status {
+	case txnConfirmed:
+		c.Confirmed++
+	case txnReverted:
+		c.Reverted++
+	case txnRejected:
+		c.Rejected++
+	case txnTimedOut:
+		c.TimedOut++
+	}
+}
+
+// LatencyStats are the turn around times of the sealed transactions in seconds
+type LatencyStats struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+type WorkloadResult struct {
+	Name    string         `json:"name"`
+	Type    Mode           `json:"type"`
+	Weight  uint64         `json:"weight"`
+	Counts  ScenarioCounts `json:"counts"`
+	Latency LatencyStats   `json:"latency"`
+}
+
+type BlockResult struct {
+	Number       uint64  `json:"number"`
+	Transactions uint64  `json:"transactions"`
+	GasUsed      uint64  `json:"gas_used"`
+	GasLimit     uint64  `json:"gas_limit"`
+	Utilization  float64 `json:"utilization"`
+}
+
+// ScenarioResult is the report of a scenario run
+type ScenarioResult struct {
+	Scenario           string            `json:"scenario"`
+	Profile            ProfileType       `json:"profile"`
+	Senders            uint64            `json:"senders"`
+	ExecTime           float64           `json:"exec_time"`
+	ApproxTPS          float64           `json:"approx_tps"`
+	Counts             ScenarioCounts    `json:"counts"`
+	Latency            LatencyStats      `json:"latency"`
+	Workloads          []WorkloadResult  `json:"workloads"`
+	Blocks             []BlockResult     `json:"blocks"`
+	AverageUtilization float64           `json:"average_utilization"`
+	Rejections         map[string]uint64 `json:"rejections"`
+	Contracts          map[string]string `json:"contracts,omitempty"`
+}
+
+// newScenarioResult aggregates the transaction records of the run
+func newScenarioResult(
+	scenario *Scenario,
+	senders uint64,
+	records []*txnRecord,
+	gasMetrics *BlockGasMetrics,
+	execTime time.Duration,
+) *ScenarioResult {
+	res := &ScenarioResult{
+		Scenario:   scenario.Name,
+		Profile:    scenario.Profile.Type,
+		Senders:    senders,
+		ExecTime:   common.ToFixedFloat(execTime.Seconds(), durationPrecision),
+		Workloads:  make([]WorkloadResult, len(scenario.Workloads)),
+		Blocks:     make([]BlockResult, 0, len(gasMetrics.Blocks)),
+		Rejections: make(map[string]uint64),
+	}
+
+	var (
+		latencies         = make([]time.Duration, 0, len(records))
+		workloadLatencies = make([][]time.Duration, len(scenario.Workloads))
+		blockTransactions = make(map[uint64]uint64)
+	)
+
+	for i, workload := range scenario.Workloads {
+		res.Workloads[i] = WorkloadResult{
+			Name:   workload.Name,
+			Type:   workload.Type,
+			Weight: workload.Weight,
+		}
+	}
+
+	for _, record := range records {
+		res.Counts.add(record)
+		res.Workloads[record.workload].Counts.add(record)
+
+		switch record.status {
+		case txnConfirmed, txnReverted:
+			latencies = append(latencies, record.latency)
+			workloadLatencies[record.workload] = append(workloadLatencies[record.workload], record.latency)
+			blockTransactions[record.block]++
+		case txnRejected:
+			res.Rejections[record.reason]++
+		case txnTimedOut:
+		}
+	}
+
+	res.Latency = calcLatencyStats(latencies)
+
+	for i := range res.Workloads {
+		res.Workloads[i].Latency = calcLatencyStats(workloadLatencies[i])
+	}
+
+	for number, gas := range gasMetrics.Blocks {
+		res.Blocks = append(res.Blocks, BlockResult{
+			Number:       number,
+			Transactions: blockTransactions[number],
+			GasUsed:      gas.GasUsed,
+			GasLimit:     gas.GasLimit,
+			Utilization:  common.ToFixedFloat(gas.Utilization, 2),
+		})
+	}
+
+	sort.Slice(res.Blocks, func(i, j int) bool {
+		return res.Blocks[i].Number < res.Blocks[j].Number
+	})
+
+	if len(gasMetrics.Blocks) > 0 {
+		res.AverageUtilization = common.ToFixedFloat(calculateAvgBlockUtil(gasMetrics.Blocks), 2)
+	}
+
+	if execTime > 0 {
+		res.ApproxTPS = common.ToFixedFloat(float64(res.Counts.Submitted)/execTime.Seconds(), 2)
+	}
+
+	return res
+}
+
+// calcLatencyStats calculates the latency percentiles using the nearest-rank method
+func calcLatencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	seconds := func(d time.Duration) float64 {
+		return common.ToFixedFloat(d.Seconds(), durationPrecision)
+	}
+
+	return LatencyStats{
+		Min:  seconds(sorted[0]),
+		Mean: seconds(total / time.Duration(len(sorted))),
+		P50:  seconds(percentile(sorted, 50)),
+		P90:  seconds(percentile(sorted, 90)),
+		P95:  seconds(percentile(sorted, 95)),
+		P99:  seconds(percentile(sorted, 99)),
+		Max:  seconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// reportFormatFromPath returns the report format of the file extension, JSON by default
+func reportFormatFromPath(path string) ReportFormat {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return csvReport
+	}
+
+	return jsonReport
+}
+
+// WriteReport writes the result to the file in the format
+func (r *ScenarioResult) WriteReport(path string, format ReportFormat) error {
+	buffer := new(bytes.Buffer)
+
+	switch format {
+	case jsonReport:
+		encoder := json.NewEncoder(buffer)
+		encoder.SetIndent("", "    ")
+
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	case csvReport:
+		if err := r.writeCSV(buffer); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", errInvalidReportFormat, format)
+	}
+
+	return os.WriteFile(path, buffer.Bytes(), 0600)
+}
+
+// writeCSV writes the result in the long format, a row for every metric:
+// section,name,metric,value
+func (r *ScenarioResult) writeCSV(output io.Writer) error {
+	writer := csv.NewWriter(output)
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	formatUint := func(value uint64) string {
+		return strconv.FormatUint(value, 10)
+	}
+
+	rows := [][]string{
+		{"section", "name", "metric", "value"},
+		{"run", r.Scenario, "profile", string(r.Profile)},
+		{"run", r.Scenario, "senders", formatUint(r.Senders)},
+		{"run", r.Scenario, "exec_time", formatFloat(r.ExecTime)},
+		{"run", r.Scenario, "approx_tps", formatFloat(r.ApproxTPS)},
+		{"run", r.Scenario, "average_utilization", formatFloat(r.AverageUtilization)},
+	}
+
+	addCounts := func(section, name string, counts ScenarioCounts) {
+		rows = append(rows,
+			[]string{section, name, "submitted", formatUint(counts.Submitted)},
+			[]string{section, name, "confirmed", formatUint(counts.Confirmed)},
+			[]string{section, name, "reverted", formatUint(counts.Reverted)},
+			[]string{section, name, "rejected", formatUint(counts.Rejected)},
+			[]string{section, name, "timed_out", formatUint(counts.TimedOut)},
+		)
+	}
+
+	addLatency := func(name string, latency LatencyStats) {
+		rows = append(rows,
+			[]string{"latency", name, "min", formatFloat(latency.Min)},
+			[]string{"latency", name, "mean", formatFloat(latency.Mean)},
+			[]string{"latency", name, "p50", formatFloat(latency.P50)},
+			[]string{"latency", name, "p90", formatFloat(latency.P90)},
+			[]string{"latency", name, "p95", formatFloat(latency.P95)},
+			[]string{"latency", name, "p99", formatFloat(latency.P99)},
+			[]string{"latency", name, "max", formatFloat(latency.Max)},
+		)
+	}
+
+	addCounts("count", "all", r.Counts)
+	addLatency("all", r.Latency)
+
+	for _, workload := range r.Workloads {
+		addCounts("count", workload.Name, workload.Counts)
+		addLatency(workload.Name, workload.Latency)
+	}
+
+	for _, block := range r.Blocks {
+		name := formatUint(block.Number)
+
+		rows = append(rows,
+			[]string{"block", name, "transactions", formatUint(block.Transactions)},
+			[]string{"block", name, "gas_used", formatUint(block.GasUsed)},
+			[]string{"block", name, "gas_limit", formatUint(block.GasLimit)},
+			[]string{"block", name, "utilization", formatFloat(block.Utilization)},
+		)
+	}
+
+	for _, reason := range r.sortedRejectionReasons() {
+		rows = append(rows, []string{"rejection", reason, "count", formatUint(r.Rejections[reason])})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+func (r *ScenarioResult) sortedRejectionReasons() []string {
+	reasons := make([]string, 0, len(r.Rejections))
+	for reason := range r.Rejections {
+		reasons = append(reasons, reason)
+	}
+
+	sort.Strings(reasons)
+
+	return reasons
+}
+
+func (r *ScenarioResult) GetOutput() string {
+	buffer := new(bytes.Buffer)
+
+	buffer.WriteString("\n=====[LOADBOT SCENARIO RUN]=====\n")
+
+	buffer.WriteString("\n[SCENARIO]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Scenario|%s", r.Scenario),
+		fmt.Sprintf("TPS profile|%s", r.Profile),
+		fmt.Sprintf("Senders|%d", r.Senders),
+		fmt.Sprintf("Total execution time|%fs", r.ExecTime),
+		fmt.Sprintf("Approximate TPS|%.2f", r.ApproxTPS),
+	}))
+
+	buffer.WriteString("\n\n[COUNT DATA]\n")
+	buffer.WriteString(helper.FormatKV(formatCounts(r.Counts)))
+
+	buffer.WriteString("\n\n[LATENCY]\n")
+	buffer.WriteString(helper.FormatKV(formatLatency(r.Latency)))
+
+	for _, workload := range r.Workloads {
+		buffer.WriteString(fmt.Sprintf("\n\n[WORKLOAD %s]\n", workload.Name))
+		buffer.WriteString(helper.FormatKV(append(
+			[]string{
+				fmt.Sprintf("Type|%s", workload.Type),
+				fmt.Sprintf("Weight|%d", workload.Weight),
+			},
+			append(formatCounts(workload.Counts), formatLatency(workload.Latency)...)...,
+		)))
+	}
+
+	if len(r.Blocks) != 0 {
+		buffer.WriteString("\n\n[BLOCK DATA]\n")
+
+		formattedStrings := make([]string, 0, len(r.Blocks)+1)
+		for _, block := range r.Blocks {
+			formattedStrings = append(formattedStrings,
+				fmt.Sprintf("Block #%d|%d txns (%d gasUsed / %d gasLimit) utilization | %.2f%%",
+					block.Number,
+					block.Transactions,
+					block.GasUsed,
+					block.GasLimit,
+					block.Utilization,
+				))
+		}
+
+		formattedStrings = append(formattedStrings,
+			fmt.Sprintf("Average utilization across all blocks|%.2f%%", r.AverageUtilization),
+		)
+
+		buffer.WriteString(helper.FormatKV(formattedStrings))
+	}
+
+	if len(r.Rejections) != 0 {
+		buffer.WriteString("\n\n[REJECTION REASONS]\n")
+
+		formattedStrings := make([]string, 0, len(r.Rejections))
+		for _, reason := range r.sortedRejectionReasons() {
+			formattedStrings = append(formattedStrings, fmt.Sprintf("%s|%d", reason, r.Rejections[reason]))
+		}
+
+		buffer.WriteString(helper.FormatKV(formattedStrings))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatCounts(counts ScenarioCounts) []string {
+	return []string{
+		fmt.Sprintf("Transactions submitted|%d", counts.Submitted),
+		fmt.Sprintf("Transactions confirmed|%d", counts.Confirmed),
+		fmt.Sprintf("Transactions reverted|%d", counts.Reverted),
+		fmt.Sprintf("Transactions rejected|%d", counts.Rejected),
+		fmt.Sprintf("Transactions timed out|%d", counts.TimedOut),
+	}
+}
+
+func formatLatency(latency LatencyStats) []string {
+	return []string{
+		fmt.Sprintf("Latency min|%fs", latency.Min),
+		fmt.Sprintf("Latency mean|%fs", latency.Mean),
+		fmt.Sprintf("Latency p50|%fs", latency.P50),
+		fmt.Sprintf("Latency p90|%fs", latency.P90),
+		fmt.Sprintf("Latency p95|%fs", latency.P95),
+		fmt.Sprintf("Latency p99|%fs", latency.P99),
+		fmt.Sprintf("Latency max|%fs", latency.Max),
+	}
+}
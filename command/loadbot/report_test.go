@@ -0,0 +1,96 @@
+package loadbot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcLatencyStats(t *testing.T) {
+	t.Parallel()
+
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := calcLatencyStats(latencies)
+
+	assert.Equal(t, 0.001, stats.Min)
+	assert.Equal(t, 0.0505, stats.Mean)
+	assert.Equal(t, 0.05, stats.P50)
+	assert.Equal(t, 0.09, stats.P90)
+	assert.Equal(t, 0.095, stats.P95)
+	assert.Equal(t, 0.099, stats.P99)
+	assert.Equal(t, 0.1, stats.Max)
+
+	assert.Equal(t, LatencyStats{}, calcLatencyStats(nil))
+}
+
+func TestNewScenarioResult(t *testing.T) {
+	t.Parallel()
+
+	scenario := &Scenario{
+		Name:    "test",
+		Profile: ProfileConfig{Type: constantProfile, TPS: 10},
+		Workloads: []WorkloadConfig{
+			{Name: "transfers", Type: transfer, Weight: 2},
+			{Name: "deploys", Type: deploy, Weight: 1},
+		},
+	}
+
+	records := []*txnRecord{
+		{workload: 0, status: txnConfirmed, latency: time.Second, block: 5},
+		{workload: 0, status: txnConfirmed, latency: 3 * time.Second, block: 6},
+		{workload: 0, status: txnRejected, reason: "nonce too low"},
+		{workload: 1, status: txnReverted, latency: 2 * time.Second, block: 6},
+		{workload: 1, status: txnRejected, reason: "nonce too low"},
+		{workload: 1, status: txnTimedOut},
+	}
+
+	gasMetrics := &BlockGasMetrics{
+		Blocks: map[uint64]GasMetrics{
+			6: {GasUsed: 50, GasLimit: 100, Utilization: 50},
+			5: {GasUsed: 25, GasLimit: 100, Utilization: 25},
+		},
+	}
+
+	result := newScenarioResult(scenario, 4, records, gasMetrics, 2*time.Second)
+
+	assert.Equal(t, ScenarioCounts{Submitted: 6, Confirmed: 2, Reverted: 1, Rejected: 2, TimedOut: 1}, result.Counts)
+	assert.Equal(t, 3.0, result.ApproxTPS)
+	assert.Equal(t, 2.0, result.Latency.P50)
+	assert.Equal(t, 3.0, result.Latency.Max)
+	assert.Equal(t, map[string]uint64{"nonce too low": 2}, result.Rejections)
+	assert.Equal(t, 37.5, result.AverageUtilization)
+
+	assert.Equal(t, uint64(3), result.Workloads[0].Counts.Submitted)
+	assert.Equal(t, 2.0, result.Workloads[0].Latency.Mean)
+	assert.Equal(t, uint64(1), result.Workloads[1].Counts.TimedOut)
+
+	assert.Equal(t, []BlockResult{
+		{Number: 5, Transactions: 1, GasUsed: 25, GasLimit: 100, Utilization: 25},
+		{Number: 6, Transactions: 2, GasUsed: 50, GasLimit: 100, Utilization: 50},
+	}, result.Blocks)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, result.writeCSV(buffer))
+
+	rows, err := csv.NewReader(buffer).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"section", "name", "metric", "value"}, rows[0])
+	assert.Contains(t, rows, []string{"latency", "transfers", "p99", "3"})
+	assert.Contains(t, rows, []string{"block", "6", "utilization", "50"})
+	assert.Contains(t, rows, []string{"rejection", "nonce too low", "count", "2"})
+}
+
+func TestReportFormatFromPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, csvReport, reportFormatFromPath("report.CSV"))
+	assert.Equal(t, jsonReport, reportFormatFromPath("report.json"))
+	assert.Equal(t, jsonReport, reportFormatFromPath(""))
+}
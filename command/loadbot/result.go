@@ -6,6 +6,7 @@ import (
 	"github.com/umbracle/ethgo"
 	"math"
 	"sort"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/loadbot/generator"
@@ -45,6 +46,13 @@ type TxnDetailedErrorData struct {
 	DetailedErrorMap map[generator.TxnErrorType][]*generator.FailedTxnInfo `json:"detailed_error_map"`
 }
 
+// TxnPathData are the results of the transactions sent through a single submission path
+type TxnPathData struct {
+	Path           SubmitPath        `json:"path"`
+	CountData      TxnCountData      `json:"count_data"`
+	TurnAroundData TxnTurnAroundData `json:"turn_around_data"`
+}
+
 type LoadbotResult struct {
 	CountData              TxnCountData         `json:"count_data"`
 	TurnAroundData         TxnTurnAroundData    `json:"turn_around_data"`
@@ -54,6 +62,8 @@ type LoadbotResult struct {
 	ApproxTPS              uint64               `json:"approx_tps"`
 	ContractAddress        ethgo.Address        `json:"contract_address,omitempty"`
 	ContractBlockData      TxnBlockData         `json:"contract_block_data,omitempty"`
+	Confirmation           ConfirmMode          `json:"confirmation"`
+	PathData               []TxnPathData        `json:"path_data"`
 }
 
 func (lr *LoadbotResult) initExecutionData(metrics *Metrics) {
@@ -116,6 +126,44 @@ func (lr *LoadbotResult) initContractDeploymentModesExecutionData(metrics *Metri
 	}
 }
 
+func (lr *LoadbotResult) initPathData(metrics *Metrics, paths []SubmitPath, confirmation ConfirmMode) {
+	lr.Confirmation = confirmation
+	lr.PathData = make([]TxnPathData, 0, len(paths))
+
+	for _, path := range paths {
+		pathMetrics, ok := metrics.PathMetrics[path]
+		if !ok {
+			continue
+		}
+
+		lr.PathData = append(lr.PathData, TxnPathData{
+			Path: path,
+			CountData: TxnCountData{
+				Total:  pathMetrics.TotalTransactionsSentCount,
+				Failed: pathMetrics.FailedTransactionsCount,
+			},
+			TurnAroundData: TxnTurnAroundData{
+				FastestTurnAround: common.ToFixedFloat(
+					pathMetrics.TransactionDuration.FastestTurnAround.Seconds(),
+					durationPrecision,
+				),
+				SlowestTurnAround: common.ToFixedFloat(
+					pathMetrics.TransactionDuration.SlowestTurnAround.Seconds(),
+					durationPrecision,
+				),
+				AverageTurnAround: common.ToFixedFloat(
+					pathMetrics.TransactionDuration.AverageTurnAround.Seconds(),
+					durationPrecision,
+				),
+				TotalExecTime: common.ToFixedFloat(
+					pathMetrics.TransactionDuration.TotalExecTime.Seconds(),
+					durationPrecision,
+				),
+			},
+		})
+	}
+}
+
 func (lr *LoadbotResult) initDetailedErrors(gen generator.TransactionGenerator) {
 	transactionErrors := gen.GetTransactionErrors()
 	if len(transactionErrors) == 0 {
@@ -234,6 +282,7 @@ func (lr *LoadbotResult) writeLoadbotResults(buffer *bytes.Buffer) {
 	lr.writeApproximateTPSData(buffer)
 	lr.writeContractDeploymentData(buffer)
 	lr.writeTurnAroundData(buffer)
+	lr.writePathData(buffer)
 	lr.writeBlockData(buffer)
 	lr.writeAverageBlockUtilization(buffer)
 	lr.writeErrorData(buffer)
@@ -273,6 +322,24 @@ func (lr *LoadbotResult) writeTurnAroundData(buffer *bytes.Buffer) {
 	}))
 }
 
+func (lr *LoadbotResult) writePathData(buffer *bytes.Buffer) {
+	buffer.WriteString("\n\n[SUBMISSION PATHS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Confirmation|%s", lr.Confirmation),
+	}))
+
+	for _, pathData := range lr.PathData {
+		buffer.WriteString(fmt.Sprintf("\n\n[%s]\n", strings.ToUpper(string(pathData.Path))))
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Transactions submitted|%d", pathData.CountData.Total),
+			fmt.Sprintf("Transactions failed|%d", pathData.CountData.Failed),
+			fmt.Sprintf("Average transaction turn around|%fs", pathData.TurnAroundData.AverageTurnAround),
+			fmt.Sprintf("Fastest transaction turn around|%fs", pathData.TurnAroundData.FastestTurnAround),
+			fmt.Sprintf("Slowest transaction turn around|%fs", pathData.TurnAroundData.SlowestTurnAround),
+		}))
+	}
+}
+
 func (lr *LoadbotResult) writeContractDeploymentData(buffer *bytes.Buffer) {
 	// skip if contract was not deployed
 	if lr.ContractAddress == ethgo.ZeroAddress {
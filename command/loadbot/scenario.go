@@ -0,0 +1,332 @@
+package loadbot
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/loadbot/generator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+	"gopkg.in/yaml.v3"
+)
+
+// call is the scenario workload calling a method of a contract
+const call Mode = "call"
+
+var (
+	errNoWorkloads           = errors.New("scenario has no workloads")
+	errInvalidWeight         = errors.New("workload weight must be positive")
+	errNoRunLimit            = errors.New("scenario needs a duration or a transaction count")
+	errMissingMethod         = errors.New("call workload has no method")
+	errMissingContract       = errors.New("call workload needs a contract artifact with an ABI")
+	errUnfundedSenders       = errors.New("senders without a mnemonic need a fund amount")
+	errDuplicateWorkload     = errors.New("duplicate workload name")
+	errMissingConstructor    = errors.New("contract ABI has no constructor")
+	errUnexpectedConstructor = errors.New("workload type doesn't take constructor arguments")
+	errInvalidProfileType    = errors.New("invalid TPS profile type")
+	errInvalidProfileParams  = errors.New("invalid TPS profile parameters")
+)
+
+// Scenario describes a loadbot run with a weighted mix of workloads,
+// sent from many accounts at a varying rate
+type Scenario struct {
+	Name     string        `yaml:"name"`
+	Duration time.Duration `yaml:"duration"`
+	Count    uint64        `yaml:"count"`
+	MaxWait  time.Duration `yaml:"max_wait"`
+	GasPrice string        `yaml:"gas_price"`
+
+	Senders   SenderConfig     `yaml:"senders"`
+	Profile   ProfileConfig    `yaml:"profile"`
+	Workloads []WorkloadConfig `yaml:"workloads"`
+}
+
+// SenderConfig describes the accounts sending the scenario transactions.
+// Without a count, only the main sender is used
+type SenderConfig struct {
+	Count    uint64 `yaml:"count"`
+	Mnemonic string `yaml:"mnemonic"`
+	// Fund is the amount in wei the main sender transfers to every account before the run
+	Fund string `yaml:"fund"`
+
+	fund *big.Int
+}
+
+// WorkloadConfig describes a kind of transaction in the scenario mix
+type WorkloadConfig struct {
+	Name     string        `yaml:"name"`
+	Type     Mode          `yaml:"type"`
+	Weight   uint64        `yaml:"weight"`
+	Value    string        `yaml:"value"`
+	GasLimit uint64        `yaml:"gas_limit"`
+	Contract string        `yaml:"contract"` // the path to the contract JSON artifact
+	Address  string        `yaml:"address"`  // the contract address, the contract is deployed if empty
+	Method   string        `yaml:"method"`
+	Args     []interface{} `yaml:"args"`
+
+	// ConstructorArgs are the arguments of the deployed contract constructor
+	ConstructorArgs []interface{} `yaml:"constructor_args"`
+
+	value           *big.Int
+	artifact        *generator.ContractArtifact
+	constructorArgs []byte
+	address         *types.Address
+}
+
+// ReadScenario reads and validates the scenario file
+func ReadScenario(path string) (*Scenario, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseScenario(raw)
+}
+
+func parseScenario(raw []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := yaml.Unmarshal(raw, scenario); err != nil {
+		return nil, fmt.Errorf("unable to parse scenario, %w", err)
+	}
+
+	if err := scenario.init(); err != nil {
+		return nil, err
+	}
+
+	return scenario, nil
+}
+
+func (s *Scenario) init() error {
+	if s.Duration == 0 && s.Count == 0 {
+		return errNoRunLimit
+	}
+
+	if err := s.Profile.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Senders.init(); err != nil {
+		return err
+	}
+
+	if len(s.Workloads) == 0 {
+		return errNoWorkloads
+	}
+
+	names := make(map[string]struct{}, len(s.Workloads))
+
+	for i := range s.Workloads {
+		workload := &s.Workloads[i]
+
+		if err := workload.init(); err != nil {
+			return fmt.Errorf("workload %d: %w", i, err)
+		}
+
+		if _, ok := names[workload.Name]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateWorkload, workload.Name)
+		}
+
+		names[workload.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func (c *SenderConfig) init() error {
+	if c.Fund != "" {
+		fund, err := types.ParseUint256orHex(&c.Fund)
+		if err != nil {
+			return fmt.Errorf("failed to decode fund amount: %w", err)
+		}
+
+		c.fund = fund
+	}
+
+	if c.Count > 0 && c.Mnemonic == "" && c.fund == nil {
+		return errUnfundedSenders
+	}
+
+	return nil
+}
+
+func (w *WorkloadConfig) init() error {
+	w.Type = Mode(strings.ToLower(string(w.Type)))
+	if w.Name == "" {
+		w.Name = string(w.Type)
+	}
+
+	if w.Weight == 0 {
+		return errInvalidWeight
+	}
+
+	w.value = big.NewInt(0)
+
+	if w.Value != "" {
+		value, err := types.ParseUint256orHex(&w.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decode value: %w", err)
+		}
+
+		w.value = value
+	}
+
+	if w.Address != "" {
+		address := types.Address{}
+		if err := address.UnmarshalText([]byte(w.Address)); err != nil {
+			return fmt.Errorf("failed to decode contract address: %w", err)
+		}
+
+		w.address = &address
+	}
+
+	if w.Contract != "" {
+		artifact, err := generator.ReadContractArtifact(w.Contract)
+		if err != nil {
+			return fmt.Errorf("failed to read contract artifact: %w", err)
+		}
+
+		w.artifact = artifact
+	}
+
+	if err := w.initArtifact(); err != nil {
+		return err
+	}
+
+	return w.initConstructorArgs()
+}
+
+// initConstructorArgs encodes the constructor arguments of the deployed call and deploy contracts
+func (w *WorkloadConfig) initConstructorArgs() error {
+	if len(w.ConstructorArgs) == 0 {
+		return nil
+	}
+
+	if w.Type != call && w.Type != deploy {
+		return fmt.Errorf("%w: %s", errUnexpectedConstructor, w.Type)
+	}
+
+	if w.artifact.ABI == nil || w.artifact.ABI.Constructor == nil {
+		return errMissingConstructor
+	}
+
+	var err error
+	if w.constructorArgs, err = abi.Encode(w.ConstructorArgs, w.artifact.ABI.Constructor.Inputs); err != nil {
+		return fmt.Errorf("failed to encode constructor parameters: %w", err)
+	}
+
+	return nil
+}
+
+// initArtifact sets the contract artifact and constructor arguments of the workload type
+func (w *WorkloadConfig) initArtifact() error {
+	var err error
+
+	switch w.Type {
+	case transfer:
+		return nil
+	case deploy:
+		if w.artifact == nil {
+			w.artifact = &generator.ContractArtifact{
+				Bytecode: generator.DefaultContractBytecode,
+			}
+		}
+
+		return nil
+	case erc20:
+		w.artifact = &generator.ContractArtifact{
+			Bytecode: ERC20BIN,
+			ABI:      abi.MustNewABI(ERC20ABI),
+		}
+
+		if w.constructorArgs, err = abi.Encode(
+			[]string{erc20TokenSupply, erc20TokenName, erc20TokenSymbol},
+			w.artifact.ABI.Constructor.Inputs,
+		); err != nil {
+			return fmt.Errorf("failed to encode erc20 constructor parameters: %w", err)
+		}
+
+		return nil
+	case erc721:
+		w.artifact = &generator.ContractArtifact{
+			Bytecode: ERC721BIN,
+			ABI:      abi.MustNewABI(ERC721ABI),
+		}
+
+		if w.constructorArgs, err = abi.Encode(
+			[]string{erc721TokenName, erc721TokenSymbol},
+			w.artifact.ABI.Constructor.Inputs,
+		); err != nil {
+			return fmt.Errorf("failed to encode erc721 constructor parameters: %w", err)
+		}
+
+		return nil
+	case call:
+		if w.Method == "" {
+			return errMissingMethod
+		}
+
+		if w.artifact == nil || w.artifact.ABI == nil {
+			return errMissingContract
+		}
+
+		if _, ok := w.artifact.ABI.Methods[w.Method]; !ok {
+			return fmt.Errorf("method %s not found in contract ABI", w.Method)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidMode, w.Type)
+	}
+}
+
+// needsDeployment checks if the workload sends transactions to a contract
+// the loadbot has to deploy before the run
+func (w *WorkloadConfig) needsDeployment() bool {
+	switch w.Type {
+	case erc20, erc721:
+		return true
+	case call:
+		return w.address == nil
+	default:
+		return false
+	}
+}
+
+// workloadPicker picks the workloads randomly, in proportion to their weights
+type workloadPicker struct {
+	cumulative []uint64
+	total      uint64
+	rand       *rand.Rand
+}
+
+func newWorkloadPicker(workloads []WorkloadConfig, seed int64) *workloadPicker {
+	picker := &workloadPicker{
+		cumulative: make([]uint64, len(workloads)),
+		rand:       rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
+
+	for i, workload := range workloads {
+		picker.total += workload.Weight
+		picker.cumulative[i] = picker.total
+	}
+
+	return picker
+}
+
+// pick returns the index of the next workload
+func (p *workloadPicker) pick() int {
+	n := uint64(p.rand.Int63n(int64(p.total)))
+
+	for i, bound := range p.cumulative {
+		if n < bound {
+			return i
+		}
+	}
+
+	return len(p.cumulative) - 1
+}
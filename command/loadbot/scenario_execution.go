@@ -0,0 +1,592 @@
+package loadbot
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/loadbot/generator"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// idleInterval is the wait between the rate checks while the profile TPS is zero
+	idleInterval = 100 * time.Millisecond
+
+	// erc20SenderSupply is the amount of tokens transferred to every sender of erc20 workloads
+	erc20SenderSupply = "1000"
+
+	transferGas = 21000
+)
+
+var errSetupTxnFailed = errors.New("setup transaction failed")
+
+type ScenarioConfiguration struct {
+	Scenario *Scenario
+	Sender   types.Address
+	JSONRPC  string
+	GRPC     string
+	MaxConns int
+	ChainID  uint64
+	GasPrice *big.Int
+}
+
+// ScenarioLoadbot runs a scenario: it deploys the contracts of the workloads and funds the
+// senders from the main sender, then sends the workload mix at the rate of the TPS profile.
+// As the nonces are assigned when the transactions are generated, a transaction rejected
+// by the txpool leaves a nonce gap, and the later transactions of its sender time out
+type ScenarioLoadbot struct {
+	cfg      *ScenarioConfiguration
+	scenario *Scenario
+
+	jsonClient *jsonrpc.Client
+	grpcClient txpoolOp.TxnPoolOperatorClient
+
+	main      *Account
+	mainNonce uint64
+	signer    *crypto.EIP155Signer
+	gasPrice  *big.Int
+	senders   []*Account
+
+	// generators holds the generator of every workload for every sender
+	generators [][]generator.TransactionGenerator
+
+	records     []*txnRecord
+	recordsLock sync.Mutex
+}
+
+func NewScenarioLoadbot(cfg *ScenarioConfiguration) *ScenarioLoadbot {
+	return &ScenarioLoadbot{
+		cfg:      cfg,
+		scenario: cfg.Scenario,
+		signer:   crypto.NewEIP155Signer(cfg.ChainID),
+	}
+}
+
+// Run sets up and runs the scenario, and returns its report
+func (l *ScenarioLoadbot) Run() (*ScenarioResult, error) {
+	var err error
+
+	if l.main, err = extractSenderAccount(l.cfg.Sender); err != nil {
+		return nil, fmt.Errorf("failed to extract sender account: %w", err)
+	}
+
+	if l.jsonClient, err = createJSONRPCClient(l.cfg.JSONRPC, l.cfg.MaxConns); err != nil {
+		return nil, fmt.Errorf("an error has occurred while creating JSON-RPC client: %w", err)
+	}
+
+	defer func(client *jsonrpc.Client) {
+		_ = client.Close()
+	}(l.jsonClient)
+
+	if l.grpcClient, err = createGRPCClient(l.cfg.GRPC); err != nil {
+		return nil, fmt.Errorf("an error has occurred while creating gRPC client: %w", err)
+	}
+
+	if err := l.setup(); err != nil {
+		return nil, err
+	}
+
+	return l.run()
+}
+
+// setup prepares the senders, the contracts and the generators of the scenario
+func (l *ScenarioLoadbot) setup() error {
+	var err error
+
+	if l.gasPrice, err = l.resolveGasPrice(); err != nil {
+		return err
+	}
+
+	if l.mainNonce, err = getInitialSenderNonce(l.jsonClient, l.main.Address); err != nil {
+		return fmt.Errorf("unable to get initial sender nonce: %w", err)
+	}
+
+	if err := l.initSenders(); err != nil {
+		return fmt.Errorf("unable to initialize senders: %w", err)
+	}
+
+	if err := l.deployContracts(); err != nil {
+		return fmt.Errorf("unable to deploy contracts: %w", err)
+	}
+
+	if err := l.fundSenders(); err != nil {
+		return fmt.Errorf("unable to fund senders: %w", err)
+	}
+
+	if err := l.initGenerators(); err != nil {
+		return fmt.Errorf("unable to initialize generators: %w", err)
+	}
+
+	return nil
+}
+
+func (l *ScenarioLoadbot) resolveGasPrice() (*big.Int, error) {
+	if l.scenario.GasPrice != "" {
+		gasPrice, err := types.ParseUint256orHex(&l.scenario.GasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gas price to value: %w", err)
+		}
+
+		return gasPrice, nil
+	}
+
+	if l.cfg.GasPrice != nil {
+		return l.cfg.GasPrice, nil
+	}
+
+	avgGasPrice, err := getAverageGasPrice(l.jsonClient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get average gas price: %w", err)
+	}
+
+	return new(big.Int).SetUint64(avgGasPrice), nil
+}
+
+// initSenders derives or generates the sender accounts, or uses the main sender
+// if the scenario doesn't specify a sender count
+func (l *ScenarioLoadbot) initSenders() error {
+	var (
+		config = l.scenario.Senders
+		err    error
+	)
+
+	switch {
+	case config.Count == 0:
+		l.senders = []*Account{l.main}
+	case config.Mnemonic != "":
+		l.senders, err = deriveAccounts(config.Mnemonic, config.Count)
+	default:
+		l.senders, err = generateAccounts(config.Count)
+	}
+
+	return err
+}
+
+// isMainSenderOnly checks if the main sender sends the scenario transactions
+func (l *ScenarioLoadbot) isMainSenderOnly() bool {
+	return len(l.senders) == 1 && l.senders[0] == l.main
+}
+
+// deployContracts deploys the contracts of the workloads without an address
+func (l *ScenarioLoadbot) deployContracts() error {
+	for i := range l.scenario.Workloads {
+		workload := &l.scenario.Workloads[i]
+		if !workload.needsDeployment() {
+			continue
+		}
+
+		bytecode, err := hex.DecodeString(workload.artifact.Bytecode)
+		if err != nil {
+			return fmt.Errorf("unable to decode bytecode of workload %s, %w", workload.Name, err)
+		}
+
+		hash, err := l.sendSetupTxn(nil, big.NewInt(0), append(bytecode, workload.constructorArgs...))
+		if err != nil {
+			return fmt.Errorf("unable to deploy contract of workload %s, %w", workload.Name, err)
+		}
+
+		receipts, err := l.waitForSetupReceipts([]ethgo.Hash{hash})
+		if err != nil {
+			return fmt.Errorf("unable to deploy contract of workload %s, %w", workload.Name, err)
+		}
+
+		address := types.Address(receipts[0].ContractAddress)
+		workload.address = &address
+	}
+
+	return nil
+}
+
+// fundSenders transfers the fund amount to the senders, and the tokens of the erc20 workloads
+func (l *ScenarioLoadbot) fundSenders() error {
+	if l.isMainSenderOnly() {
+		return nil
+	}
+
+	hashes := make([]ethgo.Hash, 0)
+
+	for _, sender := range l.senders {
+		if l.scenario.Senders.fund == nil {
+			break
+		}
+
+		to := sender.Address
+
+		hash, err := l.sendSetupTxn(&to, l.scenario.Senders.fund, nil)
+		if err != nil {
+			return err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	for _, workload := range l.scenario.Workloads {
+		if workload.Type != erc20 {
+			continue
+		}
+
+		for _, sender := range l.senders {
+			input, err := workload.artifact.ABI.Methods["transfer"].Encode(
+				[]string{sender.Address.String(), erc20SenderSupply},
+			)
+			if err != nil {
+				return fmt.Errorf("cannot encode ERC20 transfer method params: %w", err)
+			}
+
+			hash, err := l.sendSetupTxn(workload.address, big.NewInt(0), input)
+			if err != nil {
+				return err
+			}
+
+			hashes = append(hashes, hash)
+		}
+	}
+
+	_, err := l.waitForSetupReceipts(hashes)
+
+	return err
+}
+
+// sendSetupTxn sends a transaction from the main sender with the estimated gas
+func (l *ScenarioLoadbot) sendSetupTxn(to *types.Address, value *big.Int, input []byte) (ethgo.Hash, error) {
+	txn := &types.Transaction{
+		From:     l.main.Address,
+		To:       to,
+		Value:    value,
+		GasPrice: l.gasPrice,
+		Nonce:    l.mainNonce,
+		Input:    input,
+		Gas:      transferGas,
+		V:        big.NewInt(1), // it is necessary to encode in rlp
+	}
+
+	if len(input) != 0 {
+		gasEstimate, err := estimateGas(l.jsonClient, txn)
+		if err != nil {
+			return ethgo.Hash{}, err
+		}
+
+		txn.Gas = gasEstimate
+	}
+
+	signedTxn, err := l.signer.SignTx(txn, l.main.PrivateKey)
+	if err != nil {
+		return ethgo.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	hash, err := addTxn(l.grpcClient, signedTxn)
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+
+	l.mainNonce++
+
+	return hash, nil
+}
+
+// waitForSetupReceipts waits for the receipts of the setup transactions, and checks they succeeded
+func (l *ScenarioLoadbot) waitForSetupReceipts(hashes []ethgo.Hash) ([]*ethgo.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.receiptTimeout())
+	defer cancel()
+
+	receipts := make([]*ethgo.Receipt, len(hashes))
+
+	for i, hash := range hashes {
+		receipt, err := tests.WaitForReceipt(ctx, l.jsonClient.Eth(), hash)
+		if err != nil {
+			return nil, fmt.Errorf("could not get the receipt of %s, %w", hash, err)
+		}
+
+		if receipt.Status != uint64(types.ReceiptSuccess) {
+			return nil, fmt.Errorf("%w: %s", errSetupTxnFailed, hash)
+		}
+
+		receipts[i] = receipt
+	}
+
+	return receipts, nil
+}
+
+// initGenerators creates the generators of the workloads for every sender,
+// all generators of a sender sharing its nonce
+func (l *ScenarioLoadbot) initGenerators() error {
+	l.generators = make([][]generator.TransactionGenerator, len(l.senders))
+
+	for i, sender := range l.senders {
+		nonce := new(uint64)
+
+		if sender == l.main {
+			*nonce = l.mainNonce
+		} else {
+			initialNonce, err := getInitialSenderNonce(l.jsonClient, sender.Address)
+			if err != nil {
+				return fmt.Errorf("unable to get initial sender nonce: %w", err)
+			}
+
+			*nonce = initialNonce
+		}
+
+		l.generators[i] = make([]generator.TransactionGenerator, len(l.scenario.Workloads))
+
+		for j := range l.scenario.Workloads {
+			workload := &l.scenario.Workloads[j]
+
+			gen, err := l.newGenerator(workload, sender, nonce)
+			if err != nil {
+				return fmt.Errorf("workload %s: %w", workload.Name, err)
+			}
+
+			// the gas depends on the sender state, e.g. its token balance
+			gasLimit := workload.GasLimit
+			if gasLimit == 0 {
+				exampleTxn, err := gen.GetExampleTransaction()
+				if err != nil {
+					return fmt.Errorf("unable to get example transaction, %w", err)
+				}
+
+				if gasLimit, err = estimateGas(l.jsonClient, exampleTxn); err != nil {
+					return fmt.Errorf("workload %s: %w", workload.Name, err)
+				}
+			}
+
+			gen.SetGasEstimate(gasLimit)
+			l.generators[i][j] = gen
+		}
+	}
+
+	return nil
+}
+
+func (l *ScenarioLoadbot) newGenerator(
+	workload *WorkloadConfig,
+	sender *Account,
+	nonce *uint64,
+) (generator.TransactionGenerator, error) {
+	params := &generator.GeneratorParams{
+		ChainID:          l.cfg.ChainID,
+		SenderAddress:    sender.Address,
+		RecieverAddress:  l.main.Address,
+		SenderKey:        sender.PrivateKey,
+		Value:            workload.value,
+		GasPrice:         l.gasPrice,
+		ContractArtifact: workload.artifact,
+		ConstructorArgs:  workload.constructorArgs,
+		SharedNonce:      nonce,
+	}
+
+	if workload.address != nil {
+		params.ContractAddress = ethgo.Address(*workload.address)
+	}
+
+	var (
+		tokenGenerator *generator.ContractTxnsGenerator
+		err            error
+	)
+
+	switch workload.Type {
+	case transfer:
+		return generator.NewTransferGenerator(params)
+	case deploy:
+		return generator.NewDeployGenerator(params)
+	case call:
+		return generator.NewCallGenerator(params, workload.Method, workload.Args)
+	case erc20:
+		tokenGenerator, err = generator.NewERC20Generator(params)
+	case erc721:
+		tokenGenerator, err = generator.NewERC721Generator(params)
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidMode, workload.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenGenerator.SetContractAddress(*workload.address)
+
+	return tokenGenerator, nil
+}
+
+func (l *ScenarioLoadbot) receiptTimeout() time.Duration {
+	if l.scenario.MaxWait != 0 {
+		return l.scenario.MaxWait
+	}
+
+	return maxReceiptWait
+}
+
+// run sends the workload mix at the rate of the TPS profile until the scenario duration
+// passes or the transaction count is reached, then waits for the receipts
+func (l *ScenarioLoadbot) run() (*ScenarioResult, error) {
+	var (
+		picker    = newWorkloadPicker(l.scenario.Workloads, time.Now().UnixNano())
+		wg        sync.WaitGroup
+		startTime = time.Now()
+		next      = startTime
+	)
+
+	for sent := uint64(0); ; sent++ {
+		elapsed := time.Since(startTime)
+
+		if l.scenario.Duration != 0 && elapsed >= l.scenario.Duration {
+			break
+		}
+
+		if l.scenario.Count != 0 && sent >= l.scenario.Count {
+			break
+		}
+
+		tps := l.scenario.Profile.tpsAt(elapsed)
+		for tps == 0 {
+			time.Sleep(idleInterval)
+
+			next = time.Now()
+			tps = l.scenario.Profile.tpsAt(time.Since(startTime))
+		}
+
+		// don't burst to catch up after the sending falls behind the rate
+		if now := time.Now(); next.Before(now.Add(-time.Second)) {
+			next = now
+		}
+
+		next = next.Add(time.Second / time.Duration(tps))
+		time.Sleep(time.Until(next))
+
+		sender := int(sent % uint64(len(l.senders)))
+		workload := picker.pick()
+
+		wg.Add(1)
+
+		go func(txnGenerator generator.TransactionGenerator, workload int) {
+			defer wg.Done()
+
+			l.addRecord(l.sendTxn(txnGenerator, workload))
+		}(l.generators[sender][workload], workload)
+	}
+
+	wg.Wait()
+
+	execTime := time.Since(startTime)
+
+	seenBlockNums := make(map[uint64]struct{})
+
+	for _, record := range l.records {
+		if record.status == txnConfirmed || record.status == txnReverted {
+			seenBlockNums[record.block] = struct{}{}
+		}
+	}
+
+	gasMetrics, err := getBlockGasMetrics(l.jsonClient, seenBlockNums)
+	if err != nil {
+		return nil, fmt.Errorf("unable to calculate block gas metrics: %w", err)
+	}
+
+	result := newScenarioResult(
+		l.scenario,
+		uint64(len(l.senders)),
+		l.records,
+		gasMetrics,
+		execTime,
+	)
+
+	for _, workload := range l.scenario.Workloads {
+		if workload.address == nil {
+			continue
+		}
+
+		if result.Contracts == nil {
+			result.Contracts = make(map[string]string)
+		}
+
+		result.Contracts[workload.Name] = workload.address.String()
+	}
+
+	return result, nil
+}
+
+// sendTxn sends a transaction of the workload and waits for its receipt
+func (l *ScenarioLoadbot) sendTxn(txnGenerator generator.TransactionGenerator, workload int) *txnRecord {
+	record := &txnRecord{
+		workload: workload,
+	}
+
+	start := time.Now()
+
+	txn, err := txnGenerator.GenerateTransaction()
+	if err != nil {
+		record.status, record.reason = txnRejected, err.Error()
+
+		return record
+	}
+
+	txHash, err := addTxn(l.grpcClient, txn)
+	if err != nil {
+		record.status, record.reason = txnRejected, rejectionReason(err)
+
+		return record
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.receiptTimeout())
+	defer cancel()
+
+	receipt, err := tests.WaitForReceipt(ctx, l.jsonClient.Eth(), txHash)
+	if err != nil {
+		record.status = txnTimedOut
+
+		return record
+	}
+
+	record.latency = time.Since(start)
+	record.block = receipt.BlockNumber
+
+	if receipt.Status == uint64(types.ReceiptSuccess) {
+		record.status = txnConfirmed
+	} else {
+		record.status = txnReverted
+	}
+
+	return record
+}
+
+func (l *ScenarioLoadbot) addRecord(record *txnRecord) {
+	l.recordsLock.Lock()
+	defer l.recordsLock.Unlock()
+
+	l.records = append(l.records, record)
+}
+
+// addTxn adds the signed transaction to the txpool of the node
+func addTxn(client txpoolOp.TxnPoolOperatorClient, txn *types.Transaction) (ethgo.Hash, error) {
+	addRes, err := client.AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+		Raw: &any.Any{
+			Value: txn.MarshalRLP(),
+		},
+		From: types.ZeroAddress.String(),
+	})
+	if err != nil {
+		return ethgo.Hash{}, err
+	}
+
+	return ethgo.Hash(types.StringToHash(addRes.TxHash)), nil
+}
+
+// rejectionReason returns the message of the txpool error, without the gRPC status code
+func rejectionReason(err error) string {
+	if grpcStatus, ok := status.FromError(err); ok {
+		return grpcStatus.Message()
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,235 @@
+package loadbot
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScenario(t *testing.T) {
+	t.Parallel()
+
+	raw := `
+name: mixed
+duration: 2m
+max_wait: 30s
+senders:
+  count: 10
+  fund: "0x100"
+profile:
+  type: ramp
+  start_tps: 10
+  tps: 100
+  ramp_duration: 1m
+workloads:
+  - type: transfer
+    weight: 70
+    value: "1000"
+  - name: tokens
+    type: ERC20
+    weight: 20
+  - type: deploy
+    weight: 10
+`
+
+	scenario, err := parseScenario([]byte(raw))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2*time.Minute, scenario.Duration)
+	assert.Equal(t, 30*time.Second, scenario.MaxWait)
+	assert.Equal(t, big.NewInt(0x100), scenario.Senders.fund)
+	assert.Equal(t, rampProfile, scenario.Profile.Type)
+	assert.Len(t, scenario.Workloads, 3)
+
+	assert.Equal(t, "transfer", scenario.Workloads[0].Name)
+	assert.Equal(t, big.NewInt(1000), scenario.Workloads[0].value)
+	assert.False(t, scenario.Workloads[0].needsDeployment())
+
+	assert.Equal(t, erc20, scenario.Workloads[1].Type)
+	assert.NotEmpty(t, scenario.Workloads[1].constructorArgs)
+	assert.True(t, scenario.Workloads[1].needsDeployment())
+
+	assert.NotNil(t, scenario.Workloads[2].artifact)
+}
+
+func TestParseScenario_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		raw         string
+		expectedErr error
+	}{
+		{
+			"no run limit",
+			`
+profile: {tps: 10}
+workloads: [{type: transfer, weight: 1}]
+`,
+			errNoRunLimit,
+		},
+		{
+			"no workloads",
+			`
+count: 10
+profile: {tps: 10}
+`,
+			errNoWorkloads,
+		},
+		{
+			"zero weight",
+			`
+count: 10
+profile: {tps: 10}
+workloads: [{type: transfer}]
+`,
+			errInvalidWeight,
+		},
+		{
+			"unknown workload type",
+			`
+count: 10
+profile: {tps: 10}
+workloads: [{type: burn, weight: 1}]
+`,
+			errInvalidMode,
+		},
+		{
+			"call without contract",
+			`
+count: 10
+profile: {tps: 10}
+workloads: [{type: call, method: set, weight: 1}]
+`,
+			errMissingContract,
+		},
+		{
+			"duplicate workload",
+			`
+count: 10
+profile: {tps: 10}
+workloads: [{type: transfer, weight: 1}, {type: transfer, weight: 2}]
+`,
+			errDuplicateWorkload,
+		},
+		{
+			"unfunded senders",
+			`
+count: 10
+senders: {count: 5}
+profile: {tps: 10}
+workloads: [{type: transfer, weight: 1}]
+`,
+			errUnfundedSenders,
+		},
+		{
+			"unknown profile",
+			`
+count: 10
+profile: {type: wave, tps: 10}
+workloads: [{type: transfer, weight: 1}]
+`,
+			errInvalidProfileType,
+		},
+		{
+			"step profile without steps",
+			`
+count: 10
+profile: {type: step}
+workloads: [{type: transfer, weight: 1}]
+`,
+			errInvalidProfileParams,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseScenario([]byte(testCase.raw))
+			assert.True(t, errors.Is(err, testCase.expectedErr), err)
+		})
+	}
+}
+
+func TestProfile_TPSAt(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		profile  ProfileConfig
+		elapsed  []time.Duration
+		expected []uint64
+	}{
+		{
+			"constant",
+			ProfileConfig{Type: constantProfile, TPS: 50},
+			[]time.Duration{0, time.Hour},
+			[]uint64{50, 50},
+		},
+		{
+			"ramp",
+			ProfileConfig{Type: rampProfile, StartTPS: 10, TPS: 110, RampDuration: 10 * time.Second},
+			[]time.Duration{0, 5 * time.Second, 10 * time.Second, time.Minute},
+			[]uint64{10, 60, 110, 110},
+		},
+		{
+			"step",
+			ProfileConfig{Type: stepProfile, Steps: []ProfileStep{
+				{TPS: 10, Duration: 10 * time.Second},
+				{TPS: 20, Duration: 5 * time.Second},
+			}},
+			[]time.Duration{0, 10 * time.Second, 14 * time.Second, time.Minute},
+			[]uint64{10, 20, 20, 20},
+		},
+		{
+			"spike",
+			ProfileConfig{
+				Type:          spikeProfile,
+				TPS:           10,
+				SpikeTPS:      500,
+				SpikeAt:       30 * time.Second,
+				SpikeDuration: 5 * time.Second,
+			},
+			[]time.Duration{0, 30 * time.Second, 34 * time.Second, 35 * time.Second},
+			[]uint64{10, 500, 500, 10},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, testCase.profile.validate())
+
+			for i, elapsed := range testCase.elapsed {
+				assert.Equal(t, testCase.expected[i], testCase.profile.tpsAt(elapsed), elapsed)
+			}
+		})
+	}
+}
+
+func TestWorkloadPicker(t *testing.T) {
+	t.Parallel()
+
+	picker := newWorkloadPicker([]WorkloadConfig{
+		{Weight: 3},
+		{Weight: 1},
+	}, 1)
+
+	picks := make([]int, 2)
+	for i := 0; i < 4000; i++ {
+		picks[picker.pick()]++
+	}
+
+	// roughly 3:1
+	assert.InDelta(t, 3000, picks[0], 200)
+	assert.InDelta(t, 1000, picks[1], 200)
+}
@@ -0,0 +1,329 @@
+package loadbot
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+// SubmitPath is the way the loadbot sends the transactions to the node
+type SubmitPath string
+
+const (
+	grpcPath SubmitPath = "grpc" // the txpool AddTxn gRPC
+	httpPath SubmitPath = "http" // eth_sendRawTransaction over HTTP
+	wsPath   SubmitPath = "ws"   // eth_sendRawTransaction over WebSocket
+)
+
+// ConfirmMode is the way the loadbot learns the transactions are included in a block
+type ConfirmMode string
+
+const (
+	receiptConfirm  ConfirmMode = "receipt"  // eth_getTransactionReceipt polling
+	newHeadsConfirm ConfirmMode = "newheads" // eth_subscribe newHeads and the block bodies
+	txpoolConfirm   ConfirmMode = "txpool"   // the INCLUDED events of the txpool gRPC Subscribe stream
+)
+
+var (
+	errInvalidSubmitPath  = errors.New("invalid submission path")
+	errInvalidConfirmMode = errors.New("invalid confirmation mode")
+)
+
+// submitter sends the signed transactions to the node
+type submitter interface {
+	submit(txn *types.Transaction) (ethgo.Hash, error)
+}
+
+type grpcSubmitter struct {
+	client txpoolOp.TxnPoolOperatorClient
+}
+
+func (s *grpcSubmitter) submit(txn *types.Transaction) (ethgo.Hash, error) {
+	return addTxn(s.client, txn)
+}
+
+type httpSubmitter struct {
+	client *jsonrpc.Client
+}
+
+func (s *httpSubmitter) submit(txn *types.Transaction) (ethgo.Hash, error) {
+	return s.client.Eth().SendRawTransaction(txn.MarshalRLP())
+}
+
+type wsSubmitter struct {
+	client *wsClient
+}
+
+func (s *wsSubmitter) submit(txn *types.Transaction) (ethgo.Hash, error) {
+	var hash ethgo.Hash
+
+	err := s.client.call("eth_sendRawTransaction", &hash, "0x"+hex.EncodeToString(txn.MarshalRLP()))
+
+	return hash, err
+}
+
+// inclusion is the block of a transaction, and the time the loadbot learned about it
+type inclusion struct {
+	blockNumber uint64
+	time        time.Time
+}
+
+// confirmer waits for the transactions to be included in a block
+type confirmer interface {
+	// expect registers the transaction before it's submitted,
+	// so an inclusion notified before wait is called isn't missed
+	expect(hash ethgo.Hash)
+
+	// forget drops the transaction that won't be waited for
+	forget(hash ethgo.Hash)
+
+	// wait blocks until the transaction is included or the context is done
+	wait(ctx context.Context, hash ethgo.Hash) (*inclusion, error)
+
+	close() error
+}
+
+// receiptConfirmer polls the node for the transaction receipts
+type receiptConfirmer struct {
+	client *jsonrpc.Client
+}
+
+func (c *receiptConfirmer) expect(ethgo.Hash) {}
+
+func (c *receiptConfirmer) forget(ethgo.Hash) {}
+
+func (c *receiptConfirmer) wait(ctx context.Context, hash ethgo.Hash) (*inclusion, error) {
+	receipt, err := tests.WaitForReceipt(ctx, c.client.Eth(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inclusion{
+		blockNumber: receipt.BlockNumber,
+		time:        time.Now(),
+	}, nil
+}
+
+func (c *receiptConfirmer) close() error {
+	return nil
+}
+
+// inclusionTracker delivers the notified inclusions to the waiting transactions
+type inclusionTracker struct {
+	lock    sync.Mutex
+	waiters map[ethgo.Hash]chan *inclusion
+}
+
+func newInclusionTracker() *inclusionTracker {
+	return &inclusionTracker{
+		waiters: make(map[ethgo.Hash]chan *inclusion),
+	}
+}
+
+func (t *inclusionTracker) expect(hash ethgo.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.waiters[hash] = make(chan *inclusion, 1)
+}
+
+func (t *inclusionTracker) forget(hash ethgo.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.waiters, hash)
+}
+
+// markIncluded notifies the waiter of the transaction, other transactions are ignored.
+// The waiter is kept until wait returns, as the inclusion may arrive before wait is called
+func (t *inclusionTracker) markIncluded(hash ethgo.Hash, included *inclusion) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if waiter, ok := t.waiters[hash]; ok {
+		select {
+		case waiter <- included:
+		default:
+			// the first inclusion is already notified
+		}
+	}
+}
+
+func (t *inclusionTracker) wait(ctx context.Context, hash ethgo.Hash) (*inclusion, error) {
+	t.lock.Lock()
+	waiter, ok := t.waiters[hash]
+	t.lock.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("transaction %s is not expected", hash)
+	}
+
+	defer t.forget(hash)
+
+	select {
+	case included := <-waiter:
+		return included, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// newHeadsConfirmer subscribes to the new headers over WebSocket, and fetches the
+// transaction hashes of every new block over HTTP. The block bodies aren't fetched over
+// the WebSocket connection, as its reader would wait on the queued headers
+type newHeadsConfirmer struct {
+	*inclusionTracker
+
+	client *jsonrpc.Client
+
+	queueLock sync.Mutex
+	queue     []*newHead
+	notifyCh  chan struct{}
+	doneCh    chan struct{}
+}
+
+type newHead struct {
+	Hash ethgo.Hash `json:"hash"`
+
+	arrival time.Time
+}
+
+func newNewHeadsConfirmer(wsConn *wsClient, client *jsonrpc.Client) (*newHeadsConfirmer, error) {
+	c := &newHeadsConfirmer{
+		inclusionTracker: newInclusionTracker(),
+		client:           client,
+		notifyCh:         make(chan struct{}, 1),
+		doneCh:           make(chan struct{}),
+	}
+
+	if err := wsConn.subscribe(c.onHeader, "newHeads"); err != nil {
+		return nil, fmt.Errorf("unable to subscribe to new heads: %w", err)
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// onHeader queues the header with its arrival time, the inclusion time of its transactions.
+// It's called from the WebSocket reader, so it never blocks
+func (c *newHeadsConfirmer) onHeader(raw json.RawMessage) {
+	header := &newHead{
+		arrival: time.Now(),
+	}
+
+	if err := json.Unmarshal(raw, header); err != nil {
+		return
+	}
+
+	c.queueLock.Lock()
+	c.queue = append(c.queue, header)
+	c.queueLock.Unlock()
+
+	select {
+	case c.notifyCh <- struct{}{}:
+	default:
+		// the queue is already signaled
+	}
+}
+
+// popHeaders takes the queued headers
+func (c *newHeadsConfirmer) popHeaders() []*newHead {
+	c.queueLock.Lock()
+	defer c.queueLock.Unlock()
+
+	headers := c.queue
+	c.queue = nil
+
+	return headers
+}
+
+// run fetches the block bodies of the queued headers
+func (c *newHeadsConfirmer) run() {
+	for {
+		select {
+		case <-c.notifyCh:
+			for _, header := range c.popHeaders() {
+				block, err := c.client.Eth().GetBlockByHash(header.Hash, false)
+				if err != nil || block == nil {
+					continue
+				}
+
+				for _, hash := range block.TransactionsHashes {
+					c.markIncluded(hash, &inclusion{
+						blockNumber: block.Number,
+						time:        header.arrival,
+					})
+				}
+			}
+		case <-c.doneCh:
+			return
+		}
+	}
+}
+
+func (c *newHeadsConfirmer) close() error {
+	close(c.doneCh)
+
+	return nil
+}
+
+// txpoolConfirmer receives the INCLUDED events of the txpool gRPC event stream
+type txpoolConfirmer struct {
+	*inclusionTracker
+
+	cancel context.CancelFunc
+}
+
+func newTxPoolConfirmer(client txpoolOp.TxnPoolOperatorClient) (*txpoolConfirmer, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := client.Subscribe(ctx, &txpoolOp.SubscribeRequest{
+		Types: []txpoolOp.EventType{txpoolOp.EventType_INCLUDED},
+	})
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("unable to subscribe to txpool events: %w", err)
+	}
+
+	c := &txpoolConfirmer{
+		inclusionTracker: newInclusionTracker(),
+		cancel:           cancel,
+	}
+
+	go c.run(stream)
+
+	return c, nil
+}
+
+func (c *txpoolConfirmer) run(stream txpoolOp.TxnPoolOperator_SubscribeClient) {
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			// the stream ends when the confirmer is closed, or the node goes away
+			return
+		}
+
+		c.markIncluded(ethgo.HexToHash(event.TxHash), &inclusion{
+			blockNumber: event.BlockNumber,
+			time:        time.Now(),
+		})
+	}
+}
+
+func (c *txpoolConfirmer) close() error {
+	c.cancel()
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package loadbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+func TestToWSAddress(t *testing.T) {
+	testTable := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{
+			"http address",
+			"http://127.0.0.1:8545",
+			"ws://127.0.0.1:8545/ws",
+		},
+		{
+			"https address",
+			"https://rpc.example.com",
+			"wss://rpc.example.com/ws",
+		},
+		{
+			"websocket address",
+			"ws://127.0.0.1:8545/ws",
+			"ws://127.0.0.1:8545/ws",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			address, err := toWSAddress(testCase.address)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, address)
+		})
+	}
+}
+
+func TestInclusionTracker(t *testing.T) {
+	var (
+		expected   = ethgo.HexToHash("0x1")
+		unexpected = ethgo.HexToHash("0x2")
+		included   = &inclusion{blockNumber: 5, time: time.Now()}
+	)
+
+	tracker := newInclusionTracker()
+	tracker.expect(expected)
+
+	// inclusions can be notified before the wait
+	tracker.markIncluded(unexpected, included)
+	tracker.markIncluded(expected, included)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result, err := tracker.wait(ctx, expected)
+
+	assert.NoError(t, err)
+	assert.Equal(t, included, result)
+
+	// the waiter is dropped once the transaction is included
+	_, err = tracker.wait(ctx, unexpected)
+	assert.Error(t, err)
+
+	_, err = tracker.wait(ctx, expected)
+	assert.Error(t, err)
+}
+
+func TestInclusionTracker_Timeout(t *testing.T) {
+	hash := ethgo.HexToHash("0x1")
+
+	tracker := newInclusionTracker()
+	tracker.expect(hash)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := tracker.wait(ctx, hash)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, tracker.waiters)
+}
+
+func TestWSClient_SubscribeEarlyNotification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		var req codec.Request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		// the notification follows the subscription id right away
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(
+			fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, req.ID),
+		))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(
+			`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":"0x2"}}`,
+		))
+
+		// keep the connection open until the client is done
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, err := newWSClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	assert.NoError(t, err)
+
+	defer client.close()
+
+	resultCh := make(chan json.RawMessage, 1)
+
+	assert.NoError(t, client.subscribe(func(result json.RawMessage) {
+		resultCh <- result
+	}, "newHeads"))
+
+	select {
+	case result := <-resultCh:
+		assert.JSONEq(t, `"0x2"`, string(result))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first notification is lost")
+	}
+}
+
+func TestNewHeadsConfirmer_QueuedHeaders(t *testing.T) {
+	var (
+		txHash   = ethgo.HexToHash("0x1")
+		numHeads = 5000
+	)
+
+	block, err := json.Marshal(&ethgo.Block{
+		Number:             5,
+		Hash:               ethgo.HexToHash("0x5"),
+		TransactionsHashes: []ethgo.Hash{txHash},
+	})
+	assert.NoError(t, err)
+
+	// the block bodies are served over HTTP
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req codec.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(&codec.Response{
+			ID:     req.ID,
+			Result: block,
+		})
+	}))
+	defer server.Close()
+
+	client, err := jsonrpc.NewClient(server.URL)
+	assert.NoError(t, err)
+
+	c := &newHeadsConfirmer{
+		inclusionTracker: newInclusionTracker(),
+		client:           client,
+		notifyCh:         make(chan struct{}, 1),
+		doneCh:           make(chan struct{}),
+	}
+
+	defer c.close()
+
+	c.expect(txHash)
+
+	// the reader isn't blocked while the headers aren't fetched
+	for i := 0; i < numHeads; i++ {
+		c.onHeader(json.RawMessage(fmt.Sprintf(`{"hash":"%s"}`, ethgo.HexToHash("0x5"))))
+	}
+
+	assert.Len(t, c.queue, numHeads)
+
+	go c.run()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	included, err := c.wait(ctx, txHash)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(5), included.blockNumber)
+	}
+}
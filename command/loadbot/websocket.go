@@ -0,0 +1,239 @@
+package loadbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+var errWSClosed = errors.New("websocket connection closed")
+
+// wsClient is a JSON-RPC client over a single WebSocket connection.
+// Unlike the ethgo WebSocket transport, it can be used by concurrent goroutines
+type wsClient struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+	seq       uint64
+
+	pendingLock sync.Mutex
+	pending     map[uint64]*pendingCall
+	closed      bool
+
+	subsLock sync.RWMutex
+	subs     map[string]func(json.RawMessage)
+}
+
+// pendingCall is a call waiting for its response
+type pendingCall struct {
+	respCh chan *codec.Response
+
+	// callback is the notification handler of an eth_subscribe call. It's registered by
+	// the reader with the response, so the notifications following it aren't missed
+	callback func(json.RawMessage)
+}
+
+// toWSAddress returns the WebSocket endpoint of the JSON-RPC HTTP address
+func toWSAddress(jsonRPCAddress string) (string, error) {
+	address, err := url.Parse(jsonRPCAddress)
+	if err != nil {
+		return "", err
+	}
+
+	switch address.Scheme {
+	case "https":
+		address.Scheme = "wss"
+	case "ws", "wss":
+		return address.String(), nil
+	default:
+		address.Scheme = "ws"
+	}
+
+	address.Path = "/ws"
+
+	return address.String(), nil
+}
+
+func newWSClient(address string) (*wsClient, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", address, err)
+	}
+
+	client := &wsClient{
+		conn:    conn,
+		pending: make(map[uint64]*pendingCall),
+		subs:    make(map[string]func(json.RawMessage)),
+	}
+
+	go client.listen()
+
+	return client, nil
+}
+
+// call sends the request and decodes the result of its response to out
+func (c *wsClient) call(method string, out interface{}, params ...interface{}) error {
+	return c.request(method, nil, out, params...)
+}
+
+// request sends the request and decodes the result of its response to out. If set, the
+// callback is registered as the handler of the subscription returned in the response
+func (c *wsClient) request(
+	method string,
+	callback func(json.RawMessage),
+	out interface{},
+	params ...interface{},
+) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	id := atomic.AddUint64(&c.seq, 1)
+
+	raw, err := json.Marshal(&codec.Request{
+		JsonRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  rawParams,
+	})
+	if err != nil {
+		return err
+	}
+
+	respCh := make(chan *codec.Response, 1)
+
+	c.pendingLock.Lock()
+	if c.closed {
+		c.pendingLock.Unlock()
+
+		return errWSClosed
+	}
+
+	c.pending[id] = &pendingCall{
+		respCh:   respCh,
+		callback: callback,
+	}
+	c.pendingLock.Unlock()
+
+	if err := c.write(raw); err != nil {
+		c.pendingLock.Lock()
+		delete(c.pending, id)
+		c.pendingLock.Unlock()
+
+		return err
+	}
+
+	resp, ok := <-respCh
+	if !ok {
+		return errWSClosed
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	return json.Unmarshal(resp.Result, out)
+}
+
+func (c *wsClient) write(raw []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return c.conn.WriteMessage(websocket.TextMessage, raw)
+}
+
+// subscribe calls eth_subscribe and passes the results of the subscription to the callback.
+// The callback is called from the connection reader, and must not block
+func (c *wsClient) subscribe(callback func(json.RawMessage), params ...interface{}) error {
+	var id string
+
+	return c.request("eth_subscribe", callback, &id, params...)
+}
+
+// listen reads the messages of the connection until it's closed
+func (c *wsClient) listen() {
+	defer c.closePending()
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var resp codec.Response
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			continue
+		}
+
+		if resp.ID != 0 {
+			c.pendingLock.Lock()
+			pending, ok := c.pending[resp.ID]
+			delete(c.pending, resp.ID)
+			c.pendingLock.Unlock()
+
+			if ok {
+				if pending.callback != nil && resp.Error == nil {
+					c.addSubscription(resp.Result, pending.callback)
+				}
+
+				pending.respCh <- &resp
+			}
+
+			continue
+		}
+
+		var notification codec.Request
+		if err := json.Unmarshal(raw, &notification); err != nil || notification.Method != "eth_subscription" {
+			continue
+		}
+
+		var sub codec.Subscription
+		if err := json.Unmarshal(notification.Params, &sub); err != nil {
+			continue
+		}
+
+		c.subsLock.RLock()
+		callback, ok := c.subs[sub.ID]
+		c.subsLock.RUnlock()
+
+		if ok {
+			callback(sub.Result)
+		}
+	}
+}
+
+// addSubscription registers the notification handler of the subscription id result
+func (c *wsClient) addSubscription(result json.RawMessage, callback func(json.RawMessage)) {
+	var id string
+	if err := json.Unmarshal(result, &id); err != nil {
+		// the call fails on the same result
+		return
+	}
+
+	c.subsLock.Lock()
+	c.subs[id] = callback
+	c.subsLock.Unlock()
+}
+
+// closePending fails the calls waiting for a response
+func (c *wsClient) closePending() {
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+
+	c.closed = true
+
+	for id, pending := range c.pending {
+		close(pending.respCh)
+		delete(c.pending, id)
+	}
+}
+
+func (c *wsClient) close() error {
+	return c.conn.Close()
+}
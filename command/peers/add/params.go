@@ -20,11 +20,13 @@ var (
 )
 
 const (
-	addrFlag = "addr"
+	addrFlag   = "addr"
+	staticFlag = "static"
 )
 
 type addParams struct {
 	peerAddresses []string
+	static        bool
 
 	systemClient proto.SystemClient
 
@@ -73,7 +75,8 @@ func (p *addParams) addPeer(peerAddress string) error {
 	if _, err := p.systemClient.PeersAdd(
 		context.Background(),
 		&proto.PeersAddRequest{
-			Id: peerAddress,
+			Id:     peerAddress,
+			Static: p.static,
 		},
 	); err != nil {
 		return err
@@ -86,6 +89,7 @@ func (p *addParams) getResult() command.CommandResult {
 	return &PeersAddResult{
 		NumRequested: len(p.peerAddresses),
 		NumAdded:     len(p.addedPeers),
+		Static:       p.static,
 		Peers:        p.addedPeers,
 		Errors:       p.addErrors,
 	}
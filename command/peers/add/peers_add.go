@@ -27,6 +27,14 @@ func setFlags(cmd *cobra.Command) {
 		[]string{},
 		"the libp2p addresses of the peers",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.static,
+		staticFlag,
+		false,
+		"add the peers as static peers, which are always re-dialed when disconnected "+
+			"and persisted across restarts",
+	)
 }
 
 func setRequiredFlags(cmd *cobra.Command) {
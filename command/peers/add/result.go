@@ -9,6 +9,7 @@ import (
 type PeersAddResult struct {
 	NumRequested int      `json:"num_requested"`
 	NumAdded     int      `json:"num_added"`
+	Static       bool     `json:"static"`
 	Peers        []string `json:"peers"`
 	Errors       []string `json:"errors"`
 }
@@ -20,6 +21,7 @@ func (r *PeersAddResult) GetOutput() string {
 	buffer.WriteString(helper.FormatKV([]string{
 		fmt.Sprintf("Peers listed|%d", r.NumRequested), // The number of peers the user wanted to add
 		fmt.Sprintf("Peers added|%d", r.NumAdded),      // The number of peers that have been added
+		fmt.Sprintf("Static|%t", r.Static),             // Whether the peers are kept connected
 	}))
 
 	if len(r.Peers) > 0 {
@@ -0,0 +1,54 @@
+package allowlist
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	peersAllowlistCmd := &cobra.Command{
+		Use:   "allowlist",
+		Short: "Top level command for managing the peer allow list. Only accepts subcommands.",
+	}
+
+	peersAllowlistCmd.AddCommand(
+		// peers allowlist reload
+		&cobra.Command{
+			Use:   "reload",
+			Short: "Reloads the peer allow list from the allow list file and the chain, without a restart",
+			Run:   runReloadCommand,
+		},
+	)
+
+	return peersAllowlistCmd
+}
+
+func runReloadCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	resp, err := reloadAllowlist(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&PeersAllowlistResult{
+		Peers: resp.Peers,
+	})
+}
+
+func reloadAllowlist(grpcAddress string) (*proto.PeersAllowlistResponse, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PeersAllowlistReload(context.Background(), &empty.Empty{})
+}
@@ -0,0 +1,29 @@
+package allowlist
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PeersAllowlistResult struct {
+	Peers []string `json:"peers"`
+}
+
+func (r *PeersAllowlistResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEER ALLOW LIST]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No peers are permitted")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of permitted peers: %d\n\n", len(r.Peers)))
+		buffer.WriteString(helper.FormatList(r.Peers))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -31,7 +31,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	}
 
 	outputter.SetCommandResult(
-		newPeersListResult(peersList.Peers),
+		newPeersListResult(peersList),
 	)
 }
 
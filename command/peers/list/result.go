@@ -3,22 +3,45 @@ package list
 import (
 	"bytes"
 	"fmt"
+	"time"
+
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 )
 
 type PeersListResult struct {
-	Peers []string `json:"peers"`
+	Peers  []*PeerEntry `json:"peers"`
+	Banned []*PeerEntry `json:"banned"`
+}
+
+type PeerEntry struct {
+	ID        string  `json:"id"`
+	Score     float64 `json:"score"`
+	BanReason string  `json:"ban_reason,omitempty"`
+	BanExpiry string  `json:"ban_expiry,omitempty"`
 }
 
-func newPeersListResult(peers []*proto.Peer) *PeersListResult {
-	resultPeers := make([]string, len(peers))
+func newPeerEntries(peers []*proto.Peer) []*PeerEntry {
+	entries := make([]*PeerEntry, len(peers))
 	for i, p := range peers {
-		resultPeers[i] = p.Id
+		entries[i] = &PeerEntry{
+			ID:    p.Id,
+			Score: p.Score,
+		}
+
+		if p.Ban != nil {
+			entries[i].BanReason = p.Ban.Reason
+			entries[i].BanExpiry = time.Unix(p.Ban.Expiry, 0).UTC().Format(time.RFC3339)
+		}
 	}
 
+	return entries
+}
+
+func newPeersListResult(resp *proto.PeersListResponse) *PeersListResult {
 	return &PeersListResult{
-		Peers: resultPeers,
+		Peers:  newPeerEntries(resp.Peers),
+		Banned: newPeerEntries(resp.Banned),
 	}
 }
 
@@ -32,14 +55,27 @@ func (r *PeersListResult) GetOutput() string {
 	} else {
 		buffer.WriteString(fmt.Sprintf("Number of peers: %d\n\n", len(r.Peers)))
 
-		rows := make([]string, len(r.Peers))
+		rows := make([]string, len(r.Peers)+1)
+		rows[0] = "#|ID|Score"
 		for i, p := range r.Peers {
-			rows[i] = fmt.Sprintf("[%d]|%s", i, p)
+			rows[i+1] = fmt.Sprintf("[%d]|%s|%.2f", i, p.ID, p.Score)
 		}
-		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString(helper.FormatList(rows))
 	}
 
 	buffer.WriteString("\n")
 
+	if len(r.Banned) > 0 {
+		buffer.WriteString("\n[BANNED PEERS]\n")
+
+		rows := make([]string, len(r.Banned)+1)
+		rows[0] = "#|ID|Banned Until|Reason"
+		for i, p := range r.Banned {
+			rows[i+1] = fmt.Sprintf("[%d]|%s|%s|%s", i, p.ID, p.BanExpiry, p.BanReason)
+		}
+		buffer.WriteString(helper.FormatList(rows))
+		buffer.WriteString("\n")
+	}
+
 	return buffer.String()
 }
@@ -2,6 +2,8 @@ package status
 
 import (
 	"context"
+	"time"
+
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -49,9 +51,34 @@ func (p *statusParams) initPeerInfo(grpcAddress string) error {
 }
 
 func (p *statusParams) getResult() command.CommandResult {
-	return &PeersStatusResult{
+	result := &PeersStatusResult{
 		ID:        p.peerStatus.Id,
 		Protocols: p.peerStatus.Protocols,
 		Addresses: p.peerStatus.Addrs,
+		Score:     p.peerStatus.Score,
+	}
+
+	if ban := p.peerStatus.Ban; ban != nil {
+		result.BanReason = ban.Reason
+		result.BanExpiry = time.Unix(ban.Expiry, 0).UTC().Format(time.RFC3339)
 	}
+
+	if traffic := p.peerStatus.Traffic; traffic != nil {
+		result.BytesIn = traffic.BytesIn
+		result.BytesOut = traffic.BytesOut
+		result.RateIn = traffic.RateIn
+		result.RateOut = traffic.RateOut
+
+		for _, protocolTraffic := range traffic.Protocols {
+			result.Traffic = append(result.Traffic, ProtocolTrafficResult{
+				Protocol:   protocolTraffic.Protocol,
+				BytesIn:    protocolTraffic.BytesIn,
+				BytesOut:   protocolTraffic.BytesOut,
+				StreamsIn:  protocolTraffic.StreamsIn,
+				StreamsOut: protocolTraffic.StreamsOut,
+			})
+		}
+	}
+
+	return result
 }
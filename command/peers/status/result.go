@@ -7,21 +7,71 @@ import (
 )
 
 type PeersStatusResult struct {
-	ID        string   `json:"id"`
-	Protocols []string `json:"protocols"`
-	Addresses []string `json:"addresses"`
+	ID        string                  `json:"id"`
+	Protocols []string                `json:"protocols"`
+	Addresses []string                `json:"addresses"`
+	Score     float64                 `json:"score"`
+	BanReason string                  `json:"ban_reason,omitempty"`
+	BanExpiry string                  `json:"ban_expiry,omitempty"`
+	BytesIn   int64                   `json:"bytes_in"`
+	BytesOut  int64                   `json:"bytes_out"`
+	RateIn    float64                 `json:"rate_in"`
+	RateOut   float64                 `json:"rate_out"`
+	Traffic   []ProtocolTrafficResult `json:"traffic"`
+}
+
+type ProtocolTrafficResult struct {
+	Protocol   string `json:"protocol"`
+	BytesIn    int64  `json:"bytes_in"`
+	BytesOut   int64  `json:"bytes_out"`
+	StreamsIn  int64  `json:"streams_in"`
+	StreamsOut int64  `json:"streams_out"`
 }
 
 func (r *PeersStatusResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[PEER STATUS]\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	rows := []string{
 		fmt.Sprintf("ID|%s", r.ID),
 		fmt.Sprintf("Protocols|%s", r.Protocols),
 		fmt.Sprintf("Addresses|%s", r.Addresses),
-	}))
+		fmt.Sprintf("Score|%.2f", r.Score),
+	}
+
+	if r.BanExpiry != "" {
+		rows = append(rows,
+			fmt.Sprintf("Banned Until|%s", r.BanExpiry),
+			fmt.Sprintf("Ban Reason|%s", r.BanReason),
+		)
+	}
+
+	rows = append(rows,
+		fmt.Sprintf("Bytes In|%d (%.2f B/s)", r.BytesIn, r.RateIn),
+		fmt.Sprintf("Bytes Out|%d (%.2f B/s)", r.BytesOut, r.RateOut),
+	)
+
+	buffer.WriteString(helper.FormatKV(rows))
 	buffer.WriteString("\n")
 
+	if len(r.Traffic) > 0 {
+		trafficRows := make([]string, len(r.Traffic)+1)
+		trafficRows[0] = "Protocol|Bytes In|Bytes Out|Streams In|Streams Out"
+
+		for i, traffic := range r.Traffic {
+			trafficRows[i+1] = fmt.Sprintf("%s|%d|%d|%d|%d",
+				traffic.Protocol,
+				traffic.BytesIn,
+				traffic.BytesOut,
+				traffic.StreamsIn,
+				traffic.StreamsOut,
+			)
+		}
+
+		buffer.WriteString("\n[TRAFFIC]\n")
+		buffer.WriteString(helper.FormatList(trafficRows))
+		buffer.WriteString("\n")
+	}
+
 	return buffer.String()
 }
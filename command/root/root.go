@@ -3,6 +3,9 @@ package root
 import (
 	"fmt"
 	"github.com/0xPolygon/polygon-edge/command/backup"
+	"github.com/0xPolygon/polygon-edge/command/chain"
+	"github.com/0xPolygon/polygon-edge/command/db"
+	"github.com/0xPolygon/polygon-edge/command/dnstree"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft"
@@ -12,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/peers"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/version"
@@ -48,6 +52,10 @@ func (rc *RootCommand) registerSubCommands() {
 		loadbot.GetCommand(),
 		ibft.GetCommand(),
 		backup.GetCommand(),
+		chain.GetCommand(),
+		db.GetCommand(),
+		state.GetCommand(),
+		dnstree.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		license.GetCommand(),
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"gopkg.in/yaml.v3"
 
@@ -14,21 +15,27 @@ import (
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath       string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir           string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget    string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr          string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr       string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry         *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network           *Network   `json:"network" yaml:"network"`
-	ShouldSeal        bool       `json:"seal" yaml:"seal"`
-	TxPool            *TxPool    `json:"tx_pool" yaml:"tx_pool"`
-	LogLevel          string     `json:"log_level" yaml:"log_level"`
-	RestoreFile       string     `json:"restore_file" yaml:"restore_file"`
-	BlockTime         uint64     `json:"block_time_s" yaml:"block_time_s"`
-	Headers           *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath       string     `json:"log_to" yaml:"log_to"`
+	GenesisPath       string         `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath string         `json:"secrets_config" yaml:"secrets_config"`
+	DataDir           string         `json:"data_dir" yaml:"data_dir"`
+	BlockGasTarget    string         `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr          string         `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr       string         `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry         *Telemetry     `json:"telemetry" yaml:"telemetry"`
+	Network           *Network       `json:"network" yaml:"network"`
+	ShouldSeal        bool           `json:"seal" yaml:"seal"`
+	TxPool            *TxPool        `json:"tx_pool" yaml:"tx_pool"`
+	LogLevel          string         `json:"log_level" yaml:"log_level"`
+	RestoreFile       string         `json:"restore_file" yaml:"restore_file"`
+	BlockTime         uint64         `json:"block_time_s" yaml:"block_time_s"`
+	Headers           *Headers       `json:"headers" yaml:"headers"`
+	LogFilePath       string         `json:"log_to" yaml:"log_to"`
+	StoreRevertReason bool           `json:"store_revert_reason" yaml:"store_revert_reason"`
+	RecordPreimages   bool           `json:"record_preimages" yaml:"record_preimages"`
+	JSONRPCDebug      bool           `json:"jsonrpc_debug" yaml:"jsonrpc_debug"`
+	JSONRPCAccess     *JSONRPCAccess `json:"jsonrpc_access" yaml:"jsonrpc_access"`
+	GraphQL           *GraphQL       `json:"graphql" yaml:"graphql"`
+	DBBackend         string         `json:"db_backend" yaml:"db_backend"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -38,19 +45,27 @@ type Telemetry struct {
 
 // Network defines the network configuration params
 type Network struct {
-	NoDiscover       bool   `json:"no_discover" yaml:"no_discover"`
-	Libp2pAddr       string `json:"libp2p_addr" yaml:"libp2p_addr"`
-	NatAddr          string `json:"nat_addr" yaml:"nat_addr"`
-	DNSAddr          string `json:"dns_addr" yaml:"dns_addr"`
-	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
-	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
-	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	NoDiscover            bool     `json:"no_discover" yaml:"no_discover"`
+	Libp2pAddr            string   `json:"libp2p_addr" yaml:"libp2p_addr"`
+	NatAddr               string   `json:"nat_addr" yaml:"nat_addr"`
+	DNSAddr               string   `json:"dns_addr" yaml:"dns_addr"`
+	MaxPeers              int64    `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
+	MaxOutboundPeers      int64    `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
+	MaxInboundPeers       int64    `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	BanThreshold          float64  `json:"ban_threshold" yaml:"ban_threshold"`
+	BanDuration           uint64   `json:"ban_duration_s,omitempty" yaml:"ban_duration_s,omitempty"`
+	StaticPeers           []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers          []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+	PeerAllowlistFile     string   `json:"peer_allowlist_file,omitempty" yaml:"peer_allowlist_file,omitempty"`
+	PeerAllowlistContract bool     `json:"peer_allowlist_contract,omitempty" yaml:"peer_allowlist_contract,omitempty"`
+	DNSDiscovery          []string `json:"dns_discovery,omitempty" yaml:"dns_discovery,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots   uint64 `json:"max_slots" yaml:"max_slots"`
+	PriceLimit          uint64 `json:"price_limit" yaml:"price_limit"`
+	MaxSlots            uint64 `json:"max_slots" yaml:"max_slots"`
+	AllowUnprotectedTxs bool   `json:"allow_unprotected_txs" yaml:"allow_unprotected_txs"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -58,6 +73,37 @@ type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
 }
 
+// JSONRPCAccess defines the authentication and the limits of the JSON-RPC server.
+// The methods and the rate limit apply to the anonymous clients, per IP
+type JSONRPCAccess struct {
+	RequireAuth    bool              `json:"require_auth" yaml:"require_auth"`
+	JWTSecretFile  string            `json:"jwt_secret_file" yaml:"jwt_secret_file"`
+	Clients        []*JSONRPCClient  `json:"clients,omitempty" yaml:"clients,omitempty"`
+	Methods        []string          `json:"methods,omitempty" yaml:"methods,omitempty"`
+	RateLimit      float64           `json:"rate_limit" yaml:"rate_limit"`
+	RateBurst      int               `json:"rate_burst" yaml:"rate_burst"`
+	MaxBatchSize   uint64            `json:"max_batch_size" yaml:"max_batch_size"`
+	MaxBodySize    uint64            `json:"max_body_size" yaml:"max_body_size"`
+	MethodTimeouts map[string]string `json:"method_timeouts,omitempty" yaml:"method_timeouts,omitempty"`
+}
+
+// JSONRPCClient defines a client of the JSON-RPC server,
+// authenticated by its API key or by a JWT with its name as subject
+type JSONRPCClient struct {
+	Name      string   `json:"name" yaml:"name"`
+	APIKey    string   `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	Methods   []string `json:"methods,omitempty" yaml:"methods,omitempty"`
+	RateLimit float64  `json:"rate_limit" yaml:"rate_limit"`
+	RateBurst int      `json:"rate_burst" yaml:"rate_burst"`
+}
+
+// GraphQL defines the GraphQL endpoint served by the JSON-RPC server on /graphql
+type GraphQL struct {
+	Enabled       bool `json:"enabled" yaml:"enabled"`
+	MaxDepth      int  `json:"max_depth" yaml:"max_depth"`
+	MaxComplexity int  `json:"max_complexity" yaml:"max_complexity"`
+}
+
 // minimum block generation time in seconds
 const defaultBlockTime uint64 = 2
 
@@ -74,6 +120,8 @@ func DefaultConfig() *Config {
 			MaxPeers:         defaultNetworkConfig.MaxPeers,
 			MaxOutboundPeers: defaultNetworkConfig.MaxOutboundPeers,
 			MaxInboundPeers:  defaultNetworkConfig.MaxInboundPeers,
+			BanThreshold:     defaultNetworkConfig.BanThreshold,
+			BanDuration:      uint64(defaultNetworkConfig.BanDuration.Seconds()),
 			Libp2pAddr: fmt.Sprintf("%s:%d",
 				defaultNetworkConfig.Addr.IP,
 				defaultNetworkConfig.Addr.Port,
@@ -91,7 +139,13 @@ func DefaultConfig() *Config {
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
-		LogFilePath: "",
+		LogFilePath:   "",
+		JSONRPCAccess: &JSONRPCAccess{},
+		GraphQL: &GraphQL{
+			MaxDepth:      jsonrpc.DefaultGraphQLMaxDepth,
+			MaxComplexity: jsonrpc.DefaultGraphQLMaxComplexity,
+		},
+		RecordPreimages: true,
 	}
 }
 
@@ -119,10 +173,14 @@ func ReadConfigFile(path string) (*Config, error) {
 	}
 
 	config := DefaultConfig()
+	banThreshold, banDuration := config.Network.BanThreshold, config.Network.BanDuration
 	config.Network = new(Network)
 	config.Network.MaxPeers = -1
 	config.Network.MaxInboundPeers = -1
 	config.Network.MaxOutboundPeers = -1
+	// the ban threshold and duration are omitted from the file when they are the default ones
+	config.Network.BanThreshold = banThreshold
+	config.Network.BanDuration = banDuration
 
 	if err := unmarshalFunc(data, config); err != nil {
 		return nil, err
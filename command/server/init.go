@@ -4,22 +4,34 @@ import (
 	"errors"
 	"fmt"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"io/ioutil"
 	"math"
 	"net"
+	"strings"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/network/common"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// minJWTSecretSize is the minimum size in bytes of the HS256 secret of the JWTs
+const minJWTSecretSize = 32
+
 var (
 	errInvalidBlockTime       = errors.New("invalid block time specified")
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errJSONRPCAuthUnavailable = errors.New("JSON-RPC authentication required without a JWT secret or clients")
+	errInvalidBanThreshold    = errors.New("the ban threshold must be negative, as every peer starts with a zero score")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -53,10 +65,22 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initDBBackend(); err != nil {
+		return err
+	}
+
+	if err := p.initBanThreshold(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
 
+	if err := p.initJSONRPCAccess(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -71,6 +95,29 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initDBBackend() error {
+	if p.rawConfig.DBBackend == "" {
+		return nil
+	}
+
+	backend, err := storage.ParseBackend(p.rawConfig.DBBackend)
+	if err != nil {
+		return err
+	}
+
+	p.dbBackend = backend
+
+	return nil
+}
+
+func (p *serverParams) initBanThreshold() error {
+	if p.rawConfig.Network.BanThreshold >= 0 {
+		return errInvalidBanThreshold
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -143,7 +190,8 @@ func (p *serverParams) initDevConsensusConfig() {
 
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
-			"interval": p.devInterval,
+			"interval":                 p.devInterval,
+			consensus.ConfirmationsKey: p.devConfirmations,
 		},
 	}
 }
@@ -299,6 +347,86 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initJSONRPCAccess() error {
+	rawAccess := p.rawConfig.JSONRPCAccess
+	if rawAccess == nil {
+		rawAccess = &config.JSONRPCAccess{}
+	}
+
+	access := &jsonrpc.AccessConfig{
+		RequireAuth: rawAccess.RequireAuth,
+		Default: jsonrpc.ClientPolicy{
+			Methods:   rawAccess.Methods,
+			RateLimit: rawAccess.RateLimit,
+			RateBurst: rawAccess.RateBurst,
+		},
+		MaxBatchSize:   rawAccess.MaxBatchSize,
+		MaxBodySize:    rawAccess.MaxBodySize,
+		MethodTimeouts: make(map[string]time.Duration, len(rawAccess.MethodTimeouts)),
+	}
+
+	if rawAccess.JWTSecretFile != "" {
+		secret, err := readJWTSecret(rawAccess.JWTSecretFile)
+		if err != nil {
+			return err
+		}
+
+		access.JWTSecret = secret
+	}
+
+	for _, client := range rawAccess.Clients {
+		access.Clients = append(access.Clients, &jsonrpc.ClientConfig{
+			Name:   client.Name,
+			APIKey: client.APIKey,
+			ClientPolicy: jsonrpc.ClientPolicy{
+				Methods:   client.Methods,
+				RateLimit: client.RateLimit,
+				RateBurst: client.RateBurst,
+			},
+		})
+	}
+
+	for method, rawTimeout := range rawAccess.MethodTimeouts {
+		timeout, err := time.ParseDuration(rawTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid JSON-RPC timeout of %s: %w", method, err)
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("invalid JSON-RPC timeout of %s: %s", method, rawTimeout)
+		}
+
+		access.MethodTimeouts[method] = timeout
+	}
+
+	if access.RequireAuth && len(access.JWTSecret) == 0 && len(access.Clients) == 0 {
+		return errJSONRPCAuthUnavailable
+	}
+
+	p.jsonRPCAccess = access
+
+	return nil
+}
+
+// readJWTSecret reads the hex encoded JWT secret from the file
+func readJWTSecret(path string) ([]byte, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the JWT secret, %w", err)
+	}
+
+	secret, err := hex.DecodeHex(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the JWT secret, %w", err)
+	}
+
+	if len(secret) < minJWTSecretSize {
+		return nil, fmt.Errorf("the JWT secret must be at least %d bytes", minJWTSecretSize)
+	}
+
+	return secret, nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
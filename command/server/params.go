@@ -4,8 +4,11 @@ import (
 	"errors"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"net"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -27,14 +30,29 @@ const (
 	maxOutboundPeersFlag  = "max-outbound-peers"
 	priceLimitFlag        = "price-limit"
 	maxSlotsFlag          = "max-slots"
+	allowUnprotectedFlag  = "allow-unprotected-txs"
 	blockGasTargetFlag    = "block-gas-target"
 	secretsConfigFlag     = "secrets-config"
 	restoreFlag           = "restore"
 	blockTimeFlag         = "block-time"
 	devIntervalFlag       = "dev-interval"
+	devConfirmationsFlag  = "dev-confirmations"
 	devFlag               = "dev"
 	corsOriginFlag        = "access-control-allow-origins"
 	logFileLocationFlag   = "log-to"
+	storeRevertReasonFlag = "store-revert-reason"
+	recordPreimagesFlag   = "record-preimages"
+	banThresholdFlag      = "ban-threshold"
+	banDurationFlag       = "ban-duration"
+	jsonRPCDebugFlag      = "jsonrpc-debug"
+	jsonRPCJWTSecretFlag  = "jsonrpc-jwt-secret"
+	jsonRPCRateLimitFlag  = "jsonrpc-rate-limit"
+	jsonRPCBatchLimitFlag = "jsonrpc-batch-limit"
+	jsonRPCBodyLimitFlag  = "jsonrpc-body-limit"
+	graphQLFlag           = "graphql"
+	graphQLMaxDepthFlag   = "graphql-max-depth"
+	graphQLComplexityFlag = "graphql-max-complexity"
+	dbBackendFlag         = "db-backend"
 )
 
 const (
@@ -44,9 +62,11 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &config.Config{
-			Telemetry: &config.Telemetry{},
-			Network:   &config.Network{},
-			TxPool:    &config.TxPool{},
+			Telemetry:     &config.Telemetry{},
+			Network:       &config.Network{},
+			TxPool:        &config.TxPool{},
+			JSONRPCAccess: &config.JSONRPCAccess{},
+			GraphQL:       &config.GraphQL{},
 		},
 	}
 )
@@ -66,12 +86,16 @@ type serverParams struct {
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	jsonRPCAccess     *jsonrpc.AccessConfig
 
-	blockGasTarget uint64
-	devInterval    uint64
-	isDevMode      bool
+	blockGasTarget   uint64
+	devInterval      uint64
+	devConfirmations uint64
+	isDevMode        bool
+	dbBackend        storage.Backend
 
 	corsAllowedOrigins []string
+	restoreFiles       []string
 
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
@@ -121,14 +145,30 @@ func (p *serverParams) isDevConsensus() bool {
 	return server.ConsensusType(p.genesisConfig.Params.GetEngine()) == server.DevConsensus
 }
 
-func (p *serverParams) getRestoreFilePath() *string {
+// getRestoreFilePaths returns the archives given by the restore flags,
+// or the archive of the config file
+func (p *serverParams) getRestoreFilePaths() []string {
+	if len(p.restoreFiles) != 0 {
+		return p.restoreFiles
+	}
+
 	if p.rawConfig.RestoreFile != "" {
-		return &p.rawConfig.RestoreFile
+		return []string{p.rawConfig.RestoreFile}
 	}
 
 	return nil
 }
 
+// getBanDuration returns the period a misbehaving peer is banned for,
+// the default one if it isn't set, as a peer is never banned for no time
+func (p *serverParams) getBanDuration() time.Duration {
+	if p.rawConfig.Network.BanDuration == 0 {
+		return network.DefaultBanDuration
+	}
+
+	return time.Duration(p.rawConfig.Network.BanDuration) * time.Second
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -137,12 +177,27 @@ func (p *serverParams) setRawJSONRPCAddress(jsonRPCAddress string) {
 	p.rawConfig.JSONRPCAddr = jsonRPCAddress
 }
 
+// graphQLConfig returns the configuration of the GraphQL endpoint, nil if it's disabled
+func (p *serverParams) graphQLConfig() *jsonrpc.GraphQLConfig {
+	if p.rawConfig.GraphQL == nil || !p.rawConfig.GraphQL.Enabled {
+		return nil
+	}
+
+	return &jsonrpc.GraphQLConfig{
+		MaxDepth:      p.rawConfig.GraphQL.MaxDepth,
+		MaxComplexity: p.rawConfig.GraphQL.MaxComplexity,
+	}
+}
+
 func (p *serverParams) generateConfig() *server.Config {
 	return &server.Config{
 		Chain: p.genesisConfig,
 		JSONRPC: &server.JSONRPC{
 			JSONRPCAddr:              p.jsonRPCAddress,
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
+			EnableDebug:              p.rawConfig.JSONRPCDebug,
+			Access:                   p.jsonRPCAccess,
+			GraphQL:                  p.graphQLConfig(),
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -150,24 +205,36 @@ func (p *serverParams) generateConfig() *server.Config {
 			PrometheusAddr: p.prometheusAddress,
 		},
 		Network: &network.Config{
-			NoDiscover:       p.rawConfig.Network.NoDiscover,
-			Addr:             p.libp2pAddress,
-			NatAddr:          p.natAddress,
-			DNS:              p.dnsAddress,
-			DataDir:          p.rawConfig.DataDir,
-			MaxPeers:         p.rawConfig.Network.MaxPeers,
-			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
-			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
-			Chain:            p.genesisConfig,
+			NoDiscover:            p.rawConfig.Network.NoDiscover,
+			Addr:                  p.libp2pAddress,
+			NatAddr:               p.natAddress,
+			DNS:                   p.dnsAddress,
+			DataDir:               p.rawConfig.DataDir,
+			MaxPeers:              p.rawConfig.Network.MaxPeers,
+			MaxInboundPeers:       p.rawConfig.Network.MaxInboundPeers,
+			MaxOutboundPeers:      p.rawConfig.Network.MaxOutboundPeers,
+			BanThreshold:          p.rawConfig.Network.BanThreshold,
+			BanDuration:           p.getBanDuration(),
+			StaticPeers:           p.rawConfig.Network.StaticPeers,
+			TrustedPeers:          p.rawConfig.Network.TrustedPeers,
+			PeerAllowlistFile:     p.rawConfig.Network.PeerAllowlistFile,
+			PeerAllowlistContract: p.rawConfig.Network.PeerAllowlistContract,
+			DNSDiscovery:          p.rawConfig.Network.DNSDiscovery,
+			Chain:                 p.genesisConfig,
 		},
 		DataDir:        p.rawConfig.DataDir,
+		DBBackend:      p.dbBackend,
 		Seal:           p.rawConfig.ShouldSeal,
 		PriceLimit:     p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:       p.rawConfig.TxPool.MaxSlots,
 		SecretsManager: p.secretsConfig,
-		RestoreFile:    p.getRestoreFilePath(),
+		RestoreFiles:   p.getRestoreFilePaths(),
 		BlockTime:      p.rawConfig.BlockTime,
 		LogLevel:       hclog.LevelFromString(p.rawConfig.LogLevel),
 		LogFilePath:    p.logFileLocation,
+
+		StoreRevertReason:   p.rawConfig.StoreRevertReason,
+		RecordPreimages:     p.rawConfig.RecordPreimages,
+		AllowUnprotectedTxs: p.rawConfig.TxPool.AllowUnprotectedTxs,
 	}
 }
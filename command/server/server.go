@@ -111,11 +111,12 @@ func setFlags(cmd *cobra.Command) {
 			"If omitted, the local FS secrets manager is used",
 	)
 
-	cmd.Flags().StringVar(
-		&params.rawConfig.RestoreFile,
+	cmd.Flags().StringArrayVar(
+		&params.restoreFiles,
 		restoreFlag,
-		"",
-		"the path to the archive blockchain data to restore on initialization",
+		[]string{},
+		"the path to the archive blockchain data to restore on initialization. "+
+			"Can be set multiple times, the incremental archives after the archive they continue",
 	)
 
 	cmd.Flags().BoolVar(
@@ -159,6 +160,20 @@ func setFlags(cmd *cobra.Command) {
 	// override default usage value
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 
+	cmd.Flags().Float64Var(
+		&params.rawConfig.Network.BanThreshold,
+		banThresholdFlag,
+		defaultConfig.Network.BanThreshold,
+		"the reputation score below which a misbehaving peer is banned (must be negative)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Network.BanDuration,
+		banDurationFlag,
+		defaultConfig.Network.BanDuration,
+		"the duration in seconds a misbehaving peer is banned for",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -176,6 +191,13 @@ func setFlags(cmd *cobra.Command) {
 		"maximum slots in the pool",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.AllowUnprotectedTxs,
+		allowUnprotectedFlag,
+		defaultConfig.TxPool.AllowUnprotectedTxs,
+		"accept the transactions without a chain ID (pre EIP-155) over json-RPC/gRPC",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -197,6 +219,85 @@ func setFlags(cmd *cobra.Command) {
 		"write all logs to the file at specified location instead of writing them to console",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StoreRevertReason,
+		storeRevertReasonFlag,
+		defaultConfig.StoreRevertReason,
+		"the flag indicating that the client should store the revert reason of failed transactions in their receipts",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.RecordPreimages,
+		recordPreimagesFlag,
+		defaultConfig.RecordPreimages,
+		"the flag indicating that the client should record the addresses and storage slots of the state, "+
+			"so it can be dumped with the state dump command (disable with --record-preimages=false)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCDebug,
+		jsonRPCDebugFlag,
+		defaultConfig.JSONRPCDebug,
+		"the flag enabling the debug JSON-RPC namespace, which can rewind the chain with debug_setHead",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAccess.JWTSecretFile,
+		jsonRPCJWTSecretFlag,
+		defaultConfig.JSONRPCAccess.JWTSecretFile,
+		"the file holding the hex encoded HS256 secret of the JWTs authenticating JSON-RPC clients",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCAccess.RateLimit,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCAccess.RateLimit,
+		"the number of JSON-RPC requests per second accepted from an anonymous client IP (unlimited if 0)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCAccess.MaxBatchSize,
+		jsonRPCBatchLimitFlag,
+		defaultConfig.JSONRPCAccess.MaxBatchSize,
+		"the maximum number of requests in a JSON-RPC batch (unlimited if 0)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCAccess.MaxBodySize,
+		jsonRPCBodyLimitFlag,
+		defaultConfig.JSONRPCAccess.MaxBodySize,
+		"the maximum size in bytes of a JSON-RPC request (unlimited if 0)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.GraphQL.Enabled,
+		graphQLFlag,
+		defaultConfig.GraphQL.Enabled,
+		"the flag enabling the GraphQL endpoint (EIP-1767) on the /graphql path of the JSON-RPC server",
+	)
+
+	cmd.Flags().IntVar(
+		&params.rawConfig.GraphQL.MaxDepth,
+		graphQLMaxDepthFlag,
+		defaultConfig.GraphQL.MaxDepth,
+		"the maximum nesting of the fields of a GraphQL query (unlimited if 0)",
+	)
+
+	cmd.Flags().IntVar(
+		&params.rawConfig.GraphQL.MaxComplexity,
+		graphQLComplexityFlag,
+		defaultConfig.GraphQL.MaxComplexity,
+		"the maximum estimated number of fields resolved by a GraphQL query (unlimited if 0)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.DBBackend,
+		dbBackendFlag,
+		defaultConfig.DBBackend,
+		"the database backend of the data directory: leveldb or pebble. "+
+			"A new data directory uses leveldb if not set, and an existing one is opened with its backend",
+	)
+
 	setDevFlags(cmd)
 }
 
@@ -218,6 +319,15 @@ func setDevFlags(cmd *cobra.Command) {
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	cmd.Flags().Uint64Var(
+		&params.devConfirmations,
+		devConfirmationsFlag,
+		0,
+		"the number of blocks on top of a block for it to be finalized in dev mode (default 0)",
+	)
+
+	_ = cmd.Flags().MarkHidden(devConfirmationsFlag)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
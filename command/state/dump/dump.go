@@ -0,0 +1,78 @@
+package dump
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dumpCmd := &cobra.Command{
+		Use: "dump",
+		Short: "Dumps the accounts, code and storage of the state at a block to a JSON file, " +
+			"which can be used as the genesis allocation of a new chain (see genesis --alloc-from)",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dumpCmd)
+	setRequiredFlags(dumpCmd)
+
+	return dumpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the path of the state dump file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the block to dump the state at (default latest)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.allowUnresolved,
+		allowUnresolvedFlag,
+		false,
+		"dump the accounts and storage slots without a recorded preimage by hashed key, "+
+			"instead of failing. Such a dump can't be used as a genesis allocation",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	offlineChain, err := helper.OpenOfflineChain(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer offlineChain.Close()
+
+	if err := params.dumpState(offlineChain); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
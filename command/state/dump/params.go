@@ -0,0 +1,122 @@
+package dump
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+	stateDump "github.com/0xPolygon/polygon-edge/state/dump"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	outFlag             = "out"
+	blockFlag           = "block"
+	allowUnresolvedFlag = "allow-unresolved"
+)
+
+var (
+	params = &dumpParams{}
+)
+
+var (
+	errDecodeBlock = errors.New("unable to decode block number")
+)
+
+type dumpParams struct {
+	out string
+
+	blockRaw string
+	block    *uint64
+
+	allowUnresolved bool
+
+	header *stateDump.Header
+	stats  *stateDump.Stats
+}
+
+func (p *dumpParams) validateFlags() error {
+	if p.blockRaw == "" {
+		return nil
+	}
+
+	block, err := types.ParseUint64orHex(&p.blockRaw)
+	if err != nil {
+		return errDecodeBlock
+	}
+
+	p.block = &block
+
+	return nil
+}
+
+func (p *dumpParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *dumpParams) dumpState(offlineChain *server.OfflineChain) error {
+	blockchain := offlineChain.Blockchain()
+
+	header := blockchain.Header()
+
+	if p.block != nil {
+		var ok bool
+		if header, ok = blockchain.GetHeaderByNumber(*p.block); !ok {
+			return fmt.Errorf("block %d not found", *p.block)
+		}
+	}
+
+	p.header = &stateDump.Header{
+		Block:  header.Number,
+		Hash:   header.Hash,
+		Root:   header.StateRoot,
+		Params: offlineChain.Chain().Params,
+	}
+
+	file, err := os.Create(p.out)
+	if err != nil {
+		return err
+	}
+
+	// the genesis accounts are resolved even if the node didn't record the preimages
+	preimages := stateDump.NewGenesisPreimages(offlineChain.Chain().Genesis.Alloc)
+
+	p.stats, err = stateDump.DumpState(offlineChain.StateStorage(), p.header, preimages, p.allowUnresolved, file)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	file.Close()
+
+	if err != nil {
+		// don't leave an incomplete dump behind
+		_ = os.Remove(p.out)
+
+		if errors.Is(err, stateDump.ErrMissingPreimage) {
+			return fmt.Errorf("unable to dump state: %w. The node must record the preimages from the genesis "+
+				"(see the server --%s flag), or the keys can be dumped hashed with --%s",
+				err, "record-preimages", allowUnresolvedFlag)
+		}
+
+		return fmt.Errorf("unable to dump state: %w", err)
+	}
+
+	return nil
+}
+
+func (p *dumpParams) getResult() command.CommandResult {
+	return &DumpResult{
+		Block:              p.header.Block,
+		Hash:               p.header.Hash.String(),
+		Root:               p.header.Root.String(),
+		Out:                p.out,
+		Accounts:           p.stats.Accounts,
+		StorageSlots:       p.stats.StorageSlots,
+		UnresolvedAccounts: p.stats.UnresolvedAccounts,
+		UnresolvedSlots:    p.stats.UnresolvedSlots,
+	}
+}
@@ -0,0 +1,48 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DumpResult struct {
+	Block              uint64 `json:"block"`
+	Hash               string `json:"hash"`
+	Root               string `json:"root"`
+	Out                string `json:"out"`
+	Accounts           uint64 `json:"accounts"`
+	StorageSlots       uint64 `json:"storage_slots"`
+	UnresolvedAccounts uint64 `json:"unresolved_accounts"`
+	UnresolvedSlots    uint64 `json:"unresolved_slots"`
+}
+
+func (r *DumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE DUMP]\n")
+	buffer.WriteString("Dumped state successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Block hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.Root),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Storage slots|%d", r.StorageSlots),
+	}))
+	buffer.WriteString("\n")
+
+	if r.UnresolvedAccounts != 0 || r.UnresolvedSlots != 0 {
+		buffer.WriteString("\n[WARNING]\n")
+		buffer.WriteString(fmt.Sprintf(
+			"%d accounts and %d storage slots have no recorded preimage, and are dumped by hashed key. "+
+				"The dump can't be used as a genesis allocation, "+
+				"the node must record the preimages from the genesis (see the server --record-preimages flag)\n",
+			r.UnresolvedAccounts,
+			r.UnresolvedSlots,
+		))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,28 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/state/dump"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use: "state",
+		Short: "Top level command for working on the world state of a stopped node. " +
+			"Only accepts subcommands.",
+	}
+
+	helper.RegisterOfflineChainFlags(stateCmd)
+
+	registerSubcommands(stateCmd)
+
+	return stateCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// state dump
+		dump.GetCommand(),
+	)
+}
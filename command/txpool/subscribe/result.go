@@ -10,16 +10,23 @@ import (
 type TxPoolEventResult struct {
 	EventType txpoolProto.EventType `json:"event_type"`
 	TxHash    string                `json:"tx_hash"`
+	Block     uint64                `json:"block,omitempty"`
 }
 
 func (r *TxPoolEventResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[TXPOOL EVENT]\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	vals := []string{
 		fmt.Sprintf("TYPE|%s", r.EventType),
 		fmt.Sprintf("HASH|%s", r.TxHash),
-	}))
+	}
+
+	if r.EventType == txpoolProto.EventType_INCLUDED {
+		vals = append(vals, fmt.Sprintf("BLOCK|%d", r.Block))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 	buffer.WriteString("\n")
 
 	return buffer.String()
@@ -69,6 +69,12 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"should subscribe to pruned enqueued tx events in the TxPool",
 	)
+	cmd.Flags().BoolVar(
+		params.eventSubscriptionMap[txpoolProto.EventType_INCLUDED],
+		includedFlag,
+		false,
+		"should subscribe to events of the transactions included in new blocks",
+	)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
@@ -155,6 +161,7 @@ func runSubscribeLoop(
 			outputter.SetCommandResult(&TxPoolEventResult{
 				EventType: streamEvent.Type,
 				TxHash:    streamEvent.TxHash,
+				Block:     streamEvent.BlockNumber,
 			})
 			flushOutput()
 		}
@@ -2,6 +2,8 @@ package consensus
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -25,6 +27,9 @@ type Consensus interface {
 	// ProcessHeaders updates the snapshot based on the verified headers
 	ProcessHeaders(headers []*types.Header) error
 
+	// RewindHeaders rolls back the snapshot to the block, after the chain head was rewound
+	RewindHeaders(number uint64) error
+
 	// GetBlockCreator retrieves the block creator (or signer) given the block header
 	GetBlockCreator(header *types.Header) (types.Address, error)
 
@@ -34,6 +39,15 @@ type Consensus interface {
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 
+	// GetFinalizedNumber returns the number of the latest finalized block,
+	// which is also the latest safe block
+	GetFinalizedNumber() uint64
+
+	// GetValidatorStats returns the statistics of the validators added up over the epochs,
+	// a zero toEpoch is the latest epoch. The statistics are partial while the consensus
+	// is still recording the blocks written before it kept them
+	GetValidatorStats(fromEpoch, toEpoch uint64) (stats map[types.Address]*ValidatorStats, partial bool, err error)
+
 	// Initialize initializes the consensus (e.g. setup data)
 	Initialize() error
 
@@ -44,6 +58,22 @@ type Consensus interface {
 	Close() error
 }
 
+// ErrNoValidatorStats is returned by the consensus mechanisms not keeping validator statistics
+var ErrNoValidatorStats = errors.New("the validator statistics are only kept by the IBFT consensus")
+
+// ValidatorStats are the statistics of a validator
+type ValidatorStats struct {
+	// ProposedBlocks is the number of blocks proposed by the validator
+	ProposedBlocks uint64
+
+	// CommittedSeals is the number of committed seals of the validator included in the blocks
+	CommittedSeals uint64
+
+	// MissedRounds is the number of rounds the validator was selected as the proposer,
+	// without the block being proposed by it
+	MissedRounds uint64
+}
+
 // Config is the configuration for the consensus
 type Config struct {
 	// Logger to be used by the backend
@@ -59,6 +89,41 @@ type Config struct {
 	Path string
 }
 
+// ConfirmationsKey is the config key of the confirmation depth, used by the
+// consensus mechanisms without instant finality
+const ConfirmationsKey = "confirmations"
+
+// GetConfirmations returns the confirmation depth set in the config, 0 if it isn't set.
+// The value is a float64 when the config is read from the genesis file
+func (c *Config) GetConfirmations() (uint64, error) {
+	raw, ok := c.Config[ConfirmationsKey]
+	if !ok {
+		return 0, nil
+	}
+
+	switch confirmations := raw.(type) {
+	case uint64:
+		return confirmations, nil
+	case float64:
+		if confirmations < 0 {
+			return 0, fmt.Errorf("%s can't be negative", ConfirmationsKey)
+		}
+
+		return uint64(confirmations), nil
+	default:
+		return 0, fmt.Errorf("%s expected int", ConfirmationsKey)
+	}
+}
+
+// FinalizedNumber returns the number of the latest block with the confirmation depth on top of it
+func FinalizedNumber(head, confirmations uint64) uint64 {
+	if head < confirmations {
+		return 0
+	}
+
+	return head - confirmations
+}
+
 type ConsensusParams struct {
 	Context        context.Context
 	Seal           bool
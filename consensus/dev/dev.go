@@ -21,8 +21,9 @@ type Dev struct {
 	notifyCh chan struct{}
 	closeCh  chan struct{}
 
-	interval uint64
-	txpool   *txpool.TxPool
+	interval      uint64
+	confirmations uint64
+	txpool        *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
@@ -53,6 +54,13 @@ func Factory(
 		d.interval = interval
 	}
 
+	confirmations, err := params.Config.GetConfirmations()
+	if err != nil {
+		return nil, err
+	}
+
+	d.confirmations = confirmations
+
 	return d, nil
 }
 
@@ -169,7 +177,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
-	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
+	transition, err := d.executor.BeginBlock(parent.StateRoot, header, miner)
 
 	if err != nil {
 		return err
@@ -219,6 +227,10 @@ func (d *Dev) ProcessHeaders(headers []*types.Header) error {
 	return nil
 }
 
+func (d *Dev) RewindHeaders(number uint64) error {
+	return nil
+}
+
 func (d *Dev) GetBlockCreator(header *types.Header) (types.Address, error) {
 	return header.Miner, nil
 }
@@ -232,6 +244,18 @@ func (d *Dev) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// GetFinalizedNumber returns the latest block with the configured confirmation depth on top of it
+func (d *Dev) GetFinalizedNumber() uint64 {
+	return consensus.FinalizedNumber(d.blockchain.Header().Number, d.confirmations)
+}
+
+// GetValidatorStats isn't supported, the validator statistics are kept by IBFT
+func (d *Dev) GetValidatorStats(
+	fromEpoch, toEpoch uint64,
+) (map[types.Address]*consensus.ValidatorStats, bool, error) {
+	return nil, false, consensus.ErrNoValidatorStats
+}
+
 func (d *Dev) Prepare(header *types.Header) error {
 	// TODO: Remove
 	return nil
@@ -11,13 +11,14 @@ import (
 )
 
 type Dummy struct {
-	sealing    bool
-	logger     hclog.Logger
-	notifyCh   chan struct{}
-	closeCh    chan struct{}
-	txpool     *txpool.TxPool
-	blockchain *blockchain.Blockchain
-	executor   *state.Executor
+	sealing       bool
+	logger        hclog.Logger
+	notifyCh      chan struct{}
+	closeCh       chan struct{}
+	txpool        *txpool.TxPool
+	blockchain    *blockchain.Blockchain
+	executor      *state.Executor
+	confirmations uint64
 }
 
 func Factory(params *consensus.ConsensusParams) (consensus.Consensus, error) {
@@ -33,6 +34,13 @@ func Factory(params *consensus.ConsensusParams) (consensus.Consensus, error) {
 		txpool:     params.Txpool,
 	}
 
+	confirmations, err := params.Config.GetConfirmations()
+	if err != nil {
+		return nil, err
+	}
+
+	d.confirmations = confirmations
+
 	return d, nil
 }
 
@@ -56,6 +64,10 @@ func (d *Dummy) ProcessHeaders(headers []*types.Header) error {
 	return nil
 }
 
+func (d *Dummy) RewindHeaders(number uint64) error {
+	return nil
+}
+
 func (d *Dummy) GetBlockCreator(header *types.Header) (types.Address, error) {
 	return header.Miner, nil
 }
@@ -69,6 +81,18 @@ func (d *Dummy) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// GetFinalizedNumber returns the latest block with the configured confirmation depth on top of it
+func (d *Dummy) GetFinalizedNumber() uint64 {
+	return consensus.FinalizedNumber(d.blockchain.Header().Number, d.confirmations)
+}
+
+// GetValidatorStats isn't supported, the validator statistics are kept by IBFT
+func (d *Dummy) GetValidatorStats(
+	fromEpoch, toEpoch uint64,
+) (map[types.Address]*consensus.ValidatorStats, bool, error) {
+	return nil, false, consensus.ErrNoValidatorStats
+}
+
 func (d *Dummy) Close() error {
 	close(d.closeCh)
 
@@ -0,0 +1,1009 @@
+package ibft
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// SetChangeCause defines what changed the validator set
+type SetChangeCause string
+
+const (
+	// VoteCause is a change voted by the validators (PoA)
+	VoteCause SetChangeCause = "vote"
+
+	// StakingCause is a change of the stakes on the Staking SC (PoS)
+	StakingCause SetChangeCause = "staking"
+)
+
+// ValidatorSetChange is a change of the validator set at a block
+type ValidatorSetChange struct {
+	Number  uint64
+	Hash    types.Hash
+	Epoch   uint64
+	Added   []types.Address
+	Removed []types.Address
+	Cause   SetChangeCause
+}
+
+// includes checks if the validator was added or removed by the change
+func (c *ValidatorSetChange) includes(addr types.Address) bool {
+	for _, added := range c.Added {
+		if added == addr {
+			return true
+		}
+	}
+
+	for _, removed := range c.Removed {
+		if removed == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VoteRecord is a vote cast by a validator in a block
+type VoteRecord struct {
+	Number    uint64
+	Epoch     uint64
+	Validator types.Address
+	Candidate types.Address
+	Authorize bool
+}
+
+// addStats adds up the statistics
+func addStats(total, stats *consensus.ValidatorStats) {
+	total.ProposedBlocks += stats.ProposedBlocks
+	total.CommittedSeals += stats.CommittedSeals
+	total.MissedRounds += stats.MissedRounds
+}
+
+// historyStore is the storage of the validator history. The history of each epoch is written
+// apart from its votes and validator set changes, which are appended as they're recorded
+type historyStore interface {
+	// WriteValidatorHistory writes the history of the epoch, marking it as the latest epoch
+	WriteValidatorHistory(epoch uint64, blob []byte) error
+
+	// ReadValidatorHistory reads the history of the epoch
+	ReadValidatorHistory(epoch uint64) ([]byte, bool)
+
+	// ReadValidatorHistoryEpoch reads the latest epoch of the history
+	ReadValidatorHistoryEpoch() (uint64, bool)
+
+	// WriteValidatorVote writes the vote of the epoch at the index
+	WriteValidatorVote(epoch, index uint64, blob []byte) error
+
+	// ReadValidatorVote reads the vote of the epoch at the index
+	ReadValidatorVote(epoch, index uint64) ([]byte, bool)
+
+	// WriteValidatorSetChange writes the validator set change of the epoch at the index
+	WriteValidatorSetChange(epoch, index uint64, blob []byte) error
+
+	// ReadValidatorSetChange reads the validator set change of the epoch at the index
+	ReadValidatorSetChange(epoch, index uint64) ([]byte, bool)
+}
+
+// epochHistory is the validator history of an epoch
+type epochHistory struct {
+	// LastBlock is the latest block recorded in the history
+	LastBlock uint64
+
+	// LastProposer is the proposer of the latest block recorded in the history
+	LastProposer types.Address
+
+	// Backfilling is set while the history is backfilled, as of the latest epoch
+	Backfilling bool
+
+	// NumChanges is the number of validator set changes written in the epoch
+	NumChanges uint64
+
+	// NumVotes is the number of votes written in the epoch
+	NumVotes uint64
+
+	// Stats are the statistics of the validators
+	Stats map[types.Address]*consensus.ValidatorStats
+
+	// changes are the validator set changes in chronological order
+	changes []*ValidatorSetChange
+
+	// votes are the votes in chronological order
+	votes []*VoteRecord
+}
+
+// newEpochHistory returns the history of an epoch following the latest block
+func newEpochHistory(lastBlock uint64, lastProposer types.Address) *epochHistory {
+	return &epochHistory{
+		LastBlock:    lastBlock,
+		LastProposer: lastProposer,
+		Stats:        map[types.Address]*consensus.ValidatorStats{},
+		changes:      []*ValidatorSetChange{},
+		votes:        []*VoteRecord{},
+	}
+}
+
+// validatorHistory indexes the validator set changes, the votes and the statistics of the validators
+// by epoch. The votes and the changes are appended to the store as they're recorded, and the history
+// of the epoch is written along with each block. Only the history of the latest epoch is kept in memory
+type validatorHistory struct {
+	// lock is the validatorHistory mutex
+	lock sync.RWMutex
+
+	// store is the storage of the history of the epochs
+	store historyStore
+
+	// epoch is the latest epoch recorded in the history
+	epoch uint64
+
+	// current is the history of the latest epoch
+	current *epochHistory
+
+	// backfilling is set while the blocks written before the history are recorded
+	backfilling bool
+}
+
+// newValidatorHistory returns a new validator history kept in the store
+func newValidatorHistory(store historyStore) *validatorHistory {
+	return &validatorHistory{
+		store:   store,
+		current: newEpochHistory(0, types.ZeroAddress),
+	}
+}
+
+// load loads the latest epoch of the history from the store,
+// returns false if the history was never written
+func (h *validatorHistory) load() (bool, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	epoch, ok := h.store.ReadValidatorHistoryEpoch()
+	if !ok {
+		return false, nil
+	}
+
+	current, err := h.read(epoch)
+	if err != nil {
+		return false, err
+	}
+
+	h.epoch, h.current = epoch, current
+	h.backfilling = current.Backfilling
+
+	return true, nil
+}
+
+// read reads the history of the epoch from the store, the history is empty if it isn't found
+func (h *validatorHistory) read(epoch uint64) (*epochHistory, error) {
+	history := newEpochHistory(0, types.ZeroAddress)
+
+	blob, ok := h.store.ReadValidatorHistory(epoch)
+	if !ok {
+		return history, nil
+	}
+
+	if err := json.Unmarshal(blob, history); err != nil {
+		return nil, fmt.Errorf("invalid validator history of epoch %d: %w", epoch, err)
+	}
+
+	for index := uint64(0); index < history.NumChanges; index++ {
+		change := &ValidatorSetChange{}
+		if err := h.readRecord(h.store.ReadValidatorSetChange, epoch, index, change); err != nil {
+			return nil, err
+		}
+
+		history.changes = append(history.changes, change)
+	}
+
+	for index := uint64(0); index < history.NumVotes; index++ {
+		vote := &VoteRecord{}
+		if err := h.readRecord(h.store.ReadValidatorVote, epoch, index, vote); err != nil {
+			return nil, err
+		}
+
+		history.votes = append(history.votes, vote)
+	}
+
+	return history, nil
+}
+
+// readRecord reads the vote or the change of the epoch at the index
+func (h *validatorHistory) readRecord(
+	readFn func(epoch, index uint64) ([]byte, bool),
+	epoch, index uint64,
+	record interface{},
+) error {
+	blob, ok := readFn(epoch, index)
+	if !ok {
+		return fmt.Errorf("record %d of the validator history of epoch %d not found", index, epoch)
+	}
+
+	if err := json.Unmarshal(blob, record); err != nil {
+		return fmt.Errorf("invalid record %d of the validator history of epoch %d: %w", index, epoch, err)
+	}
+
+	return nil
+}
+
+// writeRecord writes the vote or the change of the latest epoch at the index
+func (h *validatorHistory) writeRecord(
+	writeFn func(epoch, index uint64, blob []byte) error,
+	index uint64,
+	record interface{},
+) error {
+	blob, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return writeFn(h.epoch, index, blob)
+}
+
+// write writes the history of the latest epoch to the store, the votes and the changes
+// up to the counts written are already in the store
+func (h *validatorHistory) write() error {
+	h.current.Backfilling = h.backfilling
+	h.current.NumChanges = uint64(len(h.current.changes))
+	h.current.NumVotes = uint64(len(h.current.votes))
+
+	blob, err := json.Marshal(h.current)
+	if err != nil {
+		return err
+	}
+
+	return h.store.WriteValidatorHistory(h.epoch, blob)
+}
+
+// at returns the history of the epoch to record to, a new history is started
+// once the blocks of the next epoch are recorded
+func (h *validatorHistory) at(epoch uint64) *epochHistory {
+	if epoch > h.epoch {
+		h.epoch = epoch
+		h.current = newEpochHistory(h.current.LastBlock, h.current.LastProposer)
+	}
+
+	return h.current
+}
+
+// get returns the history of the epoch, the previous epochs are read from the store
+func (h *validatorHistory) get(epoch uint64) (*epochHistory, error) {
+	if epoch == h.epoch {
+		return h.current, nil
+	}
+
+	return h.read(epoch)
+}
+
+// lastBlock returns the latest block recorded in the history
+func (h *validatorHistory) lastBlock() uint64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.current.LastBlock
+}
+
+// isBackfilling checks if the blocks written before the history are still being recorded
+func (h *validatorHistory) isBackfilling() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.backfilling
+}
+
+// startBackfill marks the history as backfilling, the flag is written for the backfill
+// to be resumed after a restart
+func (h *validatorHistory) startBackfill() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.backfilling = true
+
+	return h.write()
+}
+
+// finishBackfill ends the backfill once the history reaches the head, and returns true.
+// The head is read while the lock is held, so a block written meanwhile is either
+// recorded by the backfill or by the consensus once the backfill ends
+func (h *validatorHistory) finishBackfill(headNumber func() uint64) (bool, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.current.LastBlock < headNumber() {
+		return false, nil
+	}
+
+	h.backfilling = false
+
+	return true, h.write()
+}
+
+// isRecorded checks if the block is already recorded in the history
+func (h *validatorHistory) isRecorded(number uint64) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return number <= h.current.LastBlock
+}
+
+// lastProposer returns the proposer of the block, if it's the latest block recorded in the history.
+// The proposer isn't known after a rewind
+func (h *validatorHistory) lastProposer(number uint64) (types.Address, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if number != h.current.LastBlock || h.current.LastProposer == types.ZeroAddress {
+		return types.ZeroAddress, false
+	}
+
+	return h.current.LastProposer, true
+}
+
+// addVote records the vote, its count is written along with the block it's cast in
+func (h *validatorHistory) addVote(vote *VoteRecord) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current := h.at(vote.Epoch)
+
+	if err := h.writeRecord(h.store.WriteValidatorVote, uint64(len(current.votes)), vote); err != nil {
+		return err
+	}
+
+	current.votes = append(current.votes, vote)
+
+	return nil
+}
+
+// addChange records the validator set change. A change with the same cause at the
+// same block replaces the previous one, as the block can be processed again
+func (h *validatorHistory) addChange(change *ValidatorSetChange) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current := h.at(change.Epoch)
+
+	index := len(current.changes)
+
+	for idx, c := range current.changes {
+		if c.Number == change.Number && c.Cause == change.Cause {
+			index = idx
+
+			break
+		}
+	}
+
+	if err := h.writeRecord(h.store.WriteValidatorSetChange, uint64(index), change); err != nil {
+		return err
+	}
+
+	if index < len(current.changes) {
+		current.changes[index] = change
+	} else {
+		current.changes = append(current.changes, change)
+	}
+
+	return h.write()
+}
+
+// addBlock records the statistics of the block, and marks it as the latest block
+func (h *validatorHistory) addBlock(
+	number, epoch uint64,
+	proposer types.Address,
+	sealers []types.Address,
+	missed []types.Address,
+) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current := h.at(epoch)
+
+	get := func(addr types.Address) *consensus.ValidatorStats {
+		stats, ok := current.Stats[addr]
+		if !ok {
+			stats = &consensus.ValidatorStats{}
+			current.Stats[addr] = stats
+		}
+
+		return stats
+	}
+
+	get(proposer).ProposedBlocks++
+
+	for _, sealer := range sealers {
+		get(sealer).CommittedSeals++
+	}
+
+	for _, addr := range missed {
+		get(addr).MissedRounds++
+	}
+
+	current.LastBlock = number
+	current.LastProposer = proposer
+
+	return h.write()
+}
+
+// rewind deletes the changes and the votes above the block. The statistics are kept per epoch,
+// so the ones of the epoch of the block are deleted, and the history is set back to the end
+// of the previous epoch for them to be recorded again. The later epochs are dropped
+func (h *validatorHistory) rewind(number, epoch, epochBegin uint64) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current, err := h.get(epoch)
+	if err != nil {
+		return err
+	}
+
+	changes := current.changes[:0]
+
+	for _, c := range current.changes {
+		if c.Number <= number {
+			changes = append(changes, c)
+		}
+	}
+
+	votes := current.votes[:0]
+
+	for _, v := range current.votes {
+		if v.Number <= number {
+			votes = append(votes, v)
+		}
+	}
+
+	current.changes, current.votes = changes, votes
+	current.Stats = map[types.Address]*consensus.ValidatorStats{}
+
+	if h.current.LastBlock > epochBegin {
+		current.LastBlock = epochBegin
+		current.LastProposer = types.ZeroAddress
+	} else {
+		current.LastBlock, current.LastProposer = h.current.LastBlock, h.current.LastProposer
+	}
+
+	h.epoch, h.current = epoch, current
+
+	// the records kept are written again, as their indexes may have moved
+	for idx, c := range current.changes {
+		if err := h.writeRecord(h.store.WriteValidatorSetChange, uint64(idx), c); err != nil {
+			return err
+		}
+	}
+
+	for idx, v := range current.votes {
+		if err := h.writeRecord(h.store.WriteValidatorVote, uint64(idx), v); err != nil {
+			return err
+		}
+	}
+
+	return h.write()
+}
+
+// forEpochs calls the handler with the history of the epochs in the range,
+// a zero toEpoch is the latest epoch
+func (h *validatorHistory) forEpochs(fromEpoch, toEpoch uint64, handler func(*epochHistory)) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if toEpoch == 0 || toEpoch > h.epoch {
+		toEpoch = h.epoch
+	}
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		history, err := h.get(epoch)
+		if err != nil {
+			return err
+		}
+
+		handler(history)
+	}
+
+	return nil
+}
+
+// getHistory returns the validator set changes and the votes in the epochs.
+// If a validator is specified, only the changes and votes it's involved in are returned
+func (h *validatorHistory) getHistory(
+	fromEpoch, toEpoch uint64,
+	validator *types.Address,
+) ([]*ValidatorSetChange, []*VoteRecord, error) {
+	changes := []*ValidatorSetChange{}
+	votes := []*VoteRecord{}
+
+	err := h.forEpochs(fromEpoch, toEpoch, func(history *epochHistory) {
+		for _, c := range history.changes {
+			if validator != nil && !c.includes(*validator) {
+				continue
+			}
+
+			changes = append(changes, c)
+		}
+
+		for _, v := range history.votes {
+			if validator != nil && v.Validator != *validator && v.Candidate != *validator {
+				continue
+			}
+
+			votes = append(votes, v)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return changes, votes, nil
+}
+
+// getStats returns the statistics of the validators added up over the epochs
+func (h *validatorHistory) getStats(fromEpoch, toEpoch uint64) (map[types.Address]*consensus.ValidatorStats, error) {
+	res := map[types.Address]*consensus.ValidatorStats{}
+
+	err := h.forEpochs(fromEpoch, toEpoch, func(history *epochHistory) {
+		for addr, stats := range history.Stats {
+			total, ok := res[addr]
+			if !ok {
+				total = &consensus.ValidatorStats{}
+				res[addr] = total
+			}
+
+			addStats(total, stats)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// historyBackfillRetryDelay is the delay before the history backfill is resumed after a failure
+var historyBackfillRetryDelay = 5 * time.Second
+
+// setupHistory sets up the validator history for the IBFT object, kept in the blockchain storage.
+// The history is backfilled from the headers in the background if it was never written
+func (i *Ibft) setupHistory() error {
+	i.history = newValidatorHistory(i.blockchain)
+
+	ok, err := i.history.load()
+	if err != nil || ok {
+		return err
+	}
+
+	if i.blockchain.Header().Number == 0 {
+		return nil
+	}
+
+	return i.history.startBackfill()
+}
+
+// historyBackfill processes the headers recorded by the backfill of the validator history.
+// The headers are processed again by an IBFT object with its own snapshot store and consensus
+// mechanisms, so the consensus isn't held up by the backfill
+type historyBackfill struct {
+	// ibft is the IBFT object processing the headers
+	ibft *Ibft
+
+	// parent is the latest header processed, nil if the snapshots have to be rebuilt
+	parent *types.Header
+}
+
+// newHistoryBackfill returns the backfill of the validator history
+func (i *Ibft) newHistoryBackfill() (*historyBackfill, error) {
+	ibft := &Ibft{
+		logger:     i.logger,
+		config:     i.config,
+		blockchain: i.blockchain,
+		history:    i.history,
+		epochSize:  i.epochSize,
+		backfill:   true,
+	}
+
+	if err := ibft.setupMechanism(); err != nil {
+		return nil, err
+	}
+
+	return &historyBackfill{ibft: ibft}, nil
+}
+
+// rebuild rebuilds the snapshot of the block from the beginning of its epoch,
+// the headers already recorded in the history aren't recorded again
+func (b *historyBackfill) rebuild(number uint64) error {
+	ibft := b.ibft
+
+	ibft.store = newSnapshotStore()
+	ibft.rewindMechanismTimestamps(number)
+
+	beginHeight := number / ibft.epochSize * ibft.epochSize
+
+	parent, ok := ibft.blockchain.GetHeaderByNumber(beginHeight)
+	if !ok {
+		return fmt.Errorf("header %d not found", beginHeight)
+	}
+
+	if err := ibft.addHeaderSnap(parent); err != nil {
+		return err
+	}
+
+	for num := beginHeight + 1; num <= number; num++ {
+		header, ok := ibft.blockchain.GetHeaderByNumber(num)
+		if !ok {
+			return fmt.Errorf("header %d not found", num)
+		}
+
+		if err := b.process(parent, header); err != nil {
+			return err
+		}
+
+		parent = header
+	}
+
+	b.parent = parent
+
+	return nil
+}
+
+// process processes the header following the parent. The validator sets updated by
+// the Staking SC are taken from the headers sealed by them
+func (b *historyBackfill) process(parent, header *types.Header) error {
+	if b.ibft.updatesValidators(parent.Number) {
+		if err := b.ibft.backfillValidators(parent, header); err != nil {
+			return err
+		}
+	}
+
+	return b.ibft.processHeaders([]*types.Header{header})
+}
+
+// runHistoryBackfill records the blocks written before the validator history, from the genesis
+// up to the head. The blocks written meanwhile are left to the backfill, until it reaches the head.
+// The backfill is resumed from the latest block recorded after a restart
+func (i *Ibft) runHistoryBackfill() {
+	backfill, err := i.newHistoryBackfill()
+	if err != nil {
+		i.logger.Error("failed to set up the validator history backfill", "err", err)
+
+		return
+	}
+
+	i.logger.Info("backfilling the validator history", "from", i.history.lastBlock())
+
+	for {
+		select {
+		case <-i.closeCh:
+			return
+		default:
+		}
+
+		done, err := i.backfillHistory(backfill)
+		if err != nil {
+			i.logger.Error("failed to backfill the validator history", "err", err)
+
+			// the snapshots are rebuilt from the latest block recorded
+			backfill.parent = nil
+
+			select {
+			case <-i.closeCh:
+				return
+			case <-time.After(historyBackfillRetryDelay):
+			}
+
+			continue
+		}
+
+		if done {
+			i.logger.Info("validator history backfilled", "to", i.history.lastBlock())
+
+			return
+		}
+	}
+}
+
+// backfillHistory records the block following the latest block recorded in the history,
+// it returns true once the history reaches the head
+func (i *Ibft) backfillHistory(backfill *historyBackfill) (bool, error) {
+	i.historyLock.Lock()
+	defer i.historyLock.Unlock()
+
+	number := i.history.lastBlock()
+
+	// the backfill is starting or resuming, or the history was rewound
+	if backfill.parent == nil || backfill.parent.Number != number {
+		if err := backfill.rebuild(number); err != nil {
+			return false, err
+		}
+	}
+
+	done, err := i.history.finishBackfill(func() uint64 {
+		return i.blockchain.Header().Number
+	})
+	if err != nil || done {
+		return done, err
+	}
+
+	header, ok := i.blockchain.GetHeaderByNumber(number + 1)
+	if !ok {
+		return false, fmt.Errorf("header %d not found", number+1)
+	}
+
+	if err := backfill.process(backfill.parent, header); err != nil {
+		return false, err
+	}
+
+	backfill.parent = header
+
+	if i.IsLastOfEpoch(header.Number) {
+		i.logger.Info("validator history backfilled", "epoch", i.GetEpoch(header.Number))
+	}
+
+	return false, nil
+}
+
+// backfillValidators sets the validator set updated by the Staking SC at the parent
+// to the one the header was sealed by
+func (i *Ibft) backfillValidators(parent, header *types.Header) error {
+	extra, err := getIbftExtra(header)
+	if err != nil {
+		return err
+	}
+
+	snap, err := i.getSnapshot(parent.Number)
+	if err != nil {
+		return err
+	}
+
+	if snap == nil {
+		return fmt.Errorf("cannot find snapshot at %d", parent.Number)
+	}
+
+	validators := ValidatorSet(extra.Validators)
+	if snap.Set.Equal(&validators) {
+		return nil
+	}
+
+	newSnap := snap.Copy()
+	newSnap.Set = validators
+	newSnap.Number = parent.Number
+	newSnap.Hash = parent.Hash.String()
+
+	if snap.Number != parent.Number {
+		i.store.add(newSnap)
+	} else {
+		i.store.replace(newSnap)
+	}
+
+	return i.recordStakingChange(parent, snap.Set, validators)
+}
+
+// updatesValidators checks if the validator set is updated by the Staking SC at the block
+func (i *Ibft) updatesValidators(number uint64) bool {
+	for _, mechanism := range i.mechanisms {
+		if mechanism.GetType() == PoS && mechanism.IsAvailable(InsertBlockHook, number) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordHeader records the votes, the validator set change and the statistics of the header
+// in the validator history. The set is the validator set the header was sealed by
+func (i *Ibft) recordHeader(
+	header *types.Header,
+	set ValidatorSet,
+	snap *Snapshot,
+	proposer types.Address,
+) error {
+	if !i.recordsHistory() {
+		return nil
+	}
+
+	return i.addHeaderToHistory(header, set, snap, proposer)
+}
+
+// addHeaderToHistory records the header in the validator history, unless it's already recorded
+func (i *Ibft) addHeaderToHistory(
+	header *types.Header,
+	set ValidatorSet,
+	snap *Snapshot,
+	proposer types.Address,
+) error {
+	if i.history.isRecorded(header.Number) {
+		return nil
+	}
+
+	epoch := i.GetEpoch(header.Number)
+
+	if added, removed := diffValidators(set, snap.Set); len(added) > 0 || len(removed) > 0 {
+		if err := i.history.addChange(&ValidatorSetChange{
+			Number:  header.Number,
+			Hash:    header.Hash,
+			Epoch:   epoch,
+			Added:   added,
+			Removed: removed,
+			Cause:   VoteCause,
+		}); err != nil {
+			return err
+		}
+	}
+
+	sealers, err := ecrecoverCommittedSeals(header)
+	if err != nil {
+		return err
+	}
+
+	var missed []types.Address
+
+	if lastProposer, ok := i.getLastProposer(header.Number - 1); ok {
+		missed = missedProposers(set, lastProposer, proposer)
+	}
+
+	return i.history.addBlock(header.Number, epoch, proposer, sealers, missed)
+}
+
+// recordVote records a vote cast in the header, unless the header is already recorded
+func (i *Ibft) recordVote(header *types.Header, vote *Vote) error {
+	if !i.recordsHistory() || i.history.isRecorded(header.Number) {
+		return nil
+	}
+
+	return i.history.addVote(&VoteRecord{
+		Number:    header.Number,
+		Epoch:     i.GetEpoch(header.Number),
+		Validator: vote.Validator,
+		Candidate: vote.Address,
+		Authorize: vote.Authorize,
+	})
+}
+
+// recordStakingChange records the validator set change done by the Staking SC at the header,
+// unless the following header is already recorded
+func (i *Ibft) recordStakingChange(header *types.Header, prev, next ValidatorSet) error {
+	if !i.recordsHistory() || i.history.isRecorded(header.Number+1) {
+		return nil
+	}
+
+	added, removed := diffValidators(prev, next)
+
+	return i.history.addChange(&ValidatorSetChange{
+		Number:  header.Number,
+		Hash:    header.Hash,
+		Epoch:   i.GetEpoch(header.Number),
+		Added:   added,
+		Removed: removed,
+		Cause:   StakingCause,
+	})
+}
+
+// recordsHistory checks if the headers processed are recorded in the validator history.
+// The headers processed by the consensus are left to the backfill while it's running
+func (i *Ibft) recordsHistory() bool {
+	return i.backfill || !i.history.isBackfilling()
+}
+
+// rewindHistory rolls back the validator history to the block, and records the statistics
+// of the epoch of the block again. The backfill carries on from the block
+func (i *Ibft) rewindHistory(number uint64) error {
+	i.historyLock.Lock()
+	defer i.historyLock.Unlock()
+
+	epoch := i.GetEpoch(number)
+
+	var epochBegin uint64
+	if epoch > 0 {
+		epochBegin = (epoch - 1) * i.epochSize
+	}
+
+	// the backfill hasn't reached the block yet
+	if i.history.isBackfilling() && i.history.lastBlock() <= number {
+		return nil
+	}
+
+	if err := i.history.rewind(number, epoch, epochBegin); err != nil {
+		return err
+	}
+
+	for num := epochBegin + 1; num <= number; num++ {
+		header, ok := i.blockchain.GetHeaderByNumber(num)
+		if !ok {
+			return fmt.Errorf("header %d not found", num)
+		}
+
+		snap, err := i.getSnapshot(num - 1)
+		if err != nil {
+			return err
+		}
+
+		if snap == nil {
+			return fmt.Errorf("cannot find snapshot at %d", num-1)
+		}
+
+		proposer, err := ecrecoverFromHeader(header)
+		if err != nil {
+			return err
+		}
+
+		// the votes and the changes up to the block are kept, only the statistics are recorded
+		if err := i.addHeaderToHistory(header, snap.Set, snap, proposer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getLastProposer returns the proposer of the block, the proposer of the genesis is the zero address
+func (i *Ibft) getLastProposer(number uint64) (types.Address, bool) {
+	if number == 0 {
+		return types.ZeroAddress, true
+	}
+
+	if proposer, ok := i.history.lastProposer(number); ok {
+		return proposer, true
+	}
+
+	header, ok := i.blockchain.GetHeaderByNumber(number)
+	if !ok {
+		return types.ZeroAddress, false
+	}
+
+	proposer, err := ecrecoverFromHeader(header)
+	if err != nil {
+		return types.ZeroAddress, false
+	}
+
+	return proposer, true
+}
+
+// missedProposers returns the proposers of the rounds before the one the block was proposed at.
+// The round isn't part of the header, so it's taken as the first round the proposer is selected at
+func missedProposers(set ValidatorSet, lastProposer, proposer types.Address) []types.Address {
+	missed := []types.Address{}
+
+	for round := uint64(0); round < uint64(set.Len()); round++ {
+		roundProposer := set.CalcProposer(round, lastProposer)
+		if roundProposer == proposer {
+			return missed
+		}
+
+		missed = append(missed, roundProposer)
+	}
+
+	// the proposer isn't part of the set
+	return nil
+}
+
+// diffValidators returns the validators added and removed from the prev set in the next set
+func diffValidators(prev, next ValidatorSet) (added []types.Address, removed []types.Address) {
+	for _, addr := range next {
+		if !prev.Includes(addr) {
+			added = append(added, addr)
+		}
+	}
+
+	for _, addr := range prev {
+		if !next.Includes(addr) {
+			removed = append(removed, addr)
+		}
+	}
+
+	return added, removed
+}
+
+// GetValidatorStats returns the statistics of the validators added up over the epochs,
+// a zero toEpoch is the latest epoch. The statistics are partial while the history is backfilled
+func (i *Ibft) GetValidatorStats(
+	fromEpoch, toEpoch uint64,
+) (map[types.Address]*consensus.ValidatorStats, bool, error) {
+	partial := i.history.isBackfilling()
+
+	stats, err := i.history.getStats(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return stats, partial, nil
+}
@@ -0,0 +1,401 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHistoryHeader struct {
+	proposer   string
+	vote       mockVote
+	sealers    []string
+	validators []string
+}
+
+// buildSealedHeaders builds the headers proposed and sealed by the pool accounts
+func buildSealedHeaders(
+	t *testing.T,
+	pool *testerAccountPool,
+	genesis *chain.Genesis,
+	mockHeaders []mockHistoryHeader,
+) []*types.Header {
+	t.Helper()
+
+	headers := make([]*types.Header, 0, len(mockHeaders))
+	parentHash := genesis.Hash()
+
+	for num, header := range mockHeaders {
+		h := &types.Header{
+			Number:     uint64(num + 1),
+			ParentHash: parentHash,
+			Miner:      types.ZeroAddress,
+			Nonce:      nonceDropVote,
+			MixHash:    IstanbulDigest,
+			ExtraData:  genesis.ExtraData,
+		}
+
+		if header.validators != nil {
+			// the extra is shared with the genesis
+			h.ExtraData = append([]byte{}, h.ExtraData...)
+
+			set := ValidatorSet{}
+			for _, name := range header.validators {
+				pool.add(name)
+				set.Add(pool.get(name).Address())
+			}
+
+			putIbftExtraValidators(h, set)
+		}
+
+		if header.vote.candidate != "" {
+			pool.add(header.vote.candidate)
+			h.Miner = pool.get(header.vote.candidate).Address()
+
+			if header.vote.auth {
+				h.Nonce = nonceAuthVote
+			}
+		}
+
+		h = pool.get(header.proposer).sign(h)
+
+		seals := make([][]byte, len(header.sealers))
+
+		for i, sealer := range header.sealers {
+			seal, err := writeCommittedSeal(pool.get(sealer).priv, h)
+			assert.NoError(t, err)
+
+			seals[i] = seal
+		}
+
+		if len(seals) > 0 {
+			var err error
+
+			h, err = writeCommittedSeals(h, seals)
+			assert.NoError(t, err)
+		}
+
+		h.ComputeHash()
+
+		parentHash = h.Hash
+		headers = append(headers, h)
+	}
+
+	return headers
+}
+
+// newHistoryIbft returns an IBFT object with the mechanism of the type, and its validator history set up
+func newHistoryIbft(t *testing.T, bc *blockchain.Blockchain, epochSize uint64, typ MechanismType) *Ibft {
+	t.Helper()
+
+	ibft := &Ibft{
+		epochSize:  epochSize,
+		blockchain: bc,
+		config: &consensus.Config{
+			Config: map[string]interface{}{"type": string(typ)},
+		},
+		logger: hclog.NewNullLogger(),
+	}
+
+	assert.NoError(t, ibft.setupMechanism())
+
+	return ibft
+}
+
+func TestHistory_ProcessHeaders(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("a", "b", "c")
+
+	genesis := pool.genesis()
+
+	headers := buildSealedHeaders(t, pool, genesis, []mockHistoryHeader{
+		// the first proposer is selected from the genesis in round 0
+		{proposer: "a", vote: vote("a", "d", true), sealers: []string{"a", "b"}},
+		{proposer: "b", vote: vote("b", "d", true), sealers: []string{"b", "c"}},
+		// c and d are selected before a
+		{proposer: "a", sealers: []string{"a", "b", "c"}},
+	})
+
+	bc := blockchain.TestBlockchain(t, genesis)
+	assert.NoError(t, bc.WriteHeaders(headers))
+
+	ibft := newHistoryIbft(t, bc, 10, PoA)
+	assert.NoError(t, ibft.setupSnapshot())
+
+	// the blocks written before the history are recorded by the backfill
+	assert.True(t, ibft.history.isBackfilling())
+	ibft.runHistoryBackfill()
+	assert.False(t, ibft.history.isBackfilling())
+
+	addr := func(name string) types.Address {
+		return pool.get(name).Address()
+	}
+
+	getStats := func(fromEpoch, toEpoch uint64) map[types.Address]*consensus.ValidatorStats {
+		stats, partial, err := ibft.GetValidatorStats(fromEpoch, toEpoch)
+		assert.NoError(t, err)
+		assert.False(t, partial)
+
+		return stats
+	}
+
+	assertRecorded := func(t *testing.T) {
+		t.Helper()
+
+		changes, votes, err := ibft.history.getHistory(0, 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []*ValidatorSetChange{
+			{
+				Number: 2,
+				Hash:   headers[1].Hash,
+				Epoch:  1,
+				Added:  []types.Address{addr("d")},
+				Cause:  VoteCause,
+			},
+		}, changes)
+		assert.Equal(t, []*VoteRecord{
+			{Number: 1, Epoch: 1, Validator: addr("a"), Candidate: addr("d"), Authorize: true},
+			{Number: 2, Epoch: 1, Validator: addr("b"), Candidate: addr("d"), Authorize: true},
+		}, votes)
+
+		// only the votes of the validator are returned
+		c := addr("c")
+		changes, votes, err = ibft.history.getHistory(0, 0, &c)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 0)
+		assert.Len(t, votes, 0)
+	}
+
+	assertRecorded(t)
+	assert.Equal(t, map[types.Address]*consensus.ValidatorStats{
+		addr("a"): {ProposedBlocks: 2, CommittedSeals: 2},
+		addr("b"): {ProposedBlocks: 1, CommittedSeals: 3},
+		addr("c"): {CommittedSeals: 2, MissedRounds: 1},
+		addr("d"): {MissedRounds: 1},
+	}, getStats(0, 0))
+
+	// processing the headers again doesn't change the history
+	assert.NoError(t, ibft.processHeaders(headers))
+	assertRecorded(t)
+
+	// the epochs are out of the range
+	assert.Len(t, getStats(2, 0), 0)
+
+	// the statistics of the rewound blocks are removed
+	assert.NoError(t, ibft.rewindSnapshots(2))
+	assertRecorded(t)
+	assert.Equal(t, map[types.Address]*consensus.ValidatorStats{
+		addr("a"): {ProposedBlocks: 1, CommittedSeals: 1},
+		addr("b"): {ProposedBlocks: 1, CommittedSeals: 2},
+		addr("c"): {CommittedSeals: 1},
+	}, getStats(0, 0))
+
+	assert.NoError(t, ibft.rewindSnapshots(1))
+	changes, votes, err := ibft.history.getHistory(0, 0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 0)
+	assert.Len(t, votes, 1)
+}
+
+func TestHistory_Backfill(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("a", "b", "c")
+
+	genesis := pool.genesis()
+
+	headers := buildSealedHeaders(t, pool, genesis, []mockHistoryHeader{
+		{proposer: "a", vote: vote("a", "d", true), sealers: []string{"a", "b"}},
+		{proposer: "b", vote: vote("b", "d", true), sealers: []string{"b", "c"}},
+		{proposer: "a", sealers: []string{"a", "b", "c"}},
+		{proposer: "b", sealers: []string{"a", "b", "c"}},
+	})
+
+	bc := blockchain.TestBlockchain(t, genesis)
+	assert.NoError(t, bc.WriteHeaders(headers[:2]))
+
+	ibft0 := newHistoryIbft(t, bc, 10, PoA)
+	assert.NoError(t, ibft0.setupSnapshot())
+
+	backfill, err := ibft0.newHistoryBackfill()
+	assert.NoError(t, err)
+
+	done, err := ibft0.backfillHistory(backfill)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, uint64(1), ibft0.history.lastBlock())
+
+	// the statistics are partial until the backfill reaches the head
+	_, partial, err := ibft0.GetValidatorStats(0, 0)
+	assert.NoError(t, err)
+	assert.True(t, partial)
+
+	// the blocks written meanwhile are left to the backfill
+	assert.NoError(t, bc.WriteHeaders(headers[2:3]))
+	assert.NoError(t, ibft0.processHeaders(headers[2:3]))
+	assert.Equal(t, uint64(1), ibft0.history.lastBlock())
+
+	// the backfill carries on from the block the history is rewound to
+	done, err = ibft0.backfillHistory(backfill)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, uint64(2), ibft0.history.lastBlock())
+	assert.NoError(t, ibft0.rewindSnapshots(1))
+	assert.Equal(t, uint64(1), ibft0.history.lastBlock())
+
+	// the backfill is resumed after a restart
+	ibft1 := newHistoryIbft(t, bc, 10, PoA)
+	assert.NoError(t, ibft1.setupSnapshot())
+	assert.True(t, ibft1.history.isBackfilling())
+
+	ibft1.runHistoryBackfill()
+	assert.False(t, ibft1.history.isBackfilling())
+	assert.Equal(t, uint64(3), ibft1.history.lastBlock())
+
+	// the blocks written afterwards are recorded as they're processed
+	assert.NoError(t, bc.WriteHeaders(headers[3:]))
+	assert.NoError(t, ibft1.processHeaders(headers[3:]))
+	assert.Equal(t, uint64(4), ibft1.history.lastBlock())
+
+	_, votes, err := ibft1.history.getHistory(0, 0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, votes, 2)
+
+	stats, partial, err := ibft1.GetValidatorStats(0, 0)
+	assert.NoError(t, err)
+	assert.False(t, partial)
+	assert.Equal(t, uint64(2), stats[pool.get("a").Address()].ProposedBlocks)
+	assert.Equal(t, uint64(2), stats[pool.get("b").Address()].ProposedBlocks)
+
+	// the votes are appended apart from the history of the epoch
+	blob, ok := bc.ReadValidatorVote(1, 1)
+	assert.True(t, ok)
+	assert.Contains(t, string(blob), pool.get("b").Address().String())
+}
+
+func TestHistory_MissedProposers(t *testing.T) {
+	set := ValidatorSet{
+		types.StringToAddress("1"),
+		types.StringToAddress("2"),
+		types.StringToAddress("3"),
+	}
+
+	// proposed in round 0
+	assert.Equal(t, []types.Address{}, missedProposers(set, set[0], set[1]))
+
+	// proposed in round 2
+	assert.Equal(t, []types.Address{set[2], set[0]}, missedProposers(set, set[1], set[1]))
+
+	// the proposer isn't a validator
+	assert.Nil(t, missedProposers(set, set[0], types.StringToAddress("4")))
+}
+
+func TestHistory_Load(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("a", "b", "c")
+
+	genesis := pool.genesis()
+
+	headers := buildSealedHeaders(t, pool, genesis, []mockHistoryHeader{
+		{proposer: "a", vote: vote("a", "d", true), sealers: []string{"a", "b"}},
+		{proposer: "b", sealers: []string{"b", "c"}},
+		{proposer: "c", sealers: []string{"a", "c"}},
+	})
+
+	bc := blockchain.TestBlockchain(t, genesis)
+	assert.NoError(t, bc.WriteHeaders(headers))
+
+	newIbft := func() *Ibft {
+		ibft := newHistoryIbft(t, bc, 2, PoA)
+		assert.NoError(t, ibft.setupSnapshot())
+
+		return ibft
+	}
+
+	ibft0 := newIbft()
+	ibft0.runHistoryBackfill()
+
+	// the history of each epoch is written as the blocks are recorded
+	epoch, ok := bc.ReadValidatorHistoryEpoch()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), epoch)
+
+	// the history is loaded from the blockchain storage by the next instance
+	ibft1 := newIbft()
+
+	for _, epochs := range [][2]uint64{{0, 0}, {1, 1}, {2, 2}} {
+		stats0, _, err := ibft0.GetValidatorStats(epochs[0], epochs[1])
+		assert.NoError(t, err)
+
+		stats1, _, err := ibft1.GetValidatorStats(epochs[0], epochs[1])
+		assert.NoError(t, err)
+
+		assert.Equal(t, stats0, stats1)
+	}
+
+	stats, partial, err := ibft1.GetValidatorStats(2, 2)
+	assert.NoError(t, err)
+	assert.False(t, partial)
+	assert.Equal(t, uint64(1), stats[pool.get("c").Address()].ProposedBlocks)
+
+	_, votes, err := ibft1.history.getHistory(1, 1, nil)
+	assert.NoError(t, err)
+	assert.Len(t, votes, 1)
+
+	// the previous epochs are dropped by a rewind
+	assert.NoError(t, ibft1.rewindSnapshots(1))
+
+	stats, _, err = ibft1.GetValidatorStats(2, 0)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 0)
+
+	epoch, _ = bc.ReadValidatorHistoryEpoch()
+	assert.Equal(t, uint64(1), epoch)
+}
+
+func TestHistory_BackfillStakingChanges(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("a", "b", "c")
+
+	genesis := pool.genesis()
+
+	// the Staking SC adds d at the end of the first epoch
+	headers := buildSealedHeaders(t, pool, genesis, []mockHistoryHeader{
+		{proposer: "a", sealers: []string{"a", "b"}},
+		{proposer: "b", sealers: []string{"b", "c"}},
+		{proposer: "d", sealers: []string{"c", "d"}, validators: []string{"a", "b", "c", "d"}},
+	})
+
+	bc := blockchain.TestBlockchain(t, genesis)
+	assert.NoError(t, bc.WriteHeaders(headers))
+
+	ibft := newHistoryIbft(t, bc, 2, PoS)
+
+	// the snapshots can't be set up without the Staking SC, the history is backfilled alone
+	ibft.store = newSnapshotStore()
+	assert.NoError(t, ibft.setupHistory())
+	ibft.runHistoryBackfill()
+
+	changes, _, err := ibft.history.getHistory(0, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*ValidatorSetChange{
+		{
+			Number: 2,
+			Hash:   headers[1].Hash,
+			Epoch:  1,
+			Added:  []types.Address{pool.get("d").Address()},
+			Cause:  StakingCause,
+		},
+	}, changes)
+
+	stats, _, err := ibft.GetValidatorStats(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), stats[pool.get("d").Address()].ProposedBlocks)
+
+	// the snapshot store isn't changed by the backfill
+	assert.Len(t, ibft.store.list, 0)
+}
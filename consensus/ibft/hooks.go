@@ -1,7 +1,9 @@
 package ibft
 
 import (
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 )
@@ -100,6 +102,14 @@ type ConsensusMechanism interface {
 
 	// initializeHookMap initializes the hook map
 	initializeHookMap()
+
+	// resolveTimestamps sets the block numbers of the bounds activated by timestamp,
+	// once the chain reaches them
+	resolveTimestamps()
+
+	// rewindTimestamps clears the bounds activated by timestamp resolved above the block,
+	// after the chain head was rewound to it
+	rewindTimestamps(number uint64)
 }
 
 type BaseConsensusMechanism struct {
@@ -115,6 +125,59 @@ type BaseConsensusMechanism struct {
 	// Available periods
 	From uint64
 	To   *uint64
+
+	// Available periods set by timestamp, From and To are set once they're resolved
+	fromTimestamp *timestampBound
+	toTimestamp   *timestampBound
+}
+
+// notStarted is the beginning of a period set by timestamp that isn't resolved yet
+const notStarted = math.MaxUint64
+
+// timestampBound is a mechanism bound activated by timestamp. The mechanism switches
+// with the block following the first block with a timestamp equal or after the bound,
+// as the timestamp of a block isn't known before the block is built
+type timestampBound struct {
+	timestamp uint64
+	resolved  bool
+	// block is the first block with a timestamp equal or after the bound
+	block uint64
+}
+
+func newTimestampBound(timestamp *common.JSONNumber) *timestampBound {
+	if timestamp == nil {
+		return nil
+	}
+
+	return &timestampBound{timestamp: timestamp.Value}
+}
+
+// resolve looks up the block of the bound, it returns false if the chain hasn't reached it yet
+func (b *timestampBound) resolve(ibft *Ibft) bool {
+	if b.resolved {
+		return true
+	}
+
+	block, ok := ibft.firstBlockAtTimestamp(b.timestamp)
+	if !ok {
+		return false
+	}
+
+	b.resolved, b.block = true, block
+
+	return true
+}
+
+// rewind clears the resolution if the block of the bound is above the new head,
+// it returns true if the bound has to be resolved again
+func (b *timestampBound) rewind(number uint64) bool {
+	if !b.resolved || b.block <= number {
+		return false
+	}
+
+	b.resolved, b.block = false, 0
+
+	return true
 }
 
 // initializeParams initializes mechanism parameters from chain config
@@ -125,7 +188,20 @@ func (base *BaseConsensusMechanism) initializeParams(params *IBFTFork) error {
 
 	base.From = params.From.Value
 
+	if params.FromTimestamp != nil {
+		base.From = notStarted
+		base.fromTimestamp = newTimestampBound(params.FromTimestamp)
+	}
+
+	if params.To != nil && params.ToTimestamp != nil {
+		return errors.New(`only one of "to" and "toTimestamp" can be specified`)
+	}
+
 	if params.To != nil {
+		if params.FromTimestamp != nil {
+			return errors.New(`"to" can't be specified with "fromTimestamp", use "toTimestamp"`)
+		}
+
 		if params.To.Value < base.From {
 			return fmt.Errorf(
 				`"to" must be grater than or equal to from: from=%d, to=%d`,
@@ -137,9 +213,45 @@ func (base *BaseConsensusMechanism) initializeParams(params *IBFTFork) error {
 		base.To = &params.To.Value
 	}
 
+	if params.ToTimestamp != nil {
+		if params.FromTimestamp != nil && params.ToTimestamp.Value < params.FromTimestamp.Value {
+			return fmt.Errorf(
+				`"toTimestamp" must be grater than or equal to "fromTimestamp": fromTimestamp=%d, toTimestamp=%d`,
+				params.FromTimestamp.Value,
+				params.ToTimestamp.Value,
+			)
+		}
+
+		base.toTimestamp = newTimestampBound(params.ToTimestamp)
+	}
+
 	return nil
 }
 
+// resolveTimestamps implements the ConsensusMechanism interface method
+func (base *BaseConsensusMechanism) resolveTimestamps() {
+	// the bounds are resolved once, the blocks of an IBFT chain are final unless rewound
+	if base.fromTimestamp != nil && !base.fromTimestamp.resolved && base.fromTimestamp.resolve(base.ibft) {
+		base.From = base.fromTimestamp.block + 1
+	}
+
+	if base.toTimestamp != nil && !base.toTimestamp.resolved && base.toTimestamp.resolve(base.ibft) {
+		to := base.toTimestamp.block
+		base.To = &to
+	}
+}
+
+// rewindTimestamps implements the ConsensusMechanism interface method
+func (base *BaseConsensusMechanism) rewindTimestamps(number uint64) {
+	if base.fromTimestamp != nil && base.fromTimestamp.rewind(number) {
+		base.From = notStarted
+	}
+
+	if base.toTimestamp != nil && base.toTimestamp.rewind(number) {
+		base.To = nil
+	}
+}
+
 // GetType implements the ConsensusMechanism interface method
 func (base *BaseConsensusMechanism) GetType() MechanismType {
 	return base.mechanismType
@@ -165,7 +277,8 @@ func (base *BaseConsensusMechanism) IsInRange(blockNumber uint64) bool {
 	return true
 }
 
-// IBFT Fork represents setting in params.engine.ibft of genesis.json
+// IBFT Fork represents setting in params.engine.ibft of genesis.json.
+// The bounds set by timestamp take the place of the ones set by block number
 type IBFTFork struct {
 	Type              MechanismType      `json:"type"`
 	Deployment        *common.JSONNumber `json:"deployment,omitempty"`
@@ -173,6 +286,33 @@ type IBFTFork struct {
 	To                *common.JSONNumber `json:"to,omitempty"`
 	MaxValidatorCount *common.JSONNumber `json:"maxValidatorCount,omitempty"`
 	MinValidatorCount *common.JSONNumber `json:"minValidatorCount,omitempty"`
+
+	DeploymentTimestamp *common.JSONNumber `json:"deploymentTimestamp,omitempty"`
+	FromTimestamp       *common.JSONNumber `json:"fromTimestamp,omitempty"`
+	ToTimestamp         *common.JSONNumber `json:"toTimestamp,omitempty"`
+}
+
+// IsTimestampBased returns true if the fork begins at a timestamp
+func (f *IBFTFork) IsTimestampBased() bool {
+	return f.FromTimestamp != nil
+}
+
+// validateIBFTForks checks the forks begin in order. The forks beginning
+// by block number have to come before the ones beginning by timestamp
+func validateIBFTForks(forks []IBFTFork) error {
+	for idx := 1; idx < len(forks); idx++ {
+		prev, next := &forks[idx-1], &forks[idx]
+
+		switch {
+		case prev.IsTimestampBased() && !next.IsTimestampBased():
+			return fmt.Errorf("IBFT fork %d begins by block after fork %d begins by timestamp", idx, idx-1)
+		case prev.IsTimestampBased() && next.FromTimestamp.Value < prev.FromTimestamp.Value,
+			!prev.IsTimestampBased() && !next.IsTimestampBased() && next.From.Value < prev.From.Value:
+			return fmt.Errorf("IBFT fork %d begins before fork %d", idx, idx-1)
+		}
+	}
+
+	return nil
 }
 
 // ConsensusMechanismFactory is the factory function to create a consensus mechanism
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus"
@@ -20,6 +21,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"google.golang.org/grpc"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 )
@@ -40,6 +42,13 @@ type blockchainInterface interface {
 	WriteBlock(block *types.Block) error
 	VerifyPotentialBlock(block *types.Block) error
 	CalculateGasLimit(number uint64) (uint64, error)
+	WriteValidatorHistory(epoch uint64, blob []byte) error
+	ReadValidatorHistory(epoch uint64) ([]byte, bool)
+	ReadValidatorHistoryEpoch() (uint64, bool)
+	WriteValidatorVote(epoch, index uint64, blob []byte) error
+	ReadValidatorVote(epoch, index uint64) ([]byte, bool)
+	WriteValidatorSetChange(epoch, index uint64, blob []byte) error
+	ReadValidatorSetChange(epoch, index uint64) ([]byte, bool)
 }
 
 type txPoolInterface interface {
@@ -79,7 +88,10 @@ type Ibft struct {
 
 	txpool txPoolInterface // Reference to the transaction pool
 
-	store              *snapshotStore // Snapshot store that keeps track of all snapshots
+	store              *snapshotStore    // Snapshot store that keeps track of all snapshots
+	history            *validatorHistory // History of the validator set changes, votes and statistics
+	historyLock        sync.Mutex        // Lock serializing the backfill of the history with its rewinds
+	backfill           bool              // Flag indicating if the IBFT object backfills the history
 	epochSize          uint64
 	quorumSizeBlockNum uint64
 
@@ -107,6 +119,8 @@ type Ibft struct {
 
 // runHook runs a specified hook if it is present in the hook map
 func (i *Ibft) runHook(hookName HookType, height uint64, hookParam interface{}) error {
+	i.resolveMechanismTimestamps()
+
 	for _, mechanism := range i.mechanisms {
 		if !mechanism.IsAvailable(hookName, height) {
 			continue
@@ -222,6 +236,11 @@ func (i *Ibft) Start() error {
 		return err
 	}
 
+	// Record the blocks written before the validator history
+	if i.history.isBackfilling() {
+		go i.runHistoryBackfill()
+	}
+
 	// Start the syncer
 	i.syncer.Start()
 
@@ -296,6 +315,10 @@ func (i *Ibft) setupMechanism() error {
 		return err
 	}
 
+	if err := validateIBFTForks(ibftForks); err != nil {
+		return err
+	}
+
 	i.mechanisms = make([]ConsensusMechanism, len(ibftForks))
 
 	for idx, fork := range ibftForks {
@@ -313,16 +336,60 @@ func (i *Ibft) setupMechanism() error {
 	return nil
 }
 
+// resolveMechanismTimestamps resolves the mechanism bounds set by timestamp the chain has reached
+func (i *Ibft) resolveMechanismTimestamps() {
+	for _, mechanism := range i.mechanisms {
+		mechanism.resolveTimestamps()
+	}
+}
+
+// rewindMechanismTimestamps clears the mechanism bounds set by timestamp resolved above the block
+func (i *Ibft) rewindMechanismTimestamps(number uint64) {
+	for _, mechanism := range i.mechanisms {
+		mechanism.rewindTimestamps(number)
+	}
+}
+
+// firstBlockAtTimestamp returns the first block with a timestamp equal or after the given one,
+// it returns false if the chain hasn't reached the timestamp yet
+func (i *Ibft) firstBlockAtTimestamp(timestamp uint64) (uint64, bool) {
+	head := i.blockchain.Header()
+	if head == nil || head.Timestamp < timestamp {
+		return 0, false
+	}
+
+	// the timestamps of the blocks are increasing, the first block at the timestamp
+	// is found by binary search
+	low, high := uint64(0), head.Number
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		header, ok := i.blockchain.GetHeaderByNumber(mid)
+		if !ok {
+			return 0, false
+		}
+
+		if header.Timestamp >= timestamp {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	return low, true
+}
+
 // setupTransport sets up the gossip transport protocol
 func (i *Ibft) setupTransport() error {
 	// Define a new topic
-	topic, err := i.network.NewTopic(ibftProto, &proto.MessageReq{})
+	topic, err := i.network.NewTopic(ibftProto, &proto.MessageReq{}, i.validateGossipMsg)
 	if err != nil {
 		return err
 	}
 
 	// Subscribe to the newly created topic
-	err = topic.Subscribe(func(obj interface{}) {
+	err = topic.Subscribe(func(obj interface{}, from peer.ID) {
 		msg, ok := obj.(*proto.MessageReq)
 		if !ok {
 			i.logger.Error("invalid type assertion for message request")
@@ -336,12 +403,8 @@ func (i *Ibft) setupTransport() error {
 			return
 		}
 
-		// decode sender
-		if err := validateMsg(msg); err != nil {
-			i.logger.Error("failed to validate msg", "err", err)
-
-			return
-		}
+		// the sender is already recovered by the topic validator
+		i.network.ReportPeer(from, network.ValidConsensusMessage)
 
 		if msg.From == i.validatorKeyAddr.String() {
 			// we are the sender, skip this message since we already
@@ -361,6 +424,37 @@ func (i *Ibft) setupTransport() error {
 	return nil
 }
 
+// validateGossipMsg verifies the signature of the gossiped consensus message
+// before it is relayed to other peers. Only the messages signed by the
+// current validators are relayed
+func (i *Ibft) validateGossipMsg(obj interface{}, from peer.ID) network.ValidationResult {
+	msg, ok := obj.(*proto.MessageReq)
+	if !ok {
+		return network.ValidationReject
+	}
+
+	// decode sender
+	if err := validateMsg(msg); err != nil {
+		i.logger.Debug("failed to validate msg", "err", err)
+		i.network.ReportPeer(from, network.InvalidConsensusMessage)
+
+		return network.ValidationReject
+	}
+
+	snap, err := i.getSnapshot(i.blockchain.Header().Number)
+	if err != nil || snap == nil {
+		return network.ValidationIgnore
+	}
+
+	// The validator set might have just changed,
+	// so the peer which relayed the message is not penalized
+	if !snap.Set.Includes(types.StringToAddress(msg.From)) {
+		return network.ValidationIgnore
+	}
+
+	return network.ValidationAccept
+}
+
 // createKey sets the validator's private key from the secrets manager
 func (i *Ibft) createKey() error {
 	i.msgQueue = newMsgQueue()
@@ -568,6 +662,8 @@ func (i *Ibft) runSyncState() {
 // returns true if all mechanisms accept
 // otherwise return false
 func (i *Ibft) shouldWriteTransactions(height uint64) bool {
+	i.resolveMechanismTimestamps()
+
 	for _, m := range i.mechanisms {
 		if m.ShouldWriteTransactions(height) {
 			return true
@@ -620,7 +716,7 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	// we need to include in the extra field the current set of validators
 	putIbftExtraValidators(header, snap.Set)
 
-	transition, err := i.executor.BeginTxn(parent.StateRoot, header, i.validatorKeyAddr)
+	transition, err := i.executor.BeginBlock(parent.StateRoot, header, i.validatorKeyAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -1337,6 +1433,19 @@ func (i *Ibft) ProcessHeaders(headers []*types.Header) error {
 	return i.processHeaders(headers)
 }
 
+// RewindHeaders rolls back the snapshot store to the block, after the chain head was rewound
+func (i *Ibft) RewindHeaders(number uint64) error {
+	// the blocks the mechanisms switched with might be rebuilt with other timestamps
+	i.rewindMechanismTimestamps(number)
+
+	return i.rewindSnapshots(number)
+}
+
+// GetFinalizedNumber returns the head, the IBFT blocks are final once they're committed
+func (i *Ibft) GetFinalizedNumber() uint64 {
+	return i.blockchain.Header().Number
+}
+
 // GetBlockCreator retrieves the block signer from the extra data field
 func (i *Ibft) GetBlockCreator(header *types.Header) (types.Address, error) {
 	return ecrecoverFromHeader(header)
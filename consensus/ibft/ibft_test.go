@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/protocol"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -31,6 +32,9 @@ type MockBlockchain struct {
 	latestBlockNumber *uint64
 	headers           map[uint64]*types.Header
 	blocks            map[uint64]*types.Block
+	history           map[uint64][]byte
+	historyEpoch      *uint64
+	historyRecords    map[string][]byte
 
 	// Handlers to change mock's behavior
 	HeaderHandler               func() *types.Header
@@ -90,6 +94,51 @@ func (m *MockBlockchain) CalculateGasLimit(number uint64) (uint64, error) {
 	return m.CalculateGasLimitHandler(number)
 }
 
+func (m *MockBlockchain) WriteValidatorHistory(epoch uint64, blob []byte) error {
+	m.history[epoch] = blob
+	m.historyEpoch = &epoch
+
+	return nil
+}
+
+func (m *MockBlockchain) ReadValidatorHistory(epoch uint64) ([]byte, bool) {
+	blob, ok := m.history[epoch]
+
+	return blob, ok
+}
+
+func (m *MockBlockchain) ReadValidatorHistoryEpoch() (uint64, bool) {
+	if m.historyEpoch == nil {
+		return 0, false
+	}
+
+	return *m.historyEpoch, true
+}
+
+func (m *MockBlockchain) WriteValidatorVote(epoch, index uint64, blob []byte) error {
+	m.historyRecords[fmt.Sprintf("vote/%d/%d", epoch, index)] = blob
+
+	return nil
+}
+
+func (m *MockBlockchain) ReadValidatorVote(epoch, index uint64) ([]byte, bool) {
+	blob, ok := m.historyRecords[fmt.Sprintf("vote/%d/%d", epoch, index)]
+
+	return blob, ok
+}
+
+func (m *MockBlockchain) WriteValidatorSetChange(epoch, index uint64, blob []byte) error {
+	m.historyRecords[fmt.Sprintf("change/%d/%d", epoch, index)] = blob
+
+	return nil
+}
+
+func (m *MockBlockchain) ReadValidatorSetChange(epoch, index uint64) ([]byte, bool) {
+	blob, ok := m.historyRecords[fmt.Sprintf("change/%d/%d", epoch, index)]
+
+	return blob, ok
+}
+
 // helper method
 func (m *MockBlockchain) SetGenesis(validators []types.Address) *types.Block {
 	m.t.Helper()
@@ -207,6 +256,8 @@ func NewMockBlockchain(t *testing.T) *MockBlockchain {
 		latestBlockNumber: nil,
 		headers:           make(map[uint64]*types.Header),
 		blocks:            make(map[uint64]*types.Block),
+		history:           make(map[uint64][]byte),
+		historyRecords:    make(map[string][]byte),
 	}
 
 	m.HeaderHandler = m.header
@@ -1179,6 +1230,34 @@ func (m *mockIbft) CalculateGasLimit(number uint64) (uint64, error) {
 	return m.blockchain.CalculateGasLimit(number)
 }
 
+func (m *mockIbft) WriteValidatorHistory(epoch uint64, blob []byte) error {
+	return m.blockchain.WriteValidatorHistory(epoch, blob)
+}
+
+func (m *mockIbft) ReadValidatorHistory(epoch uint64) ([]byte, bool) {
+	return m.blockchain.ReadValidatorHistory(epoch)
+}
+
+func (m *mockIbft) ReadValidatorHistoryEpoch() (uint64, bool) {
+	return m.blockchain.ReadValidatorHistoryEpoch()
+}
+
+func (m *mockIbft) WriteValidatorVote(epoch, index uint64, blob []byte) error {
+	return m.blockchain.WriteValidatorVote(epoch, index, blob)
+}
+
+func (m *mockIbft) ReadValidatorVote(epoch, index uint64) ([]byte, bool) {
+	return m.blockchain.ReadValidatorVote(epoch, index)
+}
+
+func (m *mockIbft) WriteValidatorSetChange(epoch, index uint64, blob []byte) error {
+	return m.blockchain.WriteValidatorSetChange(epoch, index, blob)
+}
+
+func (m *mockIbft) ReadValidatorSetChange(epoch, index uint64) ([]byte, bool) {
+	return m.blockchain.ReadValidatorSetChange(epoch, index)
+}
+
 func newMockIbft(t *testing.T, accounts []string, account string) *mockIbft {
 	t.Helper()
 
@@ -1674,6 +1753,129 @@ func TestGetIBFTForks(t *testing.T) {
 	}
 }
 
+func TestMechanismTimestampBounds(t *testing.T) {
+	// the blocks 0 to 9 are 10 seconds apart, from the timestamp 100
+	var head uint64 = 3
+
+	mockBlockchain := &MockBlockchain{
+		t: t,
+		HeaderHandler: func() *types.Header {
+			return &types.Header{Number: head, Timestamp: 100 + head*10}
+		},
+		GetHeaderByNumberHandler: func(number uint64) (*types.Header, bool) {
+			if number > head {
+				return nil, false
+			}
+
+			return &types.Header{Number: number, Timestamp: 100 + number*10}, true
+		},
+	}
+
+	i := &Ibft{blockchain: mockBlockchain}
+	mechanism := newMockMechanism(t, i, &IBFTFork{
+		Type:          PoA,
+		FromTimestamp: &common.JSONNumber{Value: 135},
+		ToTimestamp:   &common.JSONNumber{Value: 165},
+	})
+	i.mechanisms = []ConsensusMechanism{mechanism}
+
+	// the chain hasn't reached the timestamps yet
+	i.resolveMechanismTimestamps()
+	assert.False(t, mechanism.IsInRange(4))
+	assert.False(t, mechanism.IsInRange(100))
+
+	// the block 4 is the first one at 135, the mechanism begins with the next block
+	head = 5
+	i.resolveMechanismTimestamps()
+	assert.Equal(t, uint64(5), mechanism.From)
+	assert.Nil(t, mechanism.To)
+
+	// the block 7 is the first one at 165, the mechanism ends with it
+	head = 9
+	i.resolveMechanismTimestamps()
+	assert.Equal(t, uint64(5), mechanism.From)
+	assert.Equal(t, uint64(7), *mechanism.To)
+	assert.False(t, mechanism.IsInRange(4))
+	assert.True(t, mechanism.IsInRange(5))
+	assert.True(t, mechanism.IsInRange(7))
+	assert.False(t, mechanism.IsInRange(8))
+
+	// the bound below the new head stays resolved after a rewind, the other one is cleared
+	head = 6
+	i.rewindMechanismTimestamps(head)
+	assert.Equal(t, uint64(5), mechanism.From)
+	assert.Nil(t, mechanism.To)
+
+	// and it's resolved again once the chain reaches it
+	head = 9
+	i.resolveMechanismTimestamps()
+	assert.Equal(t, uint64(7), *mechanism.To)
+
+	// the mechanism doesn't begin anymore if the chain is rewound before its beginning
+	head = 3
+	i.rewindMechanismTimestamps(head)
+	i.resolveMechanismTimestamps()
+	assert.False(t, mechanism.IsInRange(5))
+	assert.Nil(t, mechanism.To)
+}
+
+func TestValidateIBFTForks(t *testing.T) {
+	tests := []struct {
+		name  string
+		forks []IBFTFork
+		valid bool
+	}{
+		{
+			name: "should accept forks beginning by block in order",
+			forks: []IBFTFork{
+				{Type: PoA, From: common.JSONNumber{Value: 0}},
+				{Type: PoS, From: common.JSONNumber{Value: 10}},
+			},
+			valid: true,
+		},
+		{
+			name: "should accept a fork beginning by timestamp after a fork beginning by block",
+			forks: []IBFTFork{
+				{Type: PoA, From: common.JSONNumber{Value: 0}},
+				{Type: PoS, FromTimestamp: &common.JSONNumber{Value: 1000}},
+				{Type: PoA, FromTimestamp: &common.JSONNumber{Value: 2000}},
+			},
+			valid: true,
+		},
+		{
+			name: "should reject forks beginning by block out of order",
+			forks: []IBFTFork{
+				{Type: PoA, From: common.JSONNumber{Value: 10}},
+				{Type: PoS, From: common.JSONNumber{Value: 5}},
+			},
+			valid: false,
+		},
+		{
+			name: "should reject forks beginning by timestamp out of order",
+			forks: []IBFTFork{
+				{Type: PoA, FromTimestamp: &common.JSONNumber{Value: 2000}},
+				{Type: PoS, FromTimestamp: &common.JSONNumber{Value: 1000}},
+			},
+			valid: false,
+		},
+		{
+			name: "should reject a fork beginning by block after a fork beginning by timestamp",
+			forks: []IBFTFork{
+				{Type: PoA, FromTimestamp: &common.JSONNumber{Value: 1000}},
+				{Type: PoS, From: common.JSONNumber{Value: 5}},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := validateIBFTForks(testcase.forks)
+			assert.Equal(t, testcase.valid, err == nil)
+		})
+	}
+}
+
 func TestQuorumSizeSwitch(t *testing.T) {
 	t.Parallel()
 
@@ -1730,3 +1932,27 @@ func TestQuorumSizeSwitch(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGossipMsg(t *testing.T) {
+	m := newMockIbft(t, []string{"A", "B", "C"}, "A")
+	m.pool.add("X")
+
+	newSignedMsg := func(account string) *proto.MessageReq {
+		msg := &proto.MessageReq{
+			Type: proto.MessageReq_Prepare,
+			View: proto.ViewMsg(1, 0),
+		}
+
+		assert.NoError(t, signMsg(m.pool.get(account).priv, msg))
+
+		return msg
+	}
+
+	// messages of the current validators are relayed
+	validMsg := newSignedMsg("B")
+	assert.Equal(t, network.ValidationAccept, m.validateGossipMsg(validMsg, "peer"))
+	assert.Equal(t, m.pool.get("B").Address().String(), validMsg.From)
+
+	// messages of other nodes are dropped
+	assert.Equal(t, network.ValidationIgnore, m.validateGossipMsg(newSignedMsg("X"), "peer"))
+}
@@ -3,6 +3,7 @@ package ibft
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
@@ -159,3 +160,98 @@ func (o *operator) Candidates(ctx context.Context, req *empty.Empty) (*proto.Can
 
 	return resp, nil
 }
+
+// GetValidatorHistory returns the validator set changes and the votes in the requested epochs
+func (o *operator) GetValidatorHistory(
+	ctx context.Context,
+	req *proto.ValidatorHistoryReq,
+) (*proto.ValidatorHistoryResp, error) {
+	var validator *types.Address
+
+	if req.Validator != "" {
+		var addr types.Address
+		if err := addr.UnmarshalText([]byte(req.Validator)); err != nil {
+			return nil, err
+		}
+
+		validator = &addr
+	}
+
+	partial := o.ibft.history.isBackfilling()
+
+	changes, votes, err := o.ibft.history.getHistory(req.FromEpoch, req.ToEpoch, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ValidatorHistoryResp{
+		Changes: make([]*proto.ValidatorHistoryResp_Change, len(changes)),
+		Votes:   make([]*proto.ValidatorHistoryResp_Vote, len(votes)),
+		Partial: partial,
+	}
+
+	for idx, change := range changes {
+		resp.Changes[idx] = &proto.ValidatorHistoryResp_Change{
+			Number:  change.Number,
+			Hash:    change.Hash.String(),
+			Epoch:   change.Epoch,
+			Added:   addressesToStrings(change.Added),
+			Removed: addressesToStrings(change.Removed),
+			Cause:   string(change.Cause),
+		}
+	}
+
+	for idx, vote := range votes {
+		resp.Votes[idx] = &proto.ValidatorHistoryResp_Vote{
+			Number:    vote.Number,
+			Epoch:     vote.Epoch,
+			Validator: vote.Validator.String(),
+			Proposed:  vote.Candidate.String(),
+			Auth:      vote.Authorize,
+		}
+	}
+
+	return resp, nil
+}
+
+// GetValidatorStats returns the statistics of the validators in the requested epochs
+func (o *operator) GetValidatorStats(
+	ctx context.Context,
+	req *proto.ValidatorStatsReq,
+) (*proto.ValidatorStatsResp, error) {
+	stats, partial, err := o.ibft.GetValidatorStats(req.FromEpoch, req.ToEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ValidatorStatsResp{
+		Stats:   make([]*proto.ValidatorStatsResp_Stats, 0, len(stats)),
+		Partial: partial,
+	}
+
+	for addr, s := range stats {
+		resp.Stats = append(resp.Stats, &proto.ValidatorStatsResp_Stats{
+			Address:        addr.String(),
+			ProposedBlocks: s.ProposedBlocks,
+			CommittedSeals: s.CommittedSeals,
+			MissedRounds:   s.MissedRounds,
+		})
+	}
+
+	// the map iteration order is random
+	sort.Slice(resp.Stats, func(i, j int) bool {
+		return resp.Stats[i].Address < resp.Stats[j].Address
+	})
+
+	return resp, nil
+}
+
+func addressesToStrings(addrs []types.Address) []string {
+	res := make([]string, len(addrs))
+
+	for idx, addr := range addrs {
+		res[idx] = addr.String()
+	}
+
+	return res
+}
@@ -167,11 +167,17 @@ func (poa *PoAMechanism) processHeadersHook(hookParam interface{}) error {
 
 	if voteCount == 0 {
 		// cast the new vote since there is no one yet
-		params.snap.Votes = append(params.snap.Votes, &Vote{
+		vote := &Vote{
 			Validator: params.proposer,
 			Address:   params.header.Miner,
 			Authorize: authorize,
-		})
+		}
+
+		params.snap.Votes = append(params.snap.Votes, vote)
+
+		if err := poa.ibft.recordVote(params.header, vote); err != nil {
+			return err
+		}
 	}
 
 	// check the tally for the proposed validator
@@ -16,6 +16,9 @@ type PoSMechanism struct {
 	ContractDeployment uint64 // The height when deploying staking contract
 	MaxValidatorCount  uint64
 	MinValidatorCount  uint64
+
+	// deploymentTimestamp sets ContractDeployment once it's resolved
+	deploymentTimestamp *timestampBound
 }
 
 // PoSFactory initializes the required data
@@ -60,20 +63,10 @@ func (pos *PoSMechanism) initializeParams(params *IBFTFork) error {
 	}
 
 	if pos.From != 0 {
-		if params.Deployment == nil {
-			return errors.New(`"deployment" must be specified in PoS fork`)
-		}
-
-		if params.Deployment.Value > pos.From {
-			return fmt.Errorf(
-				`"deployment" must be less than or equal to "from": deployment=%d, from=%d`,
-				params.Deployment.Value,
-				pos.From,
-			)
+		if err := pos.initializeDeployment(params); err != nil {
+			return err
 		}
 
-		pos.ContractDeployment = params.Deployment.Value
-
 		if params.MaxValidatorCount == nil {
 			pos.MaxValidatorCount = stakingHelper.MaxValidatorCount
 		} else {
@@ -90,6 +83,61 @@ func (pos *PoSMechanism) initializeParams(params *IBFTFork) error {
 	return nil
 }
 
+// initializeDeployment sets the height of the staking contract deployment,
+// which is resolved later when it's set by timestamp
+func (pos *PoSMechanism) initializeDeployment(params *IBFTFork) error {
+	if params.DeploymentTimestamp != nil {
+		if params.Deployment != nil {
+			return errors.New(`only one of "deployment" and "deploymentTimestamp" can be specified`)
+		}
+
+		if params.FromTimestamp == nil || params.DeploymentTimestamp.Value > params.FromTimestamp.Value {
+			return errors.New(`"deploymentTimestamp" must be less than or equal to "fromTimestamp"`)
+		}
+
+		pos.ContractDeployment = notStarted
+		pos.deploymentTimestamp = newTimestampBound(params.DeploymentTimestamp)
+
+		return nil
+	}
+
+	if params.Deployment == nil {
+		return errors.New(`"deployment" must be specified in PoS fork`)
+	}
+
+	// a deployment block is always before a beginning timestamp
+	if params.FromTimestamp == nil && params.Deployment.Value > pos.From {
+		return fmt.Errorf(
+			`"deployment" must be less than or equal to "from": deployment=%d, from=%d`,
+			params.Deployment.Value,
+			pos.From,
+		)
+	}
+
+	pos.ContractDeployment = params.Deployment.Value
+
+	return nil
+}
+
+// resolveTimestamps implements the ConsensusMechanism interface method
+func (pos *PoSMechanism) resolveTimestamps() {
+	pos.BaseConsensusMechanism.resolveTimestamps()
+
+	if pos.deploymentTimestamp != nil && !pos.deploymentTimestamp.resolved &&
+		pos.deploymentTimestamp.resolve(pos.ibft) {
+		pos.ContractDeployment = pos.deploymentTimestamp.block + 1
+	}
+}
+
+// rewindTimestamps implements the ConsensusMechanism interface method
+func (pos *PoSMechanism) rewindTimestamps(number uint64) {
+	pos.BaseConsensusMechanism.rewindTimestamps(number)
+
+	if pos.deploymentTimestamp != nil && pos.deploymentTimestamp.rewind(number) {
+		pos.ContractDeployment = notStarted
+	}
+}
+
 // calculateProposerHook calculates the next proposer based on the last
 func (pos *PoSMechanism) calculateProposerHook(lastProposerParam interface{}) error {
 	lastProposer, ok := lastProposerParam.(types.Address)
@@ -245,6 +293,10 @@ func (pos *PoSMechanism) updateValidators(num uint64) error {
 		} else {
 			pos.ibft.store.replace(newSnap)
 		}
+
+		if err := pos.ibft.recordStakingChange(header, snap.Set, validators); err != nil {
+			return err
+		}
 	}
 
 	return nil
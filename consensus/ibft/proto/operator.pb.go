@@ -1,16 +1,15 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
+// 	protoc-gen-go v1.27.1
 // 	protoc        v3.12.0
 // source: consensus/ibft/proto/operator.proto
 
 package proto
 
 import (
-	proto "github.com/golang/protobuf/proto"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -22,10 +21,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type IbftStatusResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -356,6 +351,249 @@ func (x *Candidate) GetAuth() bool {
 	return false
 }
 
+type ValidatorHistoryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the first epoch of the history
+	FromEpoch uint64 `protobuf:"varint,1,opt,name=fromEpoch,proto3" json:"fromEpoch,omitempty"`
+	// the last epoch of the history, 0 is the latest epoch
+	ToEpoch uint64 `protobuf:"varint,2,opt,name=toEpoch,proto3" json:"toEpoch,omitempty"`
+	// the validator to return the history of, empty for all the validators
+	Validator string `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
+}
+
+func (x *ValidatorHistoryReq) Reset() {
+	*x = ValidatorHistoryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorHistoryReq) ProtoMessage() {}
+
+func (x *ValidatorHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorHistoryReq.ProtoReflect.Descriptor instead.
+func (*ValidatorHistoryReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidatorHistoryReq) GetFromEpoch() uint64 {
+	if x != nil {
+		return x.FromEpoch
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryReq) GetToEpoch() uint64 {
+	if x != nil {
+		return x.ToEpoch
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryReq) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+type ValidatorHistoryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*ValidatorHistoryResp_Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Votes   []*ValidatorHistoryResp_Vote   `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes,omitempty"`
+	// set while the blocks written before the history are recorded
+	Partial bool `protobuf:"varint,3,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+func (x *ValidatorHistoryResp) Reset() {
+	*x = ValidatorHistoryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorHistoryResp) ProtoMessage() {}
+
+func (x *ValidatorHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorHistoryResp.ProtoReflect.Descriptor instead.
+func (*ValidatorHistoryResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValidatorHistoryResp) GetChanges() []*ValidatorHistoryResp_Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ValidatorHistoryResp) GetVotes() []*ValidatorHistoryResp_Vote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *ValidatorHistoryResp) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type ValidatorStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the first epoch of the statistics
+	FromEpoch uint64 `protobuf:"varint,1,opt,name=fromEpoch,proto3" json:"fromEpoch,omitempty"`
+	// the last epoch of the statistics, 0 is the latest epoch
+	ToEpoch uint64 `protobuf:"varint,2,opt,name=toEpoch,proto3" json:"toEpoch,omitempty"`
+}
+
+func (x *ValidatorStatsReq) Reset() {
+	*x = ValidatorStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorStatsReq) ProtoMessage() {}
+
+func (x *ValidatorStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorStatsReq.ProtoReflect.Descriptor instead.
+func (*ValidatorStatsReq) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidatorStatsReq) GetFromEpoch() uint64 {
+	if x != nil {
+		return x.FromEpoch
+	}
+	return 0
+}
+
+func (x *ValidatorStatsReq) GetToEpoch() uint64 {
+	if x != nil {
+		return x.ToEpoch
+	}
+	return 0
+}
+
+type ValidatorStatsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats []*ValidatorStatsResp_Stats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	// set while the blocks written before the statistics are recorded
+	Partial bool `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+func (x *ValidatorStatsResp) Reset() {
+	*x = ValidatorStatsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorStatsResp) ProtoMessage() {}
+
+func (x *ValidatorStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorStatsResp.ProtoReflect.Descriptor instead.
+func (*ValidatorStatsResp) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidatorStatsResp) GetStats() []*ValidatorStatsResp_Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *ValidatorStatsResp) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
 type Snapshot_Validator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -367,7 +605,7 @@ type Snapshot_Validator struct {
 func (x *Snapshot_Validator) Reset() {
 	*x = Snapshot_Validator{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[6]
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -380,7 +618,7 @@ func (x *Snapshot_Validator) String() string {
 func (*Snapshot_Validator) ProtoMessage() {}
 
 func (x *Snapshot_Validator) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[6]
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +654,7 @@ type Snapshot_Vote struct {
 func (x *Snapshot_Vote) Reset() {
 	*x = Snapshot_Vote{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[7]
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -429,7 +667,7 @@ func (x *Snapshot_Vote) String() string {
 func (*Snapshot_Vote) ProtoMessage() {}
 
 func (x *Snapshot_Vote) ProtoReflect() protoreflect.Message {
-	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[7]
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,6 +704,243 @@ func (x *Snapshot_Vote) GetAuth() bool {
 	return false
 }
 
+type ValidatorHistoryResp_Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number  uint64   `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Hash    string   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Epoch   uint64   `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Added   []string `protobuf:"bytes,4,rep,name=added,proto3" json:"added,omitempty"`
+	Removed []string `protobuf:"bytes,5,rep,name=removed,proto3" json:"removed,omitempty"`
+	Cause   string   `protobuf:"bytes,6,opt,name=cause,proto3" json:"cause,omitempty"`
+}
+
+func (x *ValidatorHistoryResp_Change) Reset() {
+	*x = ValidatorHistoryResp_Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorHistoryResp_Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorHistoryResp_Change) ProtoMessage() {}
+
+func (x *ValidatorHistoryResp_Change) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorHistoryResp_Change.ProtoReflect.Descriptor instead.
+func (*ValidatorHistoryResp_Change) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ValidatorHistoryResp_Change) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryResp_Change) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ValidatorHistoryResp_Change) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryResp_Change) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ValidatorHistoryResp_Change) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *ValidatorHistoryResp_Change) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+type ValidatorHistoryResp_Vote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number    uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Epoch     uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Validator string `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
+	Proposed  string `protobuf:"bytes,4,opt,name=proposed,proto3" json:"proposed,omitempty"`
+	Auth      bool   `protobuf:"varint,5,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *ValidatorHistoryResp_Vote) Reset() {
+	*x = ValidatorHistoryResp_Vote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorHistoryResp_Vote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorHistoryResp_Vote) ProtoMessage() {}
+
+func (x *ValidatorHistoryResp_Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorHistoryResp_Vote.ProtoReflect.Descriptor instead.
+func (*ValidatorHistoryResp_Vote) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *ValidatorHistoryResp_Vote) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryResp_Vote) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ValidatorHistoryResp_Vote) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *ValidatorHistoryResp_Vote) GetProposed() string {
+	if x != nil {
+		return x.Proposed
+	}
+	return ""
+}
+
+func (x *ValidatorHistoryResp_Vote) GetAuth() bool {
+	if x != nil {
+		return x.Auth
+	}
+	return false
+}
+
+type ValidatorStatsResp_Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address        string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ProposedBlocks uint64 `protobuf:"varint,2,opt,name=proposedBlocks,proto3" json:"proposedBlocks,omitempty"`
+	CommittedSeals uint64 `protobuf:"varint,3,opt,name=committedSeals,proto3" json:"committedSeals,omitempty"`
+	MissedRounds   uint64 `protobuf:"varint,4,opt,name=missedRounds,proto3" json:"missedRounds,omitempty"`
+}
+
+func (x *ValidatorStatsResp_Stats) Reset() {
+	*x = ValidatorStatsResp_Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_operator_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorStatsResp_Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorStatsResp_Stats) ProtoMessage() {}
+
+func (x *ValidatorStatsResp_Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_operator_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorStatsResp_Stats.ProtoReflect.Descriptor instead.
+func (*ValidatorStatsResp_Stats) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_operator_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *ValidatorStatsResp_Stats) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValidatorStatsResp_Stats) GetProposedBlocks() uint64 {
+	if x != nil {
+		return x.ProposedBlocks
+	}
+	return 0
+}
+
+func (x *ValidatorStatsResp_Stats) GetCommittedSeals() uint64 {
+	if x != nil {
+		return x.CommittedSeals
+	}
+	return 0
+}
+
+func (x *ValidatorStatsResp_Stats) GetMissedRounds() uint64 {
+	if x != nil {
+		return x.MissedRounds
+	}
+	return 0
+}
+
 var File_consensus_ibft_proto_operator_proto protoreflect.FileDescriptor
 
 var file_consensus_ibft_proto_operator_proto_rawDesc = []byte{
@@ -508,21 +983,85 @@ var file_consensus_ibft_proto_operator_proto_rawDesc = []byte{
 	0x09, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
 	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
 	0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x32, 0xde, 0x01, 0x0a, 0x0c, 0x49, 0x62, 0x66,
-	0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x0b, 0x47, 0x65, 0x74,
-	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x65, 0x12, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x43, 0x61, 0x6e,
-	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x12, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x62, 0x66, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f,
+	0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x6b, 0x0a, 0x13, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12,
+	0x1c, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x22, 0xb8, 0x03, 0x0a, 0x14, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x39,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x6f, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x1a, 0x90, 0x01, 0x0a, 0x06, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x1a, 0x82, 0x01, 0x0a, 0x04,
+	0x56, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x22, 0x4b, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0xfa, 0x01,
+	0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x61, 0x6c, 0x1a, 0x95, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x53, 0x65, 0x61, 0x6c, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x53, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x69,
+	0x73, 0x73, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x32, 0xec, 0x02, 0x0a, 0x0c, 0x49,
+	0x62, 0x66, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x0f, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x65, 0x12, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x62, 0x66,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x48, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x42, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f,
 	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x69, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
@@ -539,35 +1078,49 @@ func file_consensus_ibft_proto_operator_proto_rawDescGZIP() []byte {
 	return file_consensus_ibft_proto_operator_proto_rawDescData
 }
 
-var file_consensus_ibft_proto_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_consensus_ibft_proto_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_consensus_ibft_proto_operator_proto_goTypes = []interface{}{
-	(*IbftStatusResp)(nil),     // 0: v1.IbftStatusResp
-	(*SnapshotReq)(nil),        // 1: v1.SnapshotReq
-	(*Snapshot)(nil),           // 2: v1.Snapshot
-	(*ProposeReq)(nil),         // 3: v1.ProposeReq
-	(*CandidatesResp)(nil),     // 4: v1.CandidatesResp
-	(*Candidate)(nil),          // 5: v1.Candidate
-	(*Snapshot_Validator)(nil), // 6: v1.Snapshot.Validator
-	(*Snapshot_Vote)(nil),      // 7: v1.Snapshot.Vote
-	(*empty.Empty)(nil),        // 8: google.protobuf.Empty
+	(*IbftStatusResp)(nil),              // 0: v1.IbftStatusResp
+	(*SnapshotReq)(nil),                 // 1: v1.SnapshotReq
+	(*Snapshot)(nil),                    // 2: v1.Snapshot
+	(*ProposeReq)(nil),                  // 3: v1.ProposeReq
+	(*CandidatesResp)(nil),              // 4: v1.CandidatesResp
+	(*Candidate)(nil),                   // 5: v1.Candidate
+	(*ValidatorHistoryReq)(nil),         // 6: v1.ValidatorHistoryReq
+	(*ValidatorHistoryResp)(nil),        // 7: v1.ValidatorHistoryResp
+	(*ValidatorStatsReq)(nil),           // 8: v1.ValidatorStatsReq
+	(*ValidatorStatsResp)(nil),          // 9: v1.ValidatorStatsResp
+	(*Snapshot_Validator)(nil),          // 10: v1.Snapshot.Validator
+	(*Snapshot_Vote)(nil),               // 11: v1.Snapshot.Vote
+	(*ValidatorHistoryResp_Change)(nil), // 12: v1.ValidatorHistoryResp.Change
+	(*ValidatorHistoryResp_Vote)(nil),   // 13: v1.ValidatorHistoryResp.Vote
+	(*ValidatorStatsResp_Stats)(nil),    // 14: v1.ValidatorStatsResp.Stats
+	(*emptypb.Empty)(nil),               // 15: google.protobuf.Empty
 }
 var file_consensus_ibft_proto_operator_proto_depIdxs = []int32{
-	6, // 0: v1.Snapshot.validators:type_name -> v1.Snapshot.Validator
-	7, // 1: v1.Snapshot.votes:type_name -> v1.Snapshot.Vote
-	5, // 2: v1.CandidatesResp.candidates:type_name -> v1.Candidate
-	1, // 3: v1.IbftOperator.GetSnapshot:input_type -> v1.SnapshotReq
-	5, // 4: v1.IbftOperator.Propose:input_type -> v1.Candidate
-	8, // 5: v1.IbftOperator.Candidates:input_type -> google.protobuf.Empty
-	8, // 6: v1.IbftOperator.Status:input_type -> google.protobuf.Empty
-	2, // 7: v1.IbftOperator.GetSnapshot:output_type -> v1.Snapshot
-	8, // 8: v1.IbftOperator.Propose:output_type -> google.protobuf.Empty
-	4, // 9: v1.IbftOperator.Candidates:output_type -> v1.CandidatesResp
-	0, // 10: v1.IbftOperator.Status:output_type -> v1.IbftStatusResp
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: v1.Snapshot.validators:type_name -> v1.Snapshot.Validator
+	11, // 1: v1.Snapshot.votes:type_name -> v1.Snapshot.Vote
+	5,  // 2: v1.CandidatesResp.candidates:type_name -> v1.Candidate
+	12, // 3: v1.ValidatorHistoryResp.changes:type_name -> v1.ValidatorHistoryResp.Change
+	13, // 4: v1.ValidatorHistoryResp.votes:type_name -> v1.ValidatorHistoryResp.Vote
+	14, // 5: v1.ValidatorStatsResp.stats:type_name -> v1.ValidatorStatsResp.Stats
+	1,  // 6: v1.IbftOperator.GetSnapshot:input_type -> v1.SnapshotReq
+	5,  // 7: v1.IbftOperator.Propose:input_type -> v1.Candidate
+	15, // 8: v1.IbftOperator.Candidates:input_type -> google.protobuf.Empty
+	15, // 9: v1.IbftOperator.Status:input_type -> google.protobuf.Empty
+	6,  // 10: v1.IbftOperator.GetValidatorHistory:input_type -> v1.ValidatorHistoryReq
+	8,  // 11: v1.IbftOperator.GetValidatorStats:input_type -> v1.ValidatorStatsReq
+	2,  // 12: v1.IbftOperator.GetSnapshot:output_type -> v1.Snapshot
+	15, // 13: v1.IbftOperator.Propose:output_type -> google.protobuf.Empty
+	4,  // 14: v1.IbftOperator.Candidates:output_type -> v1.CandidatesResp
+	0,  // 15: v1.IbftOperator.Status:output_type -> v1.IbftStatusResp
+	7,  // 16: v1.IbftOperator.GetValidatorHistory:output_type -> v1.ValidatorHistoryResp
+	9,  // 17: v1.IbftOperator.GetValidatorStats:output_type -> v1.ValidatorStatsResp
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_consensus_ibft_proto_operator_proto_init() }
@@ -649,7 +1202,7 @@ func file_consensus_ibft_proto_operator_proto_init() {
 			}
 		}
 		file_consensus_ibft_proto_operator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Snapshot_Validator); i {
+			switch v := v.(*ValidatorHistoryReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -661,6 +1214,54 @@ func file_consensus_ibft_proto_operator_proto_init() {
 			}
 		}
 		file_consensus_ibft_proto_operator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorHistoryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorStatsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot_Validator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Snapshot_Vote); i {
 			case 0:
 				return &v.state
@@ -672,6 +1273,42 @@ func file_consensus_ibft_proto_operator_proto_init() {
 				return nil
 			}
 		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorHistoryResp_Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorHistoryResp_Vote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_ibft_proto_operator_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorStatsResp_Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -679,7 +1316,7 @@ func file_consensus_ibft_proto_operator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_consensus_ibft_proto_operator_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,13 +1,17 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: consensus/ibft/proto/operator.proto
 
 package proto
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,9 +24,11 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type IbftOperatorClient interface {
 	GetSnapshot(ctx context.Context, in *SnapshotReq, opts ...grpc.CallOption) (*Snapshot, error)
-	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error)
-	Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
-	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	GetValidatorHistory(ctx context.Context, in *ValidatorHistoryReq, opts ...grpc.CallOption) (*ValidatorHistoryResp, error)
+	GetValidatorStats(ctx context.Context, in *ValidatorStatsReq, opts ...grpc.CallOption) (*ValidatorStatsResp, error)
 }
 
 type ibftOperatorClient struct {
@@ -42,8 +48,8 @@ func (c *ibftOperatorClient) GetSnapshot(ctx context.Context, in *SnapshotReq, o
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Propose", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -51,7 +57,7 @@ func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ..
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
+func (c *ibftOperatorClient) Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
 	out := new(CandidatesResp)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Candidates", in, out, opts...)
 	if err != nil {
@@ -60,7 +66,7 @@ func (c *ibftOperatorClient) Candidates(ctx context.Context, in *empty.Empty, op
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
+func (c *ibftOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
 	out := new(IbftStatusResp)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Status", in, out, opts...)
 	if err != nil {
@@ -69,14 +75,34 @@ func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts .
 	return out, nil
 }
 
+func (c *ibftOperatorClient) GetValidatorHistory(ctx context.Context, in *ValidatorHistoryReq, opts ...grpc.CallOption) (*ValidatorHistoryResp, error) {
+	out := new(ValidatorHistoryResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetValidatorHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) GetValidatorStats(ctx context.Context, in *ValidatorStatsReq, opts ...grpc.CallOption) (*ValidatorStatsResp, error) {
+	out := new(ValidatorStatsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetValidatorStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IbftOperatorServer is the server API for IbftOperator service.
 // All implementations must embed UnimplementedIbftOperatorServer
 // for forward compatibility
 type IbftOperatorServer interface {
 	GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error)
-	Propose(context.Context, *Candidate) (*empty.Empty, error)
-	Candidates(context.Context, *empty.Empty) (*CandidatesResp, error)
-	Status(context.Context, *empty.Empty) (*IbftStatusResp, error)
+	Propose(context.Context, *Candidate) (*emptypb.Empty, error)
+	Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error)
+	Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error)
+	GetValidatorHistory(context.Context, *ValidatorHistoryReq) (*ValidatorHistoryResp, error)
+	GetValidatorStats(context.Context, *ValidatorStatsReq) (*ValidatorStatsResp, error)
 	mustEmbedUnimplementedIbftOperatorServer()
 }
 
@@ -87,15 +113,21 @@ type UnimplementedIbftOperatorServer struct {
 func (UnimplementedIbftOperatorServer) GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
 }
-func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*empty.Empty, error) {
+func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Propose not implemented")
 }
-func (UnimplementedIbftOperatorServer) Candidates(context.Context, *empty.Empty) (*CandidatesResp, error) {
+func (UnimplementedIbftOperatorServer) Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Candidates not implemented")
 }
-func (UnimplementedIbftOperatorServer) Status(context.Context, *empty.Empty) (*IbftStatusResp, error) {
+func (UnimplementedIbftOperatorServer) Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedIbftOperatorServer) GetValidatorHistory(context.Context, *ValidatorHistoryReq) (*ValidatorHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorHistory not implemented")
+}
+func (UnimplementedIbftOperatorServer) GetValidatorStats(context.Context, *ValidatorStatsReq) (*ValidatorStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorStats not implemented")
+}
 func (UnimplementedIbftOperatorServer) mustEmbedUnimplementedIbftOperatorServer() {}
 
 // UnsafeIbftOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -146,7 +178,7 @@ func _IbftOperator_Propose_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -158,13 +190,13 @@ func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/v1.IbftOperator/Candidates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Candidates(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Candidates(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -176,7 +208,43 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: "/v1.IbftOperator/Status",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Status(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetValidatorHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetValidatorHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetValidatorHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetValidatorHistory(ctx, req.(*ValidatorHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetValidatorStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetValidatorStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetValidatorStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetValidatorStats(ctx, req.(*ValidatorStatsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -204,6 +272,14 @@ var IbftOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _IbftOperator_Status_Handler,
 		},
+		{
+			MethodName: "GetValidatorHistory",
+			Handler:    _IbftOperator_GetValidatorHistory_Handler,
+		},
+		{
+			MethodName: "GetValidatorStats",
+			Handler:    _IbftOperator_GetValidatorStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "consensus/ibft/proto/operator.proto",
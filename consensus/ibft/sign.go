@@ -254,3 +254,31 @@ func signMsg(key *ecdsa.PrivateKey, msg *proto.MessageReq) error {
 
 	return nil
 }
+
+// ecrecoverCommittedSeals recovers the addresses of the validators
+// which committed seals are included in the header
+func ecrecoverCommittedSeals(header *types.Header) ([]types.Address, error) {
+	extra, err := getIbftExtra(header)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := calculateHeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMsg := commitMsg(hash)
+	signers := make([]types.Address, 0, len(extra.CommittedSeal))
+
+	for _, seal := range extra.CommittedSeal {
+		addr, err := ecrecoverImpl(seal, rawMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		signers = append(signers, addr)
+	}
+
+	return signers, nil
+}
@@ -26,6 +26,10 @@ func (i *Ibft) setupSnapshot() error {
 		}
 	}
 
+	if err := i.setupHistory(); err != nil {
+		return err
+	}
+
 	header := i.blockchain.Header()
 	meta, err := i.getSnapshotMetadata()
 
@@ -170,6 +174,9 @@ func (i *Ibft) processHeaders(headers []*types.Header) error {
 			return fmt.Errorf("unauthorized proposer")
 		}
 
+		// the validator set the header was sealed by
+		set := parentSnap.Set
+
 		if hookErr := i.runHook(
 			ProcessHeadersHook,
 			h.Number,
@@ -183,6 +190,10 @@ func (i *Ibft) processHeaders(headers []*types.Header) error {
 			return hookErr
 		}
 
+		if err := i.recordHeader(h, set, snap, proposer); err != nil {
+			return err
+		}
+
 		if !snap.Equal(parentSnap) {
 			saveSnap(h)
 		}
@@ -194,6 +205,43 @@ func (i *Ibft) processHeaders(headers []*types.Header) error {
 	return nil
 }
 
+// rewindSnapshots deletes the snapshots above the block, and makes sure the snapshot
+// of the block is available, rebuilding it from the beginning of its epoch otherwise
+func (i *Ibft) rewindSnapshots(number uint64) error {
+	i.store.deleteHigher(number)
+	i.store.updateLastBlock(number)
+
+	if i.store.find(number) != nil {
+		return i.rewindHistory(number)
+	}
+
+	// the older snapshots were pruned
+	beginHeight := number / i.epochSize * i.epochSize
+	i.logger.Info("restore snapshot at beginning of epoch after rewind", "from", beginHeight, "to", number)
+
+	beginHeader, ok := i.blockchain.GetHeaderByNumber(beginHeight)
+	if !ok {
+		return fmt.Errorf("header at %d not found", beginHeight)
+	}
+
+	if err := i.addHeaderSnap(beginHeader); err != nil {
+		return err
+	}
+
+	for num := beginHeight + 1; num <= number; num++ {
+		header, ok := i.blockchain.GetHeaderByNumber(num)
+		if !ok {
+			return fmt.Errorf("header %d not found", num)
+		}
+
+		if err := i.processHeaders([]*types.Header{header}); err != nil {
+			return err
+		}
+	}
+
+	return i.rewindHistory(number)
+}
+
 // getSnapshotMetadata returns the latest snapshot metadata
 func (i *Ibft) getSnapshotMetadata() (*snapshotMetadata, error) {
 	meta := &snapshotMetadata{
@@ -438,6 +486,17 @@ func (s *snapshotStore) deleteLower(num uint64) {
 	s.list = s.list[i:]
 }
 
+// deleteHigher deletes snapshots that have a block number higher than the passed in parameter
+func (s *snapshotStore) deleteHigher(num uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	i := sort.Search(len(s.list), func(i int) bool {
+		return s.list[i].Number > num
+	})
+	s.list = s.list[:i]
+}
+
 // find returns the index of the first closest snapshot to the number specified
 func (s *snapshotStore) find(num uint64) *Snapshot {
 	s.lock.Lock()
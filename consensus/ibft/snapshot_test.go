@@ -872,3 +872,22 @@ func TestSnapshot_Store_Find(t *testing.T) {
 	check(21, 20)
 	check(1000, 100)
 }
+
+func TestSnapshot_Store_DeleteHigher(t *testing.T) {
+	store := newSnapshotStore()
+
+	for i := 0; i <= 100; i += 10 {
+		store.add(&Snapshot{
+			Number: uint64(i),
+		})
+	}
+
+	store.deleteHigher(45)
+
+	assert.Len(t, store.list, 5)
+	assert.Equal(t, uint64(40), store.find(45).Number)
+
+	store.deleteHigher(40)
+
+	assert.Len(t, store.list, 5)
+}
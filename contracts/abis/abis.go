@@ -6,3 +6,6 @@ import (
 
 var StakingABI = abi.MustNewABI(StakingJSONABI)
 var StressTestABI = abi.MustNewABI(StressTestJSONABI)
+var AllowListABI = abi.MustNewABI(AllowListJSONABI)
+var NativeMinterABI = abi.MustNewABI(NativeMinterJSONABI)
+var PeerAllowListABI = abi.MustNewABI(PeerAllowListJSONABI)
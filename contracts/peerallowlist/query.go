@@ -0,0 +1,78 @@
+package peerallowlist
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// AddrPeerAllowListContract is the address of the peer allow list contract
+	AddrPeerAllowListContract = types.StringToAddress("1002")
+
+	// Gas limit used when querying the allowed peers
+	queryGasLimit uint64 = 1000000
+)
+
+// DecodeAllowedPeers decodes the peer IDs returned by the allowedPeers method
+func DecodeAllowedPeers(method *abi.Method, returnValue []byte) ([]string, error) {
+	decodedResults, err := method.Outputs.Decode(returnValue)
+	if err != nil {
+		return nil, err
+	}
+
+	results, ok := decodedResults.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("failed type assertion from decodedResults to map")
+	}
+
+	peerIDs, ok := results["0"].([]string)
+	if !ok {
+		return nil, errors.New("failed type assertion from results[0] to []string")
+	}
+
+	return peerIDs, nil
+}
+
+type TxQueryHandler interface {
+	Apply(*types.Transaction) (*runtime.ExecutionResult, error)
+	GetNonce(types.Address) uint64
+}
+
+// QueryAllowedPeers returns the peer IDs permitted by the peer allow list contract.
+// No peers are returned if the contract is not deployed
+func QueryAllowedPeers(t TxQueryHandler, from types.Address) ([]string, error) {
+	method, ok := abis.PeerAllowListABI.Methods["allowedPeers"]
+	if !ok {
+		return nil, errors.New("allowedPeers method doesn't exist in PeerAllowList contract ABI")
+	}
+
+	res, err := t.Apply(&types.Transaction{
+		From:     from,
+		To:       &AddrPeerAllowListContract,
+		Value:    big.NewInt(0),
+		Input:    method.ID(),
+		GasPrice: big.NewInt(0),
+		Gas:      queryGasLimit,
+		Nonce:    t.GetNonce(from),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Failed() {
+		return nil, res.Err
+	}
+
+	if len(res.ReturnValue) == 0 {
+		// the call to an account without code succeeds with no return value
+		return []string{}, nil
+	}
+
+	return DecodeAllowedPeers(method, res.ReturnValue)
+}
@@ -0,0 +1,82 @@
+package peerallowlist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type TxMock struct {
+	res *runtime.ExecutionResult
+}
+
+func (m *TxMock) Apply(tx *types.Transaction) (*runtime.ExecutionResult, error) {
+	if m.res == nil {
+		return nil, errors.New("not found")
+	}
+
+	return m.res, nil
+}
+
+func (m *TxMock) GetNonce(addr types.Address) uint64 {
+	return 0
+}
+
+func TestQueryAllowedPeers(t *testing.T) {
+	method := abis.PeerAllowListABI.Methods["allowedPeers"]
+	assert.NotNil(t, method)
+
+	peers := []string{
+		"16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW",
+		"16Uiu2HAmS9Nq4QAaEiogE4ieJFUYsoH28magT7wSvJPpfUGBj3Hq",
+	}
+
+	encoded, err := method.Outputs.Encode([]interface{}{peers})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		res      *runtime.ExecutionResult
+		succeed  bool
+		expected []string
+	}{
+		{
+			name:     "should decode the allowed peers",
+			res:      &runtime.ExecutionResult{ReturnValue: encoded},
+			succeed:  true,
+			expected: peers,
+		},
+		{
+			name:     "should return no peers if the contract is not deployed",
+			res:      &runtime.ExecutionResult{},
+			succeed:  true,
+			expected: []string{},
+		},
+		{
+			name:    "should fail to parse",
+			res:     &runtime.ExecutionResult{ReturnValue: encoded[:40]},
+			succeed: false,
+		},
+		{
+			name:    "should fail if the call reverts",
+			res:     &runtime.ExecutionResult{Err: runtime.ErrExecutionReverted},
+			succeed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := QueryAllowedPeers(&TxMock{res: tt.res}, types.ZeroAddress)
+			if tt.succeed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
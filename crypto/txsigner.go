@@ -155,10 +155,9 @@ func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
 	return calcTxHash(tx, e.chainID)
 }
 
-// Sender returns the transaction sender
-func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
-	protected := true
-
+// IsProtected returns true if the transaction signature commits to a chain ID (EIP155),
+// false if it's a Frontier signature valid on any chain
+func IsProtected(tx *types.Transaction) bool {
 	// Check if v value conforms to an earlier standard (before EIP155)
 	bigV := big.NewInt(0)
 	if tx.V != nil {
@@ -166,13 +165,23 @@ func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
 	}
 
 	if vv := bigV.Uint64(); bits.Len(uint(vv)) <= 8 {
-		protected = vv != 27 && vv != 28
+		return vv != 27 && vv != 28
 	}
 
-	if !protected {
+	return true
+}
+
+// Sender returns the transaction sender
+func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
+	if !IsProtected(tx) {
 		return (&FrontierSigner{}).Sender(tx)
 	}
 
+	bigV := big.NewInt(0)
+	if tx.V != nil {
+		bigV.SetBytes(tx.V.Bytes())
+	}
+
 	// Reverse the V calculation to find the original V in the range [0, 1]
 	// v = CHAIN_ID * 2 + 35 + {0, 1}
 	mulOperand := big.NewInt(0).Mul(big.NewInt(int64(e.chainID)), big.NewInt(2))
@@ -143,3 +143,25 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestIsProtected(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(10),
+		GasPrice: big.NewInt(0),
+	}
+
+	frontierTx, err := (&FrontierSigner{}).SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.False(t, IsProtected(frontierTx))
+
+	for _, chainID := range []uint64{1, 100, 1 << 40} {
+		eip155Tx, err := NewEIP155Signer(chainID).SignTx(txn, key)
+		assert.NoError(t, err)
+		assert.True(t, IsProtected(eip155Tx))
+	}
+}
@@ -34,7 +34,7 @@ func TestBroadcast(t *testing.T) {
 		},
 	}
 
-	signer := &crypto.FrontierSigner{}
+	signer := crypto.NewEIP155Signer(100)
 	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
 	_, receiverAddr := tests.GenerateKeyAndAddr(t)
 
@@ -0,0 +1,481 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// apiKeyHeader is the HTTP header carrying the API key of a client
+	apiKeyHeader = "X-API-Key"
+
+	// limiterIdleTimeout is the time after which the limiter of an idle IP is dropped
+	limiterIdleTimeout = 10 * time.Minute
+)
+
+var (
+	errAuthRequired     = errors.New("authentication required")
+	errInvalidAPIKey    = errors.New("invalid API key")
+	errInvalidAuthToken = errors.New("invalid authorization header, expected a bearer token")
+	errRateLimited      = errors.New("rate limit exceeded")
+)
+
+// ClientPolicy defines the methods a client can call and how often
+type ClientPolicy struct {
+	// Methods are the allowed namespaces (eth) or methods (eth_call),
+	// every method is allowed if empty
+	Methods []string
+
+	// RateLimit is the number of requests per second, unlimited if zero.
+	// Every request of a batch counts
+	RateLimit float64
+
+	// RateBurst is the number of requests over the rate limit
+	// accepted at once, the rate limit rounded up if zero
+	RateBurst int
+}
+
+// ClientConfig defines a known client of the JSON-RPC server
+type ClientConfig struct {
+	ClientPolicy
+
+	// Name identifies the client, it's matched against the subject of the JWTs
+	Name string
+
+	// APIKey authenticates the client, if set
+	APIKey string
+}
+
+// AccessConfig defines the authentication and the limits of the JSON-RPC server
+type AccessConfig struct {
+	// RequireAuth rejects the requests without credentials
+	RequireAuth bool
+
+	// JWTSecret is the HS256 shared secret of the JWTs, they are not accepted if empty
+	JWTSecret []byte
+
+	// Clients are the clients authenticated by an API key or a JWT subject
+	Clients []*ClientConfig
+
+	// Default is the policy of the anonymous clients and of the JWTs
+	// not matching a client, the rate limit applies per IP
+	Default ClientPolicy
+
+	// MaxBatchSize is the maximum number of requests in a batch, unlimited if zero
+	MaxBatchSize uint64
+
+	// MaxBodySize is the maximum size in bytes of a request body, unlimited if zero
+	MaxBodySize uint64
+
+	// MethodTimeouts are the execution time limits of the namespaces or methods
+	MethodTimeouts map[string]time.Duration
+}
+
+// rpcClient is the authenticated origin of a request
+type rpcClient struct {
+	name    string
+	apiKey  []byte // nil if the client doesn't have an API key
+	policy  *ClientPolicy
+	limiter *rate.Limiter // nil if the client is not rate limited
+}
+
+// allowMethod checks if the client can call the method
+func (c *rpcClient) allowMethod(method string) Error {
+	if len(c.policy.Methods) == 0 || matchMethod(c.policy.Methods, method) {
+		return nil
+	}
+
+	return NewMethodNotAllowedError(method)
+}
+
+// allowRequests takes the tokens of the requests from the client bucket
+func (c *rpcClient) allowRequests(count int) Error {
+	if c.limiter == nil || c.limiter.AllowN(time.Now(), count) {
+		return nil
+	}
+
+	return NewLimitExceededError(errRateLimited.Error())
+}
+
+// accessGuard authenticates the clients and enforces their policies
+type accessGuard struct {
+	config     *AccessConfig
+	clients    map[string]*rpcClient // client name -> client
+	apiKeys    []*rpcClient          // clients with an API key
+	ipLimiters *limiterSet
+}
+
+func newAccessGuard(config *AccessConfig) (*accessGuard, error) {
+	if config == nil {
+		config = &AccessConfig{}
+	}
+
+	g := &accessGuard{
+		config:     config,
+		clients:    make(map[string]*rpcClient),
+		ipLimiters: newLimiterSet(&config.Default),
+	}
+
+	apiKeys := make(map[string]struct{})
+
+	for _, clientConfig := range config.Clients {
+		if clientConfig.Name == "" {
+			return nil, errors.New("JSON-RPC client without a name")
+		}
+
+		if _, ok := g.clients[clientConfig.Name]; ok {
+			return nil, fmt.Errorf("duplicate JSON-RPC client %s", clientConfig.Name)
+		}
+
+		client := &rpcClient{
+			name:    clientConfig.Name,
+			policy:  &clientConfig.ClientPolicy,
+			limiter: newLimiter(&clientConfig.ClientPolicy),
+		}
+
+		g.clients[client.name] = client
+
+		if clientConfig.APIKey == "" {
+			continue
+		}
+
+		if _, ok := apiKeys[clientConfig.APIKey]; ok {
+			return nil, fmt.Errorf("duplicate API key of the JSON-RPC client %s", clientConfig.Name)
+		}
+
+		apiKeys[clientConfig.APIKey] = struct{}{}
+		client.apiKey = []byte(clientConfig.APIKey)
+		g.apiKeys = append(g.apiKeys, client)
+	}
+
+	return g, nil
+}
+
+// authenticate returns the client of the request from its API key or JWT,
+// the Authorization header is ignored if JWTs are not enabled
+func (g *accessGuard) authenticate(req *http.Request) (*rpcClient, error) {
+	if key := req.Header.Get(apiKeyHeader); key != "" {
+		client := g.clientByAPIKey([]byte(key))
+		if client == nil {
+			return nil, errInvalidAPIKey
+		}
+
+		return client, nil
+	}
+
+	if auth := req.Header.Get("Authorization"); auth != "" && len(g.config.JWTSecret) > 0 {
+		token := strings.TrimPrefix(auth, "Bearer ")
+		if token == auth {
+			return nil, errInvalidAuthToken
+		}
+
+		claims, err := verifyJWT(token, g.config.JWTSecret, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if client, ok := g.clients[claims.Subject]; ok {
+			return client, nil
+		}
+
+		return g.defaultClient(req), nil
+	}
+
+	if g.config.RequireAuth {
+		return nil, errAuthRequired
+	}
+
+	return g.defaultClient(req), nil
+}
+
+// clientByAPIKey returns the client of the API key, nil if it's unknown.
+// Every key is compared in constant time, so the timing doesn't leak the known keys
+func (g *accessGuard) clientByAPIKey(key []byte) *rpcClient {
+	var match *rpcClient
+
+	for _, client := range g.apiKeys {
+		if subtle.ConstantTimeCompare(client.apiKey, key) == 1 {
+			match = client
+		}
+	}
+
+	return match
+}
+
+// defaultClient returns the client of the default policy, identified by its IP
+func (g *accessGuard) defaultClient(req *http.Request) *rpcClient {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		ip = req.RemoteAddr
+	}
+
+	return &rpcClient{
+		name:    ip,
+		policy:  &g.config.Default,
+		limiter: g.ipLimiters.get(ip),
+	}
+}
+
+// limitBody limits the reader of a request body to one byte over the maximum size,
+// so an oversized body is detected without reading it whole
+func (g *accessGuard) limitBody(body io.Reader) io.Reader {
+	if g.config.MaxBodySize == 0 {
+		return body
+	}
+
+	return io.LimitReader(body, int64(g.config.MaxBodySize)+1)
+}
+
+// checkBodySize checks the size of the request body against the limit
+func (g *accessGuard) checkBodySize(size int) Error {
+	if g.config.MaxBodySize == 0 || uint64(size) <= g.config.MaxBodySize {
+		return nil
+	}
+
+	return NewLimitExceededError(
+		fmt.Sprintf("request body exceeds the limit of %d bytes", g.config.MaxBodySize),
+	)
+}
+
+// checkBatchSize checks the number of requests of a batch against the limit
+func (g *accessGuard) checkBatchSize(size int) Error {
+	if g.config.MaxBatchSize == 0 || uint64(size) <= g.config.MaxBatchSize {
+		return nil
+	}
+
+	return NewLimitExceededError(
+		fmt.Sprintf("batch of %d requests exceeds the limit of %d", size, g.config.MaxBatchSize),
+	)
+}
+
+// wrap returns the dispatcher enforcing the client policy
+func (g *accessGuard) wrap(client *rpcClient, next dispatcher) dispatcher {
+	return &guardedDispatcher{
+		guard:  g,
+		client: client,
+		next:   next,
+	}
+}
+
+// guardedDispatcher enforces the policy of a client on the requests
+// before handing them over to the dispatcher
+type guardedDispatcher struct {
+	guard  *accessGuard
+	client *rpcClient
+	next   dispatcher
+}
+
+func (d *guardedDispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	var req Request
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		// the dispatcher reports malformed requests
+		return d.next.HandleWs(reqBody, conn)
+	}
+
+	if err := d.checkRequest(req); err != nil {
+		return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+	}
+
+	return d.next.HandleWs(reqBody, conn)
+}
+
+func (d *guardedDispatcher) Handle(reqBody []byte) ([]byte, error) {
+	x := bytes.TrimLeft(reqBody, " \t\r\n")
+	if len(x) == 0 || x[0] != '[' {
+		var req Request
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			return d.next.Handle(reqBody)
+		}
+
+		if err := d.checkRequest(req); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		return d.next.Handle(reqBody)
+	}
+
+	var requests []Request
+	if err := json.Unmarshal(reqBody, &requests); err != nil {
+		return d.next.Handle(reqBody)
+	}
+
+	if err := d.guard.checkBatchSize(len(requests)); err != nil {
+		return NewRPCResponse(nil, "2.0", nil, err).Bytes()
+	}
+
+	if err := d.client.allowRequests(len(requests)); err != nil {
+		return NewRPCResponse(nil, "2.0", nil, err).Bytes()
+	}
+
+	denied := make([]Error, len(requests))
+	allowed := make([]Request, 0, len(requests))
+
+	for i, req := range requests {
+		if denied[i] = d.client.allowMethod(req.Method); denied[i] == nil {
+			allowed = append(allowed, req)
+		}
+	}
+
+	if len(allowed) == len(requests) {
+		return d.next.Handle(reqBody)
+	}
+
+	return d.handlePartialBatch(requests, allowed, denied)
+}
+
+// handlePartialBatch dispatches the allowed requests of a batch
+// and merges their responses with the errors of the denied ones
+func (d *guardedDispatcher) handlePartialBatch(
+	requests []Request,
+	allowed []Request,
+	denied []Error,
+) ([]byte, error) {
+	results := make([]json.RawMessage, 0, len(allowed))
+
+	if len(allowed) > 0 {
+		body, err := json.Marshal(allowed)
+		if err != nil {
+			return NewRPCResponse(nil, "2.0", nil, NewInternalError("Internal error")).Bytes()
+		}
+
+		resp, err := d.next.Handle(body)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(resp, &results); err != nil || len(results) != len(allowed) {
+			return NewRPCResponse(nil, "2.0", nil, NewInternalError("Internal error")).Bytes()
+		}
+	}
+
+	responses := make([]json.RawMessage, 0, len(requests))
+
+	for i, req := range requests {
+		if denied[i] == nil {
+			responses = append(responses, results[0])
+			results = results[1:]
+
+			continue
+		}
+
+		resp, err := NewRPCResponse(req.ID, "2.0", nil, denied[i]).Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return json.Marshal(responses)
+}
+
+// checkRequest checks a single request against the client policy
+func (d *guardedDispatcher) checkRequest(req Request) Error {
+	if err := d.client.allowRequests(1); err != nil {
+		return err
+	}
+
+	return d.client.allowMethod(req.Method)
+}
+
+// matchMethod checks if the method, or its namespace, is in the list
+func matchMethod(list []string, method string) bool {
+	namespace := strings.SplitN(method, "_", 2)[0]
+
+	for _, item := range list {
+		if item == method || item == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// methodTimeout returns the time limit of the method, or of its namespace
+func methodTimeout(timeouts map[string]time.Duration, method string) time.Duration {
+	if timeout, ok := timeouts[method]; ok {
+		return timeout
+	}
+
+	return timeouts[strings.SplitN(method, "_", 2)[0]]
+}
+
+// newLimiter returns the token bucket of the policy, or nil if it's not rate limited
+func newLimiter(policy *ClientPolicy) *rate.Limiter {
+	if policy.RateLimit <= 0 {
+		return nil
+	}
+
+	burst := policy.RateBurst
+	if burst <= 0 {
+		burst = int(math.Ceil(policy.RateLimit))
+	}
+
+	return rate.NewLimiter(rate.Limit(policy.RateLimit), burst)
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// limiterSet holds the token buckets of the default policy per IP
+type limiterSet struct {
+	lock      sync.Mutex
+	policy    *ClientPolicy
+	limiters  map[string]*limiterEntry
+	lastPrune time.Time
+}
+
+func newLimiterSet(policy *ClientPolicy) *limiterSet {
+	return &limiterSet{
+		policy:    policy,
+		limiters:  make(map[string]*limiterEntry),
+		lastPrune: time.Now(),
+	}
+}
+
+// get returns the token bucket of the IP, or nil if the policy is not rate limited
+func (s *limiterSet) get(ip string) *rate.Limiter {
+	if s.policy.RateLimit <= 0 {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	// drop the buckets of the IPs idle for a while
+	if now.Sub(s.lastPrune) > limiterIdleTimeout {
+		for key, entry := range s.limiters {
+			if now.Sub(entry.lastSeen) > limiterIdleTimeout {
+				delete(s.limiters, key)
+			}
+		}
+
+		s.lastPrune = now
+	}
+
+	entry, ok := s.limiters[ip]
+	if !ok {
+		entry = &limiterEntry{limiter: newLimiter(s.policy)}
+		s.limiters[ip] = entry
+	}
+
+	entry.lastSeen = now
+
+	return entry.limiter
+}
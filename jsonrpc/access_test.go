@@ -0,0 +1,251 @@
+package jsonrpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// newTestJWT builds a HS256 JWT with the claims
+func newTestJWT(claims string, secret []byte) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	return header + "." + payload + "." +
+		base64.RawURLEncoding.EncodeToString(signJWT(header+"."+payload, secret))
+}
+
+func newTestRequest(headers map[string]string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:4000"
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return req
+}
+
+func TestJWT_Verify(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	claims, err := verifyJWT(newTestJWT(`{"sub":"partner","exp":1001}`, testJWTSecret), testJWTSecret, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", claims.Subject)
+
+	// a token without an expiry is accepted within the issued-at window
+	_, err = verifyJWT(newTestJWT(`{"iat":1060}`, testJWTSecret), testJWTSecret, now)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"expired", newTestJWT(`{"exp":1000}`, testJWTSecret), errExpiredJWT},
+		{"not valid yet", newTestJWT(`{"exp":2000,"nbf":1001}`, testJWTSecret), errJWTNotYetValid},
+		{"stale", newTestJWT(`{"iat":939}`, testJWTSecret), errStaleJWT},
+		{"issued in the future", newTestJWT(`{"iat":1061}`, testJWTSecret), errStaleJWT},
+		{"no expiry", newTestJWT(`{"sub":"partner"}`, testJWTSecret), errMissingJWTExpiry},
+		{"wrong secret", newTestJWT(`{}`, []byte("other")), errInvalidJWTSig},
+		{"malformed", "a.b", errMalformedJWT},
+		{
+			"unsupported algorithm",
+			base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + ".e30.",
+			errUnsupportedJWTAlg,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := verifyJWT(c.token, testJWTSecret, now)
+			assert.ErrorIs(t, err, c.err)
+		})
+	}
+}
+
+func TestAccessGuard_Authenticate(t *testing.T) {
+	guard, err := newAccessGuard(&AccessConfig{
+		RequireAuth: true,
+		JWTSecret:   testJWTSecret,
+		Clients: []*ClientConfig{
+			{Name: "partner", APIKey: "key"},
+		},
+	})
+	assert.NoError(t, err)
+
+	client, err := guard.authenticate(newTestRequest(map[string]string{apiKeyHeader: "key"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", client.name)
+
+	client, err = guard.authenticate(newTestRequest(map[string]string{
+		"Authorization": "Bearer " + newTestJWT(
+			fmt.Sprintf(`{"sub":"partner","iat":%d}`, time.Now().Unix()),
+			testJWTSecret,
+		),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", client.name)
+
+	// the subject doesn't match a client, the default policy applies
+	client, err = guard.authenticate(newTestRequest(map[string]string{
+		"Authorization": "Bearer " + newTestJWT(
+			fmt.Sprintf(`{"sub":"other","iat":%d}`, time.Now().Unix()),
+			testJWTSecret,
+		),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", client.name)
+
+	_, err = guard.authenticate(newTestRequest(map[string]string{apiKeyHeader: "wrong"}))
+	assert.ErrorIs(t, err, errInvalidAPIKey)
+
+	_, err = guard.authenticate(newTestRequest(map[string]string{"Authorization": "Basic abc"}))
+	assert.ErrorIs(t, err, errInvalidAuthToken)
+
+	_, err = guard.authenticate(newTestRequest(nil))
+	assert.ErrorIs(t, err, errAuthRequired)
+
+	// duplicate clients are rejected
+	_, err = newAccessGuard(&AccessConfig{
+		Clients: []*ClientConfig{{Name: "a", APIKey: "key"}, {Name: "b", APIKey: "key"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestAccessGuard_Methods(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	guard, err := newAccessGuard(&AccessConfig{
+		Default: ClientPolicy{Methods: []string{"web3", "eth_chainId"}},
+	})
+	assert.NoError(t, err)
+
+	guarded := guard.wrap(guard.defaultClient(newTestRequest(nil)), dispatcher)
+
+	resp, err := guarded.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_call","params":[]}`))
+	assert.NoError(t, err)
+
+	var errResp ErrorResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &errResp))
+	assert.Equal(t, -32004, errResp.Error.Code)
+
+	// the denied requests of a batch are answered in place
+	resp, err = guarded.Handle([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_getLogs","params":[]},
+		{"id":3,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}
+	]`))
+	assert.NoError(t, err)
+
+	var batch []SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &batch))
+	assert.Len(t, batch, 3)
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, []interface{}{batch[0].ID, batch[1].ID, batch[2].ID})
+	assert.Nil(t, batch[0].Error)
+	assert.Equal(t, -32004, batch[1].Error.Code)
+	assert.Nil(t, batch[2].Error)
+}
+
+func TestAccessGuard_Limits(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	guard, err := newAccessGuard(&AccessConfig{
+		Default:      ClientPolicy{RateLimit: 0.001, RateBurst: 3},
+		MaxBatchSize: 2,
+	})
+	assert.NoError(t, err)
+
+	client := guard.defaultClient(newTestRequest(nil))
+	guarded := guard.wrap(client, dispatcher)
+
+	request := func(count int) ErrorResponse {
+		t.Helper()
+
+		reqs := make([]string, count)
+		for i := range reqs {
+			reqs[i] = fmt.Sprintf(`{"id":%d,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}`, i)
+		}
+
+		body := reqs[0]
+		if count > 1 {
+			body = "[" + strings.Join(reqs, ",") + "]"
+		}
+
+		resp, err := guarded.Handle([]byte(body))
+		assert.NoError(t, err)
+
+		var errResp ErrorResponse
+		if resp[0] == '{' {
+			assert.NoError(t, json.Unmarshal(resp, &errResp))
+		}
+
+		return errResp
+	}
+
+	// the batch is too large
+	assert.Equal(t, -32005, request(3).Error.Code)
+
+	// the batch takes two tokens out of three
+	assert.Nil(t, request(2).Error)
+	assert.Nil(t, request(1).Error)
+	assert.Equal(t, -32005, request(1).Error.Code)
+
+	// the other IPs have their own bucket
+	otherReq := newTestRequest(nil)
+	otherReq.RemoteAddr = "10.0.0.2:4000"
+	assert.NotSame(t, client.limiter, guard.defaultClient(otherReq).limiter)
+	assert.Same(t, client.limiter, guard.defaultClient(newTestRequest(nil)).limiter)
+}
+
+func TestJSONRPC_HandleAccess(t *testing.T) {
+	guard, err := newAccessGuard(&AccessConfig{
+		RequireAuth: true,
+		Clients:     []*ClientConfig{{Name: "partner", APIKey: "key"}},
+		MaxBodySize: 100,
+	})
+	assert.NoError(t, err)
+
+	j := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		dispatcher: newDispatcher(hclog.NewNullLogger(), newMockStore(), 0),
+		guard:      guard,
+	}
+
+	handle := func(body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		recorder := httptest.NewRecorder()
+		j.handle(recorder, req)
+
+		return recorder
+	}
+
+	request := `{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}`
+
+	assert.Equal(t, http.StatusUnauthorized, handle(request, nil).Code)
+
+	resp := handle(request, map[string]string{apiKeyHeader: "key"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var success SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &success))
+	assert.Nil(t, success.Error)
+
+	resp = handle(request+strings.Repeat(" ", 100), map[string]string{apiKeyHeader: "key"})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+}
@@ -0,0 +1,22 @@
+package jsonrpc
+
+// debugStore provides access to the methods needed by debug endpoint
+type debugStore interface {
+	// SetHead rewinds the chain head to the block
+	SetHead(number uint64) error
+}
+
+// Debug is the debug jsonrpc endpoint. It changes the node state,
+// so it's only registered when enabled in the config
+type Debug struct {
+	store debugStore
+}
+
+// SetHead rewinds the chain head to the block, deleting the blocks above it (debug_setHead)
+func (d *Debug) SetHead(number argUint64) (interface{}, error) {
+	if err := d.store.SetHead(uint64(number)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type debugMockStore struct {
+	*mockStore
+
+	head uint64
+}
+
+func (m *debugMockStore) SetHead(number uint64) error {
+	m.head = number
+
+	return nil
+}
+
+func TestDebugEndpointSetHead(t *testing.T) {
+	store := &debugMockStore{mockStore: newMockStore(), head: 10}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+
+	req := []byte(`{
+		"method": "debug_setHead",
+		"params": ["0x5"]
+	}`)
+
+	// the debug namespace is disabled by default
+	resp, err := dispatcher.Handle(req)
+	assert.NoError(t, err)
+	assert.Error(t, expectJSONResult(resp, new(interface{})))
+	assert.Equal(t, uint64(10), store.head)
+
+	dispatcher.registerDebugEndpoint(store)
+
+	resp, err = dispatcher.Handle(req)
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, new(interface{})))
+	assert.Equal(t, uint64(5), store.head)
+}
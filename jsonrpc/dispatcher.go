@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strings"
+	"sync/atomic"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-hclog"
@@ -19,21 +22,43 @@ type serviceData struct {
 }
 
 type funcData struct {
-	inNum int
-	reqt  []reflect.Type
-	fv    reflect.Value
-	isDyn bool
+	inNum  int
+	reqt   []reflect.Type
+	fv     reflect.Value
+	isDyn  bool
+	hasCtx bool // flag indicating if the first argument is the context of the call
 }
 
 func (f *funcData) numParams() int {
+	if f.hasCtx {
+		return f.inNum - 2
+	}
+
 	return f.inNum - 1
 }
 
+// firstParam is the index of the first request param in the arguments
+func (f *funcData) firstParam() int {
+	return f.inNum - f.numParams()
+}
+
+// maxTimedOutCalls is the maximum number of timed out calls still running in the background,
+// the methods with a time limit are refused above it
+const maxTimedOutCalls = 64
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+	errTooManyTimedOutCalls = errors.New("too many timed out requests are still running")
+)
+
 type endpoints struct {
 	Eth    *Eth
 	Web3   *Web3
 	Net    *Net
 	TxPool *TxPool
+	Debug  *Debug
+	Ibft   *Ibft
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -44,6 +69,12 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 	chainID       uint64
+
+	// methodTimeouts are the execution time limits of the namespaces or methods
+	methodTimeouts map[string]time.Duration
+
+	// timedOutCalls is the number of timed out calls still running in the background
+	timedOutCalls int64
 }
 
 func newDispatcher(logger hclog.Logger, store JSONRPCStore, chainID uint64) *Dispatcher {
@@ -67,11 +98,20 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.Net = &Net{store, d.chainID}
 	d.endpoints.Web3 = &Web3{}
 	d.endpoints.TxPool = &TxPool{store}
+	d.endpoints.Ibft = &Ibft{store}
 
 	d.registerService("eth", d.endpoints.Eth)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
+	d.registerService("ibft", d.endpoints.Ibft)
+}
+
+// registerDebugEndpoint registers the debug endpoint, which is disabled by default
+func (d *Dispatcher) registerDebugEndpoint(store debugStore) {
+	d.endpoints.Debug = &Debug{store}
+
+	d.registerService("debug", d.endpoints.Debug)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -142,6 +182,17 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "newPendingTransactions" {
+		// the hashes are sent unless the full transactions are requested
+		fullTx := false
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
+	} else if subscribeMethod == "syncing" {
+		filterID = d.filterManager.NewSyncingFilter(conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -281,10 +332,10 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 
 	inputs := make([]interface{}, fd.numParams())
 
-	for i := 0; i < fd.inNum-1; i++ {
-		val := reflect.New(fd.reqt[i+1])
+	for i := 0; i < fd.numParams(); i++ {
+		val := reflect.New(fd.reqt[fd.firstParam()+i])
 		inputs[i] = val.Interface()
-		inArgs[i+1] = val.Elem()
+		inArgs[fd.firstParam()+i] = val.Elem()
 	}
 
 	if fd.numParams() > 0 {
@@ -293,10 +344,20 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		}
 	}
 
-	output := fd.fv.Call(inArgs)
+	output, callErr := d.callFn(req.Method, fd, inArgs)
+	if callErr != nil {
+		return nil, callErr
+	}
+
 	if err := getError(output[1]); err != nil {
 		d.logInternalError(req.Method, err)
 
+		// reverts keep their code and data
+		var revertErr *revertError
+		if errors.As(err, &revertErr) {
+			return nil, revertErr
+		}
+
 		return nil, NewInvalidRequestError(err.Error())
 	}
 
@@ -317,6 +378,54 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	return data, nil
 }
 
+// callFn calls the endpoint function, and stops waiting for its result
+// once the time limit of the method is reached. The context of the call is cancelled then,
+// the endpoints taking it stop early, the others run to completion in the background
+func (d *Dispatcher) callFn(method string, fd *funcData, inArgs []reflect.Value) ([]reflect.Value, Error) {
+	timeout := methodTimeout(d.methodTimeouts, method)
+	if timeout <= 0 {
+		if fd.hasCtx {
+			inArgs[1] = reflect.ValueOf(context.Background())
+		}
+
+		return fd.fv.Call(inArgs), nil
+	}
+
+	// the timed out calls which don't stop early would pile up otherwise
+	if atomic.LoadInt64(&d.timedOutCalls) >= maxTimedOutCalls {
+		return nil, NewLimitExceededError(errTooManyTimedOutCalls.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if fd.hasCtx {
+		inArgs[1] = reflect.ValueOf(ctx)
+	}
+
+	outputCh := make(chan []reflect.Value, 1)
+
+	go func() {
+		outputCh <- fd.fv.Call(inArgs)
+	}()
+
+	select {
+	case output := <-outputCh:
+		return output, nil
+	case <-ctx.Done():
+		d.logger.Warn("request timed out", "method", method, "timeout", timeout)
+
+		atomic.AddInt64(&d.timedOutCalls, 1)
+
+		go func() {
+			<-outputCh
+			atomic.AddInt64(&d.timedOutCalls, -1)
+		}()
+
+		return nil, NewTimeoutError(method, timeout)
+	}
+}
+
 func (d *Dispatcher) logInternalError(method string, err error) {
 	d.logger.Error("failed to dispatch", "method", method, "err", err)
 }
@@ -355,9 +464,13 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) {
 		if fd.inNum, fd.reqt, err = validateFunc(funcName, fd.fv, true); err != nil {
 			panic(fmt.Sprintf("jsonrpc: %s", err))
 		}
+
+		// the context isn't a request param, it's set by the dispatcher
+		fd.hasCtx = fd.inNum > 1 && fd.reqt[1] == contextType
+
 		// check if last item is a pointer
 		if fd.numParams() != 0 {
-			last := fd.reqt[fd.numParams()]
+			last := fd.reqt[fd.inNum-1]
 			if last.Kind() == reflect.Ptr {
 				fd.isDyn = true
 			}
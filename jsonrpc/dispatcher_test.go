@@ -1,12 +1,17 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -92,6 +97,79 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+
+		mockConnection := &mockWsConn{
+			msgCh: make(chan []byte, 1),
+		}
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		hash := types.BytesToHash([]byte{1})
+		go store.emitPromotedTx(&types.Transaction{Hash: hash})
+
+		assert.Equal(t, `"`+hash.String()+`"`, readSubscriptionResult(t, mockConnection.msgCh))
+	})
+
+	t.Run("clients should be able to receive \"syncing\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+
+		mockConnection := &mockWsConn{
+			msgCh: make(chan []byte, 1),
+		}
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["syncing"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		store.setSyncProgression(&progress.Progression{SyncType: progress.ChainSyncBulk})
+
+		select {
+		case <-mockConnection.msgCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"syncing\" event not received in 2 seconds")
+		}
+	})
+
+	t.Run("eth_subscribe should reject the invalid subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+
+		for _, params := range []string{
+			`["newPendingTransactions", "true"]`,
+			`["pendingTransactions"]`,
+		} {
+			resp, err := dispatcher.HandleWs([]byte(`{
+				"method": "eth_subscribe",
+				"params": `+params+`
+			}`), &mockWsConn{})
+			assert.NoError(t, err)
+
+			var res SuccessResponse
+
+			assert.NoError(t, json.Unmarshal(resp, &res))
+			assert.NotNil(t, res.Error)
+		}
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -197,6 +275,26 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) Revert() (interface{}, error) {
+	return nil, fmt.Errorf("call failed: %w", constructErrorFromRevert(&runtime.ExecutionResult{
+		ReturnValue: []byte{0x1, 0x2},
+		Err:         runtime.ErrExecutionReverted,
+	}))
+}
+
+func (m *mockService) Sleep() (interface{}, error) {
+	time.Sleep(100 * time.Millisecond)
+
+	return "done", nil
+}
+
+func (m *mockService) Wait(ctx context.Context, f BlockNumber) (interface{}, error) {
+	<-ctx.Done()
+	m.msgCh <- f
+
+	return nil, ctx.Err()
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	srv := &mockService{msgCh: make(chan interface{}, 10)}
 
@@ -265,6 +363,19 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcherRevertError(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	dispatcher.registerService("mock", &mockService{})
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"mock_revert","params":[]}`))
+	assert.NoError(t, err)
+
+	var res ErrorResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &res))
+	assert.Equal(t, &ObjectError{Code: 3, Message: "execution reverted", Data: "0x0102"}, res.Error)
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
 
@@ -288,3 +399,74 @@ func TestDispatcherBatchRequest(t *testing.T) {
 	assert.Equal(t, res[0].Error, jsonerr)
 	assert.Nil(t, res[3].Error)
 }
+
+func TestDispatcherMethodTimeout(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	dispatcher.registerService("mock", &mockService{})
+
+	request := []byte(`{"id":1,"jsonrpc":"2.0","method":"mock_sleep","params":[]}`)
+
+	// the method limit has precedence over the namespace one
+	dispatcher.methodTimeouts = map[string]time.Duration{
+		"mock":       time.Second,
+		"mock_sleep": 10 * time.Millisecond,
+	}
+
+	resp, err := dispatcher.Handle(request)
+	assert.NoError(t, err)
+
+	var errResp ErrorResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &errResp))
+	assert.Equal(t, -32002, errResp.Error.Code)
+
+	delete(dispatcher.methodTimeouts, "mock_sleep")
+
+	resp, err = dispatcher.Handle(request)
+	assert.NoError(t, err)
+
+	var res SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &res))
+	assert.Nil(t, res.Error)
+	assert.Equal(t, `"done"`, string(res.Result))
+}
+
+func TestDispatcherMethodTimeout_Cancel(t *testing.T) {
+	srv := &mockService{msgCh: make(chan interface{}, 1)}
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	dispatcher.registerService("mock", srv)
+	dispatcher.methodTimeouts = map[string]time.Duration{
+		"mock": 10 * time.Millisecond,
+	}
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"mock_wait","params":["0x1"]}`))
+	assert.NoError(t, err)
+
+	var errResp ErrorResponse
+
+	assert.NoError(t, json.Unmarshal(resp, &errResp))
+	assert.Equal(t, -32002, errResp.Error.Code)
+
+	// the context of the timed out call is cancelled, the params follow it
+	select {
+	case msg := <-srv.msgCh:
+		assert.Equal(t, BlockNumber(1), msg)
+	case <-time.After(time.Second):
+		t.Fatal("the context of the timed out call isn't cancelled")
+	}
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&dispatcher.timedOutCalls) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// the methods with a time limit are refused while too many timed out calls are running
+	atomic.StoreInt64(&dispatcher.timedOutCalls, maxTimedOutCalls)
+
+	resp, err = dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"mock_sleep","params":[]}`))
+	assert.NoError(t, err)
+
+	assert.NoError(t, json.Unmarshal(resp, &errResp))
+	assert.Equal(t, -32005, errResp.Error.Code)
+}
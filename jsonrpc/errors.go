@@ -1,8 +1,13 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/umbracle/ethgo/abi"
 )
@@ -15,6 +20,13 @@ type Error interface {
 	Error() string
 	ErrorCode() int
 }
+
+// DataError is an Error carrying additional data for the client
+type DataError interface {
+	Error
+	ErrorData() interface{}
+}
+
 type invalidParamsError struct {
 	err string
 }
@@ -75,6 +87,70 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32001
+}
+
+type timeoutError struct {
+	err string
+}
+
+func (e *timeoutError) Error() string {
+	return e.err
+}
+
+func (e *timeoutError) ErrorCode() int {
+	return -32002
+}
+
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32004
+}
+
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
+func NewUnauthorizedError(msg string) *unauthorizedError {
+	return &unauthorizedError{msg}
+}
+
+func NewTimeoutError(method string, timeout time.Duration) *timeoutError {
+	return &timeoutError{fmt.Sprintf("the method %s timed out after %s", method, timeout)}
+}
+
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
+
+func NewLimitExceededError(msg string) *limitExceededError {
+	return &limitExceededError{msg}
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -93,11 +169,77 @@ func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
+// revertError is an EVM revert, it is returned to the client
+// with the raw return data so custom errors can be decoded
+type revertError struct {
+	reason string
+	data   []byte
+}
+
+func (e *revertError) Error() string {
+	if e.reason == "" {
+		return "execution reverted"
+	}
+
+	return fmt.Sprintf("execution reverted: %s", e.reason)
+}
+
+// ErrorCode returns the code of an execution error
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded return data of the revert
+func (e *revertError) ErrorData() interface{} {
+	return hex.EncodeToHex(e.data)
+}
+
+func (e *revertError) Unwrap() error {
+	return runtime.ErrExecutionReverted
+}
+
 func constructErrorFromRevert(result *runtime.ExecutionResult) error {
-	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
-	if unpackErr != nil {
-		return result.Err
+	return &revertError{
+		reason: unpackRevertReason(result.ReturnValue),
+		data:   result.ReturnValue,
+	}
+}
+
+// panicSelector is the selector of the Panic(uint256) error
+var panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+// panicReasons are the descriptions of the Panic(uint256) codes emitted by solidity
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// unpackRevertReason returns a readable reason for the revert data
+// of an Error(string) or a Panic(uint256), or an empty string
+// for anything else, like custom errors
+func unpackRevertReason(data []byte) string {
+	if reason, err := abi.UnpackRevertError(data); err == nil {
+		return reason
+	}
+
+	if len(data) != len(panicSelector)+32 || !bytes.Equal(data[:len(panicSelector)], panicSelector) {
+		return ""
+	}
+
+	code := new(big.Int).SetBytes(data[len(panicSelector):])
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
 	}
 
-	return fmt.Errorf("%w: %s", result.Err, revertErrMsg)
+	return fmt.Sprintf("unknown panic code: 0x%x", code)
 }
@@ -0,0 +1,73 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+func TestConstructErrorFromRevert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		returnValue     string
+		expectedMessage string
+	}{
+		{
+			name: "Error(string)",
+			returnValue: "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"000000000000000000000000000000000000000000000000000000000000000d" +
+				"72657665727420726561736f6e00000000000000000000000000000000000000",
+			expectedMessage: "execution reverted: revert reason",
+		},
+		{
+			name:            "Panic(uint256) with known code",
+			returnValue:     "0x4e487b710000000000000000000000000000000000000000000000000000000000000011",
+			expectedMessage: "execution reverted: arithmetic underflow or overflow",
+		},
+		{
+			name:            "Panic(uint256) with unknown code",
+			returnValue:     "0x4e487b7100000000000000000000000000000000000000000000000000000000000000ff",
+			expectedMessage: "execution reverted: unknown panic code: 0xff",
+		},
+		{
+			name:            "custom error",
+			returnValue:     "0xcafebabe0000000000000000000000000000000000000000000000000000000000000001",
+			expectedMessage: "execution reverted",
+		},
+		{
+			name:            "empty return data",
+			returnValue:     "0x",
+			expectedMessage: "execution reverted",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			returnValue, err := hex.DecodeHex(tt.returnValue)
+			assert.NoError(t, err)
+
+			revertErr := constructErrorFromRevert(&runtime.ExecutionResult{
+				ReturnValue: returnValue,
+				Err:         runtime.ErrExecutionReverted,
+			})
+
+			assert.ErrorIs(t, revertErr, runtime.ErrExecutionReverted)
+			assert.EqualError(t, revertErr, tt.expectedMessage)
+
+			dataErr, ok := revertErr.(DataError)
+			if assert.True(t, ok) {
+				assert.Equal(t, 3, dataErr.ErrorCode())
+				assert.Equal(t, tt.returnValue, dataErr.ErrorData())
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -51,6 +54,29 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_Finalized(t *testing.T) {
+	store := &mockBlockStore{confirmations: 3}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	for _, number := range []BlockNumber{SafeBlockNumber, FinalizedBlockNumber} {
+		res, err := eth.GetBlockByNumber(number, false)
+		assert.NoError(t, err)
+
+		block, ok := res.(*block)
+		assert.True(t, ok)
+		assert.Equal(t, argUint64(6), block.Number)
+	}
+
+	// the tags resolve through the state methods too
+	header, err := eth.getBlockHeader(FinalizedBlockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), header.Number)
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -213,6 +239,30 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Equal(t, txn.Hash, response.TxHash)
 		assert.Equal(t, block.Hash(), response.BlockHash)
 		assert.NotNil(t, response.Logs)
+		assert.Nil(t, response.RevertReason)
+	})
+
+	t.Run("returns the stored revert reason", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+		txn := newTestTransaction(uint64(0), addr0)
+		block.Transactions = append(block.Transactions, txn)
+		rec := &types.Receipt{
+			RevertReason: []byte{0x1, 0x2},
+		}
+		rec.SetStatus(types.ReceiptFailed)
+		store.receipts[hash4] = []*types.Receipt{rec}
+
+		res, err := eth.GetTransactionReceipt(txn.Hash)
+		assert.NoError(t, err)
+
+		// nolint:forcetypeassert
+		response := res.(*receipt)
+		assert.Equal(t, argBytesPtr([]byte{0x1, 0x2}), response.RevertReason)
 	})
 }
 
@@ -281,7 +331,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -305,11 +355,59 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
+
+	t.Run("passes the state and block overrides to the execution", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+		contractCall := &txnArgs{
+			From:     &addr0,
+			To:       &addr1,
+			GasPrice: argBytesPtr([]byte{0x64}),
+			Value:    argBytesPtr([]byte{0x64}),
+			Nonce:    argUintPtr(0),
+		}
+
+		var (
+			overrides stateOverride
+			block     *blockOverride
+		)
+
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"`+addr1.String()+`": {
+				"nonce": "0x5",
+				"balance": "0x100",
+				"code": "0x6001",
+				"stateDiff": {"`+hash1.String()+`": "`+hash2.String()+`"}
+			}
+		}`), &overrides))
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"number": "0x200",
+			"gasLimit": "0x1000",
+			"coinbase": "`+addr0.String()+`"
+		}`), &block))
+
+		_, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, overrides, block)
+		assert.NoError(t, err)
+
+		account := store.stateOverride[addr1]
+		assert.Equal(t, uint64(5), *account.Nonce)
+		assert.Equal(t, big.NewInt(0x100), account.Balance)
+		assert.Equal(t, []byte{0x60, 0x01}, account.Code)
+		assert.Equal(t, map[types.Hash]types.Hash{hash1: hash2}, account.StateDiff)
+
+		assert.Equal(t, uint64(0x200), *store.blockOverride.Number)
+		assert.Equal(t, uint64(0x1000), *store.blockOverride.GasLimit)
+		assert.Equal(t, addr0, *store.blockOverride.Coinbase)
+		assert.Nil(t, store.blockOverride.Timestamp)
+	})
 }
 
 type mockBlockStore struct {
@@ -321,6 +419,9 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+	stateOverride   types.StateOverride
+	blockOverride   *types.BlockOverride
+	confirmations   uint64
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -460,6 +561,10 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
+func (m *mockBlockStore) GetFinalizedNumber() uint64 {
+	return m.Header().Number - m.confirmations
+}
+
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
 		for _, txn := range block.Transactions {
@@ -499,7 +604,16 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) ApplyTxn(
+	_ context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+	stateOverride types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*runtime.ExecutionResult, error) {
+	m.stateOverride = stateOverride
+	m.blockOverride = blockOverride
+
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
 
@@ -507,6 +621,10 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxPoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return nil, func() {}
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
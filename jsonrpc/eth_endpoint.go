@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -29,7 +30,7 @@ type ethTxPoolStore interface {
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*state.Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
-	GetForksInTime(blockNumber uint64) chain.ForksInTime
+	GetForksInTime(blockNumber, timestamp uint64) chain.ForksInTime
 	GetCode(hash types.Hash) ([]byte, error)
 }
 
@@ -55,11 +56,23 @@ type ethBlockchainStore interface {
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
-	// ApplyTxn applies a transaction object to the blockchain
-	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// ApplyTxn applies a transaction object to the blockchain,
+	// on top of the overridden state and block context, if any.
+	// The execution is interrupted once the context is done
+	ApplyTxn(
+		ctx context.Context,
+		header *types.Header,
+		txn *types.Transaction,
+		stateOverride types.StateOverride,
+		blockOverride *types.BlockOverride,
+	) (*runtime.ExecutionResult, error)
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
+
+	// GetFinalizedNumber returns the number of the latest finalized block,
+	// the safe and finalized block tags resolve to it
+	GetFinalizedNumber() uint64
 }
 
 // ethStore provides access to the methods needed by eth endpoint
@@ -114,12 +127,7 @@ func (e *Eth) getHeaderFromBlockNumberOrHash(bnh *BlockNumberOrHash) (*types.Hea
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: hex.EncodeUint64(syncProgression.StartingBlock),
-			CurrentBlock:  hex.EncodeUint64(syncProgression.CurrentBlock),
-			HighestBlock:  hex.EncodeUint64(syncProgression.HighestBlock),
-		}, nil
+		return toProgression(syncProgression), nil
 	}
 
 	// Node is not bulk syncing
@@ -134,6 +142,9 @@ func GetNumericBlockNumber(number BlockNumber, e *Eth) (uint64, error) {
 	case EarliestBlockNumber:
 		return 0, nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		return e.store.GetFinalizedNumber(), nil
+
 	case PendingBlockNumber:
 		return 0, fmt.Errorf("fetching the pending header is not supported")
 
@@ -376,6 +387,10 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		Logs:              logs,
 	}
 
+	if len(raw.RevertReason) != 0 {
+		res.RevertReason = argBytesPtr(raw.RevertReason)
+	}
+
 	return res, nil
 }
 
@@ -434,8 +449,15 @@ func (e *Eth) GasPrice() (interface{}, error) {
 	return avgGasPrice, nil
 }
 
-// Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+// Call executes a smart contract call using the transaction object data.
+// The call can be simulated on top of overridden accounts and block context
+func (e *Eth) Call(
+	ctx context.Context,
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	stateOverrides stateOverride,
+	blockOverrides *blockOverride,
+) (interface{}, error) {
 	var (
 		header *types.Header
 		err    error
@@ -456,13 +478,16 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+
+	blockOverride := blockOverrides.toTypes()
+
 	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 	if transaction.Gas == 0 {
-		transaction.Gas = header.GasLimit
+		transaction.Gas = blockOverride.Apply(header).GasLimit
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction)
+	result, err := e.store.ApplyTxn(ctx, header, transaction, stateOverrides.toTypes(), blockOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -479,8 +504,15 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+// EstimateGas estimates the gas needed to execute a transaction.
+// The estimation can run on top of overridden accounts and block context
+func (e *Eth) EstimateGas(
+	ctx context.Context,
+	arg *txnArgs,
+	rawNum *BlockNumber,
+	stateOverrides stateOverride,
+	blockOverrides *blockOverride,
+) (interface{}, error) {
 	transaction, err := e.decodeTxn(arg)
 	if err != nil {
 		return nil, err
@@ -497,7 +529,13 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
-	forksInTime := e.store.GetForksInTime(uint64(number))
+	stateOverride := stateOverrides.toTypes()
+	blockOverride := blockOverrides.toTypes()
+
+	// the estimation runs in the context of the overridden block
+	overriddenHeader := blockOverride.Apply(header)
+
+	forksInTime := e.store.GetForksInTime(overriddenHeader.Number, overriddenHeader.Timestamp)
 
 	var standardGas uint64
 	if transaction.IsContractCreation() && forksInTime.Homestead {
@@ -516,7 +554,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = transaction.Gas
 	} else {
 		// If not, use the referenced block number
-		highEnd = header.GasLimit
+		highEnd = overriddenHeader.GasLimit
 	}
 
 	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
@@ -542,6 +580,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		// The overridden balance takes precedence
+		if override, ok := stateOverride[transaction.From]; ok && override.Balance != nil {
+			accountBalance = override.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 
 		if transaction.Value != nil {
@@ -596,7 +639,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		txn := transaction.Copy()
 		txn.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, txn)
+		result, applyErr := e.store.ApplyTxn(ctx, header, txn, stateOverride, blockOverride)
 
 		if applyErr != nil {
 			// Check the application error.
@@ -634,6 +677,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 	// Start the binary search for the lowest possible gas price
 	for lowEnd < highEnd {
+		// the caller might have given up on the estimation, e.g. on timeout
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		mid := (lowEnd + highEnd) / 2
 
 		failed, testErr := testTransaction(mid, true)
@@ -668,18 +716,18 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 }
 
 // GetFilterLogs returns an array of logs for the specified filter
-func (e *Eth) GetFilterLogs(id string) (interface{}, error) {
+func (e *Eth) GetFilterLogs(ctx context.Context, id string) (interface{}, error) {
 	logFilter, err := e.filterManager.GetLogFilterFromID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return e.filterManager.GetLogsForQuery(logFilter.query)
+	return e.filterManager.GetLogsForQuery(ctx, logFilter.query)
 }
 
 // GetLogs returns an array of logs matching the filter options
-func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
-	return e.filterManager.GetLogsForQuery(query)
+func (e *Eth) GetLogs(ctx context.Context, query *LogQuery) (interface{}, error) {
+	return e.filterManager.GetLogsForQuery(ctx, query)
 }
 
 // GetBalance returns the account's balance at the referenced block.
@@ -826,6 +874,16 @@ func (e *Eth) getBlockHeader(number BlockNumber) (*types.Header, error) {
 
 		return header, nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		number := e.store.GetFinalizedNumber()
+
+		header, ok := e.store.GetHeaderByNumber(number)
+		if !ok {
+			return nil, fmt.Errorf("error fetching block number %d header", number)
+		}
+
+		return header, nil
+
 	case PendingBlockNumber:
 		return nil, fmt.Errorf("fetching the pending header is not supported")
 
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -683,7 +684,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(context.Background(), testCase.transaction, nil, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -732,8 +733,11 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 
 	// Run the estimation
 	estimate, estimateErr := ethEndpoint.EstimateGas(
+		context.Background(),
 		constructMockTx(nil, nil),
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -758,8 +762,11 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 
 	// Run the estimation
 	estimate, estimateErr := ethEndpoint.EstimateGas(
+		context.Background(),
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -768,6 +775,48 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_EstimateGas_Overrides(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// Account doesn't have any balance
+	store.account.account.Balance = big.NewInt(0)
+
+	// The transaction has a value > 0
+	mockTx := constructMockTx(nil, nil)
+	mockTx.Value = argBytesPtr([]byte{0x1})
+
+	gasLimit := argUint64(state.TxGas * 2)
+
+	// Only values up to the overridden gas limit are tried
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, error) {
+		assert.LessOrEqual(t, txn.Gas, uint64(gasLimit))
+
+		if txn.Gas < state.TxGas {
+			return &runtime.ExecutionResult{}, state.ErrNotEnoughIntrinsicGas
+		}
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	// Run the estimation with the overridden balance and gas limit
+	estimate, estimateErr := ethEndpoint.EstimateGas(
+		context.Background(),
+		mockTx,
+		nil,
+		stateOverride{
+			addr0: overrideAccount{Balance: argBigPtr(big.NewInt(1000))},
+		},
+		&blockOverride{GasLimit: &gasLimit},
+	)
+
+	assert.NoError(t, estimateErr)
+	assert.Equal(t, fmt.Sprintf("0x%x", state.TxGas), estimate)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
@@ -831,11 +880,17 @@ func (m *mockSpecialStore) GetCode(hash types.Hash) ([]byte, error) {
 	return nil, fmt.Errorf("code not found")
 }
 
-func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
+func (m *mockSpecialStore) GetForksInTime(blockNumber, timestamp uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(
+	_ context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+	stateOverride types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*runtime.ExecutionResult, error) {
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
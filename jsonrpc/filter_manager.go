@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"container/heap"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -28,9 +31,16 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// defaultSyncStatusInterval is the interval to check the changes of the sync status
+var defaultSyncStatusInterval = 1 * time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
+
+	// maxPendingTxUpdates is the maximum number of promoted transactions buffered by a filter,
+	// the oldest ones are dropped when the client doesn't keep up
+	maxPendingTxUpdates = 4096
 )
 
 // filter is an interface that BlockFilter and LogFilter implement
@@ -61,15 +71,28 @@ type filterBase struct {
 
 	// websocket connection
 	ws wsConn
+
+	// flushCh notifies the writer of the websocket filter of new updates
+	flushCh chan struct{}
+
+	// doneCh stops the writer of the websocket filter once the filter is removed
+	doneCh chan struct{}
 }
 
 // newFilterBase initializes filterBase with unique ID
 func newFilterBase(ws wsConn) filterBase {
-	return filterBase{
+	base := filterBase{
 		id:        uuid.New().String(),
 		ws:        ws,
 		heapIndex: NoIndexInHeap,
 	}
+
+	if ws != nil {
+		base.flushCh = make(chan struct{}, 1)
+		base.doneCh = make(chan struct{})
+	}
+
+	return base
 }
 
 // getFilterBase returns its own reference so that child struct can return base
@@ -91,6 +114,15 @@ const ethSubscriptionTemplate = `{
 	}
 }`
 
+// notifyFlush notifies the writer of the websocket filter of new updates, without blocking.
+// The updates are kept by the filter until they're written
+func (f *filterBase) notifyFlush() {
+	select {
+	case f.flushCh <- struct{}{}:
+	default:
+	}
+}
+
 // writeMessageToWs sends given message to websocket stream
 func (f *filterBase) writeMessageToWs(msg string) error {
 	res := fmt.Sprintf(ethSubscriptionTemplate, f.id, msg)
@@ -110,11 +142,11 @@ type blockFilter struct {
 
 // takeBlockUpdates advances blocks from head to latest and returns header array
 func (f *blockFilter) takeBlockUpdates() []*types.Header {
-	updates, newHead := f.block.getUpdates()
-
 	f.Lock()
+	defer f.Unlock()
+
+	updates, newHead := f.block.getUpdates()
 	f.block = newHead
-	f.Unlock()
 
 	return updates
 }
@@ -206,6 +238,126 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions promoted in the TxPool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+	fullTx bool
+	txs    []interface{}
+}
+
+// appendTx appends the hash of the promoted transaction, or the transaction itself if fullTx is set
+func (f *pendingTxFilter) appendTx(hash types.Hash, txn *types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.fullTx {
+		f.txs = append(f.txs, hash)
+	} else if txn != nil {
+		// the transaction may have left the pool before it's processed
+		f.txs = append(f.txs, toPendingTransaction(txn))
+	}
+
+	if overflow := len(f.txs) - maxPendingTxUpdates; overflow > 0 {
+		f.txs = f.txs[overflow:]
+	}
+}
+
+// takeTxUpdates returns all saved transactions in filter and set new slice
+func (f *pendingTxFilter) takeTxUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []interface{}{}
+
+	return txs
+}
+
+// getUpdates returns stored transactions in string
+func (f *pendingTxFilter) getUpdates() (string, error) {
+	res, err := json.Marshal(f.takeTxUpdates())
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
+// sendUpdates writes stored transactions to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	for _, txn := range f.takeTxUpdates() {
+		res, err := json.Marshal(txn)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncingResult is the update of the syncing filter while the node is syncing
+type syncingResult struct {
+	Syncing bool         `json:"syncing"`
+	Status  *progression `json:"status"`
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	filterBase
+	sync.Mutex
+	updates []interface{}
+}
+
+// appendStatus appends new sync status, false if the node is not syncing
+func (f *syncingFilter) appendStatus(status interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.updates = append(f.updates, status)
+}
+
+// takeStatusUpdates returns all saved sync statuses in filter and set new slice
+func (f *syncingFilter) takeStatusUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.updates
+	f.updates = []interface{}{}
+
+	return updates
+}
+
+// getUpdates returns stored sync statuses in string
+func (f *syncingFilter) getUpdates() (string, error) {
+	res, err := json.Marshal(f.takeStatusUpdates())
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
+// sendUpdates writes stored sync statuses to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	for _, status := range f.takeStatusUpdates() {
+		res, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -222,18 +374,40 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetFinalizedNumber returns the number of the latest finalized block
+	GetFinalizedNumber() uint64
+
+	// SubscribeTxPoolEvents subscribes for the given TxPool events
+	SubscribeTxPoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func())
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
 type FilterManager struct {
 	logger hclog.Logger
 
-	timeout time.Duration
+	timeout            time.Duration
+	syncStatusInterval time.Duration
 
 	store        filterManagerStore
 	subscription blockchain.Subscription
 	blockStream  *blockStream
 
+	// txPoolEventCh receives the transactions promoted in the TxPool, the TxPool is only
+	// subscribed to while there are PendingTxFilters
+	txPoolEventCh            <-chan *proto.TxPoolEvent
+	cancelTxPoolSubscription func()
+	pendingTxFilterCount     int
+
+	// syncStatus is the last sync progression seen, nil if the node is not syncing
+	syncStatus *progress.Progression
+
 	lock     sync.RWMutex
 	filters  map[string]filter
 	timeouts timeHeapImpl
@@ -244,15 +418,16 @@ type FilterManager struct {
 
 func NewFilterManager(logger hclog.Logger, store filterManagerStore) *FilterManager {
 	m := &FilterManager{
-		logger:      logger.Named("filter"),
-		timeout:     defaultTimeout,
-		store:       store,
-		blockStream: &blockStream{},
-		lock:        sync.RWMutex{},
-		filters:     make(map[string]filter),
-		timeouts:    timeHeapImpl{},
-		updateCh:    make(chan struct{}),
-		closeCh:     make(chan struct{}),
+		logger:             logger.Named("filter"),
+		timeout:            defaultTimeout,
+		syncStatusInterval: defaultSyncStatusInterval,
+		store:              store,
+		blockStream:        &blockStream{},
+		lock:               sync.RWMutex{},
+		filters:            make(map[string]filter),
+		timeouts:           timeHeapImpl{},
+		updateCh:           make(chan struct{}, 1),
+		closeCh:            make(chan struct{}),
 	}
 
 	// start blockstream with the current header
@@ -282,6 +457,9 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	syncStatusTicker := time.NewTicker(f.syncStatusInterval)
+	defer syncStatusTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterBase := f.nextTimeoutFilter()
@@ -291,6 +469,9 @@ func (f *FilterManager) Run() {
 			timeoutCh = time.After(time.Until(filterBase.expiredAt))
 		}
 
+		// watch the promoted transactions while there are PendingTxFilters
+		txPoolEventCh := f.getTxPoolEventCh()
+
 		select {
 		case evnt := <-watchCh:
 			// new blockchain event
@@ -298,6 +479,25 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case evnt, ok := <-txPoolEventCh:
+			if !ok {
+				// the subscription is canceled, or the TxPool is closed
+				f.closeTxPoolEventCh(txPoolEventCh)
+
+				continue
+			}
+
+			// new promoted transaction
+			if err := f.dispatchTxPoolEvent(evnt); err != nil {
+				f.logger.Error("failed to dispatch txpool event", "err", err)
+			}
+
+		case <-syncStatusTicker.C:
+			// check for the changes of the sync status
+			if err := f.dispatchSyncStatus(); err != nil {
+				f.logger.Error("failed to dispatch sync status", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -317,9 +517,53 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.lock.Lock()
+	f.unsubscribeTxPool()
+	f.lock.Unlock()
+
 	close(f.closeCh)
 }
 
+// getTxPoolEventCh returns the channel of the promoted transactions, nil if the TxPool isn't subscribed to
+func (f *FilterManager) getTxPoolEventCh() <-chan *proto.TxPoolEvent {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.txPoolEventCh
+}
+
+// closeTxPoolEventCh stops watching the channel of the promoted transactions once it's closed
+func (f *FilterManager) closeTxPoolEventCh(ch <-chan *proto.TxPoolEvent) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.txPoolEventCh == ch {
+		f.txPoolEventCh = nil
+	}
+}
+
+// subscribeTxPool subscribes to the promoted transactions on the first PendingTxFilter,
+// unsafe against race condition
+func (f *FilterManager) subscribeTxPool() {
+	f.pendingTxFilterCount++
+	if f.pendingTxFilterCount > 1 {
+		return
+	}
+
+	f.txPoolEventCh, f.cancelTxPoolSubscription = f.store.SubscribeTxPoolEvents(proto.EventType_PROMOTED)
+	f.emitSignalToUpdateCh()
+}
+
+// unsubscribeTxPool cancels the subscription to the promoted transactions,
+// unsafe against race condition
+func (f *FilterManager) unsubscribeTxPool() {
+	if f.cancelTxPoolSubscription != nil {
+		f.cancelTxPoolSubscription()
+	}
+
+	f.txPoolEventCh, f.cancelTxPoolSubscription = nil, nil
+}
+
 // NewBlockFilter adds new BlockFilter
 func (f *FilterManager) NewBlockFilter(ws wsConn) string {
 	filter := &blockFilter{
@@ -340,6 +584,25 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.lock.RLock()
@@ -378,7 +641,7 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 	return logs, nil
 }
 
-func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
+func (f *FilterManager) getLogsFromBlocks(ctx context.Context, query *LogQuery) ([]*Log, error) {
 	latestBlockNumber := f.store.Header().Number
 
 	resolveNum := func(num BlockNumber) (uint64, error) {
@@ -389,6 +652,8 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 			num = 0
 		case LatestBlockNumber:
 			return latestBlockNumber, nil
+		case SafeBlockNumber, FinalizedBlockNumber:
+			return f.store.GetFinalizedNumber(), nil
 		}
 
 		return uint64(num), nil
@@ -417,6 +682,11 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 	logs := make([]*Log, 0)
 
 	for i := from; i <= to; i++ {
+		// the caller might have given up on a long range, e.g. on timeout
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
@@ -438,8 +708,9 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 	return logs, nil
 }
 
-// GetLogsForQuery return array of logs for given query
-func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
+// GetLogsForQuery return array of logs for given query,
+// the lookup of a range of blocks stops once the context is done
+func (f *FilterManager) GetLogsForQuery(ctx context.Context, query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
 		//	BlockHash is set -> fetch logs from this block only
 		block, ok := f.store.GetBlockByHash(*query.BlockHash, true)
@@ -456,7 +727,7 @@ func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	}
 
 	//	gets logs from a range of blocks
-	return f.getLogsFromBlocks(query)
+	return f.getLogsFromBlocks(ctx, query)
 }
 
 //GetLogFilterFromID return log filter for given filterID
@@ -523,6 +794,18 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 		f.emitSignalToUpdateCh()
 	}
 
+	if filter.isWS() {
+		close(filter.getFilterBase().doneCh)
+	}
+
+	// cancel the subscription to the promoted transactions with the last PendingTxFilter
+	if _, ok := filter.(*pendingTxFilter); ok {
+		f.pendingTxFilterCount--
+		if f.pendingTxFilterCount == 0 {
+			f.unsubscribeTxPool()
+		}
+	}
+
 	return true
 }
 
@@ -540,11 +823,46 @@ func (f *FilterManager) addFilter(filter filter) string {
 		base.expiredAt = time.Now().Add(f.timeout)
 		f.timeouts.addFilter(base)
 		f.emitSignalToUpdateCh()
+	} else {
+		go f.runWsWriter(filter)
+	}
+
+	if _, ok := filter.(*pendingTxFilter); ok {
+		f.subscribeTxPool()
 	}
 
 	return base.id
 }
 
+// runWsWriter writes the updates of the filter to its web socket stream. The updates are written
+// apart from the event loop, so a slow connection doesn't hold up the events of the other filters.
+// runWsWriter removes the filter if it notices the connection is closed
+func (f *FilterManager) runWsWriter(filter filter) {
+	base := filter.getFilterBase()
+
+	for {
+		select {
+		case <-base.flushCh:
+		case <-base.doneCh:
+			return
+		case <-f.closeCh:
+			return
+		}
+
+		if flushErr := filter.sendUpdates(); flushErr != nil {
+			// remove the filter if the connection is closed
+			if errors.Is(flushErr, websocket.ErrCloseSent) {
+				f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", base.id))
+				f.Uninstall(base.id)
+
+				return
+			}
+
+			f.logger.Error(fmt.Sprintf("Unable to process flush, %v", flushErr))
+		}
+	}
+}
+
 func (f *FilterManager) emitSignalToUpdateCh() {
 	select {
 	// notify worker of new filter with timeout
@@ -577,10 +895,69 @@ func (f *FilterManager) dispatchEvent(evnt *blockchain.Event) error {
 	}
 
 	// send data to web socket stream
-	if err := f.flushWsFilters(); err != nil {
-		return err
+	f.flushWsFilters()
+
+	return nil
+}
+
+// dispatchTxPoolEvent is a event handler for new promoted transaction event
+func (f *FilterManager) dispatchTxPoolEvent(evnt *proto.TxPoolEvent) error {
+	filters := f.getPendingTxFilters()
+	if len(filters) == 0 {
+		return nil
+	}
+
+	hash := types.StringToHash(evnt.TxHash)
+
+	// the full transaction is nil if it has already left the pool
+	txn, _ := f.store.GetPendingTx(hash)
+
+	for _, filter := range filters {
+		filter.appendTx(hash, txn)
+	}
+
+	f.flushWsFilters()
+
+	return nil
+}
+
+// dispatchSyncStatus makes each SyncingFilter append the sync status if it has changed
+func (f *FilterManager) dispatchSyncStatus() error {
+	var status *progress.Progression
+
+	// copy the progression as it's updated in place by the syncer
+	if syncProgression := f.store.GetSyncProgression(); syncProgression != nil {
+		current := *syncProgression
+		status = &current
+	}
+
+	if status == f.syncStatus || (status != nil && f.syncStatus != nil && *status == *f.syncStatus) {
+		return nil
+	}
+
+	f.syncStatus = status
+
+	filters := f.getSyncingFilters()
+	if len(filters) == 0 {
+		return nil
 	}
 
+	var update interface{} = false
+
+	if status != nil {
+		current := toProgression(status)
+		update = &syncingResult{
+			Syncing: true,
+			Status:  &current,
+		}
+	}
+
+	for _, filter := range filters {
+		filter.appendStatus(update)
+	}
+
+	f.flushWsFilters()
+
 	return nil
 }
 
@@ -596,6 +973,13 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) error {
 
 	// process old chain to include old logs marked removed for LogFilter
 	for _, header := range evnt.OldChain {
+		// the blocks deleted by a rewind of the chain are carried by the event
+		if removed, ok := evnt.GetRemovedBlock(header.Hash); ok {
+			f.appendLogsOfBlock(removed.Block, removed.Receipts, true)
+
+			continue
+		}
+
 		if processErr := f.appendLogsToFilters(header, true); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
@@ -631,10 +1015,26 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header, removed bool)
 		return nil
 	}
 
+	f.appendLogsOfBlock(block, receipts, removed)
+
+	return nil
+}
+
+// appendLogsOfBlock makes each LogFilters append the logs of the block receipts
+func (f *FilterManager) appendLogsOfBlock(block *types.Block, receipts []*types.Receipt, removed bool) {
+	// Get logFilters from filters
+	logFilters := f.getLogFilters()
+	if len(logFilters) == 0 {
+		return
+	}
+
+	header := block.Header
+
 	for indx, receipt := range receipts {
-		if receipt.TxHash == types.ZeroHash {
+		txHash := receipt.TxHash
+		if txHash == types.ZeroHash && indx < len(block.Transactions) {
 			// Extract tx Hash
-			receipt.TxHash = block.Transactions[indx].Hash
+			txHash = block.Transactions[indx].Hash
 		}
 		// check the logs with the filters
 		for _, log := range receipt.Logs {
@@ -644,7 +1044,7 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header, removed bool)
 				Data:        argBytes(log.Data),
 				BlockNumber: argUint64(header.Number),
 				BlockHash:   header.Hash,
-				TxHash:      receipt.TxHash,
+				TxHash:      txHash,
 				TxIndex:     argUint64(indx),
 				Removed:     removed,
 			}
@@ -656,68 +1056,66 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header, removed bool)
 			}
 		}
 	}
-
-	return nil
 }
 
-// flushWsFilters make each filters with web socket connection write the updates to web socket stream
-// flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
-func (f *FilterManager) flushWsFilters() error {
-	closedFilterIDs := make([]string, 0)
-
+// flushWsFilters notifies the writers of the filters with web socket connection of the updates
+func (f *FilterManager) flushWsFilters() {
 	f.lock.RLock()
+	defer f.lock.RUnlock()
 
-	for id, filter := range f.filters {
-		if !filter.isWS() {
-			continue
+	for _, filter := range f.filters {
+		if filter.isWS() {
+			filter.getFilterBase().notifyFlush()
 		}
+	}
+}
 
-		if flushErr := filter.sendUpdates(); flushErr != nil {
-			// mark as closed if the connection is closed
-			if errors.Is(flushErr, websocket.ErrCloseSent) {
-				closedFilterIDs = append(closedFilterIDs, id)
-
-				f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", id))
+// getLogFilters returns logFilters
+func (f *FilterManager) getLogFilters() []*logFilter {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
 
-				continue
-			}
+	logFilters := []*logFilter{}
 
-			f.logger.Error(fmt.Sprintf("Unable to process flush, %v", flushErr))
+	for _, f := range f.filters {
+		if logFilter, ok := f.(*logFilter); ok {
+			logFilters = append(logFilters, logFilter)
 		}
 	}
 
-	f.lock.RUnlock()
+	return logFilters
+}
 
-	// remove filters with closed web socket connections from FilterManager
-	if len(closedFilterIDs) > 0 {
-		f.lock.Lock()
+// getPendingTxFilters returns pendingTxFilters
+func (f *FilterManager) getPendingTxFilters() []*pendingTxFilter {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
 
-		for _, id := range closedFilterIDs {
-			f.removeFilterByID(id)
-		}
+	pendingTxFilters := []*pendingTxFilter{}
 
-		f.lock.Unlock()
-		f.emitSignalToUpdateCh()
-		f.logger.Info(fmt.Sprintf("Removed %d filters due to closed connections", len(closedFilterIDs)))
+	for _, f := range f.filters {
+		if pendingTxFilter, ok := f.(*pendingTxFilter); ok {
+			pendingTxFilters = append(pendingTxFilters, pendingTxFilter)
+		}
 	}
 
-	return nil
+	return pendingTxFilters
 }
 
-// getLogFilters returns logFilters
-func (f *FilterManager) getLogFilters() []*logFilter {
+// getSyncingFilters returns syncingFilters
+func (f *FilterManager) getSyncingFilters() []*syncingFilter {
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 
-	logFilters := []*logFilter{}
+	syncingFilters := []*syncingFilter{}
 
 	for _, f := range f.filters {
-		if logFilter, ok := f.(*logFilter); ok {
-			logFilters = append(logFilters, logFilter)
+		if syncingFilter, ok := f.(*syncingFilter); ok {
+			syncingFilters = append(syncingFilters, syncingFilter)
 		}
 	}
 
-	return logFilters
+	return syncingFilters
 }
 
 type timeHeapImpl []*filterBase
@@ -789,7 +1187,7 @@ func (b *blockStream) push(header *types.Header) {
 	}
 
 	if b.head != nil {
-		b.head.next = newHead
+		b.head.setNext(newHead)
 	}
 
 	b.head = newHead
@@ -797,7 +1195,24 @@ func (b *blockStream) push(header *types.Header) {
 
 type headElem struct {
 	header *types.Header
-	next   *headElem
+
+	// next is read by the filters while the stream is pushed
+	nextLock sync.RWMutex
+	next     *headElem
+}
+
+func (h *headElem) getNext() *headElem {
+	h.nextLock.RLock()
+	defer h.nextLock.RUnlock()
+
+	return h.next
+}
+
+func (h *headElem) setNext(next *headElem) {
+	h.nextLock.Lock()
+	defer h.nextLock.Unlock()
+
+	h.next = next
 }
 
 func (h *headElem) getUpdates() ([]*types.Header, *headElem) {
@@ -806,11 +1221,12 @@ func (h *headElem) getUpdates() ([]*types.Header, *headElem) {
 	cur := h
 
 	for {
-		if cur.next == nil {
+		next := cur.getNext()
+		if next == nil {
 			break
 		}
 
-		cur = cur.next
+		cur = next
 		res = append(res, cur.header)
 	}
 
@@ -1,12 +1,17 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -119,7 +124,7 @@ func Test_GetLogsForQuery(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			foundLogs, logError := f.GetLogsForQuery(testCase.query)
+			foundLogs, logError := f.GetLogsForQuery(context.Background(), testCase.query)
 
 			if logError != nil && testCase.expectedError == nil {
 				// If there is an error and test isn't expected to fail
@@ -135,6 +140,48 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_Finalized(t *testing.T) {
+	topic := types.StringToHash("4")
+
+	store := &mockBlockStore{
+		topics:        []types.Hash{topic},
+		confirmations: 2,
+	}
+	store.setupLogs()
+
+	for i := 0; i < 5; i++ {
+		block := newTestBlock(uint64(i), types.StringToHash(strconv.Itoa(i)))
+		block.Transactions = []*types.Transaction{{}, {}, {}}
+
+		store.add(block)
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store)
+
+	// the logs of the blocks up to the finalized block 2 are returned
+	logs, err := f.GetLogsForQuery(context.Background(), &LogQuery{
+		fromBlock: EarliestBlockNumber,
+		toBlock:   FinalizedBlockNumber,
+		Topics:    [][]types.Hash{{topic}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+
+	for _, log := range logs {
+		assert.LessOrEqual(t, uint64(log.BlockNumber), uint64(2))
+	}
+
+	// the lookup of the range stops once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = f.GetLogsForQuery(ctx, &LogQuery{
+		fromBlock: EarliestBlockNumber,
+		toBlock:   LatestBlockNumber,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func Test_GetLogFilterFromID(t *testing.T) {
 	store := newMockStore()
 
@@ -215,6 +262,48 @@ func TestFilterLog(t *testing.T) {
 	}
 }
 
+func TestFilterLog_Removed(t *testing.T) {
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+
+	id := m.NewLogFilter(&LogQuery{
+		Topics: [][]types.Hash{
+			{hash1},
+		},
+	}, nil)
+
+	removed := &types.Block{
+		Header:       &types.Header{Number: 1, Hash: hash2},
+		Transactions: []*types.Transaction{{Hash: hash3}},
+	}
+
+	// the rewound block is deleted from the store, the event carries its logs
+	assert.NoError(t, m.dispatchEvent(&blockchain.Event{
+		OldChain: []*types.Header{removed.Header},
+		NewChain: []*types.Header{{Hash: hash1}},
+		RemovedBlocks: []*blockchain.RemovedBlock{{
+			Block: removed,
+			Receipts: []*types.Receipt{{
+				Logs: []*types.Log{{Topics: []types.Hash{hash1}}},
+			}},
+		}},
+	}))
+
+	changes, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+
+	var logs []*Log
+
+	assert.NoError(t, json.Unmarshal([]byte(changes), &logs))
+
+	if assert.Len(t, logs, 1) {
+		assert.True(t, logs[0].Removed)
+		assert.Equal(t, hash2, logs[0].BlockHash)
+		assert.Equal(t, hash3, logs[0].TxHash)
+	}
+}
+
 func TestFilterBlock(t *testing.T) {
 	store := newMockStore()
 
@@ -326,6 +415,98 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+// readSubscriptionResult reads the result of the next subscription message
+func readSubscriptionResult(t *testing.T, msgCh chan []byte) string {
+	t.Helper()
+
+	select {
+	case msg := <-msgCh:
+		var res struct {
+			Params struct {
+				Result json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+
+		assert.NoError(t, json.Unmarshal(msg, &res))
+
+		return string(res.Params.Result)
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription message not received in 2 seconds")
+	}
+
+	return ""
+}
+
+func TestFilterPendingTxWebsocket(t *testing.T) {
+	store := newMockStore()
+
+	hashConn := &mockWsConn{msgCh: make(chan []byte, 1)}
+	fullConn := &mockWsConn{msgCh: make(chan []byte, 1)}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+	go m.Run()
+
+	m.NewPendingTxFilter(false, hashConn)
+	m.NewPendingTxFilter(true, fullConn)
+
+	txn := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+		Hash:     types.BytesToHash([]byte{1}),
+	}
+
+	store.emitPromotedTx(txn)
+
+	assert.Equal(t, `"`+txn.Hash.String()+`"`, readSubscriptionResult(t, hashConn.msgCh))
+
+	var full transaction
+
+	assert.NoError(t, json.Unmarshal([]byte(readSubscriptionResult(t, fullConn.msgCh)), &full))
+	assert.Equal(t, txn.Hash, full.Hash)
+	assert.Equal(t, argUint64(1), full.Nonce)
+	assert.Nil(t, full.BlockHash)
+}
+
+func TestFilterSyncingWebsocket(t *testing.T) {
+	store := newMockStore()
+
+	mock := &mockWsConn{msgCh: make(chan []byte, 1)}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+	m.syncStatusInterval = 50 * time.Millisecond
+
+	go m.Run()
+
+	m.NewSyncingFilter(mock)
+
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  5,
+		HighestBlock:  10,
+	})
+
+	assert.JSONEq(t, `{
+		"syncing": true,
+		"status": {"type": "bulk-sync", "startingBlock": "0x1", "currentBlock": "0x5", "highestBlock": "0xa"}
+	}`, readSubscriptionResult(t, mock.msgCh))
+
+	// the unchanged status is not sent again
+	select {
+	case <-mock.msgCh:
+		t.Fatal("unchanged sync status sent")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	store.setSyncProgression(nil)
+
+	assert.Equal(t, "false", readSubscriptionResult(t, mock.msgCh))
+}
+
 type mockWsConn struct {
 	msgCh chan []byte
 }
@@ -388,6 +569,106 @@ func TestClosedFilterDeletion(t *testing.T) {
 	// should not return error when the error is websocket.ErrCloseSen because filter is removed instead
 	assert.NoError(t, err)
 
-	// false because filter was removed automatically
-	assert.False(t, m.Exists(id))
+	// false because filter was removed automatically by its writer
+	assert.Eventually(t, func() bool {
+		return !m.Exists(id)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// blockingWsConn is a connection that doesn't write until it's released
+type blockingWsConn struct {
+	releaseCh chan struct{}
+}
+
+func (m *blockingWsConn) WriteMessage(_messageType int, _data []byte) error {
+	<-m.releaseCh
+
+	return nil
+}
+
+func TestFilterWebsocket_SlowConnection(t *testing.T) {
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+	go m.Run()
+
+	defer m.Close()
+
+	slow := &blockingWsConn{releaseCh: make(chan struct{})}
+	defer close(slow.releaseCh)
+
+	mock := &mockWsConn{msgCh: make(chan []byte, 2)}
+
+	m.NewBlockFilter(slow)
+	m.NewBlockFilter(mock)
+
+	// the slow connection doesn't hold up the events of the other filters
+	for i := 1; i <= 2; i++ {
+		store.emitEvent(&mockEvent{
+			NewChain: []*mockHeader{
+				{
+					header: &types.Header{
+						Number: uint64(i),
+						Hash:   types.StringToHash(fmt.Sprintf("%d", i)),
+					},
+				},
+			},
+		})
+
+		readSubscriptionResult(t, mock.msgCh)
+	}
+}
+
+func TestPendingTxFilter_Overflow(t *testing.T) {
+	filter := &pendingTxFilter{}
+
+	for i := 0; i < maxPendingTxUpdates+10; i++ {
+		filter.appendTx(types.StringToHash(strconv.Itoa(i)), nil)
+	}
+
+	// the oldest transactions are dropped once the buffer is full
+	txs := filter.takeTxUpdates()
+	if assert.Len(t, txs, maxPendingTxUpdates) {
+		assert.Equal(t, types.StringToHash("10"), txs[0])
+	}
+}
+
+func TestFilterPendingTx_Subscription(t *testing.T) {
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+	go m.Run()
+
+	defer m.Close()
+
+	subscriptions := func() int32 {
+		return atomic.LoadInt32(&store.txPoolSubs)
+	}
+
+	// the TxPool isn't subscribed to without PendingTxFilters
+	m.NewBlockFilter(nil)
+	assert.Equal(t, int32(0), subscriptions())
+
+	id1 := m.NewPendingTxFilter(false, nil)
+	id2 := m.NewPendingTxFilter(true, nil)
+	assert.Equal(t, int32(1), subscriptions())
+
+	assert.True(t, m.Uninstall(id1))
+	assert.Equal(t, int32(1), subscriptions())
+
+	// the subscription is canceled with the last PendingTxFilter
+	assert.True(t, m.Uninstall(id2))
+	assert.Equal(t, int32(0), subscriptions())
+
+	id3 := m.NewPendingTxFilter(false, nil)
+	assert.Equal(t, int32(1), subscriptions())
+
+	txn := &types.Transaction{Hash: types.BytesToHash([]byte{1})}
+	store.emitPromotedTx(txn)
+
+	assert.Eventually(t, func() bool {
+		res, err := m.GetFilterChanges(id3)
+
+		return err == nil && res == `["`+txn.Hash.String()+`"]`
+	}, 2*time.Second, 10*time.Millisecond)
 }
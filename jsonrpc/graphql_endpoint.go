@@ -0,0 +1,1025 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// DefaultGraphQLMaxDepth is the default maximum nesting of the fields of a GraphQL query
+	DefaultGraphQLMaxDepth = 10
+
+	// DefaultGraphQLMaxComplexity is the default maximum number of fields resolved by a GraphQL query
+	DefaultGraphQLMaxComplexity = 10000
+)
+
+// graphQLStore provides access to the methods needed by the GraphQL endpoint
+type graphQLStore interface {
+	ethStore
+	txPoolStore
+}
+
+// GraphQLConfig is the configuration of the GraphQL endpoint
+type GraphQLConfig struct {
+	// MaxDepth is the maximum nesting of the fields of a query, unlimited if zero
+	MaxDepth int
+
+	// MaxComplexity is the maximum number of fields resolved by a query,
+	// unlimited if zero. A list counts its fields once per item, the block
+	// ranges are counted by their number of blocks before they are scanned
+	MaxComplexity int
+}
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL is the GraphQL endpoint, implementing the EIP-1767 schema
+// on top of the stores of the eth endpoint
+type GraphQL struct {
+	logger        hclog.Logger
+	eth           *Eth
+	store         graphQLStore
+	schema        *graphql.Schema
+	maxComplexity int
+}
+
+// newGraphQL returns the GraphQL endpoint, the eth endpoint
+// is reused for the transaction decoding and the gas estimation
+func newGraphQL(logger hclog.Logger, eth *Eth, store graphQLStore, config *GraphQLConfig) (*GraphQL, error) {
+	g := &GraphQL{
+		logger:        logger.Named("graphql"),
+		eth:           eth,
+		store:         store,
+		maxComplexity: config.MaxComplexity,
+	}
+
+	schema, err := graphql.ParseSchema(
+		graphQLSchema,
+		&gqlResolver{g: g},
+		graphql.MaxDepth(config.MaxDepth),
+		graphql.Tracer(gqlTracer{}),
+		graphql.Logger(&gqlLogger{logger: g.logger}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	g.schema = schema
+
+	return g, nil
+}
+
+// Execute runs a GraphQL request
+func (g *GraphQL) Execute(ctx context.Context, req *graphQLRequest) *graphql.Response {
+	ctx, budget := withGraphQLBudget(ctx, g.maxComplexity)
+	defer budget.cancel()
+
+	resp := g.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	// the fields resolved before the budget was exceeded are discarded
+	if budget.exceeded() {
+		return &graphql.Response{Errors: []*gqlerrors.QueryError{{Message: budget.err().Error()}}}
+	}
+
+	return resp
+}
+
+var (
+	errGraphQLBlockArgs  = errors.New("only one of number or hash can be specified")
+	errGraphQLBlockRange = errors.New("the block range is invalid")
+)
+
+// blockArgs are the arguments of the account fields, the block of the state
+type blockArgs struct {
+	Block *gqlLong
+}
+
+// addressArgs are the arguments of the fields returning an account
+type addressArgs struct {
+	Address gqlAddress
+}
+
+// indexArgs are the arguments of the fields returning an item of a list
+type indexArgs struct {
+	Index int32
+}
+
+// callArgs are the arguments of the call and gas estimation fields
+type callArgs struct {
+	Data gqlCallData
+}
+
+// gqlCallData is the CallData input, the fields of a call
+type gqlCallData struct {
+	From     *gqlAddress
+	To       *gqlAddress
+	Gas      *gqlLong
+	GasPrice *gqlBigInt
+	Value    *gqlBigInt
+	Data     *gqlBytes
+}
+
+// gqlBlockFilterCriteria is the BlockFilterCriteria input, the filter of the logs of a block
+type gqlBlockFilterCriteria struct {
+	Addresses *[]gqlAddress
+	Topics    *[][]gqlBytes32
+}
+
+// gqlFilterCriteria is the FilterCriteria input, the filter of the logs of a block range
+type gqlFilterCriteria struct {
+	FromBlock *gqlLong
+	ToBlock   *gqlLong
+	Addresses *[]gqlAddress
+	Topics    *[][]gqlBytes32
+}
+
+// gqlAccount is an account at the state of a block,
+// its nonce is the one of the pool if the account is pending
+type gqlAccount struct {
+	g       *GraphQL
+	address types.Address
+	header  *types.Header
+	pending bool
+}
+
+func (a *gqlAccount) Address() gqlAddress {
+	return gqlAddress(a.address)
+}
+
+// getAccount returns the state of the account, nil if it doesn't exist
+func (a *gqlAccount) getAccount() (*state.Account, error) {
+	acc, err := a.g.store.GetAccount(a.header.StateRoot, a.address)
+	if errors.Is(err, ErrStateNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return acc, nil
+}
+
+func (a *gqlAccount) Balance() (gqlBigInt, error) {
+	acc, err := a.getAccount()
+	if err != nil || acc == nil {
+		return gqlBigInt{}, err
+	}
+
+	return toGQLBigInt(acc.Balance), nil
+}
+
+func (a *gqlAccount) TransactionCount() (gqlLong, error) {
+	if a.pending {
+		return gqlLong(a.g.store.GetNonce(a.address)), nil
+	}
+
+	acc, err := a.getAccount()
+	if err != nil || acc == nil {
+		return 0, err
+	}
+
+	return gqlLong(acc.Nonce), nil
+}
+
+func (a *gqlAccount) Code() (gqlBytes, error) {
+	acc, err := a.getAccount()
+	if err != nil || acc == nil {
+		return gqlBytes{}, err
+	}
+
+	code, err := a.g.store.GetCode(types.BytesToHash(acc.CodeHash))
+	if err != nil {
+		// the code hash of the accounts without code isn't stored
+		return gqlBytes{}, nil
+	}
+
+	return code, nil
+}
+
+func (a *gqlAccount) Storage(args struct{ Slot gqlBytes32 }) (gqlBytes32, error) {
+	result, err := a.g.store.GetStorage(a.header.StateRoot, a.address, types.Hash(args.Slot))
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return gqlBytes32{}, nil
+		}
+
+		return gqlBytes32{}, err
+	}
+
+	// the storage values are RLP encoded
+	v, err := (&fastrlp.Parser{}).Parse(result)
+	if err != nil {
+		return gqlBytes32{}, nil
+	}
+
+	data, err := v.Bytes()
+	if err != nil {
+		return gqlBytes32{}, nil
+	}
+
+	return gqlBytes32(types.BytesToHash(data)), nil
+}
+
+// gqlLog is a log of a sealed transaction, at the index in the block
+type gqlLog struct {
+	g     *GraphQL
+	log   *types.Log
+	txn   *gqlTransaction
+	index int
+}
+
+func (l *gqlLog) Index() int32 {
+	return int32(l.index)
+}
+
+func (l *gqlLog) Account(args blockArgs) (*gqlAccount, error) {
+	return l.g.accountAt(l.log.Address, args.Block)
+}
+
+func (l *gqlLog) Topics() []gqlBytes32 {
+	topics := make([]gqlBytes32, len(l.log.Topics))
+	for i, topic := range l.log.Topics {
+		topics[i] = gqlBytes32(topic)
+	}
+
+	return topics
+}
+
+func (l *gqlLog) Data() gqlBytes {
+	return l.log.Data
+}
+
+func (l *gqlLog) Transaction() *gqlTransaction {
+	return l.txn
+}
+
+// gqlTransaction is a transaction, sealed in a block at the index or pending
+type gqlTransaction struct {
+	g     *GraphQL
+	txn   *types.Transaction
+	block *types.Block
+	index int
+}
+
+func (t *gqlTransaction) Hash() gqlBytes32 {
+	return gqlBytes32(t.txn.Hash)
+}
+
+func (t *gqlTransaction) Nonce() gqlLong {
+	return gqlLong(t.txn.Nonce)
+}
+
+func (t *gqlTransaction) Index() *int32 {
+	if t.block == nil {
+		return nil
+	}
+
+	index := int32(t.index)
+
+	return &index
+}
+
+func (t *gqlTransaction) From(args blockArgs) (*gqlAccount, error) {
+	return t.g.accountAt(t.txn.From, args.Block)
+}
+
+func (t *gqlTransaction) To(args blockArgs) (*gqlAccount, error) {
+	if t.txn.To == nil {
+		return nil, nil
+	}
+
+	return t.g.accountAt(*t.txn.To, args.Block)
+}
+
+func (t *gqlTransaction) Value() gqlBigInt {
+	return toGQLBigInt(t.txn.Value)
+}
+
+func (t *gqlTransaction) GasPrice() gqlBigInt {
+	return toGQLBigInt(t.txn.GasPrice)
+}
+
+func (t *gqlTransaction) Gas() gqlLong {
+	return gqlLong(t.txn.Gas)
+}
+
+func (t *gqlTransaction) InputData() gqlBytes {
+	return t.txn.Input
+}
+
+func (t *gqlTransaction) Block() *gqlBlock {
+	if t.block == nil {
+		return nil
+	}
+
+	return &gqlBlock{g: t.g, block: t.block}
+}
+
+// receipt returns the receipt of the transaction, nil if it's pending
+func (t *gqlTransaction) receipt() (*types.Receipt, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+
+	receipts, err := t.g.store.GetReceiptsByHash(t.block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.index >= len(receipts) {
+		return nil, fmt.Errorf("receipt of transaction %s not found", t.txn.Hash)
+	}
+
+	return receipts[t.index], nil
+}
+
+// receiptField resolves a field of the receipt of the transaction, null if it's pending
+func (t *gqlTransaction) receiptField(fn func(r *types.Receipt) *gqlLong) (*gqlLong, error) {
+	receipt, err := t.receipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	return fn(receipt), nil
+}
+
+func (t *gqlTransaction) Status() (*gqlLong, error) {
+	return t.receiptField(func(r *types.Receipt) *gqlLong {
+		if r.Status == nil {
+			return nil
+		}
+
+		status := gqlLong(*r.Status)
+
+		return &status
+	})
+}
+
+func (t *gqlTransaction) GasUsed() (*gqlLong, error) {
+	return t.receiptField(func(r *types.Receipt) *gqlLong {
+		gasUsed := gqlLong(r.GasUsed)
+
+		return &gasUsed
+	})
+}
+
+func (t *gqlTransaction) CumulativeGasUsed() (*gqlLong, error) {
+	return t.receiptField(func(r *types.Receipt) *gqlLong {
+		gasUsed := gqlLong(r.CumulativeGasUsed)
+
+		return &gasUsed
+	})
+}
+
+func (t *gqlTransaction) CreatedContract(args blockArgs) (*gqlAccount, error) {
+	receipt, err := t.receipt()
+	if err != nil || receipt == nil || receipt.ContractAddress == nil {
+		return nil, err
+	}
+
+	return t.g.accountAt(*receipt.ContractAddress, args.Block)
+}
+
+func (t *gqlTransaction) Logs() (*[]*gqlLog, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+
+	receipts, err := t.g.store.GetReceiptsByHash(t.block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// the index of the logs is the position in the block
+	var (
+		logs  []*gqlLog
+		index int
+	)
+
+	for i, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if i == t.index {
+				logs = append(logs, &gqlLog{g: t.g, log: log, txn: t, index: index})
+			}
+
+			index++
+		}
+	}
+
+	return &logs, nil
+}
+
+func (t *gqlTransaction) R() gqlBigInt {
+	return toGQLBigInt(t.txn.R)
+}
+
+func (t *gqlTransaction) S() gqlBigInt {
+	return toGQLBigInt(t.txn.S)
+}
+
+func (t *gqlTransaction) V() gqlBigInt {
+	return toGQLBigInt(t.txn.V)
+}
+
+// gqlBlock is a sealed block with its transactions
+type gqlBlock struct {
+	g     *GraphQL
+	block *types.Block
+}
+
+func (b *gqlBlock) Number() gqlLong {
+	return gqlLong(b.block.Number())
+}
+
+func (b *gqlBlock) Hash() gqlBytes32 {
+	return gqlBytes32(b.block.Hash())
+}
+
+func (b *gqlBlock) Parent() *gqlBlock {
+	header := b.block.Header
+	if header.Number == 0 {
+		return nil
+	}
+
+	return b.g.blockByHash(header.ParentHash)
+}
+
+func (b *gqlBlock) Nonce() gqlBytes {
+	return b.block.Header.Nonce[:]
+}
+
+func (b *gqlBlock) TransactionsRoot() gqlBytes32 {
+	return gqlBytes32(b.block.Header.TxRoot)
+}
+
+func (b *gqlBlock) TransactionCount() *int32 {
+	count := int32(len(b.block.Transactions))
+
+	return &count
+}
+
+func (b *gqlBlock) StateRoot() gqlBytes32 {
+	return gqlBytes32(b.block.Header.StateRoot)
+}
+
+func (b *gqlBlock) ReceiptsRoot() gqlBytes32 {
+	return gqlBytes32(b.block.Header.ReceiptsRoot)
+}
+
+func (b *gqlBlock) Miner(args blockArgs) (*gqlAccount, error) {
+	return b.g.accountAt(b.block.Header.Miner, args.Block)
+}
+
+func (b *gqlBlock) ExtraData() gqlBytes {
+	return b.block.Header.ExtraData
+}
+
+func (b *gqlBlock) GasLimit() gqlLong {
+	return gqlLong(b.block.Header.GasLimit)
+}
+
+func (b *gqlBlock) GasUsed() gqlLong {
+	return gqlLong(b.block.Header.GasUsed)
+}
+
+func (b *gqlBlock) Timestamp() gqlBigInt {
+	return toGQLBigInt(new(big.Int).SetUint64(b.block.Header.Timestamp))
+}
+
+func (b *gqlBlock) LogsBloom() gqlBytes {
+	return b.block.Header.LogsBloom[:]
+}
+
+func (b *gqlBlock) MixHash() gqlBytes32 {
+	return gqlBytes32(b.block.Header.MixHash)
+}
+
+func (b *gqlBlock) Difficulty() gqlBigInt {
+	return toGQLBigInt(new(big.Int).SetUint64(b.block.Header.Difficulty))
+}
+
+func (b *gqlBlock) TotalDifficulty() gqlBigInt {
+	// not needed for POS
+	return b.Difficulty()
+}
+
+func (b *gqlBlock) OmmerCount() *int32 {
+	count := int32(len(b.block.Uncles))
+
+	return &count
+}
+
+func (b *gqlBlock) Ommers() *[]*gqlBlock {
+	// the ommers are not kept, only their count is known
+	ommers := make([]*gqlBlock, len(b.block.Uncles))
+
+	return &ommers
+}
+
+func (b *gqlBlock) OmmerAt(indexArgs) *gqlBlock {
+	return nil
+}
+
+func (b *gqlBlock) OmmerHash() gqlBytes32 {
+	return gqlBytes32(b.block.Header.Sha3Uncles)
+}
+
+func (b *gqlBlock) Transactions() *[]*gqlTransaction {
+	txns := make([]*gqlTransaction, len(b.block.Transactions))
+	for i, txn := range b.block.Transactions {
+		txns[i] = &gqlTransaction{g: b.g, txn: txn, block: b.block, index: i}
+	}
+
+	return &txns
+}
+
+func (b *gqlBlock) TransactionAt(args indexArgs) *gqlTransaction {
+	index := int(args.Index)
+	if index < 0 || index >= len(b.block.Transactions) {
+		return nil
+	}
+
+	return &gqlTransaction{g: b.g, txn: b.block.Transactions[index], block: b.block, index: index}
+}
+
+func (b *gqlBlock) Logs(ctx context.Context, args struct{ Filter gqlBlockFilterCriteria }) ([]*gqlLog, error) {
+	if err := allowGraphQLMethod(ctx, "eth_getLogs"); err != nil {
+		return nil, err
+	}
+
+	return b.g.blockLogs(b.block, logQuery(args.Filter.Addresses, args.Filter.Topics))
+}
+
+func (b *gqlBlock) Account(args addressArgs) *gqlAccount {
+	return &gqlAccount{g: b.g, address: types.Address(args.Address), header: b.block.Header}
+}
+
+func (b *gqlBlock) Call(ctx context.Context, args callArgs) (*gqlCallResult, error) {
+	return b.g.call(ctx, args.Data, b.block.Header)
+}
+
+func (b *gqlBlock) EstimateGas(ctx context.Context, args callArgs) (gqlLong, error) {
+	return b.g.estimateGas(ctx, args.Data, BlockNumber(b.block.Number()))
+}
+
+// gqlCallResult is the result of a call
+type gqlCallResult struct {
+	data    []byte
+	gasUsed uint64
+	status  uint64
+}
+
+func (r *gqlCallResult) Data() gqlBytes {
+	return r.data
+}
+
+func (r *gqlCallResult) GasUsed() gqlLong {
+	return gqlLong(r.gasUsed)
+}
+
+func (r *gqlCallResult) Status() gqlLong {
+	return gqlLong(r.status)
+}
+
+// gqlSyncState is the progression of the bulk sync
+type gqlSyncState struct {
+	progression *progress.Progression
+}
+
+func (s *gqlSyncState) StartingBlock() gqlLong {
+	return gqlLong(s.progression.StartingBlock)
+}
+
+func (s *gqlSyncState) CurrentBlock() gqlLong {
+	return gqlLong(s.progression.CurrentBlock)
+}
+
+func (s *gqlSyncState) HighestBlock() gqlLong {
+	return gqlLong(s.progression.HighestBlock)
+}
+
+// gqlPending is the pending state, the transactions of the pool on top of the latest block
+type gqlPending struct {
+	g *GraphQL
+}
+
+func (p *gqlPending) TransactionCount() int32 {
+	return int32(len(p.g.pendingTransactions()))
+}
+
+func (p *gqlPending) Transactions() *[]*gqlTransaction {
+	txns := p.g.pendingTransactions()
+
+	return &txns
+}
+
+func (p *gqlPending) Account(args addressArgs) *gqlAccount {
+	return &gqlAccount{g: p.g, address: types.Address(args.Address), header: p.g.store.Header(), pending: true}
+}
+
+func (p *gqlPending) Call(ctx context.Context, args callArgs) (*gqlCallResult, error) {
+	return p.g.call(ctx, args.Data, p.g.store.Header())
+}
+
+func (p *gqlPending) EstimateGas(ctx context.Context, args callArgs) (gqlLong, error) {
+	return p.g.estimateGas(ctx, args.Data, LatestBlockNumber)
+}
+
+// gqlResolver is the root resolver of the queries and the mutations
+type gqlResolver struct {
+	g *GraphQL
+}
+
+func (r *gqlResolver) Block(args struct {
+	Number *gqlLong
+	Hash   *gqlBytes32
+}) (*gqlBlock, error) {
+	switch {
+	case args.Number != nil && args.Hash != nil:
+		return nil, errGraphQLBlockArgs
+	case args.Hash != nil:
+		return r.g.blockByHash(types.Hash(*args.Hash)), nil
+	case args.Number != nil:
+		return r.g.blockByNumber(uint64(*args.Number)), nil
+	default:
+		return r.g.blockByNumber(r.g.store.Header().Number), nil
+	}
+}
+
+func (r *gqlResolver) Blocks(ctx context.Context, args struct {
+	From gqlLong
+	To   *gqlLong
+}) ([]*gqlBlock, error) {
+	from, to, err := r.g.blockRange(&args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chargeGraphQLBudget(ctx, rangeSize(from, to)); err != nil {
+		return nil, err
+	}
+
+	var blocks []*gqlBlock
+
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		block := r.g.blockByNumber(number)
+		if block == nil {
+			break
+		}
+
+		blocks = append(blocks, block)
+
+		if number == math.MaxUint64 {
+			break
+		}
+	}
+
+	return blocks, nil
+}
+
+func (r *gqlResolver) Pending() *gqlPending {
+	return &gqlPending{g: r.g}
+}
+
+func (r *gqlResolver) Transaction(args struct{ Hash gqlBytes32 }) *gqlTransaction {
+	hash := types.Hash(args.Hash)
+
+	if blockHash, ok := r.g.store.ReadTxLookup(hash); ok {
+		if block, ok := r.g.store.GetBlockByHash(blockHash, true); ok {
+			for i, txn := range block.Transactions {
+				if txn.Hash == hash {
+					return &gqlTransaction{g: r.g, txn: txn, block: block, index: i}
+				}
+			}
+		}
+	}
+
+	if txn, ok := r.g.store.GetPendingTx(hash); ok {
+		return &gqlTransaction{g: r.g, txn: txn}
+	}
+
+	return nil
+}
+
+func (r *gqlResolver) Logs(ctx context.Context, args struct{ Filter gqlFilterCriteria }) ([]*gqlLog, error) {
+	if err := allowGraphQLMethod(ctx, "eth_getLogs"); err != nil {
+		return nil, err
+	}
+
+	from, to, err := r.g.blockRange(args.Filter.FromBlock, args.Filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chargeGraphQLBudget(ctx, rangeSize(from, to)); err != nil {
+		return nil, err
+	}
+
+	query := logQuery(args.Filter.Addresses, args.Filter.Topics)
+	logs := []*gqlLog{}
+
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		block, ok := r.g.store.GetBlockByNumber(number, true)
+		if !ok {
+			break
+		}
+
+		blockLogs, err := r.g.blockLogs(block, query)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, blockLogs...)
+
+		if number == math.MaxUint64 {
+			break
+		}
+	}
+
+	return logs, nil
+}
+
+func (r *gqlResolver) GasPrice() gqlBigInt {
+	return toGQLBigInt(r.g.store.GetAvgGasPrice())
+}
+
+func (r *gqlResolver) ChainID() gqlBigInt {
+	return toGQLBigInt(new(big.Int).SetUint64(r.g.eth.chainID))
+}
+
+func (r *gqlResolver) Syncing() *gqlSyncState {
+	// nil if the node isn't bulk syncing
+	progression := r.g.store.GetSyncProgression()
+	if progression == nil {
+		return nil
+	}
+
+	return &gqlSyncState{progression: progression}
+}
+
+func (r *gqlResolver) SendRawTransaction(ctx context.Context, args struct{ Data gqlBytes }) (gqlBytes32, error) {
+	if err := allowGraphQLMethod(ctx, "eth_sendRawTransaction"); err != nil {
+		return gqlBytes32{}, err
+	}
+
+	hash, err := r.g.eth.SendRawTransaction(hex.EncodeToHex(args.Data))
+	if err != nil {
+		return gqlBytes32{}, err
+	}
+
+	encoded, _ := hash.(string)
+
+	return gqlBytes32(types.StringToHash(encoded)), nil
+}
+
+// accountAt returns the account at the state of the block number, the latest one if not set
+func (g *GraphQL) accountAt(address types.Address, number *gqlLong) (*gqlAccount, error) {
+	header := g.store.Header()
+
+	if number != nil {
+		if *number > math.MaxInt64 {
+			return nil, fmt.Errorf("block number %d out of range", *number)
+		}
+
+		var err error
+		if header, err = g.eth.getBlockHeader(BlockNumber(*number)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &gqlAccount{g: g, address: address, header: header}, nil
+}
+
+// blockByHash returns the block with its transactions, nil if not found
+func (g *GraphQL) blockByHash(hash types.Hash) *gqlBlock {
+	block, ok := g.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil
+	}
+
+	return &gqlBlock{g: g, block: block}
+}
+
+// blockByNumber returns the block with its transactions, nil if not found
+func (g *GraphQL) blockByNumber(number uint64) *gqlBlock {
+	block, ok := g.store.GetBlockByNumber(number, true)
+	if !ok {
+		return nil
+	}
+
+	return &gqlBlock{g: g, block: block}
+}
+
+// blockRange resolves the bounds of a block range, the latest block if not set
+func (g *GraphQL) blockRange(from, to *gqlLong) (uint64, uint64, error) {
+	latest := g.store.Header().Number
+
+	fromNumber, toNumber := latest, latest
+
+	if from != nil {
+		fromNumber = uint64(*from)
+	}
+
+	if to != nil {
+		toNumber = uint64(*to)
+	}
+
+	if toNumber < fromNumber {
+		return 0, 0, errGraphQLBlockRange
+	}
+
+	return fromNumber, toNumber, nil
+}
+
+// pendingTransactions returns the transactions of the pool pending for inclusion
+func (g *GraphQL) pendingTransactions() []*gqlTransaction {
+	pending, _ := g.store.GetTxs(false)
+
+	var txns []*gqlTransaction
+
+	for _, accountTxns := range pending {
+		for _, txn := range accountTxns {
+			txns = append(txns, &gqlTransaction{g: g, txn: txn})
+		}
+	}
+
+	return txns
+}
+
+// logQuery converts the filter criteria to a log query
+func logQuery(addresses *[]gqlAddress, topics *[][]gqlBytes32) *LogQuery {
+	query := &LogQuery{}
+
+	if addresses != nil {
+		for _, address := range *addresses {
+			query.Addresses = append(query.Addresses, types.Address(address))
+		}
+	}
+
+	if topics != nil {
+		for _, set := range *topics {
+			hashes := []types.Hash{}
+			for _, topic := range set {
+				hashes = append(hashes, types.Hash(topic))
+			}
+
+			query.Topics = append(query.Topics, hashes)
+		}
+	}
+
+	return query
+}
+
+// blockLogs returns the logs of the block matching the query
+func (g *GraphQL) blockLogs(block *types.Block, query *LogQuery) ([]*gqlLog, error) {
+	logs := []*gqlLog{}
+
+	if len(block.Transactions) == 0 {
+		return logs, nil
+	}
+
+	receipts, err := g.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	index := 0
+
+	for i, receipt := range receipts {
+		if i >= len(block.Transactions) {
+			break
+		}
+
+		txn := &gqlTransaction{g: g, txn: block.Transactions[i], block: block, index: i}
+
+		for _, log := range receipt.Logs {
+			if query.Match(log) {
+				logs = append(logs, &gqlLog{g: g, log: log, txn: txn, index: index})
+			}
+
+			index++
+		}
+	}
+
+	return logs, nil
+}
+
+// toTxnArgs converts the call data to the arguments of a transaction
+func toTxnArgs(data gqlCallData) *txnArgs {
+	arg := &txnArgs{}
+
+	if data.From != nil {
+		from := types.Address(*data.From)
+		arg.From = &from
+	}
+
+	if data.To != nil {
+		to := types.Address(*data.To)
+		arg.To = &to
+	}
+
+	if data.Gas != nil {
+		arg.Gas = argUintPtr(uint64(*data.Gas))
+	}
+
+	if data.GasPrice != nil {
+		gasPrice := big.Int(*data.GasPrice)
+		arg.GasPrice = argBytesPtr(gasPrice.Bytes())
+	}
+
+	if data.Value != nil {
+		value := big.Int(*data.Value)
+		arg.Value = argBytesPtr(value.Bytes())
+	}
+
+	if data.Data != nil {
+		arg.Data = argBytesPtr(*data.Data)
+	}
+
+	return arg
+}
+
+// call executes the call on top of the state of the block,
+// the failed executions are returned with a zero status
+func (g *GraphQL) call(ctx context.Context, data gqlCallData, header *types.Header) (*gqlCallResult, error) {
+	if err := allowGraphQLMethod(ctx, "eth_call"); err != nil {
+		return nil, err
+	}
+
+	txn, err := g.eth.decodeTxn(toTxnArgs(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// the call can use the whole block gas by default
+	if txn.Gas == 0 {
+		txn.Gas = header.GasLimit
+	}
+
+	result, err := g.store.ApplyTxn(ctx, header, txn, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &gqlCallResult{
+		data:    result.ReturnValue,
+		gasUsed: result.GasUsed,
+		status:  1,
+	}
+
+	if result.Failed() {
+		res.status = 0
+	}
+
+	if res.data == nil {
+		res.data = []byte{}
+	}
+
+	return res, nil
+}
+
+// estimateGas estimates the gas of the call on top of the state of the block
+func (g *GraphQL) estimateGas(ctx context.Context, data gqlCallData, number BlockNumber) (gqlLong, error) {
+	if err := allowGraphQLMethod(ctx, "eth_estimateGas"); err != nil {
+		return 0, err
+	}
+
+	estimate, err := g.eth.EstimateGas(ctx, toTxnArgs(data), &number, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	encoded, _ := estimate.(string)
+
+	gas, err := strconv.ParseUint(strings.TrimPrefix(encoded, "0x"), 16, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return gqlLong(gas), nil
+}
@@ -0,0 +1,504 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGraphQLStore struct {
+	*mockBlockStore
+	accounts map[types.Address]*state.Account
+}
+
+func (m *mockGraphQLStore) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
+	account, ok := m.accounts[addr]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+
+	return account, nil
+}
+
+func (m *mockGraphQLStore) GetCode(hash types.Hash) ([]byte, error) {
+	return nil, fmt.Errorf("code not found")
+}
+
+func (m *mockGraphQLStore) GetNonce(addr types.Address) uint64 {
+	return 5
+}
+
+func (m *mockGraphQLStore) GetTxs(inclQueued bool) (
+	map[types.Address][]*types.Transaction,
+	map[types.Address][]*types.Transaction,
+) {
+	pending := make(map[types.Address][]*types.Transaction)
+	for _, txn := range m.pendingTxns {
+		pending[txn.From] = append(pending[txn.From], txn)
+	}
+
+	return pending, nil
+}
+
+func (m *mockGraphQLStore) GetCapacity() (uint64, uint64) {
+	return 0, 0
+}
+
+func newTestGraphQLTxn(hash types.Hash, from types.Address, to *types.Address) *types.Transaction {
+	return &types.Transaction{
+		Hash:     hash,
+		From:     from,
+		To:       to,
+		Nonce:    1,
+		Gas:      21000,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(1000),
+		Input:    []byte{},
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+	}
+}
+
+// newTestGraphQLStore returns a chain of 4 blocks, the block 2 holding
+// a transfer and a contract creation with their logs
+func newTestGraphQLStore() *mockGraphQLStore {
+	store := &mockGraphQLStore{
+		mockBlockStore: newMockBlockStore(),
+		accounts: map[types.Address]*state.Account{
+			addr1: {Balance: big.NewInt(100), Nonce: 3},
+		},
+	}
+
+	for i := 0; i < 4; i++ {
+		block := newTestBlock(uint64(i), types.Hash{byte(i + 1)})
+		block.Header.GasLimit = 1000000
+
+		if i > 0 {
+			block.Header.ParentHash = types.Hash{byte(i)}
+		}
+
+		store.add(block)
+	}
+
+	block := store.blocks[2]
+	block.Transactions = []*types.Transaction{
+		newTestGraphQLTxn(hash1, addr1, &addr2),
+		newTestGraphQLTxn(hash2, addr2, nil),
+	}
+
+	success := types.ReceiptSuccess
+	store.receipts[block.Hash()] = []*types.Receipt{
+		{
+			Status:            &success,
+			GasUsed:           21000,
+			CumulativeGasUsed: 21000,
+			Logs:              []*types.Log{{Address: addr2, Topics: []types.Hash{hash3}}},
+		},
+		{
+			Status:            &success,
+			GasUsed:           50000,
+			CumulativeGasUsed: 71000,
+			ContractAddress:   &addr0,
+			Logs: []*types.Log{
+				{Address: addr0, Topics: []types.Hash{hash4}},
+				{Address: addr0, Topics: []types.Hash{hash3}, Data: []byte{0x1}},
+			},
+		},
+	}
+
+	store.pendingTxns = []*types.Transaction{newTestGraphQLTxn(hash4, addr1, &addr2)}
+
+	return store
+}
+
+func newTestGraphQL(t *testing.T, store graphQLStore, config *GraphQLConfig) *GraphQL {
+	t.Helper()
+
+	g, err := newGraphQL(hclog.NewNullLogger(), newTestEthEndpoint(store), store, config)
+	assert.NoError(t, err)
+
+	return g
+}
+
+// executeGraphQL runs the query and decodes its data
+func executeGraphQL(t *testing.T, g *GraphQL, query string, variables map[string]interface{}) interface{} {
+	t.Helper()
+
+	resp := g.Execute(context.Background(), &graphQLRequest{Query: query, Variables: variables})
+	for _, err := range resp.Errors {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	raw, err := json.Marshal(resp)
+	assert.NoError(t, err)
+
+	var res struct {
+		Data interface{} `json:"data"`
+	}
+
+	assert.NoError(t, json.Unmarshal(raw, &res))
+
+	return res.Data
+}
+
+func TestGraphQL_Block(t *testing.T) {
+	g := newTestGraphQL(t, newTestGraphQLStore(), &GraphQLConfig{})
+
+	data := executeGraphQL(t, g, `{
+		block(number: 2) {
+			number
+			hash
+			parent { number }
+			transactionCount
+			gasLimit
+			transactions {
+				hash
+				index
+				value
+				from { address balance transactionCount }
+				to { address }
+				status
+				gasUsed
+				cumulativeGasUsed
+				createdContract { address }
+				logs { index topics account { address } }
+			}
+		}
+		genesis: block(hash: "0x0100000000000000000000000000000000000000000000000000000000000000") {
+			number
+			parent { number }
+		}
+		latest: block { number }
+		missing: block(number: 10) { number }
+	}`, nil)
+
+	expected := map[string]interface{}{
+		"block": map[string]interface{}{
+			"number":           float64(2),
+			"hash":             types.Hash{0x3}.String(),
+			"parent":           map[string]interface{}{"number": float64(1)},
+			"transactionCount": float64(2),
+			"gasLimit":         float64(1000000),
+			"transactions": []interface{}{
+				map[string]interface{}{
+					"hash":  hash1.String(),
+					"index": float64(0),
+					"value": "0x3e8",
+					"from": map[string]interface{}{
+						"address":          addr1.String(),
+						"balance":          "0x64",
+						"transactionCount": float64(3),
+					},
+					"to":                map[string]interface{}{"address": addr2.String()},
+					"status":            float64(1),
+					"gasUsed":           float64(21000),
+					"cumulativeGasUsed": float64(21000),
+					"createdContract":   nil,
+					"logs": []interface{}{
+						map[string]interface{}{
+							"index":   float64(0),
+							"topics":  []interface{}{hash3.String()},
+							"account": map[string]interface{}{"address": addr2.String()},
+						},
+					},
+				},
+				map[string]interface{}{
+					"hash":  hash2.String(),
+					"index": float64(1),
+					"value": "0x3e8",
+					"from": map[string]interface{}{
+						"address":          addr2.String(),
+						"balance":          "0x0",
+						"transactionCount": float64(0),
+					},
+					"to":                nil,
+					"status":            float64(1),
+					"gasUsed":           float64(50000),
+					"cumulativeGasUsed": float64(71000),
+					"createdContract":   map[string]interface{}{"address": addr0.String()},
+					"logs": []interface{}{
+						map[string]interface{}{
+							"index":   float64(1),
+							"topics":  []interface{}{hash4.String()},
+							"account": map[string]interface{}{"address": addr0.String()},
+						},
+						map[string]interface{}{
+							"index":   float64(2),
+							"topics":  []interface{}{hash3.String()},
+							"account": map[string]interface{}{"address": addr0.String()},
+						},
+					},
+				},
+			},
+		},
+		"genesis": map[string]interface{}{"number": float64(0), "parent": nil},
+		"latest":  map[string]interface{}{"number": float64(3)},
+		"missing": nil,
+	}
+
+	assert.Equal(t, expected, data)
+}
+
+func TestGraphQL_Logs(t *testing.T) {
+	g := newTestGraphQL(t, newTestGraphQLStore(), &GraphQLConfig{})
+
+	data := executeGraphQL(t, g, `query($topic: Bytes32!) {
+		logs(filter: {fromBlock: 0, topics: [[$topic]]}) {
+			index
+			data
+			transaction { hash block { number } }
+		}
+		block(number: 2) {
+			logs(filter: {addresses: ["`+addr0.String()+`"]}) { index }
+		}
+	}`, map[string]interface{}{"topic": hash3.String()})
+
+	assert.Equal(t, map[string]interface{}{
+		"logs": []interface{}{
+			map[string]interface{}{
+				"index":       float64(0),
+				"data":        "0x",
+				"transaction": map[string]interface{}{"hash": hash1.String(), "block": map[string]interface{}{"number": float64(2)}},
+			},
+			map[string]interface{}{
+				"index":       float64(2),
+				"data":        "0x01",
+				"transaction": map[string]interface{}{"hash": hash2.String(), "block": map[string]interface{}{"number": float64(2)}},
+			},
+		},
+		"block": map[string]interface{}{
+			"logs": []interface{}{
+				map[string]interface{}{"index": float64(1)},
+				map[string]interface{}{"index": float64(2)},
+			},
+		},
+	}, data)
+}
+
+func TestGraphQL_TransactionAndPending(t *testing.T) {
+	store := newTestGraphQLStore()
+	store.isSyncing = true
+
+	g := newTestGraphQL(t, store, &GraphQLConfig{})
+
+	data := executeGraphQL(t, g, `query($sealed: Bytes32!, $pending: Bytes32!, $missing: Bytes32!) {
+		sealed: transaction(hash: $sealed) { index block { number } status }
+		pending: transaction(hash: $pending) { index block { number } status logs { index } }
+		missing: transaction(hash: $missing) { index }
+		pendingState: pending {
+			transactionCount
+			transactions { hash }
+			account(address: "`+addr1.String()+`") { transactionCount balance }
+		}
+		syncing { startingBlock currentBlock highestBlock }
+		chainID
+	}`, map[string]interface{}{
+		"sealed":  hash2.String(),
+		"pending": hash4.String(),
+		"missing": types.Hash{0xff}.String(),
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"sealed":  map[string]interface{}{"index": float64(1), "block": map[string]interface{}{"number": float64(2)}, "status": float64(1)},
+		"pending": map[string]interface{}{"index": nil, "block": nil, "status": nil, "logs": nil},
+		"missing": nil,
+		"pendingState": map[string]interface{}{
+			"transactionCount": float64(1),
+			"transactions":     []interface{}{map[string]interface{}{"hash": hash4.String()}},
+			"account":          map[string]interface{}{"transactionCount": float64(5), "balance": "0x64"},
+		},
+		"syncing": map[string]interface{}{"startingBlock": float64(1), "currentBlock": float64(10), "highestBlock": float64(100)},
+		"chainID": "0x64",
+	}, data)
+}
+
+func TestGraphQL_Call(t *testing.T) {
+	store := newTestGraphQLStore()
+	g := newTestGraphQL(t, store, &GraphQLConfig{})
+
+	query := `{ block(number: 1) { call(data: {to: "` + addr2.String() + `", data: "0x01"}) { data gasUsed status } } }`
+
+	data := executeGraphQL(t, g, query, nil)
+	assert.Equal(t, map[string]interface{}{
+		"block": map[string]interface{}{
+			"call": map[string]interface{}{"data": "0x", "gasUsed": float64(0), "status": float64(1)},
+		},
+	}, data)
+
+	// the failed executions have a zero status
+	store.ethCallError = runtime.ErrExecutionReverted
+
+	data = executeGraphQL(t, g, query, nil)
+	assert.Equal(t, map[string]interface{}{
+		"block": map[string]interface{}{
+			"call": map[string]interface{}{"data": "0x", "gasUsed": float64(0), "status": float64(0)},
+		},
+	}, data)
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+	g := newTestGraphQL(t, newTestGraphQLStore(), &GraphQLConfig{
+		MaxDepth:      4,
+		MaxComplexity: 100,
+	})
+
+	cases := []struct {
+		name  string
+		query string
+		err   string
+	}{
+		{"block range within the limits", `{ blocks(from: 0, to: 3) { number } }`, ""},
+		{"log range within the limits", `{ logs(filter: {fromBlock: 0}) { index } }`, ""},
+		{"block range exceeding the complexity", `{ blocks(from: 0, to: 100000) { number } }`, "complexity"},
+		{"fields within the complexity", `{ blocks(from: 0, to: 3) { transactions { from { balance code } } } }`, ""},
+		{"nested fields exceeding the complexity", `{
+			a: blocks(from: 0, to: 3) { number hash parent { number hash stateRoot gasUsed gasLimit } }
+			b: blocks(from: 0, to: 3) { number hash parent { number hash stateRoot gasUsed gasLimit } }
+			c: blocks(from: 0, to: 3) { number hash parent { number hash stateRoot gasUsed gasLimit } }
+			d: blocks(from: 0, to: 3) { number hash parent { number hash stateRoot gasUsed gasLimit } }
+		}`, "complexity"},
+		{"log range exceeding the complexity", `{ logs(filter: {fromBlock: 0, toBlock: 1000000}) { index } }`, "complexity"},
+		{"invalid block range", `{ blocks(from: 3, to: 0) { number } }`, errGraphQLBlockRange.Error()},
+		{"depth exceeded", `{ block { parent { parent { parent { number } } } } }`, "depth"},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			resp := g.Execute(context.Background(), &graphQLRequest{Query: c.query})
+
+			if c.err == "" {
+				assert.Empty(t, resp.Errors)
+
+				return
+			}
+
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Contains(t, resp.Errors[0].Message, c.err)
+			}
+		})
+	}
+}
+
+func TestGraphQL_AllowedMethods(t *testing.T) {
+	g := newTestGraphQL(t, newTestGraphQLStore(), &GraphQLConfig{})
+
+	client := &rpcClient{name: "partner", policy: &ClientPolicy{Methods: []string{"graphql", "eth_call"}}}
+	ctx := withGraphQLClient(context.Background(), client)
+
+	// the fields are allowed as their JSON-RPC method
+	resp := g.Execute(ctx, &graphQLRequest{Query: `{
+		pending { call(data: {to: "` + addr2.String() + `"}) { status } }
+		block(number: 2) { logs(filter: {}) { index } }
+	}`})
+
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, NewMethodNotAllowedError("eth_getLogs").Error(), resp.Errors[0].Message)
+	}
+
+	resp = g.Execute(ctx, &graphQLRequest{Query: `mutation { sendRawTransaction(data: "0x01") }`})
+
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, NewMethodNotAllowedError("eth_sendRawTransaction").Error(), resp.Errors[0].Message)
+	}
+
+	resp = g.Execute(ctx, &graphQLRequest{Query: `{ pending { estimateGas(data: {}) } }`})
+
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, NewMethodNotAllowedError("eth_estimateGas").Error(), resp.Errors[0].Message)
+	}
+}
+
+func TestGraphQL_SendRawTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	g := newTestGraphQL(t, &mockGraphQLStore{
+		mockBlockStore: &mockBlockStore{ethStore: store},
+	}, &GraphQLConfig{})
+
+	txn := &types.Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       &addr1,
+		Value:    big.NewInt(1),
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+	}
+	txn.ComputeHash()
+
+	data := executeGraphQL(t, g, `mutation($data: Bytes!) { sendRawTransaction(data: $data) }`, map[string]interface{}{
+		"data": hex.EncodeToHex(txn.MarshalRLP()),
+	})
+
+	assert.Equal(t, map[string]interface{}{"sendRawTransaction": txn.Hash.String()}, data)
+	assert.Equal(t, txn.Hash, store.txn.Hash)
+}
+
+func TestJSONRPC_HandleGraphQL(t *testing.T) {
+	guard, err := newAccessGuard(&AccessConfig{
+		Clients: []*ClientConfig{{Name: "partner", APIKey: "key", ClientPolicy: ClientPolicy{Methods: []string{"graphql"}}}},
+		Default: ClientPolicy{Methods: []string{"eth"}},
+	})
+	assert.NoError(t, err)
+
+	j := &JSONRPC{
+		logger:  hclog.NewNullLogger(),
+		guard:   guard,
+		graphQL: newTestGraphQL(t, newTestGraphQLStore(), &GraphQLConfig{MaxDepth: 2}),
+	}
+
+	handle := func(method, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/graphql", strings.NewReader(body))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		recorder := httptest.NewRecorder()
+		j.handleGraphQL(recorder, req)
+
+		return recorder
+	}
+
+	partner := map[string]string{apiKeyHeader: "key"}
+	request := `{"query": "query($n: Long) { block(number: $n) { number } }", "variables": {"n": 2}}`
+
+	// the anonymous clients can only call the eth namespace
+	assert.Equal(t, http.StatusForbidden, handle(http.MethodPost, request, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, handle(http.MethodPost, request, map[string]string{apiKeyHeader: "bad"}).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, handle(http.MethodGet, "", partner).Code)
+	assert.Equal(t, http.StatusBadRequest, handle(http.MethodPost, "{", partner).Code)
+
+	resp := handle(http.MethodPost, request, partner)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"data": {"block": {"number": 2}}}`, resp.Body.String())
+
+	// the errors of the query are returned with the status OK
+	resp = handle(http.MethodPost, `{"query": "{ block { parent { parent { number } } } }"}`, partner)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var gqlResp struct {
+		Errors []*gqlerrors.QueryError `json:"errors"`
+	}
+
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gqlResp))
+
+	if assert.Len(t, gqlResp.Errors, 1) {
+		assert.Contains(t, gqlResp.Errors[0].Message, "depth")
+	}
+}
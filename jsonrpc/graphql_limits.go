@@ -0,0 +1,131 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+	"github.com/hashicorp/go-hclog"
+)
+
+type gqlContextKey int
+
+const (
+	// gqlBudgetKey is the context key of the complexity budget of a query
+	gqlBudgetKey gqlContextKey = iota
+
+	// gqlClientKey is the context key of the client running a query
+	gqlClientKey
+)
+
+// gqlBudget is the complexity budget of a query, the number of fields it can resolve.
+// The fields of a list are charged once per item, and the block ranges are charged
+// by their number of blocks before they are scanned
+type gqlBudget struct {
+	limit  int64
+	used   int64
+	cancel context.CancelFunc
+}
+
+// withGraphQLBudget returns the context of a query limited to the complexity,
+// it's canceled once the budget is spent. An unlimited budget is used if zero
+func withGraphQLBudget(ctx context.Context, limit int) (context.Context, *gqlBudget) {
+	ctx, cancel := context.WithCancel(ctx)
+	budget := &gqlBudget{limit: int64(limit), cancel: cancel}
+
+	return context.WithValue(ctx, gqlBudgetKey, budget), budget
+}
+
+// chargeGraphQLBudget takes n from the budget of the query in the context,
+// the query is canceled if the budget is exceeded
+func chargeGraphQLBudget(ctx context.Context, n int) error {
+	budget, ok := ctx.Value(gqlBudgetKey).(*gqlBudget)
+	if !ok || budget.limit == 0 {
+		return nil
+	}
+
+	if atomic.AddInt64(&budget.used, int64(n)) > budget.limit {
+		budget.cancel()
+
+		return budget.err()
+	}
+
+	return nil
+}
+
+// exceeded returns true if the query was canceled for exceeding the budget
+func (b *gqlBudget) exceeded() bool {
+	return b.limit != 0 && atomic.LoadInt64(&b.used) > b.limit
+}
+
+func (b *gqlBudget) err() error {
+	return fmt.Errorf("query complexity exceeds the limit of %d", b.limit)
+}
+
+// rangeSize returns the number of blocks in the range
+func rangeSize(from, to uint64) int {
+	if to-from >= math.MaxInt32 {
+		return math.MaxInt32
+	}
+
+	return int(to-from) + 1
+}
+
+// withGraphQLClient returns the context of a query run by the client
+func withGraphQLClient(ctx context.Context, client *rpcClient) context.Context {
+	return context.WithValue(ctx, gqlClientKey, client)
+}
+
+// allowGraphQLMethod checks if the client running the query can call the JSON-RPC method
+// matching a field, the fields calling the state or scanning the chain are allowed
+// as their JSON-RPC counterpart
+func allowGraphQLMethod(ctx context.Context, method string) error {
+	client, ok := ctx.Value(gqlClientKey).(*rpcClient)
+	if !ok {
+		return nil
+	}
+
+	if err := client.allowMethod(method); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// gqlTracer charges every resolved field to the budget of the query
+type gqlTracer struct{}
+
+func (gqlTracer) TraceQuery(
+	ctx context.Context,
+	_ string,
+	_ string,
+	_ map[string]interface{},
+	_ map[string]*introspection.Type,
+) (context.Context, trace.TraceQueryFinishFunc) {
+	return ctx, func([]*gqlerrors.QueryError) {}
+}
+
+func (gqlTracer) TraceField(
+	ctx context.Context,
+	_, _, _ string,
+	_ bool,
+	_ map[string]interface{},
+) (context.Context, trace.TraceFieldFinishFunc) {
+	// the exceeded budget cancels the context, the remaining fields aren't resolved
+	_ = chargeGraphQLBudget(ctx, 1)
+
+	return ctx, func(*gqlerrors.QueryError) {}
+}
+
+// gqlLogger logs the panics of the resolvers
+type gqlLogger struct {
+	logger hclog.Logger
+}
+
+func (l *gqlLogger) LogPanic(_ context.Context, value interface{}) {
+	l.logger.Error("graphql resolver panic", "err", value)
+}
@@ -0,0 +1,192 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The custom scalars of the EIP-1767 schema
+
+// gqlBytes32 is a 32 byte value, encoded as hex
+type gqlBytes32 types.Hash
+
+func (gqlBytes32) ImplementsGraphQLType(name string) bool {
+	return name == "Bytes32"
+}
+
+func (b *gqlBytes32) UnmarshalGraphQL(input interface{}) error {
+	buf, err := parseHexBytes(input)
+	if err != nil || len(buf) != types.HashLength {
+		return fmt.Errorf("Bytes32 cannot represent %v", input)
+	}
+
+	*b = gqlBytes32(types.BytesToHash(buf))
+
+	return nil
+}
+
+func (b gqlBytes32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(types.Hash(b).String())
+}
+
+// gqlAddress is a 20 byte address, encoded as hex
+type gqlAddress types.Address
+
+func (gqlAddress) ImplementsGraphQLType(name string) bool {
+	return name == "Address"
+}
+
+func (a *gqlAddress) UnmarshalGraphQL(input interface{}) error {
+	buf, err := parseHexBytes(input)
+	if err != nil || len(buf) != types.AddressLength {
+		return fmt.Errorf("Address cannot represent %v", input)
+	}
+
+	*a = gqlAddress(types.BytesToAddress(buf))
+
+	return nil
+}
+
+func (a gqlAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(types.Address(a).String())
+}
+
+// gqlBytes is an arbitrary length binary string, encoded as hex
+type gqlBytes []byte
+
+func (gqlBytes) ImplementsGraphQLType(name string) bool {
+	return name == "Bytes"
+}
+
+func (b *gqlBytes) UnmarshalGraphQL(input interface{}) error {
+	buf, err := parseHexBytes(input)
+	if err != nil {
+		return fmt.Errorf("Bytes cannot represent %v", input)
+	}
+
+	*b = buf
+
+	return nil
+}
+
+func (b gqlBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToHex(b))
+}
+
+// gqlBigInt is a large integer, encoded as hex.
+// The input is either a number, or a decimal or 0x prefixed hex string
+type gqlBigInt big.Int
+
+// toGQLBigInt converts the integer, nil being zero
+func toGQLBigInt(n *big.Int) gqlBigInt {
+	if n == nil {
+		return gqlBigInt{}
+	}
+
+	return gqlBigInt(*n)
+}
+
+func (gqlBigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+func (b *gqlBigInt) UnmarshalGraphQL(input interface{}) error {
+	var raw string
+
+	switch v := input.(type) {
+	case string:
+		raw = v
+	case int32:
+		raw = strconv.FormatInt(int64(v), 10)
+	case float64:
+		if v != math.Trunc(v) {
+			return fmt.Errorf("BigInt cannot represent %v", input)
+		}
+
+		raw = strconv.FormatFloat(v, 'f', 0, 64)
+	default:
+		return fmt.Errorf("BigInt cannot represent %v", input)
+	}
+
+	n, ok := new(big.Int).SetString(raw, 10)
+	if strings.HasPrefix(raw, "0x") {
+		n, ok = new(big.Int).SetString(raw[2:], 16)
+	}
+
+	if !ok || n.Sign() < 0 {
+		return fmt.Errorf("BigInt cannot represent %v", input)
+	}
+
+	*b = gqlBigInt(*n)
+
+	return nil
+}
+
+func (b gqlBigInt) MarshalJSON() ([]byte, error) {
+	n := big.Int(b)
+
+	return json.Marshal(hex.EncodeBig(&n))
+}
+
+// gqlLong is a 64 bit unsigned integer.
+// The input is either a number, or a decimal or 0x prefixed hex string
+type gqlLong uint64
+
+func (gqlLong) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *gqlLong) UnmarshalGraphQL(input interface{}) error {
+	var (
+		n   uint64
+		err error
+	)
+
+	switch v := input.(type) {
+	case int32:
+		if v < 0 {
+			err = fmt.Errorf("negative value %d", v)
+		}
+
+		n = uint64(v)
+	case float64:
+		if v < 0 || v >= math.MaxUint64 || v != math.Trunc(v) {
+			err = fmt.Errorf("invalid value %v", v)
+		}
+
+		n = uint64(v)
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			n, err = strconv.ParseUint(v[2:], 16, 64)
+		} else {
+			n, err = strconv.ParseUint(v, 10, 64)
+		}
+	default:
+		err = fmt.Errorf("unexpected type %T", input)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Long cannot represent %v", input)
+	}
+
+	*l = gqlLong(n)
+
+	return nil
+}
+
+// parseHexBytes decodes a 0x prefixed hex string
+func parseHexBytes(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("expected a 0x prefixed hex string")
+	}
+
+	return hex.DecodeHex(s)
+}
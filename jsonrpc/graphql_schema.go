@@ -0,0 +1,157 @@
+package jsonrpc
+
+// graphQLSchema is the EIP-1767 schema served by the GraphQL endpoint
+const graphQLSchema = `
+# Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+scalar Bytes32
+# Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+scalar Address
+# Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+# An empty byte string is represented as '0x'.
+scalar Bytes
+# BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+# Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+# 0x-prefixed hexadecimal.
+scalar BigInt
+# Long is a 64 bit unsigned integer.
+scalar Long
+
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+# Account is an Ethereum account at a particular block.
+type Account {
+	address: Address!
+	balance: BigInt!
+	transactionCount: Long!
+	code: Bytes!
+	storage(slot: Bytes32!): Bytes32!
+}
+
+# Log is an Ethereum event log.
+type Log {
+	index: Int!
+	account(block: Long): Account!
+	topics: [Bytes32!]!
+	data: Bytes!
+	transaction: Transaction!
+}
+
+# Transaction is an Ethereum transaction.
+type Transaction {
+	hash: Bytes32!
+	nonce: Long!
+	index: Int
+	from(block: Long): Account!
+	to(block: Long): Account
+	value: BigInt!
+	gasPrice: BigInt!
+	gas: Long!
+	inputData: Bytes!
+	block: Block
+	status: Long
+	gasUsed: Long
+	cumulativeGasUsed: Long
+	createdContract(block: Long): Account
+	logs: [Log!]
+	r: BigInt!
+	s: BigInt!
+	v: BigInt!
+}
+
+# BlockFilterCriteria encapsulates log filter criteria for a filter applied
+# to a single block.
+input BlockFilterCriteria {
+	addresses: [Address!]
+	topics: [[Bytes32!]!]
+}
+
+# Block is an Ethereum block.
+type Block {
+	number: Long!
+	hash: Bytes32!
+	parent: Block
+	nonce: Bytes!
+	transactionsRoot: Bytes32!
+	transactionCount: Int
+	stateRoot: Bytes32!
+	receiptsRoot: Bytes32!
+	miner(block: Long): Account!
+	extraData: Bytes!
+	gasLimit: Long!
+	gasUsed: Long!
+	timestamp: BigInt!
+	logsBloom: Bytes!
+	mixHash: Bytes32!
+	difficulty: BigInt!
+	totalDifficulty: BigInt!
+	ommerCount: Int
+	ommers: [Block]
+	ommerAt(index: Int!): Block
+	ommerHash: Bytes32!
+	transactions: [Transaction!]
+	transactionAt(index: Int!): Transaction
+	logs(filter: BlockFilterCriteria!): [Log!]!
+	account(address: Address!): Account!
+	call(data: CallData!): CallResult
+	estimateGas(data: CallData!): Long!
+}
+
+# CallData represents the data associated with a local contract call.
+input CallData {
+	from: Address
+	to: Address
+	gas: Long
+	gasPrice: BigInt
+	value: BigInt
+	data: Bytes
+}
+
+# CallResult is the result of a local call operation.
+type CallResult {
+	data: Bytes!
+	gasUsed: Long!
+	status: Long!
+}
+
+# FilterCriteria encapsulates log filter criteria for searching log entries.
+input FilterCriteria {
+	fromBlock: Long
+	toBlock: Long
+	addresses: [Address!]
+	topics: [[Bytes32!]!]
+}
+
+# SyncState contains the current synchronisation state of the client.
+type SyncState {
+	startingBlock: Long!
+	currentBlock: Long!
+	highestBlock: Long!
+}
+
+# Pending represents the current pending state.
+type Pending {
+	transactionCount: Int!
+	transactions: [Transaction!]
+	account(address: Address!): Account!
+	call(data: CallData!): CallResult
+	estimateGas(data: CallData!): Long!
+}
+
+type Query {
+	block(number: Long, hash: Bytes32): Block
+	blocks(from: Long!, to: Long): [Block!]!
+	pending: Pending!
+	transaction(hash: Bytes32!): Transaction
+	logs(filter: FilterCriteria!): [Log!]!
+	gasPrice: BigInt!
+	chainID: BigInt!
+	syncing: SyncState
+}
+
+type Mutation {
+	sendRawTransaction(data: Bytes!): Bytes32!
+}
+`
@@ -0,0 +1,76 @@
+package jsonrpc
+
+import (
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ValidatorStats are the statistics of an IBFT validator
+type ValidatorStats struct {
+	ProposedBlocks uint64
+	CommittedSeals uint64
+	MissedRounds   uint64
+}
+
+// ibftStore provides access to the methods needed by ibft endpoint
+type ibftStore interface {
+	// GetValidatorStats returns the statistics of the validators added up over the epochs,
+	// a zero toEpoch is the latest epoch. The statistics are partial while the validator
+	// history is backfilled
+	GetValidatorStats(fromEpoch, toEpoch uint64) (map[types.Address]*ValidatorStats, bool, error)
+}
+
+// Ibft is the ibft jsonrpc endpoint
+type Ibft struct {
+	store ibftStore
+}
+
+type validatorStatsResponse struct {
+	// Partial is set while the blocks written before the validator history are recorded
+	Partial bool              `json:"partial"`
+	Stats   []*validatorStats `json:"stats"`
+}
+
+type validatorStats struct {
+	Address        types.Address `json:"address"`
+	ProposedBlocks argUint64     `json:"proposedBlocks"`
+	CommittedSeals argUint64     `json:"committedSeals"`
+	MissedRounds   argUint64     `json:"missedRounds"`
+}
+
+// GetValidatorStats returns the statistics of the validators in the epochs, all the epochs
+// are included by default (ibft_getValidatorStats)
+func (i *Ibft) GetValidatorStats(fromEpoch, toEpoch *argUint64) (interface{}, error) {
+	var from, to uint64
+
+	if fromEpoch != nil {
+		from = uint64(*fromEpoch)
+	}
+
+	if toEpoch != nil {
+		to = uint64(*toEpoch)
+	}
+
+	stats, partial, err := i.store.GetValidatorStats(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*validatorStats, 0, len(stats))
+
+	for addr, s := range stats {
+		res = append(res, &validatorStats{
+			Address:        addr,
+			ProposedBlocks: argUint64(s.ProposedBlocks),
+			CommittedSeals: argUint64(s.CommittedSeals),
+			MissedRounds:   argUint64(s.MissedRounds),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Address.String() < res[j].Address.String()
+	})
+
+	return &validatorStatsResponse{Partial: partial, Stats: res}, nil
+}
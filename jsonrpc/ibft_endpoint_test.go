@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type ibftMockStore struct {
+	*mockStore
+
+	fromEpoch, toEpoch uint64
+	partial            bool
+}
+
+func (m *ibftMockStore) GetValidatorStats(
+	fromEpoch, toEpoch uint64,
+) (map[types.Address]*ValidatorStats, bool, error) {
+	m.fromEpoch, m.toEpoch = fromEpoch, toEpoch
+
+	return map[types.Address]*ValidatorStats{
+		types.StringToAddress("2"): {ProposedBlocks: 3, CommittedSeals: 5},
+		types.StringToAddress("1"): {ProposedBlocks: 2, CommittedSeals: 4, MissedRounds: 1},
+	}, m.partial, nil
+}
+
+func TestIbftEndpointGetValidatorStats(t *testing.T) {
+	store := &ibftMockStore{mockStore: newMockStore()}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+
+	resp, err := dispatcher.Handle([]byte(`{
+		"method": "ibft_getValidatorStats",
+		"params": ["0x1", "0x2"]
+	}`))
+	assert.NoError(t, err)
+
+	var res *validatorStatsResponse
+
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Equal(t, uint64(1), store.fromEpoch)
+	assert.Equal(t, uint64(2), store.toEpoch)
+	assert.False(t, res.Partial)
+	assert.Equal(t, []*validatorStats{
+		{
+			Address:        types.StringToAddress("1"),
+			ProposedBlocks: 2,
+			CommittedSeals: 4,
+			MissedRounds:   1,
+		},
+		{
+			Address:        types.StringToAddress("2"),
+			ProposedBlocks: 3,
+			CommittedSeals: 5,
+		},
+	}, res.Stats)
+
+	// all the epochs are included by default, the statistics are partial while backfilled
+	store.partial = true
+
+	resp, err = dispatcher.Handle([]byte(`{
+		"method": "ibft_getValidatorStats",
+		"params": []
+	}`))
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Equal(t, uint64(0), store.fromEpoch)
+	assert.Equal(t, uint64(0), store.toEpoch)
+	assert.True(t, res.Partial)
+}
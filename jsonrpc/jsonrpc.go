@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -8,6 +10,8 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -37,6 +41,8 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+	guard      *accessGuard
+	graphQL    *GraphQL // nil if the GraphQL endpoint is disabled
 }
 
 type dispatcher interface {
@@ -51,6 +57,8 @@ type JSONRPCStore interface {
 	networkStore
 	txPoolStore
 	filterManagerStore
+	debugStore
+	ibftStore
 }
 
 type Config struct {
@@ -58,14 +66,36 @@ type Config struct {
 	Addr                     *net.TCPAddr
 	ChainID                  uint64
 	AccessControlAllowOrigin []string
+	EnableDebug              bool
+	Access                   *AccessConfig
+	GraphQL                  *GraphQLConfig // the GraphQL endpoint is served on /graphql if set
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	guard, err := newAccessGuard(config.Access)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDispatcher(logger, config.Store, config.ChainID)
+	if config.EnableDebug {
+		d.registerDebugEndpoint(config.Store)
+	}
+
+	d.methodTimeouts = guard.config.MethodTimeouts
+
 	srv := &JSONRPC{
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
-		dispatcher: newDispatcher(logger, config.Store, config.ChainID),
+		dispatcher: d,
+		guard:      guard,
+	}
+
+	if config.GraphQL != nil {
+		if srv.graphQL, err = newGraphQL(logger, d.endpoints.Eth, config.Store, config.GraphQL); err != nil {
+			return nil, err
+		}
 	}
 
 	// start http server
@@ -92,6 +122,10 @@ func (j *JSONRPC) setupHTTP() error {
 
 	mux.HandleFunc("/ws", j.handleWs)
 
+	if j.graphQL != nil {
+		mux.Handle("/graphql", middlewareFactory(j.config)(http.HandlerFunc(j.handleGraphQL)))
+	}
+
 	srv := http.Server{
 		Handler: mux,
 	}
@@ -171,6 +205,14 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
+	// Authenticate the client before the upgrade
+	client, err := j.guard.authenticate(req)
+	if err != nil {
+		writeHTTPError(w, http.StatusUnauthorized, NewUnauthorizedError(err.Error()))
+
+		return
+	}
+
 	// Upgrade the connection to a WS one
 	ws, err := wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
@@ -189,7 +231,12 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
+	if j.guard.config.MaxBodySize > 0 {
+		ws.SetReadLimit(int64(j.guard.config.MaxBodySize))
+	}
+
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	guarded := j.guard.wrap(client, j.dispatcher)
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -214,7 +261,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := guarded.HandleWs(message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -256,7 +303,14 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	data, err := ioutil.ReadAll(req.Body)
+	client, err := j.guard.authenticate(req)
+	if err != nil {
+		writeHTTPError(w, http.StatusUnauthorized, NewUnauthorizedError(err.Error()))
+
+		return
+	}
+
+	data, err := ioutil.ReadAll(j.guard.limitBody(req.Body))
 
 	if err != nil {
 		//nolint
@@ -265,10 +319,16 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err := j.guard.checkBodySize(len(data)); err != nil {
+		writeHTTPError(w, http.StatusRequestEntityTooLarge, err)
+
+		return
+	}
+
 	// log request
-	j.logger.Debug("handle", "request", string(data))
+	j.logger.Debug("handle", "client", client.name, "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.guard.wrap(client, j.dispatcher).Handle(data)
 
 	if err != nil {
 		//nolint
@@ -280,3 +340,114 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 
 	j.logger.Debug("handle", "response", string(resp))
 }
+
+// graphQLMethod is the method name of the GraphQL requests, for the method
+// allowlists and timeouts of the clients. The fields calling the state, scanning
+// the logs or sending transactions also need their JSON-RPC method allowed
+const graphQLMethod = "graphql"
+
+func (j *JSONRPC) handleGraphQL(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set(
+		"Access-Control-Allow-Headers",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+	)
+
+	if req.Method == "OPTIONS" {
+		return
+	}
+
+	if req.Method != "POST" {
+		writeGraphQLError(w, http.StatusMethodNotAllowed, "method "+req.Method+" not allowed")
+
+		return
+	}
+
+	client, err := j.guard.authenticate(req)
+	if err != nil {
+		writeGraphQLError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	data, err := ioutil.ReadAll(j.guard.limitBody(req.Body))
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if err := j.guard.checkBodySize(len(data)); err != nil {
+		writeGraphQLError(w, http.StatusRequestEntityTooLarge, err.Error())
+
+		return
+	}
+
+	if err := client.allowMethod(graphQLMethod); err != nil {
+		writeGraphQLError(w, http.StatusForbidden, err.Error())
+
+		return
+	}
+
+	if err := client.allowRequests(1); err != nil {
+		writeGraphQLError(w, http.StatusTooManyRequests, err.Error())
+
+		return
+	}
+
+	gqlReq := &graphQLRequest{}
+
+	if err := json.Unmarshal(data, gqlReq); err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+
+		return
+	}
+
+	j.logger.Debug("handle graphql", "client", client.name, "request", string(data))
+
+	// the fields are also checked against the methods allowed to the client
+	ctx := withGraphQLClient(req.Context(), client)
+
+	if timeout := methodTimeout(j.guard.config.MethodTimeouts, graphQLMethod); timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	resp, err := json.Marshal(j.graphQL.Execute(ctx, gqlReq))
+	if err != nil {
+		writeGraphQLError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	//nolint
+	w.Write(resp)
+
+	j.logger.Debug("handle graphql", "response", string(resp))
+}
+
+// writeGraphQLError writes the error of a GraphQL request rejected before its execution
+func writeGraphQLError(w http.ResponseWriter, status int, msg string) {
+	resp, _ := json.Marshal(&graphql.Response{Errors: []*gqlerrors.QueryError{{Message: msg}}})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint
+	w.Write(resp)
+}
+
+// writeHTTPError writes the JSON-RPC error of a request rejected before dispatching
+func writeHTTPError(w http.ResponseWriter, status int, rpcErr Error) {
+	resp, err := NewRPCResponse(nil, "2.0", nil, rpcErr).Bytes()
+	if err != nil {
+		resp = []byte(rpcErr.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint
+	w.Write(resp)
+}
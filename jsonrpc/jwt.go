@@ -0,0 +1,110 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	errMalformedJWT       = errors.New("malformed JWT")
+	errUnsupportedJWTAlg  = errors.New("unsupported JWT algorithm, only HS256 is supported")
+	errInvalidJWTSig      = errors.New("invalid JWT signature")
+	errExpiredJWT         = errors.New("expired JWT")
+	errJWTNotYetValid     = errors.New("JWT is not valid yet")
+	errStaleJWT           = errors.New("JWT issued-at is outside the allowed window")
+	errMissingJWTExpiry   = errors.New("JWT has neither an exp nor an iat claim")
+	errInvalidJWTClaimSet = errors.New("invalid JWT claims")
+)
+
+// jwtIssuedAtWindow is the allowed drift of the issued-at of a JWT without an expiry
+const jwtIssuedAtWindow = 60 * time.Second
+
+// jwtHeader is the JOSE header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// jwtClaims are the registered claims of a JWT used by the server
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt *int64 `json:"exp"`
+	IssuedAt  *int64 `json:"iat"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// verifyJWT verifies the HS256 signature and the validity period of the token,
+// and returns its claims. The token must expire, or be issued within jwtIssuedAtWindow
+// of now, so it can't be replayed forever
+func verifyJWT(token string, secret []byte, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedJWT
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, errMalformedJWT
+	}
+
+	if header.Alg != "HS256" {
+		return nil, errUnsupportedJWTAlg
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedJWT
+	}
+
+	if !hmac.Equal(sig, signJWT(parts[0]+"."+parts[1], secret)) {
+		return nil, errInvalidJWTSig
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, errInvalidJWTClaimSet
+	}
+
+	if claims.ExpiresAt == nil && claims.IssuedAt == nil {
+		return nil, errMissingJWTExpiry
+	}
+
+	if claims.ExpiresAt != nil && now.Unix() >= *claims.ExpiresAt {
+		return nil, errExpiredJWT
+	}
+
+	if claims.IssuedAt != nil {
+		drift := now.Sub(time.Unix(*claims.IssuedAt, 0))
+		if drift > jwtIssuedAtWindow || drift < -jwtIssuedAtWindow {
+			return nil, errStaleJWT
+		}
+	}
+
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return nil, errJWTNotYetValid
+	}
+
+	return &claims, nil
+}
+
+// signJWT returns the HS256 signature of the signing input of a JWT
+func signJWT(signingInput string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return mac.Sum(nil)
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
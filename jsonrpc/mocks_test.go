@@ -3,10 +3,13 @@ package jsonrpc
 import (
 	"errors"
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"math/big"
 	"sync"
+	"sync/atomic"
 )
 
 type mockAccount struct {
@@ -47,21 +50,44 @@ type mockEvent struct {
 type mockStore struct {
 	JSONRPCStore
 
-	header       *types.Header
-	subscription *blockchain.MockSubscription
-	receiptsLock sync.Mutex
-	receipts     map[types.Hash][]*types.Receipt
-	accounts     map[types.Address]*state.Account
+	header        *types.Header
+	subscription  *blockchain.MockSubscription
+	receiptsLock  sync.Mutex
+	receipts      map[types.Hash][]*types.Receipt
+	accounts      map[types.Address]*state.Account
+	txPoolEventCh chan *proto.TxPoolEvent
+	txPoolSubs    int32
+	pendingTxs    sync.Map
+	syncLock      sync.Mutex
+	progression   *progress.Progression
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
-		header:       &types.Header{Number: 0},
-		subscription: blockchain.NewMockSubscription(),
-		accounts:     map[types.Address]*state.Account{},
+		header:        &types.Header{Number: 0},
+		subscription:  blockchain.NewMockSubscription(),
+		accounts:      map[types.Address]*state.Account{},
+		txPoolEventCh: make(chan *proto.TxPoolEvent),
 	}
 }
 
+// emitPromotedTx adds the transaction to the pending ones and emits its promoted event
+func (m *mockStore) emitPromotedTx(txn *types.Transaction) {
+	m.pendingTxs.Store(txn.Hash, txn)
+
+	m.txPoolEventCh <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: txn.Hash.String(),
+	}
+}
+
+func (m *mockStore) setSyncProgression(progression *progress.Progression) {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	m.progression = progression
+}
+
 func (m *mockStore) emitEvent(evnt *mockEvent) {
 	if m.receipts == nil {
 		m.receipts = map[types.Hash][]*types.Receipt{}
@@ -114,6 +140,30 @@ func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
 
+func (m *mockStore) SubscribeTxPoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	atomic.AddInt32(&m.txPoolSubs, 1)
+
+	return m.txPoolEventCh, func() {
+		atomic.AddInt32(&m.txPoolSubs, -1)
+	}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	txn, ok := m.pendingTxs.Load(txHash)
+	if !ok {
+		return nil, false
+	}
+
+	return txn.(*types.Transaction), true
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	return m.progression
+}
+
 func (m *mockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	return nil, false
 }
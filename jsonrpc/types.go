@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -169,6 +170,7 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	RevertReason      *argBytes      `json:"revertReason,omitempty"`
 }
 
 type Log struct {
@@ -310,3 +312,76 @@ type progression struct {
 	CurrentBlock  string `json:"currentBlock"`
 	HighestBlock  string `json:"highestBlock"`
 }
+
+func toProgression(p *progress.Progression) progression {
+	return progression{
+		Type:          string(p.SyncType),
+		StartingBlock: hex.EncodeUint64(p.StartingBlock),
+		CurrentBlock:  hex.EncodeUint64(p.CurrentBlock),
+		HighestBlock:  hex.EncodeUint64(p.HighestBlock),
+	}
+}
+
+// stateOverride is the state override argument of the call endpoints
+type stateOverride map[types.Address]overrideAccount
+
+type overrideAccount struct {
+	Nonce     *argUint64                `json:"nonce"`
+	Code      *argBytes                 `json:"code"`
+	Balance   *argBig                   `json:"balance"`
+	State     map[types.Hash]types.Hash `json:"state"`
+	StateDiff map[types.Hash]types.Hash `json:"stateDiff"`
+}
+
+func (s stateOverride) toTypes() types.StateOverride {
+	if s == nil {
+		return nil
+	}
+
+	override := types.StateOverride{}
+
+	for addr, account := range s {
+		acc := types.OverrideAccount{
+			State:     account.State,
+			StateDiff: account.StateDiff,
+		}
+
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			acc.Nonce = &nonce
+		}
+
+		if account.Code != nil {
+			acc.Code = *account.Code
+		}
+
+		if account.Balance != nil {
+			acc.Balance = (*big.Int)(account.Balance)
+		}
+
+		override[addr] = acc
+	}
+
+	return override
+}
+
+// blockOverride is the block override argument of the call endpoints
+type blockOverride struct {
+	Number    *argUint64     `json:"number"`
+	Timestamp *argUint64     `json:"time"`
+	GasLimit  *argUint64     `json:"gasLimit"`
+	Coinbase  *types.Address `json:"coinbase"`
+}
+
+func (b *blockOverride) toTypes() *types.BlockOverride {
+	if b == nil {
+		return nil
+	}
+
+	return &types.BlockOverride{
+		Number:    (*uint64)(b.Number),
+		Timestamp: (*uint64)(b.Timestamp),
+		GasLimit:  (*uint64)(b.GasLimit),
+		Coinbase:  b.Coinbase,
+	}
+}
@@ -0,0 +1,188 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	ErrPeerAllowlistDisabled = errors.New("peer allow list is not enabled")
+	ErrNoPeerAllowlistSource = errors.New("on-chain peer allow list source is not set")
+)
+
+// PeerAllowlistSource provides additional permitted peer IDs, like the on-chain allow list
+type PeerAllowlistSource func() ([]peer.ID, error)
+
+// peerAllowlist keeps the peer IDs permitted to connect to the node.
+// When disabled, all the peers are permitted
+type peerAllowlist struct {
+	sync.RWMutex
+
+	enabled bool
+	peers   map[peer.ID]struct{}
+}
+
+func newPeerAllowlist(enabled bool) *peerAllowlist {
+	return &peerAllowlist{
+		enabled: enabled,
+		peers:   make(map[peer.ID]struct{}),
+	}
+}
+
+// set replaces the permitted peers, returns false if they haven't changed [Thread safe]
+func (a *peerAllowlist) set(peerIDs []peer.ID) bool {
+	peers := make(map[peer.ID]struct{}, len(peerIDs))
+	for _, id := range peerIDs {
+		peers[id] = struct{}{}
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	changed := len(peers) != len(a.peers)
+
+	for id := range peers {
+		if _, ok := a.peers[id]; !ok {
+			changed = true
+		}
+	}
+
+	a.peers = peers
+
+	return changed
+}
+
+// isAllowed checks if the peer is permitted to connect [Thread safe]
+func (a *peerAllowlist) isAllowed(peerID peer.ID) bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	if !a.enabled {
+		return true
+	}
+
+	_, ok := a.peers[peerID]
+
+	return ok
+}
+
+// list returns the permitted peers, ordered [Thread safe]
+func (a *peerAllowlist) list() []peer.ID {
+	a.RLock()
+	defer a.RUnlock()
+
+	peers := make([]peer.ID, 0, len(a.peers))
+	for id := range a.peers {
+		peers = append(peers, id)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i] < peers[j]
+	})
+
+	return peers
+}
+
+// readPeerAllowlistFile reads the allow list file, which contains one peer ID per line.
+// Empty lines and lines starting with # are ignored
+func readPeerAllowlistFile(path string) ([]peer.ID, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read peer allow list, %w", err)
+	}
+
+	peerIDs := make([]peer.ID, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		rawID := strings.TrimSpace(scanner.Text())
+		if rawID == "" || strings.HasPrefix(rawID, "#") {
+			continue
+		}
+
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer ID at line %d of the peer allow list, %w", line, err)
+		}
+
+		peerIDs = append(peerIDs, id)
+	}
+
+	return peerIDs, scanner.Err()
+}
+
+// isPeerAllowlistEnabled checks if an admission policy is configured for the node
+func (s *Server) isPeerAllowlistEnabled() bool {
+	return s.config.PeerAllowlistFile != "" || s.config.PeerAllowlistContract
+}
+
+// SetPeerAllowlistSource sets the source of the peer IDs permitted on-chain.
+// It is used only when the on-chain allow list is enabled in the configuration
+func (s *Server) SetPeerAllowlistSource(source PeerAllowlistSource) {
+	s.allowlistSource = source
+}
+
+// ReloadPeerAllowlist reloads the permitted peer IDs from the allow list file
+// and the on-chain allow list, and disconnects from the peers which are no longer permitted.
+// The previous allow list is kept if any of the sources fails to load.
+// It is called on every new head when the on-chain allow list is enabled
+func (s *Server) ReloadPeerAllowlist() ([]peer.ID, error) {
+	if !s.isPeerAllowlistEnabled() {
+		return nil, ErrPeerAllowlistDisabled
+	}
+
+	peerIDs := make([]peer.ID, 0)
+
+	if s.config.PeerAllowlistFile != "" {
+		filePeers, err := readPeerAllowlistFile(s.config.PeerAllowlistFile)
+		if err != nil {
+			return nil, err
+		}
+
+		peerIDs = append(peerIDs, filePeers...)
+	}
+
+	if s.config.PeerAllowlistContract {
+		if s.allowlistSource == nil {
+			return nil, ErrNoPeerAllowlistSource
+		}
+
+		onChainPeers, err := s.allowlistSource()
+		if err != nil {
+			return nil, fmt.Errorf("unable to query on-chain peer allow list, %w", err)
+		}
+
+		peerIDs = append(peerIDs, onChainPeers...)
+	}
+
+	if !s.allowlist.set(peerIDs) {
+		return s.allowlist.list(), nil
+	}
+
+	s.logger.Info("Peer allow list loaded", "peers", len(peerIDs))
+
+	for _, connectionInfo := range s.Peers() {
+		if !s.IsPeerAllowed(connectionInfo.Info.ID) {
+			s.DisconnectFromPeer(connectionInfo.Info.ID, "removed from the peer allow list")
+		}
+	}
+
+	return s.allowlist.list(), nil
+}
+
+// IsPeerAllowed checks if the peer is permitted by the peer allow list.
+// The bootnodes, static and trusted peers are always permitted, so that a new node
+// can sync the chain state holding the on-chain allow list [Thread safe]
+func (s *Server) IsPeerAllowed(peerID peer.ID) bool {
+	return s.allowlist.isAllowed(peerID) ||
+		s.bootnodes.isBootnode(peerID) ||
+		s.staticPeers.isTrusted(peerID)
+}
@@ -0,0 +1,160 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	peerEvent "github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePeerAllowlist(t *testing.T, path string, peerIDs ...peer.ID) {
+	t.Helper()
+
+	content := "# consortium members\n\n"
+	for _, id := range peerIDs {
+		content += fmt.Sprintf("%s\n", id)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Unable to write peer allow list, %v", err)
+	}
+}
+
+func TestReadPeerAllowlistFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	firstID, secondID := newTestPeerID(t), newTestPeerID(t)
+
+	validPath := filepath.Join(dir, "valid")
+	writePeerAllowlist(t, validPath, firstID, secondID)
+
+	peerIDs, err := readPeerAllowlistFile(validPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []peer.ID{firstID, secondID}, peerIDs)
+
+	invalidPath := filepath.Join(dir, "invalid")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte(firstID.String()+"\nnot-a-peer-id\n"), 0600))
+
+	_, err = readPeerAllowlistFile(invalidPath)
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = readPeerAllowlistFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestPeerAllowlist_IsAllowed(t *testing.T) {
+	t.Parallel()
+
+	peerID := newTestPeerID(t)
+
+	// all the peers are permitted when the allow list is disabled
+	assert.True(t, newPeerAllowlist(false).isAllowed(peerID))
+
+	// no peers are permitted until the allow list is loaded
+	allowlist := newPeerAllowlist(true)
+	assert.False(t, allowlist.isAllowed(peerID))
+
+	allowlist.set([]peer.ID{peerID})
+	assert.True(t, allowlist.isAllowed(peerID))
+	assert.Equal(t, []peer.ID{peerID}, allowlist.list())
+}
+
+func TestPeerAllowlist_AlwaysAllowed(t *testing.T) {
+	t.Parallel()
+
+	bootnodeID, staticID, otherID := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	server := &Server{
+		allowlist: newPeerAllowlist(true),
+		bootnodes: &bootnodesWrapper{
+			bootnodesMap: map[peer.ID]*peer.AddrInfo{bootnodeID: {ID: bootnodeID}},
+		},
+		staticPeers: newStaticPeers(),
+	}
+
+	server.staticPeers.addStatic(&peer.AddrInfo{ID: staticID})
+
+	// the bootnodes and static peers are permitted before the on-chain allow list is synced
+	assert.True(t, server.IsPeerAllowed(bootnodeID))
+	assert.True(t, server.IsPeerAllowed(staticID))
+	assert.False(t, server.IsPeerAllowed(otherID))
+}
+
+func TestPeerAllowlist_Admission(t *testing.T) {
+	allowlistPath := filepath.Join(t.TempDir(), "allowlist")
+	writePeerAllowlist(t, allowlistPath)
+
+	var onChainPeers []peer.ID
+
+	noDiscover := func(c *Config) {
+		c.NoDiscover = true
+	}
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				noDiscover(c)
+				c.PeerAllowlistFile = allowlistPath
+				c.PeerAllowlistContract = true
+			},
+			ServerCallback: func(server *Server) {
+				server.SetPeerAllowlistSource(func() ([]peer.ID, error) {
+					return onChainPeers, nil
+				})
+			},
+		},
+		1: {ConfigCallback: noDiscover},
+		2: {ConfigCallback: noDiscover},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	fileID, onChainID := servers[1].AddrInfo().ID, servers[2].AddrInfo().ID
+
+	sub, subErr := servers[0].Subscribe()
+	if subErr != nil {
+		t.Fatalf("Unable to subscribe to peer events, %v", subErr)
+	}
+
+	defer sub.Close()
+
+	// unlisted peers are refused
+	servers[1].joinPeer(servers[0].AddrInfo())
+	waitForEvent(t, sub, fileID, peerEvent.PeerFailedToConnect)
+	assert.False(t, servers[0].hasPeer(fileID))
+
+	// the allow list is reloaded at runtime, from the file and the chain
+	writePeerAllowlist(t, allowlistPath, fileID)
+	onChainPeers = []peer.ID{onChainID}
+
+	peerIDs, err := servers[0].ReloadPeerAllowlist()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []peer.ID{fileID, onChainID}, peerIDs)
+
+	if joinErr := JoinAndWait(servers[1], servers[0], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	if joinErr := JoinAndWait(servers[2], servers[0], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	// the peers removed from the allow list are disconnected
+	onChainPeers = nil
+
+	_, err = servers[0].ReloadPeerAllowlist()
+	assert.NoError(t, err)
+
+	waitForEvent(t, sub, onChainID, peerEvent.PeerDisconnected)
+	assert.True(t, servers[0].hasPeer(fileID))
+}
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"sort"
+	"sync"
+
+	libp2pMetrics "github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// ProtocolTraffic is the traffic exchanged with a peer on a single protocol
+type ProtocolTraffic struct {
+	Protocol   string
+	BytesIn    int64
+	BytesOut   int64
+	StreamsIn  int64
+	StreamsOut int64
+}
+
+// PeerTraffic is the traffic exchanged with a peer since the node started
+type PeerTraffic struct {
+	libp2pMetrics.Stats
+
+	Protocols []ProtocolTraffic // ordered by protocol ID
+}
+
+// bandwidthReporter is the libp2p bandwidth reporter, which keeps track of
+// the traffic by peer and protocol, and feeds it to the network metrics by protocol.
+// The traffic of a peer is dropped once it disconnects
+type bandwidthReporter struct {
+	*libp2pMetrics.BandwidthCounter
+
+	metrics *Metrics
+
+	lock  sync.Mutex
+	peers map[peer.ID]map[protocol.ID]*ProtocolTraffic
+}
+
+func newBandwidthReporter(metrics *Metrics) *bandwidthReporter {
+	return &bandwidthReporter{
+		BandwidthCounter: libp2pMetrics.NewBandwidthCounter(),
+		metrics:          metrics,
+		peers:            make(map[peer.ID]map[protocol.ID]*ProtocolTraffic),
+	}
+}
+
+// protocolTraffic returns the traffic of the peer on the protocol.
+// The lock needs to be held by the caller
+func (r *bandwidthReporter) protocolTraffic(peerID peer.ID, proto protocol.ID) *ProtocolTraffic {
+	protocols, ok := r.peers[peerID]
+	if !ok {
+		protocols = make(map[protocol.ID]*ProtocolTraffic)
+		r.peers[peerID] = protocols
+	}
+
+	traffic, ok := protocols[proto]
+	if !ok {
+		traffic = &ProtocolTraffic{Protocol: string(proto)}
+		protocols[proto] = traffic
+	}
+
+	return traffic
+}
+
+// LogSentMessageStream implements the libp2p metrics.Reporter interface
+func (r *bandwidthReporter) LogSentMessageStream(size int64, proto protocol.ID, peerID peer.ID) {
+	r.BandwidthCounter.LogSentMessageStream(size, proto, peerID)
+	r.metrics.BytesSent.With("protocol", string(proto)).Add(float64(size))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.protocolTraffic(peerID, proto).BytesOut += size
+}
+
+// LogRecvMessageStream implements the libp2p metrics.Reporter interface
+func (r *bandwidthReporter) LogRecvMessageStream(size int64, proto protocol.ID, peerID peer.ID) {
+	r.BandwidthCounter.LogRecvMessageStream(size, proto, peerID)
+	r.metrics.BytesReceived.With("protocol", string(proto)).Add(float64(size))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.protocolTraffic(peerID, proto).BytesIn += size
+}
+
+// logStream records a newly opened stream with the peer
+func (r *bandwidthReporter) logStream(proto protocol.ID, peerID peer.ID, direction network.Direction) {
+	directionLabel := "inbound"
+	if direction == network.DirOutbound {
+		directionLabel = "outbound"
+	}
+
+	r.metrics.StreamsOpened.With("protocol", string(proto), "direction", directionLabel).Add(1)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	traffic := r.protocolTraffic(peerID, proto)
+
+	if direction == network.DirOutbound {
+		traffic.StreamsOut++
+	} else {
+		traffic.StreamsIn++
+	}
+}
+
+// removePeer drops the traffic exchanged with the disconnected peer
+func (r *bandwidthReporter) removePeer(peerID peer.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.peers, peerID)
+}
+
+// peerTraffic returns the traffic exchanged with the peer
+func (r *bandwidthReporter) peerTraffic(peerID peer.ID) *PeerTraffic {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	traffic := &PeerTraffic{
+		Protocols: make([]ProtocolTraffic, 0, len(r.peers[peerID])),
+	}
+
+	// the libp2p meters are only used for the rates,
+	// as their totals are updated periodically
+	stats := r.GetBandwidthForPeer(peerID)
+	traffic.RateIn, traffic.RateOut = stats.RateIn, stats.RateOut
+
+	for _, protocolTraffic := range r.peers[peerID] {
+		traffic.Protocols = append(traffic.Protocols, *protocolTraffic)
+		traffic.TotalIn += protocolTraffic.BytesIn
+		traffic.TotalOut += protocolTraffic.BytesOut
+	}
+
+	sort.Slice(traffic.Protocols, func(i, j int) bool {
+		return traffic.Protocols[i].Protocol < traffic.Protocols[j].Protocol
+	})
+
+	return traffic
+}
+
+// GetPeerTraffic returns the traffic exchanged with the peer, by protocol [Thread safe]
+func (s *Server) GetPeerTraffic(peerID peer.ID) *PeerTraffic {
+	return s.bandwidth.peerTraffic(peerID)
+}
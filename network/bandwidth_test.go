@@ -0,0 +1,98 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthReporter_PeerTraffic(t *testing.T) {
+	t.Parallel()
+
+	metrics := NilMetrics()
+	bytesSent, streamsOpened := &testCounter{}, &testCounter{}
+	metrics.BytesSent = bytesSent
+	metrics.StreamsOpened = streamsOpened
+
+	reporter := newBandwidthReporter(metrics)
+	peerID := peer.ID("peer")
+
+	reporter.logStream("/b/0.1", peerID, network.DirOutbound)
+	reporter.LogSentMessageStream(10, "/b/0.1", peerID)
+	reporter.LogRecvMessageStream(20, "/b/0.1", peerID)
+
+	reporter.logStream("/a/0.1", peerID, network.DirInbound)
+	reporter.LogSentMessageStream(5, "/a/0.1", peerID)
+
+	// the traffic of other peers is tracked separately
+	reporter.LogSentMessageStream(100, "/a/0.1", "other")
+
+	traffic := reporter.peerTraffic(peerID)
+
+	assert.Equal(t, int64(15), traffic.TotalOut)
+	assert.Equal(t, int64(20), traffic.TotalIn)
+	assert.Equal(t, []ProtocolTraffic{
+		{Protocol: "/a/0.1", BytesOut: 5, StreamsIn: 1},
+		{Protocol: "/b/0.1", BytesIn: 20, BytesOut: 10, StreamsOut: 1},
+	}, traffic.Protocols)
+
+	assert.Equal(t, int64(115), bytesSent.get())
+	assert.Equal(t, int64(2), streamsOpened.get())
+
+	// the traffic of a disconnected peer is dropped
+	reporter.removePeer(peerID)
+
+	assert.Empty(t, reporter.peerTraffic(peerID).Protocols)
+	assert.Len(t, reporter.peers, 1)
+}
+
+func TestPeerTraffic_Identity(t *testing.T) {
+	noDiscover := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: noDiscover,
+		1: noDiscover,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	findTraffic := func(traffic *PeerTraffic, proto protocol.ID) *ProtocolTraffic {
+		for _, protocolTraffic := range traffic.Protocols {
+			if protocolTraffic.Protocol == string(proto) {
+				return &protocolTraffic
+			}
+		}
+
+		return nil
+	}
+
+	// the handshake runs over the identity protocol
+	dialerTraffic := findTraffic(servers[0].GetPeerTraffic(servers[1].AddrInfo().ID), common.IdentityProto)
+	if assert.NotNil(t, dialerTraffic) {
+		assert.Equal(t, int64(1), dialerTraffic.StreamsOut)
+		assert.Greater(t, dialerTraffic.BytesOut, int64(0))
+		assert.Greater(t, dialerTraffic.BytesIn, int64(0))
+	}
+
+	listenerTraffic := findTraffic(servers[1].GetPeerTraffic(servers[0].AddrInfo().ID), common.IdentityProto)
+	if assert.NotNil(t, listenerTraffic) {
+		assert.Equal(t, int64(1), listenerTraffic.StreamsIn)
+	}
+}
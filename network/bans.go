@@ -0,0 +1,153 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// bansFile is the name of the file the ban list is persisted to, in the libp2p data directory
+const bansFile = "bans.json"
+
+// PeerBan is a temporary ban of a misbehaving peer
+type PeerBan struct {
+	ID     peer.ID   `json:"id"`
+	Reason string    `json:"reason"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// banList keeps the temporarily banned peers, and persists them
+// so that the bans survive node restarts
+type banList struct {
+	sync.Mutex
+
+	path string // the path of the persisted ban list, empty if not persisted
+	bans map[peer.ID]*PeerBan
+	now  func() time.Time
+}
+
+// newBanList creates the ban list persisted in the data directory,
+// loading the bans which haven't expired yet
+func newBanList(dataDir string) (*banList, error) {
+	list := &banList{
+		bans: make(map[peer.ID]*PeerBan),
+		now:  time.Now,
+	}
+
+	if dataDir == "" {
+		return list, nil
+	}
+
+	list.path = filepath.Join(dataDir, bansFile)
+
+	data, err := os.ReadFile(list.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return list, nil
+		}
+
+		return nil, fmt.Errorf("unable to read ban list, %w", err)
+	}
+
+	var bans []*PeerBan
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("unable to parse ban list, %w", err)
+	}
+
+	now := list.now()
+
+	for _, ban := range bans {
+		if ban.Expiry.After(now) {
+			list.bans[ban.ID] = ban
+		}
+	}
+
+	return list, nil
+}
+
+// ban bans the peer for the given duration and persists the ban list [Thread safe]
+func (b *banList) ban(peerID peer.ID, reason string, duration time.Duration) error {
+	b.Lock()
+	defer b.Unlock()
+
+	b.bans[peerID] = &PeerBan{
+		ID:     peerID,
+		Reason: reason,
+		Expiry: b.now().Add(duration),
+	}
+
+	return b.save()
+}
+
+// get returns the active ban of the peer, if any [Thread safe]
+func (b *banList) get(peerID peer.ID) *PeerBan {
+	b.Lock()
+	defer b.Unlock()
+
+	ban, ok := b.bans[peerID]
+	if !ok {
+		return nil
+	}
+
+	if !ban.Expiry.After(b.now()) {
+		// the ban has expired, the list is pruned on the next save
+		return nil
+	}
+
+	return ban
+}
+
+// list returns the active bans ordered by expiry [Thread safe]
+func (b *banList) list() []*PeerBan {
+	b.Lock()
+	defer b.Unlock()
+
+	now := b.now()
+	bans := make([]*PeerBan, 0, len(b.bans))
+
+	for _, ban := range b.bans {
+		if ban.Expiry.After(now) {
+			bans = append(bans, ban)
+		}
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Expiry.Before(bans[j].Expiry)
+	})
+
+	return bans
+}
+
+// save prunes the expired bans and writes the list to disk
+func (b *banList) save() error {
+	now := b.now()
+	bans := make([]*PeerBan, 0, len(b.bans))
+
+	for id, ban := range b.bans {
+		if !ban.Expiry.After(now) {
+			delete(b.bans, id)
+
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	if b.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(bans)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(b.path, data)
+}
@@ -5,21 +5,29 @@ import (
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/multiformats/go-multiaddr"
 	"net"
+	"time"
 )
 
 // Config details the params for the base networking server
 type Config struct {
-	NoDiscover       bool                   // flag indicating if the discovery mechanism should be turned on
-	Addr             *net.TCPAddr           // the base address
-	NatAddr          net.IP                 // the NAT address
-	DNS              multiaddr.Multiaddr    // the DNS address
-	DataDir          string                 // the base data directory for the client
-	MaxPeers         int64                  // the maximum number of peer connections
-	MaxInboundPeers  int64                  // the maximum number of inbound peer connections
-	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
-	Chain            *chain.Chain           // the reference to the chain configuration
-	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
-	Metrics          *Metrics               // the metrics reporting reference
+	NoDiscover            bool                   // flag indicating if the discovery mechanism should be turned on
+	Addr                  *net.TCPAddr           // the base address
+	NatAddr               net.IP                 // the NAT address
+	DNS                   multiaddr.Multiaddr    // the DNS address
+	DataDir               string                 // the base data directory for the client
+	MaxPeers              int64                  // the maximum number of peer connections
+	MaxInboundPeers       int64                  // the maximum number of inbound peer connections
+	MaxOutboundPeers      int64                  // the maximum number of outbound peer connections
+	Chain                 *chain.Chain           // the reference to the chain configuration
+	SecretsManager        secrets.SecretsManager // the secrets manager used for key storage
+	Metrics               *Metrics               // the metrics reporting reference
+	BanThreshold          float64                // the score below which a misbehaving peer is banned
+	BanDuration           time.Duration          // the period a misbehaving peer is banned for
+	StaticPeers           []string               // the peers which are always kept connected
+	TrustedPeers          []string               // the peers which bypass the connection slot limits
+	PeerAllowlistFile     string                 // the file with the peer IDs permitted to connect
+	PeerAllowlistContract bool                   // flag indicating if the permitted peer IDs are also read from the chain
+	DNSDiscovery          []string               // the enrtree URLs of the DNS trees of node records used for discovery
 }
 
 func DefaultConfig() *Config {
@@ -36,5 +44,7 @@ func DefaultConfig() *Config {
 		// The default ratio for outbound / inbound connections is 0.25
 		MaxInboundPeers:  32,
 		MaxOutboundPeers: 8,
+		BanThreshold:     DefaultBanThreshold,
+		BanDuration:      DefaultBanDuration,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/discovery/dnsdisc"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -28,6 +29,13 @@ const (
 	// bootnodeDiscoveryInterval is the interval at which
 	// random bootnodes are dialed for their peer sets
 	bootnodeDiscoveryInterval = 60 * time.Second
+
+	// dnsDiscoveryInterval is the interval at which
+	// the DNS trees of node records are resolved again
+	dnsDiscoveryInterval = 30 * time.Minute
+
+	// dnsResolveTimeout is the timeout of resolving a single DNS tree
+	dnsResolveTimeout = 30 * time.Second
 )
 
 // networkingServer defines the base communication interface between
@@ -90,6 +98,9 @@ type DiscoveryService struct {
 	logger       hclog.Logger     // The DiscoveryService logger
 	routingTable *kb.RoutingTable // Kademlia 'k-bucket' routing table that contains connected nodes info
 
+	dnsClient *dnsdisc.Client // The client resolving the DNS trees of node records
+	dnsTrees  []string        // The enrtree URLs of the DNS trees used for discovery
+
 	closeCh chan struct{} // Channel used for stopping the DiscoveryService
 }
 
@@ -107,6 +118,13 @@ func NewDiscoveryService(
 	}
 }
 
+// SetDNSTrees sets the DNS trees (EIP-1459) used as an additional discovery source.
+// It should be called before the service is started
+func (d *DiscoveryService) SetDNSTrees(client *dnsdisc.Client, urls []string) {
+	d.dnsClient = client
+	d.dnsTrees = urls
+}
+
 // Start starts the discovery service
 func (d *DiscoveryService) Start() {
 	go d.startDiscovery()
@@ -264,12 +282,17 @@ func (d *DiscoveryService) findPeersCall(
 func (d *DiscoveryService) startDiscovery() {
 	peerDiscoveryTicker := time.NewTicker(peerDiscoveryInterval)
 	bootnodeDiscoveryTicker := time.NewTicker(bootnodeDiscoveryInterval)
+	dnsDiscoveryTicker := time.NewTicker(dnsDiscoveryInterval)
 
 	defer func() {
 		peerDiscoveryTicker.Stop()
 		bootnodeDiscoveryTicker.Stop()
+		dnsDiscoveryTicker.Stop()
 	}()
 
+	// The DNS trees are resolved right away, as they might be the only source of peers
+	go d.dnsPeerDiscovery()
+
 	for {
 		select {
 		case <-d.closeCh:
@@ -278,6 +301,8 @@ func (d *DiscoveryService) startDiscovery() {
 			go d.regularPeerDiscovery()
 		case <-bootnodeDiscoveryTicker.C:
 			go d.bootnodePeerDiscovery()
+		case <-dnsDiscoveryTicker.C:
+			go d.dnsPeerDiscovery()
 		}
 	}
 }
@@ -372,6 +397,37 @@ func (d *DiscoveryService) bootnodePeerDiscovery() {
 	d.addPeersToTable(foundNodes)
 }
 
+// dnsPeerDiscovery resolves the DNS trees of node records,
+// and adds the found nodes to the routing table
+func (d *DiscoveryService) dnsPeerDiscovery() {
+	for _, url := range d.dnsTrees {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsResolveTimeout)
+		nodes, err := d.dnsClient.Resolve(ctx, url)
+
+		cancel()
+
+		if err != nil {
+			d.logger.Error("Unable to resolve DNS tree", "url", url, "err", err)
+
+			continue
+		}
+
+		d.logger.Debug("Resolved DNS tree", "url", url, "nodes", len(nodes))
+
+		for _, nodeInfo := range nodes {
+			if err := d.addToTable(nodeInfo); err != nil {
+				d.logger.Error(
+					"Failed to add new peer to routing table",
+					"peer",
+					nodeInfo.ID,
+					"err",
+					err,
+				)
+			}
+		}
+	}
+}
+
 // FindPeers implements the proto service for finding the target's peers
 func (d *DiscoveryService) FindPeers(
 	ctx context.Context,
@@ -3,8 +3,10 @@ package discovery
 import (
 	"context"
 	"errors"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/discovery/dnsdisc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -295,3 +297,71 @@ func TestDiscoveryService_RegularPeerDiscoveryUnconnected(t *testing.T) {
 	// Make sure that no peers were added to the peer store
 	assert.Len(t, peerStore, 0)
 }
+
+// dnsRecords is an in-process DNS resolver stub
+type dnsRecords map[string]string
+
+func (r dnsRecords) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if txt, ok := r[name]; ok {
+		return []string{txt}, nil
+	}
+
+	return nil, errors.New("no such host")
+}
+
+// TestDiscoveryService_DNSPeerDiscovery makes sure the nodes
+// published in the DNS tree are added to the routing table
+func TestDiscoveryService_DNSPeerDiscovery(t *testing.T) {
+	randomPeers := getRandomPeers(t, 3)
+	peerStore := make(map[peer.ID]*peer.AddrInfo)
+	domain := "nodes.example.org"
+
+	nodes := make([]string, len(randomPeers))
+	for i, randomPeer := range randomPeers {
+		nodes[i] = common.AddrInfoToString(randomPeer)
+	}
+
+	tree, treeErr := dnsdisc.MakeTree(1, nodes, nil)
+	if treeErr != nil {
+		t.Fatalf("Unable to create DNS tree, %v", treeErr)
+	}
+
+	key, keyErr := crypto.GenerateKey()
+	if keyErr != nil {
+		t.Fatalf("Unable to generate key, %v", keyErr)
+	}
+
+	url, signErr := tree.Sign(key, domain)
+	if signErr != nil {
+		t.Fatalf("Unable to sign DNS tree, %v", signErr)
+	}
+
+	// Create an instance of the discovery service
+	discoveryService, setupErr := newDiscoveryService(
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the peer store addition
+			server.HookAddToPeerStore(func(info *peer.AddrInfo) {
+				peerStore[info.ID] = info
+			})
+		},
+	)
+	if setupErr != nil {
+		t.Fatalf("Unable to setup the discovery service")
+	}
+
+	discoveryService.SetDNSTrees(
+		dnsdisc.NewClient(dnsRecords(tree.Records(domain))),
+		[]string{url, dnsdisc.TreeURL(&key.PublicKey, "missing.example.org")},
+	)
+
+	// Run the DNS peer discovery method
+	discoveryService.dnsPeerDiscovery()
+
+	// Make sure the published nodes are added, and the missing tree is skipped
+	assert.Len(t, peerStore, len(randomPeers))
+
+	for _, randomPeer := range randomPeers {
+		assert.Equal(t, randomPeer.Addrs, peerStore[randomPeer.ID].Addrs)
+		assert.Equal(t, randomPeer.ID, discoveryService.routingTable.Find(randomPeer.ID))
+	}
+}
@@ -0,0 +1,202 @@
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// maxTreeLinks is the maximum number of trees followed from a single tree, including the linked ones
+	maxTreeLinks = 16
+
+	// maxTreeEntries is the maximum number of records fetched from a single tree
+	maxTreeEntries = 4096
+)
+
+// Resolver resolves the DNS TXT records. It is implemented by net.Resolver
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Client resolves the signed DNS trees of node records into peer address infos
+type Client struct {
+	resolver Resolver
+}
+
+// NewClient creates a new DNS discovery client. The system resolver is used if none is set
+func NewClient(resolver Resolver) *Client {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &Client{
+		resolver: resolver,
+	}
+}
+
+// treeSync is the state of a single tree traversal, including the linked trees
+type treeSync struct {
+	client  *Client
+	visited map[string]struct{} // the visited tree domains
+	peers   map[peer.ID]*peer.AddrInfo
+	fetched int
+}
+
+// Resolve fetches the tree at the enrtree URL, verifies it, and returns the nodes
+// of the tree and of all the trees it links to
+func (c *Client) Resolve(ctx context.Context, url string) ([]*peer.AddrInfo, error) {
+	domain, pubkey, err := ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	sync := &treeSync{
+		client:  c,
+		visited: make(map[string]struct{}),
+		peers:   make(map[peer.ID]*peer.AddrInfo),
+	}
+
+	if err := sync.syncTree(ctx, domain, pubkey); err != nil {
+		return nil, err
+	}
+
+	infos := make([]*peer.AddrInfo, 0, len(sync.peers))
+	for _, info := range sync.peers {
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// syncTree verifies the root of the tree, and walks both of its subtrees
+func (s *treeSync) syncTree(ctx context.Context, domain string, pubkey *ecdsa.PublicKey) error {
+	if _, ok := s.visited[domain]; ok {
+		return nil
+	}
+
+	if len(s.visited) >= maxTreeLinks {
+		return fmt.Errorf("too many linked trees, omitting %s", domain)
+	}
+
+	s.visited[domain] = struct{}{}
+
+	rawRoot, err := s.client.lookup(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	root, err := parseRoot(rawRoot)
+	if err != nil {
+		return err
+	}
+
+	if !root.verifySignature(pubkey) {
+		return ErrInvalidSignature
+	}
+
+	links := make([]*linkEntry, 0)
+
+	if err := s.walk(ctx, domain, root.eroot, &links, false); err != nil {
+		return err
+	}
+
+	if err := s.walk(ctx, domain, root.lroot, &links, true); err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if err := s.syncTree(ctx, link.domain, link.pubkey); err != nil {
+			return fmt.Errorf("unable to sync linked tree %s, %w", link.domain, err)
+		}
+	}
+
+	return nil
+}
+
+// walk fetches the subtree under the hash, collecting its nodes or links
+func (s *treeSync) walk(ctx context.Context, domain, hash string, links *[]*linkEntry, isLinkTree bool) error {
+	if s.fetched >= maxTreeEntries {
+		return fmt.Errorf("too many records in tree %s", domain)
+	}
+
+	s.fetched++
+
+	raw, err := s.client.lookup(ctx, hash+"."+domain)
+	if err != nil {
+		return err
+	}
+
+	e, err := parseEntry(raw)
+	if err != nil {
+		return fmt.Errorf("invalid record %s.%s, %w", hash, domain, err)
+	}
+
+	// the records are authenticated by the signed root, through the hashes
+	if !matchesHash(hash, e) {
+		return ErrHashMismatch
+	}
+
+	switch e := e.(type) {
+	case *branchEntry:
+		for _, child := range e.children {
+			if err := s.walk(ctx, domain, child, links, isLinkTree); err != nil {
+				return err
+			}
+		}
+	case *linkEntry:
+		if isLinkTree {
+			*links = append(*links, e)
+		}
+	case *enrEntry:
+		if !isLinkTree {
+			info, err := e.record.addrInfo()
+			if err != nil {
+				return err
+			}
+
+			s.addPeer(info)
+		}
+	case *multiaddrEntry:
+		if !isLinkTree {
+			info, err := peer.AddrInfoFromP2pAddr(e.addr)
+			if err != nil {
+				return err
+			}
+
+			s.addPeer(info)
+		}
+	}
+
+	return nil
+}
+
+func (s *treeSync) addPeer(info *peer.AddrInfo) {
+	if existing, ok := s.peers[info.ID]; ok {
+		existing.Addrs = append(existing.Addrs, info.Addrs...)
+
+		return
+	}
+
+	s.peers[info.ID] = info
+}
+
+// lookup fetches the tree record among the TXT records of the name
+func (c *Client) lookup(ctx context.Context, name string) (string, error) {
+	txts, err := c.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s, %w", name, err)
+	}
+
+	for _, txt := range txts {
+		if strings.HasPrefix(txt, "enr") || strings.HasPrefix(txt, multiaddrPrefix) {
+			return txt, nil
+		}
+	}
+
+	return "", fmt.Errorf("no tree record found at %s", name)
+}
@@ -0,0 +1,267 @@
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	libp2pCrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+// mapResolver is an in-process DNS resolver stub
+type mapResolver map[string]string
+
+func (m mapResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if txt, ok := m[name]; ok {
+		return []string{txt}, nil
+	}
+
+	return nil, errors.New("no such host")
+}
+
+func (m mapResolver) add(records map[string]string) {
+	for name, txt := range records {
+		m[name] = txt
+	}
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unable to generate key, %v", err)
+	}
+
+	return key
+}
+
+func generateNodeAddrs(t *testing.T, count int) ([]string, []peer.ID) {
+	t.Helper()
+
+	addrs := make([]string, count)
+	ids := make([]peer.ID, count)
+
+	for i := 0; i < count; i++ {
+		_, pub, err := libp2pCrypto.GenerateKeyPair(libp2pCrypto.Secp256k1, 256)
+		if err != nil {
+			t.Fatalf("Unable to generate key pair, %v", err)
+		}
+
+		ids[i], err = peer.IDFromPublicKey(pub)
+		if err != nil {
+			t.Fatalf("Unable to generate peer ID, %v", err)
+		}
+
+		addrs[i] = fmt.Sprintf("/ip4/10.0.0.%d/tcp/1478/p2p/%s", i+1, ids[i])
+	}
+
+	return addrs, ids
+}
+
+func signTree(t *testing.T, tree *Tree, key *ecdsa.PrivateKey, domain string) string {
+	t.Helper()
+
+	url, err := tree.Sign(key, domain)
+	if err != nil {
+		t.Fatalf("Unable to sign tree, %v", err)
+	}
+
+	return url
+}
+
+func resolvedIDs(infos []*peer.AddrInfo) []peer.ID {
+	ids := make([]peer.ID, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
+	}
+
+	return ids
+}
+
+func TestResolve_SignedTree(t *testing.T) {
+	t.Parallel()
+
+	// more nodes than fit a single branch
+	addrs, ids := generateNodeAddrs(t, 2*maxChildren+1)
+
+	tree, err := MakeTree(1, addrs, nil)
+	assert.NoError(t, err)
+
+	key := generateKey(t)
+	url := signTree(t, tree, key, "nodes.example.org")
+
+	resolver := mapResolver{}
+	resolver.add(tree.Records("nodes.example.org"))
+
+	infos, err := NewClient(resolver).Resolve(context.Background(), url)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, ids, resolvedIDs(infos))
+
+	for _, info := range infos {
+		assert.Len(t, info.Addrs, 1)
+	}
+}
+
+func TestResolve_LinkedTrees(t *testing.T) {
+	t.Parallel()
+
+	resolver := mapResolver{}
+
+	linkedAddrs, linkedIDs := generateNodeAddrs(t, 2)
+	linkedTree, err := MakeTree(1, linkedAddrs, nil)
+	assert.NoError(t, err)
+
+	linkedURL := signTree(t, linkedTree, generateKey(t), "partner.example.org")
+	resolver.add(linkedTree.Records("partner.example.org"))
+
+	addrs, ids := generateNodeAddrs(t, 3)
+	tree, err := MakeTree(2, addrs, []string{linkedURL})
+	assert.NoError(t, err)
+
+	url := signTree(t, tree, generateKey(t), "nodes.example.org")
+	resolver.add(tree.Records("nodes.example.org"))
+
+	infos, err := NewClient(resolver).Resolve(context.Background(), url)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, append(ids, linkedIDs...), resolvedIDs(infos))
+}
+
+func TestResolve_InvalidTrees(t *testing.T) {
+	t.Parallel()
+
+	addrs, _ := generateNodeAddrs(t, 3)
+	domain := "nodes.example.org"
+
+	tree, err := MakeTree(1, addrs, nil)
+	assert.NoError(t, err)
+
+	url := signTree(t, tree, generateKey(t), domain)
+	records := tree.Records(domain)
+
+	t.Run("signed by another key", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := mapResolver{}
+		resolver.add(records)
+
+		_, err := NewClient(resolver).Resolve(context.Background(), TreeURL(&generateKey(t).PublicKey, domain))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("tampered node record", func(t *testing.T) {
+		t.Parallel()
+
+		otherAddrs, _ := generateNodeAddrs(t, 1)
+
+		resolver := mapResolver{}
+		resolver.add(records)
+
+		for name, txt := range resolver {
+			if name != domain && txt[:len(multiaddrPrefix)] == multiaddrPrefix {
+				resolver[name] = multiaddrPrefix + otherAddrs[0]
+
+				break
+			}
+		}
+
+		_, err := NewClient(resolver).Resolve(context.Background(), url)
+		assert.ErrorIs(t, err, ErrHashMismatch)
+	})
+
+	t.Run("missing record", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(mapResolver{}).Resolve(context.Background(), url)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(mapResolver{}).Resolve(context.Background(), "https://"+domain)
+		assert.ErrorIs(t, err, ErrInvalidURL)
+	})
+}
+
+// encodeNodeRecord encodes a "v4" node record of the key, signed by the signer
+func encodeNodeRecord(t *testing.T, key, signer *ecdsa.PrivateKey, ip net.IP, port uint64) string {
+	t.Helper()
+
+	arena := &fastrlp.Arena{}
+	pubkey := (*btcec.PublicKey)(&key.PublicKey).SerializeCompressed()
+
+	content := []*fastrlp.Value{
+		arena.NewUint(1),
+		arena.NewString("id"), arena.NewString("v4"),
+		arena.NewString("ip"), arena.NewBytes(ip.To4()),
+		arena.NewString("secp256k1"), arena.NewBytes(pubkey),
+		arena.NewString("tcp"), arena.NewUint(port),
+	}
+
+	list := arena.NewArray()
+	for _, v := range content {
+		list.Set(v)
+	}
+
+	sig, err := crypto.Sign(signer, crypto.Keccak256(list.MarshalTo(nil)))
+	if err != nil {
+		t.Fatalf("Unable to sign node record, %v", err)
+	}
+
+	record := arena.NewArray()
+	record.Set(arena.NewBytes(sig[:64]))
+
+	for _, v := range content {
+		record.Set(v)
+	}
+
+	return enrPrefix + b64format.EncodeToString(record.MarshalTo(nil))
+}
+
+func TestResolve_NodeRecords(t *testing.T) {
+	t.Parallel()
+
+	nodeKey := generateKey(t)
+	rawRecord := encodeNodeRecord(t, nodeKey, nodeKey, net.ParseIP("10.0.0.1"), 1478)
+
+	libp2pKey, err := libp2pCrypto.UnmarshalSecp256k1PublicKey(
+		(*btcec.PublicKey)(&nodeKey.PublicKey).SerializeCompressed(),
+	)
+	assert.NoError(t, err)
+
+	expectedID, err := peer.IDFromPublicKey(libp2pKey)
+	assert.NoError(t, err)
+
+	tree, err := MakeTree(1, []string{rawRecord}, nil)
+	assert.NoError(t, err)
+
+	url := signTree(t, tree, generateKey(t), "nodes.example.org")
+
+	resolver := mapResolver{}
+	resolver.add(tree.Records("nodes.example.org"))
+
+	infos, err := NewClient(resolver).Resolve(context.Background(), url)
+	assert.NoError(t, err)
+
+	if assert.Len(t, infos, 1) {
+		assert.Equal(t, expectedID, infos[0].ID)
+		assert.Equal(t, "/ip4/10.0.0.1/tcp/1478", infos[0].Addrs[0].String())
+	}
+
+	// records not signed by the node key are refused
+	_, err = MakeTree(1, []string{encodeNodeRecord(t, nodeKey, generateKey(t), net.ParseIP("10.0.0.1"), 1478)}, nil)
+	assert.ErrorIs(t, err, ErrInvalidRecordSig)
+
+	// node addresses must include the peer ID
+	_, err = MakeTree(1, []string{"/ip4/10.0.0.1/tcp/1478"}, nil)
+	assert.Error(t, err)
+}
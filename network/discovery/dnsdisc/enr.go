@@ -0,0 +1,212 @@
+package dnsdisc
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	libp2pCrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/umbracle/fastrlp"
+)
+
+// maxRecordSize is the maximum size of an encoded node record, as defined in EIP-778
+const maxRecordSize = 300
+
+var (
+	ErrRecordTooBig         = errors.New("node record is too big")
+	ErrUnsupportedIdentity  = errors.New("unsupported node record identity scheme")
+	ErrInvalidRecordSig     = errors.New("invalid node record signature")
+	ErrNoRecordTCPEndpoint  = errors.New("node record has no TCP endpoint")
+	ErrMissingRecordPubkey  = errors.New("node record has no public key")
+	ErrInvalidRecordPairing = errors.New("node record keys and values are not paired")
+)
+
+// nodeRecord is a decoded Ethereum Node Record (EIP-778), using the "v4" identity scheme
+type nodeRecord struct {
+	seq    uint64
+	pubkey []byte // compressed secp256k1 public key
+	ip     net.IP
+	tcp    uint16
+	ip6    net.IP
+	tcp6   uint16
+}
+
+// decodeNodeRecord decodes the base64 node record, and verifies its signature
+func decodeNodeRecord(raw string) (*nodeRecord, error) {
+	data, err := b64format.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode node record, %w", err)
+	}
+
+	if len(data) > maxRecordSize {
+		return nil, ErrRecordTooBig
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	// [signature, seq, k, v, ...]
+	if len(elems) < 2 || len(elems)%2 != 0 {
+		return nil, ErrInvalidRecordPairing
+	}
+
+	sig, err := elems[0].Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &nodeRecord{}
+	if record.seq, err = elems[1].GetUint64(); err != nil {
+		return nil, err
+	}
+
+	scheme := ""
+
+	for i := 2; i < len(elems); i += 2 {
+		key, err := elems[i].GetString()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := elems[i+1].Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "id":
+			scheme = string(value)
+		case "secp256k1":
+			record.pubkey = append([]byte{}, value...)
+		case "ip":
+			record.ip = append(net.IP{}, value...)
+		case "ip6":
+			record.ip6 = append(net.IP{}, value...)
+		case "tcp":
+			record.tcp = decodePort(value)
+		case "tcp6":
+			record.tcp6 = decodePort(value)
+		}
+	}
+
+	if scheme != "v4" {
+		return nil, ErrUnsupportedIdentity
+	}
+
+	if len(record.pubkey) == 0 {
+		return nil, ErrMissingRecordPubkey
+	}
+
+	if err := verifyRecordSignature(record.pubkey, sig, elems[1:]); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// verifyRecordSignature verifies the "v4" signature of the record content, which is
+// the keccak256 hash of the RLP list of the sequence number and the key / value pairs
+func verifyRecordSignature(rawPubkey, sig []byte, content []*fastrlp.Value) error {
+	if len(sig) != 64 {
+		return ErrInvalidRecordSig
+	}
+
+	pubkey, err := btcec.ParsePubKey(rawPubkey, btcec.S256())
+	if err != nil {
+		return fmt.Errorf("invalid node record public key, %w", err)
+	}
+
+	arena := &fastrlp.Arena{}
+	list := arena.NewArray()
+
+	for _, v := range content {
+		list.Set(v)
+	}
+
+	hash := crypto.Keccak256(list.MarshalTo(nil))
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+
+	if !ecdsa.Verify(pubkey.ToECDSA(), hash, r, s) {
+		return ErrInvalidRecordSig
+	}
+
+	return nil
+}
+
+func decodePort(value []byte) uint16 {
+	if len(value) > 2 {
+		return 0
+	}
+
+	buf := make([]byte, 2)
+	copy(buf[2-len(value):], value)
+
+	return binary.BigEndian.Uint16(buf)
+}
+
+// addrInfo converts the record to the libp2p peer address info. The libp2p peer ID is
+// derived from the secp256k1 record key, which is the networking key of the node
+func (r *nodeRecord) addrInfo() (*peer.AddrInfo, error) {
+	pubkey, err := libp2pCrypto.UnmarshalSecp256k1PublicKey(r.pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := peer.IDFromPublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &peer.AddrInfo{
+		ID:    id,
+		Addrs: make([]multiaddr.Multiaddr, 0, 2),
+	}
+
+	if len(r.ip) == net.IPv4len && r.tcp != 0 {
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", r.ip, r.tcp))
+		if err != nil {
+			return nil, err
+		}
+
+		info.Addrs = append(info.Addrs, addr)
+	}
+
+	// the IPv6 TCP port defaults to the IPv4 one
+	tcp6 := r.tcp6
+	if tcp6 == 0 {
+		tcp6 = r.tcp
+	}
+
+	if len(r.ip6) == net.IPv6len && tcp6 != 0 {
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", r.ip6, tcp6))
+		if err != nil {
+			return nil, err
+		}
+
+		info.Addrs = append(info.Addrs, addr)
+	}
+
+	if len(info.Addrs) == 0 {
+		return nil, ErrNoRecordTCPEndpoint
+	}
+
+	return info, nil
+}
@@ -0,0 +1,410 @@
+package dnsdisc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Record prefixes, as defined in EIP-1459
+const (
+	rootPrefix   = "enrtree-root:v1"
+	branchPrefix = "enrtree-branch:"
+	linkPrefix   = "enrtree://"
+	enrPrefix    = "enr:"
+
+	// multiaddrPrefix is the prefix of the leaves holding a libp2p multiaddr,
+	// used for the nodes whose signed ENR is not available
+	multiaddrPrefix = "multiaddr:"
+)
+
+const (
+	// hashAbbrevSize is the size of the abbreviated record hash, in bytes
+	hashAbbrevSize = 16
+
+	// maxChildren is the maximum number of children of a branch,
+	// so that the branch record fits a single TXT record
+	maxChildren = 370 / (hashAbbrevSize*8/5 + 1)
+)
+
+var (
+	ErrInvalidRoot      = errors.New("invalid root record")
+	ErrInvalidSignature = errors.New("invalid root signature")
+	ErrInvalidURL       = errors.New("invalid enrtree URL")
+	ErrHashMismatch     = errors.New("record hash mismatch")
+	ErrUnknownEntry     = errors.New("unknown entry type")
+	ErrInvalidChild     = errors.New("invalid child hash")
+)
+
+var (
+	b32format = base32.StdEncoding.WithPadding(base32.NoPadding)
+	b64format = base64.RawURLEncoding
+)
+
+// entry is a record of the tree
+type entry interface {
+	fmt.Stringer
+}
+
+// rootEntry is the signed root of the tree
+type rootEntry struct {
+	eroot string // the root hash of the node records subtree
+	lroot string // the root hash of the links subtree
+	seq   uint64
+	sig   []byte
+}
+
+// branchEntry is an intermediate node of the tree
+type branchEntry struct {
+	children []string
+}
+
+// linkEntry is a leaf pointing to another tree
+type linkEntry struct {
+	str    string
+	domain string
+	pubkey *ecdsa.PublicKey
+}
+
+// enrEntry is a leaf holding a node record
+type enrEntry struct {
+	record *nodeRecord
+	raw    string
+}
+
+// multiaddrEntry is a leaf holding a libp2p multiaddr
+type multiaddrEntry struct {
+	addr multiaddr.Multiaddr
+}
+
+func (e *rootEntry) sigHash() []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("%s e=%s l=%s seq=%d", rootPrefix, e.eroot, e.lroot, e.seq)))
+}
+
+// verifySignature checks the root signature against the public key of the tree
+func (e *rootEntry) verifySignature(pubkey *ecdsa.PublicKey) bool {
+	if len(e.sig) != 65 {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(e.sig[:32])
+	s := new(big.Int).SetBytes(e.sig[32:64])
+
+	return ecdsa.Verify(pubkey, e.sigHash(), r, s)
+}
+
+func (e *rootEntry) String() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d sig=%s", rootPrefix, e.eroot, e.lroot, e.seq, b64format.EncodeToString(e.sig))
+}
+
+func (e *branchEntry) String() string {
+	return branchPrefix + strings.Join(e.children, ",")
+}
+
+func (e *linkEntry) String() string {
+	return linkPrefix + e.str
+}
+
+func (e *enrEntry) String() string {
+	return e.raw
+}
+
+func (e *multiaddrEntry) String() string {
+	return multiaddrPrefix + e.addr.String()
+}
+
+// subdomain returns the abbreviated hash of the record, which is its subdomain in the tree
+func subdomain(e entry) string {
+	return b32format.EncodeToString(crypto.Keccak256([]byte(e.String()))[:hashAbbrevSize])
+}
+
+// matchesHash checks if the (possibly shortened) hash is the hash of the record
+func matchesHash(hash string, e entry) bool {
+	decoded, err := b32format.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+
+	return bytes.HasPrefix(crypto.Keccak256([]byte(e.String())), decoded)
+}
+
+// parseEntry parses a non-root record of the tree
+func parseEntry(raw string) (entry, error) {
+	switch {
+	case strings.HasPrefix(raw, branchPrefix):
+		return parseBranch(strings.TrimPrefix(raw, branchPrefix))
+	case strings.HasPrefix(raw, linkPrefix):
+		return parseLink(strings.TrimPrefix(raw, linkPrefix))
+	case strings.HasPrefix(raw, enrPrefix):
+		record, err := decodeNodeRecord(strings.TrimPrefix(raw, enrPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		return &enrEntry{record: record, raw: raw}, nil
+	case strings.HasPrefix(raw, multiaddrPrefix):
+		addr, err := multiaddr.NewMultiaddr(strings.TrimPrefix(raw, multiaddrPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		return &multiaddrEntry{addr: addr}, nil
+	default:
+		return nil, ErrUnknownEntry
+	}
+}
+
+// parseRoot parses the root record of the tree
+func parseRoot(raw string) (*rootEntry, error) {
+	fields := strings.Fields(raw)
+	if len(fields) != 5 || fields[0] != rootPrefix {
+		return nil, ErrInvalidRoot
+	}
+
+	values := make(map[string]string, 4)
+
+	for _, field := range fields[1:] {
+		keyValue := strings.SplitN(field, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, ErrInvalidRoot
+		}
+
+		values[keyValue[0]] = keyValue[1]
+	}
+
+	seq, err := strconv.ParseUint(values["seq"], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRoot
+	}
+
+	sig, err := b64format.DecodeString(values["sig"])
+	if err != nil || len(sig) != 65 {
+		return nil, ErrInvalidSignature
+	}
+
+	root := &rootEntry{
+		eroot: values["e"],
+		lroot: values["l"],
+		seq:   seq,
+		sig:   sig,
+	}
+
+	if !isValidHash(root.eroot) || !isValidHash(root.lroot) {
+		return nil, ErrInvalidRoot
+	}
+
+	return root, nil
+}
+
+func parseBranch(raw string) (entry, error) {
+	branch := &branchEntry{
+		children: make([]string, 0),
+	}
+
+	if raw == "" {
+		return branch, nil
+	}
+
+	for _, child := range strings.Split(raw, ",") {
+		if !isValidHash(child) {
+			return nil, ErrInvalidChild
+		}
+
+		branch.children = append(branch.children, child)
+	}
+
+	return branch, nil
+}
+
+// parseLink parses the tree location in the <base32 public key>@<domain> format
+func parseLink(raw string) (*linkEntry, error) {
+	keyDomain := strings.SplitN(raw, "@", 2)
+	if len(keyDomain) != 2 || keyDomain[1] == "" {
+		return nil, ErrInvalidURL
+	}
+
+	keyBytes, err := b32format.DecodeString(keyDomain[0])
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+
+	pubkey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key in enrtree URL, %w", err)
+	}
+
+	return &linkEntry{
+		str:    raw,
+		domain: keyDomain[1],
+		pubkey: pubkey.ToECDSA(),
+	}, nil
+}
+
+// ParseURL parses the enrtree://<base32 public key>@<domain> URL of a tree
+func ParseURL(url string) (domain string, pubkey *ecdsa.PublicKey, err error) {
+	if !strings.HasPrefix(url, linkPrefix) {
+		return "", nil, ErrInvalidURL
+	}
+
+	link, err := parseLink(strings.TrimPrefix(url, linkPrefix))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return link.domain, link.pubkey, nil
+}
+
+func isValidHash(hash string) bool {
+	decoded, err := b32format.DecodeString(hash)
+
+	return err == nil && len(decoded) >= 12 && len(decoded) <= 32
+}
+
+// Tree is a signed tree of node records, which can be published as DNS TXT records
+type Tree struct {
+	root    *rootEntry
+	entries map[string]entry // the non-root records, by subdomain
+}
+
+// MakeTree creates an unsigned tree of the nodes and the links to other trees.
+// The nodes are either libp2p multiaddrs including the peer ID, or signed node records (enr:...)
+func MakeTree(seq uint64, nodes []string, links []string) (*Tree, error) {
+	tree := &Tree{
+		entries: make(map[string]entry),
+	}
+
+	nodeEntries := make([]entry, 0, len(nodes))
+
+	for _, node := range nodes {
+		if strings.HasPrefix(node, enrPrefix) {
+			e, err := parseEntry(node)
+			if err != nil {
+				return nil, fmt.Errorf("invalid node record %s, %w", node, err)
+			}
+
+			nodeEntries = append(nodeEntries, e)
+
+			continue
+		}
+
+		addr, err := multiaddr.NewMultiaddr(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node address %s, %w", node, err)
+		}
+
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return nil, fmt.Errorf("invalid node address %s, %w", node, err)
+		}
+
+		nodeEntries = append(nodeEntries, &multiaddrEntry{addr: addr})
+	}
+
+	linkEntries := make([]entry, 0, len(links))
+
+	for _, url := range links {
+		if !strings.HasPrefix(url, linkPrefix) {
+			return nil, ErrInvalidURL
+		}
+
+		link, err := parseLink(strings.TrimPrefix(url, linkPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		linkEntries = append(linkEntries, link)
+	}
+
+	tree.root = &rootEntry{
+		eroot: tree.build(nodeEntries),
+		lroot: tree.build(linkEntries),
+		seq:   seq,
+	}
+
+	return tree, nil
+}
+
+// build adds the leaves to the tree, grouped under branches, and returns the subtree root hash
+func (t *Tree) build(leaves []entry) string {
+	// the leaves are sorted, so the same input yields the same tree
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].String() < leaves[j].String()
+	})
+
+	for _, leaf := range leaves {
+		t.entries[subdomain(leaf)] = leaf
+	}
+
+	// an empty subtree is represented by an empty branch
+	if len(leaves) == 0 {
+		branch := &branchEntry{children: []string{}}
+		t.entries[subdomain(branch)] = branch
+
+		return subdomain(branch)
+	}
+
+	for len(leaves) > 1 {
+		branches := make([]entry, 0, len(leaves)/maxChildren+1)
+
+		for i := 0; i < len(leaves); i += maxChildren {
+			end := i + maxChildren
+			if end > len(leaves) {
+				end = len(leaves)
+			}
+
+			branch := &branchEntry{children: make([]string, 0, end-i)}
+			for _, child := range leaves[i:end] {
+				branch.children = append(branch.children, subdomain(child))
+			}
+
+			t.entries[subdomain(branch)] = branch
+			branches = append(branches, branch)
+		}
+
+		leaves = branches
+	}
+
+	return subdomain(leaves[0])
+}
+
+// Sign signs the root of the tree with the tree key, and returns the tree URL
+func (t *Tree) Sign(key *ecdsa.PrivateKey, domain string) (string, error) {
+	sig, err := crypto.Sign(key, t.root.sigHash())
+	if err != nil {
+		return "", err
+	}
+
+	t.root.sig = sig
+
+	return TreeURL(&key.PublicKey, domain), nil
+}
+
+// Records returns the TXT records of the tree, by their fully qualified names
+func (t *Tree) Records(domain string) map[string]string {
+	records := make(map[string]string, len(t.entries)+1)
+	records[domain] = t.root.String()
+
+	for name, e := range t.entries {
+		records[name+"."+domain] = e.String()
+	}
+
+	return records
+}
+
+// TreeURL returns the enrtree URL of the tree published on the domain
+func TreeURL(pubkey *ecdsa.PublicKey, domain string) string {
+	compressed := (*btcec.PublicKey)(pubkey).SerializeCompressed()
+
+	return fmt.Sprintf("%s%s@%s", linkPrefix, b32format.EncodeToString(compressed), domain)
+}
@@ -2,9 +2,11 @@ package network
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"google.golang.org/protobuf/proto"
 )
@@ -16,9 +18,30 @@ const (
 	subscribeOutputBufferSize = 1024
 )
 
+// ValidationResult is the outcome of validating a gossiped message
+type ValidationResult int
+
+const (
+	// ValidationAccept delivers the message to the subscribers and relays it to the network
+	ValidationAccept ValidationResult = iota
+
+	// ValidationReject drops the message, and the peer which relayed it
+	// is penalized by the pubsub peer scoring
+	ValidationReject
+
+	// ValidationIgnore drops the message without penalizing the peer which relayed it
+	ValidationIgnore
+)
+
+// TopicValidator validates the decoded gossiped message before it is
+// delivered to the subscribers and relayed to the rest of the network
+type TopicValidator func(obj interface{}, from peer.ID) ValidationResult
+
 type Topic struct {
-	logger hclog.Logger
+	logger  hclog.Logger
+	metrics *Metrics
 
+	name    string
 	topic   *pubsub.Topic
 	typ     reflect.Type
 	closeCh chan struct{}
@@ -39,10 +62,12 @@ func (t *Topic) Publish(obj proto.Message) error {
 		return err
 	}
 
+	t.metrics.GossipMessageSize.With("topic", t.name, "direction", "published").Observe(float64(len(data)))
+
 	return t.topic.Publish(context.Background(), data)
 }
 
-func (t *Topic) Subscribe(handler func(obj interface{})) error {
+func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
 		return err
@@ -53,7 +78,7 @@ func (t *Topic) Subscribe(handler func(obj interface{})) error {
 	return nil
 }
 
-func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{})) {
+func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, from peer.ID)) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 
 	go func() {
@@ -69,29 +94,76 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{})
 			continue
 		}
 
-		go func() {
-			obj := t.createObj()
-			if err := proto.Unmarshal(msg.Data, obj); err != nil {
-				t.logger.Error("failed to unmarshal topic", "err", err)
+		t.metrics.GossipMessageSize.With("topic", t.name, "direction", "delivered").Observe(float64(len(msg.Data)))
 
-				return
+		go func() {
+			// The message is already decoded if the topic has a validator
+			obj, ok := msg.ValidatorData.(proto.Message)
+			if !ok {
+				obj = t.createObj()
+				if err := proto.Unmarshal(msg.Data, obj); err != nil {
+					t.logger.Error("failed to unmarshal topic", "err", err)
+
+					return
+				}
 			}
 
-			handler(obj)
+			handler(obj, msg.ReceivedFrom)
 		}()
 	}
 }
 
-func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
+// validate decodes the gossiped message and runs the topic validator on it.
+// The decoded message is kept, so it doesn't have to be decoded again when delivered
+func (t *Topic) validate(validator TopicValidator) pubsub.ValidatorEx {
+	return func(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		obj := t.createObj()
+		if err := proto.Unmarshal(msg.Data, obj); err != nil {
+			t.logger.Debug("rejecting malformed message", "from", from, "err", err)
+			t.metrics.GossipMessagesRejected.With("topic", t.name).Add(1)
+
+			return pubsub.ValidationReject
+		}
+
+		switch validator(obj, from) {
+		case ValidationReject:
+			t.metrics.GossipMessagesRejected.With("topic", t.name).Add(1)
+
+			return pubsub.ValidationReject
+		case ValidationIgnore:
+			t.metrics.GossipMessagesIgnored.With("topic", t.name).Add(1)
+
+			return pubsub.ValidationIgnore
+		default:
+			msg.ValidatorData = obj
+
+			return pubsub.ValidationAccept
+		}
+	}
+}
+
+// NewTopic joins the pubsub topic. If the validator is set, the gossiped messages
+// are validated before they are delivered to the subscribers and relayed to other peers
+func (s *Server) NewTopic(protoID string, obj proto.Message, validator TopicValidator) (*Topic, error) {
 	topic, err := s.ps.Join(protoID)
 	if err != nil {
 		return nil, err
 	}
 
 	tt := &Topic{
-		logger: s.logger.Named(protoID),
-		topic:  topic,
-		typ:    reflect.TypeOf(obj).Elem(),
+		logger:  s.logger.Named(protoID),
+		metrics: s.metrics,
+		name:    protoID,
+		topic:   topic,
+		typ:     reflect.TypeOf(obj).Elem(),
+	}
+
+	if validator != nil {
+		if err := s.ps.RegisterTopicValidator(protoID, tt.validate(validator)); err != nil {
+			_ = topic.Close()
+
+			return nil, fmt.Errorf("unable to register topic validator, %w", err)
+		}
 	}
 
 	return tt, nil
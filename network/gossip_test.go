@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	testproto "github.com/0xPolygon/polygon-edge/network/proto"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	testproto "github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/go-kit/kit/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
 )
 
 func NumSubscribers(srv *Server, topic string) int {
@@ -52,14 +57,14 @@ func TestSimpleGossip(t *testing.T) {
 	serverTopics := make([]*Topic, numServers)
 
 	for i := 0; i < numServers; i++ {
-		topic, topicErr := servers[i].NewTopic(topicName, &testproto.GenericMessage{})
+		topic, topicErr := servers[i].NewTopic(topicName, &testproto.GenericMessage{}, nil)
 		if topicErr != nil {
 			t.Fatalf("Unable to create topic, %v", topicErr)
 		}
 
 		serverTopics[i] = topic
 
-		if subscribeErr := topic.Subscribe(func(obj interface{}) {
+		if subscribeErr := topic.Subscribe(func(obj interface{}, _ peer.ID) {
 			// Everyone should relay they got the message
 			genericMessage, ok := obj.(*testproto.GenericMessage)
 			if !ok {
@@ -105,3 +110,141 @@ func TestSimpleGossip(t *testing.T) {
 		}
 	}
 }
+
+// testCounter is a metrics counter which sums up the values of all the labels
+type testCounter struct {
+	value int64
+}
+
+func (c *testCounter) With(...string) metrics.Counter {
+	return c
+}
+
+func (c *testCounter) Add(delta float64) {
+	atomic.AddInt64(&c.value, int64(delta))
+}
+
+func (c *testCounter) get() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+func TestGossip_Validation(t *testing.T) {
+	noDiscover := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	rejected := &testCounter{}
+
+	// The servers are connected in line, so the messages
+	// from the publisher are relayed through the validating server
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: noDiscover,
+		1: {
+			ConfigCallback: noDiscover.ConfigCallback,
+			ServerCallback: func(server *Server) {
+				server.metrics.GossipMessagesRejected = rejected
+			},
+		},
+		2: noDiscover,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	for i := 0; i < len(servers)-1; i++ {
+		if joinErr := JoinAndWait(servers[i], servers[i+1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+			t.Fatalf("Unable to join servers, %v", joinErr)
+		}
+	}
+
+	topicName := "msg-validated"
+	invalidMessage := "invalid"
+	validator := func(obj interface{}, _ peer.ID) ValidationResult {
+		if obj.(*testproto.GenericMessage).Message == invalidMessage {
+			return ValidationReject
+		}
+
+		return ValidationAccept
+	}
+
+	validatorsByServer := []TopicValidator{nil, validator, nil}
+	messageChs := make([]chan string, len(servers))
+	topics := make([]*Topic, len(servers))
+
+	for i, server := range servers {
+		topic, topicErr := server.NewTopic(topicName, &testproto.GenericMessage{}, validatorsByServer[i])
+		if topicErr != nil {
+			t.Fatalf("Unable to create topic, %v", topicErr)
+		}
+
+		messageCh := make(chan string, 64)
+		if subscribeErr := topic.Subscribe(func(obj interface{}, _ peer.ID) {
+			select {
+			case messageCh <- obj.(*testproto.GenericMessage).Message:
+			default:
+			}
+		}); subscribeErr != nil {
+			t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+		}
+
+		topics[i], messageChs[i] = topic, messageCh
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for i, server := range servers {
+		expectedPeers := 1
+		if i == 1 {
+			expectedPeers = 2
+		}
+
+		if waitErr := WaitForSubscribers(ctx, server, topicName, expectedPeers); waitErr != nil {
+			t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+		}
+	}
+
+	// The messages are published until the mesh is formed and the valid one gets through
+	publishTicker := time.NewTicker(500 * time.Millisecond)
+	defer publishTicker.Stop()
+
+	received := make([]string, 0)
+	timeoutCh := time.After(15 * time.Second)
+
+	for len(received) == 0 {
+		select {
+		case <-publishTicker.C:
+			for _, message := range []string{invalidMessage, "valid"} {
+				if publishErr := topics[0].Publish(&testproto.GenericMessage{Message: message}); publishErr != nil {
+					t.Fatalf("Unable to publish message, %v", publishErr)
+				}
+			}
+		case message := <-messageChs[2]:
+			received = append(received, message)
+		case <-timeoutCh:
+			t.Fatalf("Gossip messages not received before timeout")
+		}
+	}
+
+	// The invalid messages are neither delivered nor relayed by the validating server
+	for _, messageCh := range messageChs[1:] {
+		for len(messageCh) > 0 {
+			received = append(received, <-messageCh)
+		}
+	}
+
+	for _, message := range received {
+		assert.Equal(t, "valid", message)
+	}
+
+	// the invalid messages may still be validated when the valid one is received
+	assert.Eventually(t, func() bool {
+		return rejected.get() > 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -18,6 +18,8 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerBanned       = errors.New("peer is banned")
+	ErrPeerNotAllowed   = errors.New("peer is not in the allow list")
 )
 
 // networkingServer defines the base communication interface between
@@ -47,6 +49,15 @@ type networkingServer interface {
 	// IsTemporaryDial checks if the peer connection is a temporary dial [Thread safe]
 	IsTemporaryDial(peerID peer.ID) bool
 
+	// IsBanned checks if the peer is temporarily banned for misbehaving [Thread safe]
+	IsBanned(peerID peer.ID) bool
+
+	// IsPeerAllowed checks if the peer is permitted by the peer allow list [Thread safe]
+	IsPeerAllowed(peerID peer.ID) bool
+
+	// IsTrustedPeer checks if the peer is exempt from the connection slot limits [Thread safe]
+	IsTrustedPeer(peerID peer.ID) bool
+
 	// CONNECTION INFORMATION //
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
@@ -92,7 +103,8 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			// Trusted peers are accepted even when the connection slots are full
+			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) && !i.baseServer.IsTrustedPeer(peerID) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -162,6 +174,16 @@ func (i *IdentityService) disconnectFromPeer(peerID peer.ID, reason string) {
 
 // handleConnected handles new network connections (handshakes)
 func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Direction) error {
+	// Banned peers are refused before the handshake
+	if i.baseServer.IsBanned(peerID) {
+		return ErrPeerBanned
+	}
+
+	// Peers outside of the allow list are refused, if the admission policy is enabled
+	if !i.baseServer.IsPeerAllowed(peerID) {
+		return ErrPeerNotAllowed
+	}
+
 	clt, clientErr := i.baseServer.NewIdentityClient(peerID)
 	if clientErr != nil {
 		return fmt.Errorf(
@@ -121,3 +121,96 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHandshake_BannedPeer makes sure banned peers are refused before the handshake
+func TestHandshake_BannedPeer(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+	helloCalled := false
+
+	// Create an instance of the identity service
+	identityService := newIdentityService(
+		// Set the relevant hook responses from the mock server
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the banned peer hook
+			server.HookIsBanned(func(peerID peer.ID) bool {
+				return true
+			})
+
+			// Define the add peer hook
+			server.HookAddPeer(func(
+				id peer.ID,
+				direction network.Direction,
+			) {
+				peersArray = append(peersArray, id)
+			})
+
+			// Define the mock IdentityClient response
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				helloCalled = true
+
+				return &proto.Status{}, nil
+			})
+		},
+	)
+
+	connectErr := identityService.handleConnected("TestPeer", network.DirInbound)
+
+	assert.ErrorIs(t, connectErr, ErrPeerBanned)
+
+	// Make sure the handshake didn't start, and no peers have been added
+	assert.False(t, helloCalled)
+	assert.Len(t, peersArray, 0)
+}
+
+// TestHandshake_NotAllowedPeer makes sure peers outside of the allow list are refused before the handshake
+func TestHandshake_NotAllowedPeer(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+	helloCalled := false
+
+	// Create an instance of the identity service
+	identityService := newIdentityService(
+		// Set the relevant hook responses from the mock server
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the allow list hook
+			server.HookIsPeerAllowed(func(peerID peer.ID) bool {
+				return peerID == "AllowedPeer"
+			})
+
+			// Define the add peer hook
+			server.HookAddPeer(func(
+				id peer.ID,
+				direction network.Direction,
+			) {
+				peersArray = append(peersArray, id)
+			})
+
+			// Define the mock IdentityClient response
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				helloCalled = true
+
+				return &proto.Status{}, nil
+			})
+		},
+	)
+
+	connectErr := identityService.handleConnected("TestPeer", network.DirInbound)
+
+	assert.ErrorIs(t, connectErr, ErrPeerNotAllowed)
+
+	// Make sure the handshake didn't start, and no peers have been added
+	assert.False(t, helloCalled)
+	assert.Len(t, peersArray, 0)
+
+	// The permitted peer completes the handshake
+	assert.NoError(t, identityService.handleConnected("AllowedPeer", network.DirInbound))
+	assert.True(t, helloCalled)
+	assert.Len(t, peersArray, 1)
+}
@@ -23,6 +23,24 @@ type Metrics struct {
 
 	// Number of pending inbound connections
 	PendingInboundConnectionsCount metrics.Gauge
+
+	// Number of gossiped messages rejected by the topic validators
+	GossipMessagesRejected metrics.Counter
+
+	// Number of gossiped messages ignored by the topic validators
+	GossipMessagesIgnored metrics.Counter
+
+	// Size of the published and delivered gossip messages
+	GossipMessageSize metrics.Histogram
+
+	// Number of bytes sent, by protocol
+	BytesSent metrics.Counter
+
+	// Number of bytes received, by protocol
+	BytesReceived metrics.Counter
+
+	// Number of opened streams, by protocol and direction
+	StreamsOpened metrics.Counter
 }
 
 // GetPrometheusMetrics return the network metrics instance
@@ -33,6 +51,14 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 		labels = append(labels, labelsWithValues[i])
 	}
 
+	// the gossip metrics are tracked per topic
+	topicLabels := append(append([]string{}, labels...), "topic")
+	topicDirectionLabels := append(append([]string{}, topicLabels...), "direction")
+
+	// the traffic metrics are tracked per protocol, the traffic per peer is served by the peers status
+	protocolLabels := append(append([]string{}, labels...), "protocol")
+	protocolDirectionLabels := append(append([]string{}, protocolLabels...), "direction")
+
 	return &Metrics{
 		TotalPeerCount: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
@@ -68,6 +94,49 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "pending_inbound_connections_count",
 			Help:      "Number of pending inbound connections",
 		}, labels).With(labelsWithValues...),
+
+		GossipMessagesRejected: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "gossip_messages_rejected",
+			Help:      "Number of gossiped messages rejected by the topic validators",
+		}, topicLabels).With(labelsWithValues...),
+
+		GossipMessagesIgnored: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "gossip_messages_ignored",
+			Help:      "Number of gossiped messages ignored by the topic validators",
+		}, topicLabels).With(labelsWithValues...),
+
+		GossipMessageSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "gossip_message_size_bytes",
+			Help:      "Size of the published and delivered gossip messages",
+			Buckets:   stdprometheus.ExponentialBuckets(64, 4, 8),
+		}, topicDirectionLabels).With(labelsWithValues...),
+
+		BytesSent: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "sent_bytes",
+			Help:      "Number of bytes sent, by protocol",
+		}, protocolLabels).With(labelsWithValues...),
+
+		BytesReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "received_bytes",
+			Help:      "Number of bytes received, by protocol",
+		}, protocolLabels).With(labelsWithValues...),
+
+		StreamsOpened: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "streams_opened",
+			Help:      "Number of opened streams, by protocol and direction",
+		}, protocolDirectionLabels).With(labelsWithValues...),
 	}
 }
 
@@ -79,5 +148,11 @@ func NilMetrics() *Metrics {
 		InboundConnectionsCount:         discard.NewGauge(),
 		PendingOutboundConnectionsCount: discard.NewGauge(),
 		PendingInboundConnectionsCount:  discard.NewGauge(),
+		GossipMessagesRejected:          discard.NewCounter(),
+		GossipMessagesIgnored:           discard.NewCounter(),
+		GossipMessageSize:               discard.NewHistogram(),
+		BytesSent:                       discard.NewCounter(),
+		BytesReceived:                   discard.NewCounter(),
+		StreamsOpened:                   discard.NewCounter(),
 	}
 }
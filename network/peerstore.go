@@ -0,0 +1,312 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// peerstoreFile is the name of the file the known peers are persisted to, in the libp2p data directory
+	peerstoreFile = "peers.json"
+
+	// maxPersistedPeers is the maximum number of non-static peers kept in the persistent peerstore
+	maxPersistedPeers = 256
+
+	// peerstoreFlushInterval is the period the changes of the persistent peerstore are written to disk with
+	peerstoreFlushInterval = time.Minute
+)
+
+// persistedPeer is a peer record of the persistent peerstore
+type persistedPeer struct {
+	ID       peer.ID   `json:"id"`
+	Addrs    []string  `json:"addrs"`
+	Static   bool      `json:"static,omitempty"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// addrInfo converts the record to the peer address info, skipping the malformed addresses
+func (p *persistedPeer) addrInfo() *peer.AddrInfo {
+	info := &peer.AddrInfo{
+		ID:    p.ID,
+		Addrs: make([]multiaddr.Multiaddr, 0, len(p.Addrs)),
+	}
+
+	for _, rawAddr := range p.Addrs {
+		addr, err := multiaddr.NewMultiaddr(rawAddr)
+		if err != nil {
+			continue
+		}
+
+		info.Addrs = append(info.Addrs, addr)
+	}
+
+	return info
+}
+
+// persistentPeerstore keeps the addresses of the peers the node has connected to,
+// so they can be dialed right away after a restart
+type persistentPeerstore struct {
+	sync.Mutex
+
+	path  string // the path of the persisted peerstore, empty if not persisted
+	peers map[peer.ID]*persistedPeer
+	dirty bool // flag indicating if there are changes not written to disk yet
+	now   func() time.Time
+
+	flushLock sync.Mutex // lock serializing the writes to disk
+}
+
+// newPersistentPeerstore creates the peerstore persisted in the data directory
+func newPersistentPeerstore(dataDir string) (*persistentPeerstore, error) {
+	store := &persistentPeerstore{
+		peers: make(map[peer.ID]*persistedPeer),
+		now:   time.Now,
+	}
+
+	if dataDir == "" {
+		return store, nil
+	}
+
+	store.path = filepath.Join(dataDir, peerstoreFile)
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+
+		return nil, fmt.Errorf("unable to read peerstore, %w", err)
+	}
+
+	var peers []*persistedPeer
+	if err := json.Unmarshal(data, &peers); err != nil {
+		return nil, fmt.Errorf("unable to parse peerstore, %w", err)
+	}
+
+	for _, p := range peers {
+		store.peers[p.ID] = p
+	}
+
+	return store, nil
+}
+
+// add records the peer addresses, to be written to disk on the next flush.
+// Once marked as static, a peer stays static [Thread safe]
+func (s *persistentPeerstore) add(info *peer.AddrInfo, static bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	addrs := make([]string, len(info.Addrs))
+	for i, addr := range info.Addrs {
+		addrs[i] = addr.String()
+	}
+
+	record, ok := s.peers[info.ID]
+	if !ok {
+		record = &persistedPeer{ID: info.ID}
+		s.peers[info.ID] = record
+	}
+
+	if len(addrs) > 0 {
+		record.Addrs = addrs
+	}
+
+	record.Static = record.Static || static
+	record.LastSeen = s.now()
+
+	s.evict()
+
+	s.dirty = true
+}
+
+// staticPeers returns the peers marked as static [Thread safe]
+func (s *persistentPeerstore) staticPeers() []*peer.AddrInfo {
+	return s.filter(func(p *persistedPeer) bool {
+		return p.Static
+	})
+}
+
+// knownPeers returns the non-static peers, most recently seen first [Thread safe]
+func (s *persistentPeerstore) knownPeers() []*peer.AddrInfo {
+	return s.filter(func(p *persistedPeer) bool {
+		return !p.Static
+	})
+}
+
+func (s *persistentPeerstore) filter(keep func(p *persistedPeer) bool) []*peer.AddrInfo {
+	s.Lock()
+	defer s.Unlock()
+
+	records := make([]*persistedPeer, 0, len(s.peers))
+
+	for _, p := range s.peers {
+		if keep(p) {
+			records = append(records, p)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].LastSeen.After(records[j].LastSeen)
+	})
+
+	infos := make([]*peer.AddrInfo, 0, len(records))
+
+	for _, record := range records {
+		if info := record.addrInfo(); len(info.Addrs) > 0 {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// evict drops the least recently seen non-static peers over the limit
+func (s *persistentPeerstore) evict() {
+	known := make([]*persistedPeer, 0, len(s.peers))
+
+	for _, p := range s.peers {
+		if !p.Static {
+			known = append(known, p)
+		}
+	}
+
+	if len(known) <= maxPersistedPeers {
+		return
+	}
+
+	sort.Slice(known, func(i, j int) bool {
+		return known[i].LastSeen.Before(known[j].LastSeen)
+	})
+
+	for _, p := range known[:len(known)-maxPersistedPeers] {
+		delete(s.peers, p.ID)
+	}
+}
+
+// flush writes the peerstore to disk, if it changed since the last flush [Thread safe]
+func (s *persistentPeerstore) flush() error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.Lock()
+
+	if !s.dirty || s.path == "" {
+		s.Unlock()
+
+		return nil
+	}
+
+	peers := make([]*persistedPeer, 0, len(s.peers))
+	for _, p := range s.peers {
+		peers = append(peers, p)
+	}
+
+	data, err := json.Marshal(peers)
+	s.dirty = false
+	s.Unlock()
+
+	if err == nil {
+		err = writeFileAtomic(s.path, data)
+	}
+
+	if err != nil {
+		// the changes are written on the next flush
+		s.Lock()
+		s.dirty = true
+		s.Unlock()
+	}
+
+	return err
+}
+
+// writeFileAtomic writes to a temporary file first, and then renames it,
+// so a crash can't leave a truncated file behind
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// dialKnownPeers seeds the dial queue with the peers persisted in the peerstore
+func (s *Server) dialKnownPeers() {
+	for _, info := range s.peerstore.knownPeers() {
+		if info.ID == s.host.ID() || s.isConnected(info.ID) {
+			continue
+		}
+
+		s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.AddressTTL)
+		s.addToDialQueue(info, common.PriorityRandomDial)
+	}
+}
+
+// getRandomKnownPeer returns a random disconnected peer from the peerstore, if any
+func (s *Server) getRandomKnownPeer() *peer.AddrInfo {
+	nonConnectedPeers := make([]*peer.AddrInfo, 0)
+
+	for _, info := range s.peerstore.knownPeers() {
+		if info.ID != s.host.ID() && !s.hasPeer(info.ID) {
+			nonConnectedPeers = append(nonConnectedPeers, info)
+		}
+	}
+
+	if len(nonConnectedPeers) > 0 {
+		randNum, _ := rand.Int(rand.Reader, big.NewInt(int64(len(nonConnectedPeers))))
+
+		return nonConnectedPeers[randNum.Int64()]
+	}
+
+	return nil
+}
+
+// persistPeer records the connected peer addresses in the persistent peerstore
+func (s *Server) persistPeer(peerID peer.ID) {
+	info := s.host.Peerstore().PeerInfo(peerID)
+	if len(info.Addrs) == 0 {
+		return
+	}
+
+	s.peerstore.add(&info, false)
+}
+
+// runPeerstoreFlush periodically writes the changes of the persistent peerstore to disk,
+// so the connections don't wait on the disk writes
+func (s *Server) runPeerstoreFlush() {
+	ticker := time.NewTicker(peerstoreFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushPeerstore()
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// flushPeerstore writes the changes of the persistent peerstore to disk
+func (s *Server) flushPeerstore() {
+	if err := s.peerstore.flush(); err != nil {
+		s.logger.Error("Unable to persist the peerstore", "err", err)
+	}
+}
@@ -0,0 +1,152 @@
+package network
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// PeerBehaviour is a behaviour of a peer reported by the protocol handlers
+type PeerBehaviour int
+
+const (
+	// ValidBlock is reported when a block received from the peer passes verification
+	ValidBlock PeerBehaviour = iota
+	// InvalidBlock is reported when a block received from the peer fails verification
+	InvalidBlock
+	// ValidConsensusMessage is reported when a consensus message gossiped by the peer is valid
+	ValidConsensusMessage
+	// InvalidConsensusMessage is reported when a consensus message gossiped by the peer is malformed
+	InvalidConsensusMessage
+	// ValidTransaction is reported when a transaction gossiped by the peer is accepted
+	ValidTransaction
+	// InvalidTransaction is reported when a transaction gossiped by the peer is malformed or invalid
+	InvalidTransaction
+)
+
+// behaviourScores are the score changes applied for each of the reported behaviours
+var behaviourScores = map[PeerBehaviour]float64{
+	ValidBlock:              1,
+	InvalidBlock:            -50,
+	ValidConsensusMessage:   0.1,
+	InvalidConsensusMessage: -20,
+	ValidTransaction:        0.1,
+	InvalidTransaction:      -10,
+}
+
+func (b PeerBehaviour) String() string {
+	switch b {
+	case ValidBlock:
+		return "valid block"
+	case InvalidBlock:
+		return "invalid block"
+	case ValidConsensusMessage:
+		return "valid consensus message"
+	case InvalidConsensusMessage:
+		return "invalid consensus message"
+	case ValidTransaction:
+		return "valid transaction"
+	case InvalidTransaction:
+		return "invalid transaction"
+	default:
+		return "unknown behaviour"
+	}
+}
+
+const (
+	// DefaultBanThreshold is the score below which a peer is banned
+	DefaultBanThreshold float64 = -100
+
+	// DefaultBanDuration is the period a peer below the threshold is banned for
+	DefaultBanDuration = time.Hour
+
+	// maxPeerScore caps the score a peer can build up with good behaviour,
+	// so that it can't offset an unlimited amount of misbehaviour
+	maxPeerScore float64 = 100
+
+	// scoreHalfLife is the period after which a score decays to half of its value
+	scoreHalfLife = 10 * time.Minute
+)
+
+// PeerReporter is the interface through which the protocol handlers
+// report the behaviour of the peers
+type PeerReporter interface {
+	// ReportPeer updates the score of the peer with the reported behaviour
+	ReportPeer(peerID peer.ID, behaviour PeerBehaviour)
+}
+
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// peerScorer keeps the reputation score of the peers. Scores decay
+// towards zero over time, so old behaviour is gradually forgotten
+type peerScorer struct {
+	sync.Mutex
+
+	scores   map[peer.ID]*peerScore
+	halfLife time.Duration
+	now      func() time.Time
+}
+
+func newPeerScorer(halfLife time.Duration) *peerScorer {
+	return &peerScorer{
+		scores:   make(map[peer.ID]*peerScore),
+		halfLife: halfLife,
+		now:      time.Now,
+	}
+}
+
+// update applies the score change to the decayed score of the peer,
+// and returns the new score [Thread safe]
+func (s *peerScorer) update(peerID peer.ID, delta float64) float64 {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+
+	score, ok := s.scores[peerID]
+	if !ok {
+		score = &peerScore{}
+		s.scores[peerID] = score
+	}
+
+	score.value = math.Min(s.decay(score, now)+delta, maxPeerScore)
+	score.updated = now
+
+	return score.value
+}
+
+// get returns the decayed score of the peer [Thread safe]
+func (s *peerScorer) get(peerID peer.ID) float64 {
+	s.Lock()
+	defer s.Unlock()
+
+	score, ok := s.scores[peerID]
+	if !ok {
+		return 0
+	}
+
+	return s.decay(score, s.now())
+}
+
+// remove drops the score of the peer [Thread safe]
+func (s *peerScorer) remove(peerID peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.scores, peerID)
+}
+
+// decay returns the value of the score decayed up to the given time
+func (s *peerScorer) decay(score *peerScore, now time.Time) float64 {
+	elapsed := now.Sub(score.updated)
+	if elapsed <= 0 || score.value == 0 {
+		return score.value
+	}
+
+	return score.value * math.Pow(0.5, float64(elapsed)/float64(s.halfLife))
+}
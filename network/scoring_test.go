@@ -0,0 +1,191 @@
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	peerEvent "github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	if err != nil {
+		t.Fatalf("Unable to generate key pair, %v", err)
+	}
+
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("Unable to generate peer ID, %v", err)
+	}
+
+	return id
+}
+
+func TestPeerScorer_UpdateAndDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	scorer := newPeerScorer(time.Minute)
+	scorer.now = func() time.Time {
+		return now
+	}
+
+	peerID := peer.ID("peer")
+
+	assert.Equal(t, float64(0), scorer.get(peerID))
+	assert.Equal(t, float64(-40), scorer.update(peerID, -40))
+
+	// the score halves after every half life
+	now = now.Add(time.Minute)
+	assert.InDelta(t, -20, scorer.get(peerID), 0.0001)
+
+	now = now.Add(time.Minute)
+	assert.InDelta(t, -10, scorer.update(peerID, 0), 0.0001)
+
+	scorer.remove(peerID)
+	assert.Equal(t, float64(0), scorer.get(peerID))
+}
+
+func TestPeerScorer_MaxScore(t *testing.T) {
+	t.Parallel()
+
+	scorer := newPeerScorer(time.Minute)
+	peerID := peer.ID("peer")
+
+	for i := 0; i < 200; i++ {
+		scorer.update(peerID, behaviourScores[ValidBlock])
+	}
+
+	// good behaviour can't build up an unlimited score
+	assert.LessOrEqual(t, scorer.get(peerID), maxPeerScore)
+	assert.Less(t, scorer.update(peerID, 2*DefaultBanThreshold), DefaultBanThreshold)
+}
+
+func TestBanList_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	list, err := newBanList("")
+	assert.NoError(t, err)
+
+	list.now = func() time.Time {
+		return now
+	}
+
+	peerID := peer.ID("peer")
+
+	assert.NoError(t, list.ban(peerID, "reason", time.Minute))
+	assert.NotNil(t, list.get(peerID))
+	assert.Len(t, list.list(), 1)
+
+	now = now.Add(time.Minute)
+
+	assert.Nil(t, list.get(peerID))
+	assert.Len(t, list.list(), 0)
+}
+
+func TestBanList_Persistence(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	bannedID, expiredID := newTestPeerID(t), newTestPeerID(t)
+
+	list, err := newBanList(dataDir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, list.ban(bannedID, "invalid block", time.Hour))
+	assert.NoError(t, list.ban(expiredID, "invalid block", time.Millisecond))
+
+	time.Sleep(10 * time.Millisecond)
+
+	assert.FileExists(t, filepath.Join(dataDir, bansFile))
+
+	// the active bans survive a restart
+	restored, err := newBanList(dataDir)
+	assert.NoError(t, err)
+
+	ban := restored.get(bannedID)
+	if assert.NotNil(t, ban) {
+		assert.Equal(t, "invalid block", ban.Reason)
+	}
+
+	assert.Nil(t, restored.get(expiredID))
+	assert.Len(t, restored.list(), 1)
+}
+
+func TestBanList_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, bansFile), []byte("{"), 0600))
+
+	_, err := newBanList(dataDir)
+	assert.Error(t, err)
+}
+
+func TestReportPeer_BansMisbehavingPeer(t *testing.T) {
+	noDiscover := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: noDiscover,
+		1: noDiscover,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// Server 0 should connect to Server 1
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	misbehavingID := servers[1].AddrInfo().ID
+
+	// a single invalid block doesn't get the peer banned
+	servers[0].ReportPeer(misbehavingID, InvalidBlock)
+	assert.False(t, servers[0].IsBanned(misbehavingID))
+	assert.InDelta(t, behaviourScores[InvalidBlock], servers[0].GetPeerScore(misbehavingID), 0.01)
+
+	for i := 0; i < 2; i++ {
+		servers[0].ReportPeer(misbehavingID, InvalidBlock)
+	}
+
+	assert.True(t, servers[0].IsBanned(misbehavingID))
+	assert.Len(t, servers[0].BannedPeers(), 1)
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultLeaveTimeout)
+	defer disconnectFn()
+
+	if _, err := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], misbehavingID); err != nil {
+		t.Fatalf("Unable to disconnect from banned peer, %v", err)
+	}
+
+	sub, subErr := servers[0].Subscribe()
+	if subErr != nil {
+		t.Fatalf("Unable to subscribe to peer events, %v", subErr)
+	}
+
+	defer sub.Close()
+
+	// the banned peer can't connect again
+	servers[1].joinPeer(servers[0].AddrInfo())
+	waitForEvent(t, sub, misbehavingID, peerEvent.PeerFailedToConnect)
+	assert.False(t, servers[0].hasPeer(misbehavingID))
+}
@@ -66,7 +66,8 @@ type Server struct {
 	peers     map[peer.ID]*PeerConnInfo // map of all peer connections
 	peersLock sync.Mutex                // lock for the peer map
 
-	metrics *Metrics // reference for metrics tracking
+	metrics   *Metrics           // reference for metrics tracking
+	bandwidth *bandwidthReporter // reference for the traffic tracking
 
 	dialQueue *dial.DialQueue // queue used to asynchronously connect to peers
 
@@ -86,6 +87,15 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	scores *peerScorer // reputation scores of the peers
+	bans   *banList    // temporarily banned misbehaving peers
+
+	peerstore   *persistentPeerstore // known peers persisted across restarts
+	staticPeers *staticPeers         // static and trusted peers
+
+	allowlist       *peerAllowlist      // peers permitted to connect, if the admission policy is enabled
+	allowlistSource PeerAllowlistSource // source of the on-chain permitted peers
 }
 
 // NewServer returns a new instance of the networking server
@@ -116,12 +126,15 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
+	bandwidth := newBandwidthReporter(config.Metrics)
+
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		libp2p.BandwidthReporter(bandwidth),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -132,6 +145,16 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	bans, err := newBanList(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	knownPeers, err := newPersistentPeerstore(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
 	srv := &Server{
 		logger:           logger,
 		config:           config,
@@ -139,6 +162,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		addrs:            host.Addrs(),
 		peers:            make(map[peer.ID]*PeerConnInfo),
 		metrics:          config.Metrics,
+		bandwidth:        bandwidth,
 		dialQueue:        dial.NewDialQueue(),
 		closeCh:          make(chan struct{}),
 		emitterPeerEvent: emitter,
@@ -153,6 +177,11 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		scores:      newPeerScorer(scoreHalfLife),
+		bans:        bans,
+		peerstore:   knownPeers,
+		staticPeers: newStaticPeers(),
+		allowlist:   newPeerAllowlist(config.PeerAllowlistFile != "" || config.PeerAllowlistContract),
 	}
 
 	// start gossip protocol
@@ -243,6 +272,12 @@ func setupLibp2pKey(secretsManager secrets.SecretsManager) (crypto.PrivKey, erro
 func (s *Server) Start() error {
 	s.logger.Info("LibP2P server running", "addr", common.AddrInfoToString(s.AddrInfo()))
 
+	if s.isPeerAllowlistEnabled() {
+		if _, loadErr := s.ReloadPeerAllowlist(); loadErr != nil {
+			return fmt.Errorf("unable to load peer allow list, %w", loadErr)
+		}
+	}
+
 	if setupErr := s.setupIdentity(); setupErr != nil {
 		return fmt.Errorf("unable to setup identity, %w", setupErr)
 	}
@@ -260,7 +295,16 @@ func (s *Server) Start() error {
 		}
 	}
 
+	if setupErr := s.setupStaticPeers(); setupErr != nil {
+		return fmt.Errorf("unable to setup static peers, %w", setupErr)
+	}
+
+	// Seed the dial queue with the peers known from previous runs
+	s.dialKnownPeers()
+
 	go s.runDial()
+	go s.runStaticPeers()
+	go s.runPeerstoreFlush()
 	go s.checkPeerConnections()
 
 	// watch for disconnected peers
@@ -268,6 +312,11 @@ func (s *Server) Start() error {
 		DisconnectedF: func(net network.Network, conn network.Conn) {
 			// Update the local connection metrics
 			s.removePeer(conn.RemotePeer())
+
+			// Drop the traffic of the peer, unless another connection to it is still open
+			if net.Connectedness(conn.RemotePeer()) != network.Connected {
+				s.bandwidth.removePeer(conn.RemotePeer())
+			}
 		},
 	})
 
@@ -276,6 +325,11 @@ func (s *Server) Start() error {
 
 // setupBootnodes sets up the node's bootnode connections
 func (s *Server) setupBootnodes() error {
+	// The bootnodes are optional when the DNS trees of node records are used instead
+	if len(s.config.Chain.Bootnodes) == 0 && len(s.config.DNSDiscovery) > 0 {
+		return nil
+	}
+
 	// Check the bootnode config is present
 	if s.config.Chain.Bootnodes == nil {
 		return ErrNoBootnodes
@@ -328,7 +382,9 @@ func (s *Server) checkPeerConnections() {
 
 		if s.numPeers() < MinimumPeerConnections {
 			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
-				//TODO: dial peers from the peerstore
+				if knownPeer := s.getRandomKnownPeer(); knownPeer != nil {
+					s.addToDialQueue(knownPeer, common.PriorityRandomDial)
+				}
 			} else {
 				randomNode := s.GetRandomBootnode()
 				s.addToDialQueue(randomNode, common.PriorityRandomDial)
@@ -390,6 +446,11 @@ func (s *Server) runDial() {
 
 			peerInfo := tt.GetAddrInfo()
 
+			if s.IsBanned(peerInfo.ID) {
+				// the peer might have been banned after it was queued
+				continue
+			}
+
 			s.logger.Debug(fmt.Sprintf("Dialing peer [%s] as local [%s]", peerInfo.String(), s.host.ID()))
 
 			if !s.isConnected(peerInfo.ID) {
@@ -580,6 +641,9 @@ func (s *Server) Close() error {
 
 	close(s.closeCh)
 
+	// Write the peers connected since the last flush
+	s.flushPeerstore()
+
 	return err
 }
 
@@ -603,7 +667,14 @@ func (s *Server) newProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.C
 }
 
 func (s *Server) NewStream(proto string, id peer.ID) (network.Stream, error) {
-	return s.host.NewStream(context.Background(), id, protocol.ID(proto))
+	stream, err := s.host.NewStream(context.Background(), id, protocol.ID(proto))
+	if err != nil {
+		return nil, err
+	}
+
+	s.bandwidth.logStream(protocol.ID(proto), id, network.DirOutbound)
+
+	return stream, nil
 }
 
 type Protocol interface {
@@ -623,6 +694,7 @@ func (s *Server) wrapStream(id string, handle func(network.Stream)) {
 	s.host.SetStreamHandler(protocol.ID(id), func(stream network.Stream) {
 		peerID := stream.Conn().RemotePeer()
 		s.logger.Debug("open stream", "protocol", id, "peer", peerID)
+		s.bandwidth.logStream(protocol.ID(id), peerID, network.DirInbound)
 
 		handle(stream)
 	})
@@ -636,6 +708,12 @@ func (s *Server) AddrInfo() *peer.AddrInfo {
 }
 
 func (s *Server) addToDialQueue(addr *peer.AddrInfo, priority common.DialPriority) {
+	if s.IsBanned(addr.ID) {
+		s.logger.Debug("Omitting dial to banned peer", "id", addr.ID)
+
+		return
+	}
+
 	s.dialQueue.AddTask(addr, priority)
 	s.emitEvent(addr.ID, peerEvent.PeerAddedToDialQueue)
 }
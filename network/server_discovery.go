@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/discovery"
+	"github.com/0xPolygon/polygon-edge/network/discovery/dnsdisc"
 	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -232,6 +233,11 @@ func (s *Server) setupDiscovery() error {
 	// Register the actual discovery service as a valid protocol
 	s.registerDiscoveryService(discoveryService)
 
+	// Resolve the DNS trees of node records, if any, along with the bootnodes
+	if len(s.config.DNSDiscovery) > 0 {
+		discoveryService.SetDNSTrees(dnsdisc.NewClient(nil), s.config.DNSDiscovery)
+	}
+
 	// Make sure the discovery service has the bootnodes in its routing table,
 	// and instantiates connections to them
 	discoveryService.ConnectToBootnodes(s.bootnodes.getBootnodes())
@@ -35,6 +35,9 @@ func (s *Server) AddPeer(id peer.ID, direction network.Direction) {
 		return
 	}
 
+	// Remember the peer, so it can be dialed after a restart
+	s.persistPeer(id)
+
 	// Emit the event alerting listeners
 	// WARNING: THIS CALL IS POTENTIALLY BLOCKING
 	// UNDER HEAVY LOAD. IT SHOULD BE SUBSTITUTED
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// ReportPeer updates the reputation score of the peer with the reported behaviour.
+// Peers whose score drops below the ban threshold are disconnected and banned [Thread safe]
+func (s *Server) ReportPeer(peerID peer.ID, behaviour PeerBehaviour) {
+	score := s.scores.update(peerID, behaviourScores[behaviour])
+
+	s.logger.Debug("Peer reported", "id", peerID, "behaviour", behaviour, "score", score)
+
+	if score < s.config.BanThreshold {
+		s.BanPeer(peerID, fmt.Sprintf("score %.2f below threshold, last reported for %s", score, behaviour))
+	}
+}
+
+// BanPeer disconnects from the peer and bans it for the configured ban duration [Thread safe]
+func (s *Server) BanPeer(peerID peer.ID, reason string) {
+	s.logger.Warn("Banning peer", "id", peerID, "reason", reason, "duration", s.config.BanDuration)
+
+	if err := s.bans.ban(peerID, reason, s.config.BanDuration); err != nil {
+		// the ban is still active in memory
+		s.logger.Error("Unable to persist the ban list", "err", err)
+	}
+
+	// the peer starts from a clean score once the ban expires
+	s.scores.remove(peerID)
+
+	s.DisconnectFromPeer(peerID, fmt.Sprintf("banned, %s", reason))
+}
+
+// IsBanned checks if the peer is currently banned [Thread safe]
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.bans.get(peerID) != nil
+}
+
+// GetPeerBan returns the active ban of the peer, if any [Thread safe]
+func (s *Server) GetPeerBan(peerID peer.ID) *PeerBan {
+	return s.bans.get(peerID)
+}
+
+// BannedPeers returns the currently banned peers [Thread safe]
+func (s *Server) BannedPeers() []*PeerBan {
+	return s.bans.list()
+}
+
+// GetPeerScore returns the current reputation score of the peer [Thread safe]
+func (s *Server) GetPeerScore(peerID peer.ID) float64 {
+	return s.scores.get(peerID)
+}
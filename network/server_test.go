@@ -688,6 +688,7 @@ func TestMinimumBootNodeCount(t *testing.T) {
 	tests := []struct {
 		name          string
 		bootNodes     []string
+		dnsDiscovery  []string
 		expectedError error
 	}{
 		{
@@ -705,6 +706,12 @@ func TestMinimumBootNodeCount(t *testing.T) {
 			bootNodes:     []string{tests.GenerateTestMultiAddr(t).String()},
 			expectedError: nil,
 		},
+		{
+			name:          "Server config with DNS discovery and no bootnodes",
+			bootNodes:     nil,
+			dnsDiscovery:  []string{"enrtree://AM5FCQLWIZX2QFPNJAP7VUERCCRNGRHWZG3YYHIUV7BVDQ5FDPRT2@nodes.example.org"},
+			expectedError: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -712,6 +719,7 @@ func TestMinimumBootNodeCount(t *testing.T) {
 			_, createErr := CreateServer(&CreateServerParams{
 				ServerCallback: func(server *Server) {
 					server.config.Chain.Bootnodes = tt.bootNodes
+					server.config.DNSDiscovery = tt.dnsDiscovery
 				},
 			})
 
@@ -0,0 +1,236 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+)
+
+const (
+	// staticPeerMinBackoff is the initial delay before re-dialing a disconnected static peer
+	staticPeerMinBackoff = 5 * time.Second
+
+	// staticPeerMaxBackoff is the maximum delay between the static peer re-dials
+	staticPeerMaxBackoff = 5 * time.Minute
+
+	// staticPeersCheckInterval is the interval of the static peer connection checks
+	staticPeersCheckInterval = time.Second
+)
+
+var (
+	ErrStaticPeerSelf = errors.New("unable to add the node itself as a static peer")
+)
+
+// staticPeer is a peer that is always kept connected
+type staticPeer struct {
+	info     *peer.AddrInfo
+	backoff  time.Duration // the delay before the next re-dial, if the current one fails
+	nextDial time.Time     // the earliest time of the next re-dial
+}
+
+// staticPeers keeps the static and trusted peers of the node.
+// Static peers are always re-dialed when disconnected, while
+// trusted peers can connect even when the connection slots are full
+type staticPeers struct {
+	sync.Mutex
+
+	static  map[peer.ID]*staticPeer
+	trusted map[peer.ID]struct{}
+
+	minBackoff    time.Duration // the initial re-dial delay, restored once the peer connects
+	maxBackoff    time.Duration // the maximum re-dial delay
+	checkInterval time.Duration // the interval of the connection checks
+}
+
+func newStaticPeers() *staticPeers {
+	return &staticPeers{
+		static:        make(map[peer.ID]*staticPeer),
+		trusted:       make(map[peer.ID]struct{}),
+		minBackoff:    staticPeerMinBackoff,
+		maxBackoff:    staticPeerMaxBackoff,
+		checkInterval: staticPeersCheckInterval,
+	}
+}
+
+// addStatic adds the static peer, returns false if it was already present [Thread safe]
+func (sp *staticPeers) addStatic(info *peer.AddrInfo) bool {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if _, ok := sp.static[info.ID]; ok {
+		return false
+	}
+
+	sp.static[info.ID] = &staticPeer{
+		info:    info,
+		backoff: sp.minBackoff,
+	}
+
+	return true
+}
+
+// addTrusted adds the trusted peer [Thread safe]
+func (sp *staticPeers) addTrusted(peerID peer.ID) {
+	sp.Lock()
+	defer sp.Unlock()
+
+	sp.trusted[peerID] = struct{}{}
+}
+
+// isTrusted checks if the peer is exempt from the connection slot limits.
+// Static peers are implicitly trusted [Thread safe]
+func (sp *staticPeers) isTrusted(peerID peer.ID) bool {
+	sp.Lock()
+	defer sp.Unlock()
+
+	_, trusted := sp.trusted[peerID]
+	_, static := sp.static[peerID]
+
+	return trusted || static
+}
+
+// dueForDial returns the disconnected static peers whose backoff has passed,
+// and schedules their next re-dial. The backoff of connected peers is reset [Thread safe]
+func (sp *staticPeers) dueForDial(now time.Time, isConnected func(peer.ID) bool) []*peer.AddrInfo {
+	sp.Lock()
+	defer sp.Unlock()
+
+	due := make([]*peer.AddrInfo, 0)
+
+	for id, p := range sp.static {
+		if isConnected(id) {
+			p.backoff = sp.minBackoff
+			p.nextDial = time.Time{}
+
+			continue
+		}
+
+		if now.Before(p.nextDial) {
+			continue
+		}
+
+		due = append(due, p.info)
+
+		p.nextDial = now.Add(p.backoff)
+
+		p.backoff *= 2
+		if p.backoff > sp.maxBackoff {
+			p.backoff = sp.maxBackoff
+		}
+	}
+
+	return due
+}
+
+// setupStaticPeers parses the configured static and trusted peers,
+// and restores the static peers added at runtime from the peerstore
+func (s *Server) setupStaticPeers() error {
+	for _, rawAddr := range s.config.StaticPeers {
+		info, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("failed to parse static peer %s: %w", rawAddr, err)
+		}
+
+		s.addStaticPeer(info)
+	}
+
+	for _, rawAddr := range s.config.TrustedPeers {
+		info, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("failed to parse trusted peer %s: %w", rawAddr, err)
+		}
+
+		s.staticPeers.addTrusted(info.ID)
+		s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+	}
+
+	for _, info := range s.peerstore.staticPeers() {
+		s.addStaticPeer(info)
+	}
+
+	return nil
+}
+
+// AddStaticPeer adds a peer which is always re-dialed when disconnected,
+// and persists it so it survives node restarts
+func (s *Server) AddStaticPeer(rawPeerMultiaddr string) error {
+	info, err := common.StringToAddrInfo(rawPeerMultiaddr)
+	if err != nil {
+		return err
+	}
+
+	if info.ID == s.host.ID() {
+		return ErrStaticPeerSelf
+	}
+
+	s.addStaticPeer(info)
+
+	// the static peers are added at runtime by the operator, so they are persisted right away
+	s.peerstore.add(info, true)
+
+	if err := s.peerstore.flush(); err != nil {
+		return fmt.Errorf("unable to persist static peer, %w", err)
+	}
+
+	return nil
+}
+
+// addStaticPeer registers the static peer and its addresses
+func (s *Server) addStaticPeer(info *peer.AddrInfo) {
+	if info.ID == s.host.ID() {
+		return
+	}
+
+	s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+
+	if s.staticPeers.addStatic(info) {
+		s.logger.Info("Static peer added", "addr", info.String())
+	}
+}
+
+// IsTrustedPeer checks if the peer is exempt from the connection slot limits [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.staticPeers.isTrusted(peerID)
+}
+
+// runStaticPeers keeps the static peers connected, re-dialing them
+// with an exponential backoff whenever they disconnect
+func (s *Server) runStaticPeers() {
+	ticker := time.NewTicker(s.staticPeers.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, info := range s.staticPeers.dueForDial(time.Now(), s.isConnected) {
+			if s.IsBanned(info.ID) {
+				continue
+			}
+
+			go s.dialStaticPeer(info)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// dialStaticPeer dials the static peer directly, bypassing the dial queue
+// and the outbound connection limit
+func (s *Server) dialStaticPeer(info *peer.AddrInfo) {
+	s.logger.Debug("Dialing static peer", "addr", info.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	if err := s.host.Connect(ctx, *info); err != nil {
+		s.logger.Debug("failed to dial static peer", "addr", info.String(), "err", err)
+	}
+}
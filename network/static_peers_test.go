@@ -0,0 +1,240 @@
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	peerEvent "github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAddrInfo(t *testing.T) *peer.AddrInfo {
+	t.Helper()
+
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/1478")
+	if err != nil {
+		t.Fatalf("Unable to create multiaddr, %v", err)
+	}
+
+	return &peer.AddrInfo{
+		ID:    newTestPeerID(t),
+		Addrs: []multiaddr.Multiaddr{addr},
+	}
+}
+
+func TestStaticPeers_Backoff(t *testing.T) {
+	t.Parallel()
+
+	sp := newStaticPeers()
+	info := newTestAddrInfo(t)
+	connected := false
+
+	isConnected := func(peer.ID) bool {
+		return connected
+	}
+
+	assert.True(t, sp.addStatic(info))
+	assert.False(t, sp.addStatic(info))
+	assert.True(t, sp.isTrusted(info.ID))
+
+	now := time.Now()
+
+	// the first dial is immediate
+	assert.Len(t, sp.dueForDial(now, isConnected), 1)
+	assert.Len(t, sp.dueForDial(now, isConnected), 0)
+
+	// the re-dials back off exponentially
+	now = now.Add(staticPeerMinBackoff)
+	assert.Len(t, sp.dueForDial(now, isConnected), 1)
+
+	now = now.Add(staticPeerMinBackoff)
+	assert.Len(t, sp.dueForDial(now, isConnected), 0)
+
+	now = now.Add(staticPeerMinBackoff)
+	assert.Len(t, sp.dueForDial(now, isConnected), 1)
+
+	// the backoff is reset once the peer connects
+	connected = true
+	assert.Len(t, sp.dueForDial(now, isConnected), 0)
+
+	connected = false
+	assert.Len(t, sp.dueForDial(now, isConnected), 1)
+	assert.Equal(t, 2*staticPeerMinBackoff, sp.static[info.ID].backoff)
+}
+
+func TestPersistentPeerstore_Persistence(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	knownPeer, staticPeer := newTestAddrInfo(t), newTestAddrInfo(t)
+
+	store, err := newPersistentPeerstore(dataDir)
+	assert.NoError(t, err)
+
+	store.add(knownPeer, false)
+	store.add(staticPeer, true)
+
+	// reconnecting doesn't clear the static flag
+	store.add(staticPeer, false)
+
+	// the peers are written to disk on flush only
+	assert.NoFileExists(t, filepath.Join(dataDir, peerstoreFile))
+	assert.NoError(t, store.flush())
+	assert.FileExists(t, filepath.Join(dataDir, peerstoreFile))
+
+	// the peers survive a restart
+	restored, err := newPersistentPeerstore(dataDir)
+	assert.NoError(t, err)
+
+	if known := restored.knownPeers(); assert.Len(t, known, 1) {
+		assert.Equal(t, knownPeer.ID, known[0].ID)
+		assert.Equal(t, knownPeer.Addrs, known[0].Addrs)
+	}
+
+	if static := restored.staticPeers(); assert.Len(t, static, 1) {
+		assert.Equal(t, staticPeer.ID, static[0].ID)
+	}
+}
+
+func TestPersistentPeerstore_Eviction(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store, err := newPersistentPeerstore("")
+	assert.NoError(t, err)
+
+	store.now = func() time.Time {
+		return now
+	}
+
+	staticPeer := newTestAddrInfo(t)
+	store.add(staticPeer, true)
+
+	oldestPeer := newTestAddrInfo(t)
+	store.add(oldestPeer, false)
+
+	for i := 0; i < maxPersistedPeers; i++ {
+		now = now.Add(time.Second)
+
+		store.add(newTestAddrInfo(t), false)
+	}
+
+	// the least recently seen peer is evicted, static peers are kept
+	known := store.knownPeers()
+	assert.Len(t, known, maxPersistedPeers)
+
+	for _, info := range known {
+		assert.NotEqual(t, oldestPeer.ID, info.ID)
+	}
+
+	assert.Len(t, store.staticPeers(), 1)
+}
+
+func TestPersistentPeerstore_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, peerstoreFile), []byte("["), 0600))
+
+	_, err := newPersistentPeerstore(dataDir)
+	assert.Error(t, err)
+}
+
+// waitForEvent waits for the event of the peer, skipping the other events
+func waitForEvent(t *testing.T, sub *Subscription, peerID peer.ID, eventType peerEvent.PeerEventType) {
+	t.Helper()
+
+	timeoutCh := time.After(DefaultJoinTimeout)
+
+	for {
+		select {
+		case evnt := <-sub.GetCh():
+			if evnt.PeerID == peerID && evnt.Type == eventType {
+				return
+			}
+		case <-timeoutCh:
+			t.Fatalf("Event %d of peer %s not received", eventType, peerID)
+		}
+	}
+}
+
+// shortenStaticPeerBackoff speeds up the re-dials of the static peers of the server
+func shortenStaticPeerBackoff(server *Server) {
+	server.staticPeers.minBackoff = 100 * time.Millisecond
+	server.staticPeers.maxBackoff = 500 * time.Millisecond
+	server.staticPeers.checkInterval = 100 * time.Millisecond
+}
+
+func TestStaticAndTrustedPeers(t *testing.T) {
+	limitConfig := func(c *Config) {
+		c.MaxInboundPeers = 1
+		c.MaxOutboundPeers = 1
+		c.NoDiscover = true
+	}
+
+	trusted, createErr := CreateServer(&CreateServerParams{ConfigCallback: limitConfig})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	limited, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			limitConfig(c)
+			c.TrustedPeers = []string{common.AddrInfoToString(trusted.AddrInfo())}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	static, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: limitConfig,
+		ServerCallback: shortenStaticPeerBackoff,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{trusted, limited, static})
+	})
+
+	limitedID := limited.AddrInfo().ID
+
+	assert.ErrorIs(t, static.AddStaticPeer(common.AddrInfoToString(static.AddrInfo())), ErrStaticPeerSelf)
+
+	// The static peer takes the only inbound slot of the limited server
+	assert.NoError(t, static.AddStaticPeer(common.AddrInfoToString(limited.AddrInfo())))
+
+	connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer connectFn()
+
+	if _, err := WaitUntilPeerConnectsTo(connectCtx, static, limitedID); err != nil {
+		t.Fatalf("Unable to connect to static peer, %v", err)
+	}
+
+	// The static peer is re-dialed after the remote side closes the connection
+	sub, subErr := static.Subscribe()
+	if subErr != nil {
+		t.Fatalf("Unable to subscribe to peer events, %v", subErr)
+	}
+
+	defer sub.Close()
+
+	limited.DisconnectFromPeer(static.AddrInfo().ID, "bye")
+
+	waitForEvent(t, sub, limitedID, peerEvent.PeerDisconnected)
+	waitForEvent(t, sub, limitedID, peerEvent.PeerConnected)
+
+	// The trusted peer connects even though the inbound slots are full
+	if joinErr := JoinAndWait(trusted, limited, DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join trusted peer, %v", joinErr)
+	}
+}
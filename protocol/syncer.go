@@ -382,6 +382,7 @@ func (s *Syncer) WatchSyncWithPeer(p *SyncPeer, newBlockHandler func(b *types.Bl
 
 		if err := s.blockchain.VerifyFinalizedBlock(b); err != nil {
 			s.logger.Error("unable to verify block, %w", err)
+			s.server.ReportPeer(p.peer, network.InvalidBlock)
 
 			return
 		}
@@ -392,6 +393,8 @@ func (s *Syncer) WatchSyncWithPeer(p *SyncPeer, newBlockHandler func(b *types.Bl
 			break
 		}
 
+		s.server.ReportPeer(p.peer, network.ValidBlock)
+
 		shouldExit := newBlockHandler(b)
 
 		s.prunePeerEnqueuedBlocks(b)
@@ -473,6 +476,8 @@ func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types
 			// Verify and write the data locally
 			for _, block := range sk.blocks {
 				if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
+					s.server.ReportPeer(p.peer, network.InvalidBlock)
+
 					return fmt.Errorf("unable to verify block, %w", err)
 				}
 
@@ -480,6 +485,8 @@ func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types
 					return fmt.Errorf("failed to write block while bulk syncing: %w", err)
 				}
 
+				s.server.ReportPeer(p.peer, network.ValidBlock)
+
 				newBlockHandler(block)
 				s.prunePeerEnqueuedBlocks(block)
 				currentSyncHeight++
@@ -5,7 +5,9 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -25,11 +27,15 @@ type Config struct {
 	MaxSlots   uint64
 	BlockTime  uint64
 
+	// AllowUnprotectedTxs accepts the transactions without a chain ID over json-RPC/gRPC
+	AllowUnprotectedTxs bool
+
 	Telemetry *Telemetry
 	Network   *network.Config
 
-	DataDir     string
-	RestoreFile *string
+	DataDir      string
+	DBBackend    storage.Backend // resolved from the data directory if not set
+	RestoreFiles []string        // incremental archives are listed after the archive they continue
 
 	Seal bool
 
@@ -38,6 +44,11 @@ type Config struct {
 	LogLevel hclog.Level
 
 	LogFilePath string
+
+	StoreRevertReason bool
+
+	// RecordPreimages records the addresses and storage slots hashed to the state trie keys
+	RecordPreimages bool
 }
 
 // Telemetry holds the config details for metric services
@@ -49,4 +60,7 @@ type Telemetry struct {
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
 	AccessControlAllowOrigin []string
+	EnableDebug              bool
+	Access                   *jsonrpc.AccessConfig
+	GraphQL                  *jsonrpc.GraphQLConfig
 }
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+// OfflineChain is the blockchain of a data directory, opened without the networking,
+// the txpool and the RPC servers. It's used by the commands working on the chain data
+// of a stopped node, and verifies and executes blocks the same way as the node does
+type OfflineChain struct {
+	server *Server
+}
+
+// NewOfflineChain opens the blockchain and the consensus state in the data directory
+func NewOfflineChain(config *Config) (*OfflineChain, error) {
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not setup new logger instance, %w", err)
+	}
+
+	s := &Server{
+		logger:             logger,
+		config:             config,
+		chain:              config.Chain,
+		serverMetrics:      metricProvider("polygon", config.Chain.Name, false),
+		restoreProgression: progress.NewProgressionWrapper(progress.ChainSyncRestore),
+	}
+
+	if err := common.SetupDataDir(config.DataDir, dirPaths); err != nil {
+		return nil, fmt.Errorf("failed to create data directories: %w", err)
+	}
+
+	if err := s.setupBlockchain(); err != nil {
+		if s.stateStorage != nil {
+			s.stateStorage.Close()
+		}
+
+		return nil, err
+	}
+
+	c := &OfflineChain{server: s}
+
+	// the consensus is needed for the header verification and hashing,
+	// but the consensus mechanism is not started
+	if err := s.setupConsensus(); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	s.blockchain.SetConsensus(s.consensus)
+
+	if err := s.blockchain.ComputeGenesis(); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	if err := s.consensus.Initialize(); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Blockchain returns the blockchain of the data directory
+func (c *OfflineChain) Blockchain() *blockchain.Blockchain {
+	return c.server.blockchain
+}
+
+// Chain returns the chain configuration of the genesis file
+func (c *OfflineChain) Chain() *chain.Chain {
+	return c.server.chain
+}
+
+// StateStorage returns the storage of the state trie
+func (c *OfflineChain) StateStorage() itrie.Storage {
+	return c.server.stateStorage
+}
+
+// Close closes the blockchain, the consensus and the state storage, persisting their data
+func (c *OfflineChain) Close() {
+	s := c.server
+
+	if err := s.blockchain.Close(); err != nil {
+		s.logger.Error("failed to close blockchain", "err", err.Error())
+	}
+
+	if s.consensus != nil {
+		if err := s.consensus.Close(); err != nil {
+			s.logger.Error("failed to close consensus", "err", err.Error())
+		}
+	}
+
+	if err := s.stateStorage.Close(); err != nil {
+		s.logger.Error("failed to close storage for trie", "err", err.Error())
+	}
+}
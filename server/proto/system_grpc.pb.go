@@ -25,6 +25,8 @@ type SystemClient interface {
 	PeersAdd(ctx context.Context, in *PeersAddRequest, opts ...grpc.CallOption) (*PeersAddResponse, error)
 	// PeersList returns the list of peers
 	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
+	// PeersAllowlistReload reloads the peer allow list, and returns the permitted peers
+	PeersAllowlistReload(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersAllowlistResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
 	// Subscribe subscribes to blockchain events
@@ -70,6 +72,15 @@ func (c *systemClient) PeersList(ctx context.Context, in *emptypb.Empty, opts ..
 	return out, nil
 }
 
+func (c *systemClient) PeersAllowlistReload(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersAllowlistResponse, error) {
+	out := new(PeersAllowlistResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersAllowlistReload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error) {
 	out := new(Peer)
 	err := c.cc.Invoke(ctx, "/v1.System/PeersStatus", in, out, opts...)
@@ -162,6 +173,8 @@ type SystemServer interface {
 	PeersAdd(context.Context, *PeersAddRequest) (*PeersAddResponse, error)
 	// PeersList returns the list of peers
 	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
+	// PeersAllowlistReload reloads the peer allow list, and returns the permitted peers
+	PeersAllowlistReload(context.Context, *emptypb.Empty) (*PeersAllowlistResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
 	// Subscribe subscribes to blockchain events
@@ -186,6 +199,9 @@ func (UnimplementedSystemServer) PeersAdd(context.Context, *PeersAddRequest) (*P
 func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersList not implemented")
 }
+func (UnimplementedSystemServer) PeersAllowlistReload(context.Context, *emptypb.Empty) (*PeersAllowlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersAllowlistReload not implemented")
+}
 func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
@@ -265,6 +281,24 @@ func _System_PeersList_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersAllowlistReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersAllowlistReload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersAllowlistReload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersAllowlistReload(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PeersStatusRequest)
 	if err := dec(in); err != nil {
@@ -362,6 +396,10 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersList",
 			Handler:    _System_PeersList_Handler,
 		},
+		{
+			MethodName: "PeersAllowlistReload",
+			Handler:    _System_PeersAllowlistReload_Handler,
+		},
 		{
 			MethodName: "PeersStatus",
 			Handler:    _System_PeersStatus_Handler,
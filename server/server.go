@@ -12,9 +12,10 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
-	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/contracts/peerallowlist"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -30,6 +31,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -60,6 +62,9 @@ type Server struct {
 	// libp2p network
 	network *network.Server
 
+	// subscription reloading the on-chain peer allow list on new heads
+	allowlistSub blockchain.Subscription
+
 	// transaction pool
 	txpool *txpool.TxPool
 
@@ -168,32 +173,14 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
-	if err != nil {
+	if err := m.setupBlockchain(); err != nil {
 		return nil, err
 	}
 
-	m.stateStorage = stateStorage
-
-	st := itrie.NewState(stateStorage)
-	m.state = st
-
-	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
-	m.executor.SetRuntime(precompiled.NewPrecompiled())
-	m.executor.SetRuntime(evm.NewEVM())
-
-	// compute the genesis root state
-	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
-	config.Chain.Genesis.StateRoot = genesisRoot
-
-	// blockchain object
-	m.blockchain, err = blockchain.NewBlockchain(logger, m.config.DataDir, config.Chain, nil, m.executor)
-	if err != nil {
-		return nil, err
+	if config.Network.PeerAllowlistContract {
+		m.network.SetPeerAllowlistSource(m.queryAllowedPeers)
 	}
 
-	m.executor.GetHash = m.blockchain.GetHashHelper
-
 	{
 		hub := &txpoolHub{
 			state:      m.state,
@@ -202,24 +189,24 @@ func NewServer(config *Config) (*Server, error) {
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
-			m.chain.Params.Forks.At(0),
+			m.chain.Params.Forks,
 			hub,
 			m.grpcServer,
 			m.network,
 			m.serverMetrics.txpool,
 			&txpool.Config{
-				Sealing:    m.config.Seal,
-				MaxSlots:   m.config.MaxSlots,
-				PriceLimit: m.config.PriceLimit,
+				Sealing:     m.config.Seal,
+				MaxSlots:    m.config.MaxSlots,
+				PriceLimit:  m.config.PriceLimit,
+				Precompiles: m.chain.Params.Precompiles,
+				ChainID:     uint64(m.chain.Params.ChainID),
+
+				AllowUnprotectedTxs: m.config.AllowUnprotectedTxs,
 			},
 		)
 		if err != nil {
 			return nil, err
 		}
-
-		// use the eip155 signer
-		signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
-		m.txpool.SetSigner(signer)
 	}
 
 	{
@@ -266,17 +253,98 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if config.Network.PeerAllowlistContract {
+		m.allowlistSub = m.blockchain.SubscribeEvents()
+		go m.watchPeerAllowlist(m.allowlistSub)
+	}
+
 	m.txpool.Start()
 
 	return m, nil
 }
 
+// setupBlockchain sets up the state storage, the executor and the blockchain object
+func (s *Server) setupBlockchain() error {
+	backend, err := s.resolveDBBackend()
+	if err != nil {
+		return err
+	}
+
+	stateStorage, err := openStateStorage(filepath.Join(s.config.DataDir, "trie"), backend, s.logger)
+	if err != nil {
+		return err
+	}
+
+	s.stateStorage = stateStorage
+
+	st := itrie.NewState(stateStorage)
+	if s.config.RecordPreimages {
+		st.RecordPreimages()
+	}
+
+	s.state = st
+
+	s.executor = state.NewExecutor(s.config.Chain.Params, st, s.logger)
+	s.executor.StoreRevertReason = s.config.StoreRevertReason
+
+	precompiledRuntime := precompiled.NewPrecompiled()
+	if err := precompiledRuntime.SetupStateful(s.config.Chain.Params.Precompiles); err != nil {
+		return err
+	}
+
+	s.executor.SetRuntime(precompiledRuntime)
+	s.executor.SetRuntime(evm.NewEVM())
+
+	// compute the genesis root state
+	genesisRoot := s.executor.WriteGenesis(s.config.Chain.Genesis.Alloc)
+	s.config.Chain.Genesis.StateRoot = genesisRoot
+
+	// blockchain object
+	s.blockchain, err = blockchain.NewBlockchain(s.logger, s.config.DataDir, backend, s.config.Chain, nil, s.executor)
+	if err != nil {
+		return err
+	}
+
+	s.executor.GetHash = s.blockchain.GetHashHelper
+
+	return nil
+}
+
+// resolveDBBackend returns the backend of the databases in the data directory,
+// making sure the blockchain and the trie databases use the same one
+func (s *Server) resolveDBBackend() (storage.Backend, error) {
+	backend, err := storage.ResolveBackend(filepath.Join(s.config.DataDir, "blockchain"), s.config.DBBackend)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := storage.ResolveBackend(filepath.Join(s.config.DataDir, "trie"), backend); err != nil {
+		return "", err
+	}
+
+	s.logger.Info("database backend", "backend", backend)
+
+	return backend, nil
+}
+
+// openStateStorage opens the trie database at the path with the backend
+func openStateStorage(path string, backend storage.Backend, logger hclog.Logger) (itrie.Storage, error) {
+	switch backend {
+	case storage.LevelDB:
+		return itrie.NewLevelDBStorage(path, logger)
+	case storage.Pebble:
+		return itrie.NewPebbleStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", storage.ErrUnknownBackend, backend)
+	}
+}
+
 func (s *Server) restoreChain() error {
-	if s.config.RestoreFile == nil {
+	if len(s.config.RestoreFiles) == 0 {
 		return nil
 	}
 
-	if err := archive.RestoreChain(s.blockchain, *s.config.RestoreFile, s.restoreProgression); err != nil {
+	if err := archive.RestoreChain(s.blockchain, s.config.RestoreFiles, s.restoreProgression); err != nil {
 		return err
 	}
 
@@ -327,6 +395,15 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+func (t *txpoolHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	snap, err := t.state.NewSnapshotAt(root)
+	if err != nil {
+		return types.ZeroHash
+	}
+
+	return state.NewTxn(t.state, snap).GetState(addr, slot)
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -466,9 +543,9 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*state.Acc
 	return &account, nil
 }
 
-// GetForksInTime returns the active forks at the given block height
-func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
-	return j.Executor.GetForksInTime(blockNumber)
+// GetForksInTime returns the active forks at the given block height and timestamp
+func (j *jsonRPCHub) GetForksInTime(blockNumber, timestamp uint64) chain.ForksInTime {
+	return j.Executor.GetForksInTime(blockNumber, timestamp)
 }
 
 func (j *jsonRPCHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
@@ -498,20 +575,34 @@ func (j *jsonRPCHub) GetCode(hash types.Hash) ([]byte, error) {
 }
 
 func (j *jsonRPCHub) ApplyTxn(
+	ctx context.Context,
 	header *types.Header,
 	txn *types.Transaction,
+	stateOverride types.StateOverride,
+	blockOverride *types.BlockOverride,
 ) (result *runtime.ExecutionResult, err error) {
+	// the block creator is recovered from the seal of the original header
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	if blockOverride != nil && blockOverride.Coinbase != nil {
+		blockCreator = *blockOverride.Coinbase
+	}
+
+	transition, err := j.BeginTxn(header.StateRoot, blockOverride.Apply(header), blockCreator)
 
 	if err != nil {
 		return
 	}
 
+	if err = transition.WithStateOverride(stateOverride); err != nil {
+		return
+	}
+
+	transition.WithContext(ctx)
+
 	result, err = transition.Apply(txn)
 
 	return
@@ -531,6 +622,28 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// GetValidatorStats returns the statistics of the validators kept by the consensus
+func (j *jsonRPCHub) GetValidatorStats(
+	fromEpoch, toEpoch uint64,
+) (map[types.Address]*jsonrpc.ValidatorStats, bool, error) {
+	stats, partial, err := j.Consensus.GetValidatorStats(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res := make(map[types.Address]*jsonrpc.ValidatorStats, len(stats))
+
+	for addr, s := range stats {
+		res[addr] = &jsonrpc.ValidatorStats{
+			ProposedBlocks: s.ProposedBlocks,
+			CommittedSeals: s.CommittedSeals,
+			MissedRounds:   s.MissedRounds,
+		}
+	}
+
+	return res, partial, nil
+}
+
 // SETUP //
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
@@ -550,6 +663,9 @@ func (s *Server) setupJSONRPC() error {
 		Addr:                     s.config.JSONRPC.JSONRPCAddr,
 		ChainID:                  uint64(s.config.Chain.Params.ChainID),
 		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
+		EnableDebug:              s.config.JSONRPC.EnableDebug,
+		Access:                   s.config.JSONRPC.Access,
+		GraphQL:                  s.config.JSONRPC.GraphQL,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -587,6 +703,55 @@ func (s *Server) Chain() *chain.Chain {
 	return s.chain
 }
 
+// queryAllowedPeers returns the peer IDs permitted by the on-chain peer allow list at the latest block
+func (s *Server) queryAllowedPeers() ([]peer.ID, error) {
+	header := s.blockchain.Header()
+
+	transition, err := s.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDs, err := peerallowlist.QueryAllowedPeers(transition, types.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	peerIDs := make([]peer.ID, 0, len(rawIDs))
+
+	for _, rawID := range rawIDs {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			s.logger.Warn("Omitting invalid peer ID from the on-chain peer allow list", "id", rawID, "err", err)
+
+			continue
+		}
+
+		peerIDs = append(peerIDs, id)
+	}
+
+	return peerIDs, nil
+}
+
+// watchPeerAllowlist reloads the peer allow list on every new head,
+// so the changes of the on-chain allow list are applied as the node syncs
+func (s *Server) watchPeerAllowlist(sub blockchain.Subscription) {
+	for {
+		evnt := sub.GetEvent()
+		if evnt == nil {
+			return
+		}
+
+		if evnt.Type == blockchain.EventFork {
+			continue
+		}
+
+		if _, err := s.network.ReloadPeerAllowlist(); err != nil {
+			s.logger.Error("Unable to reload the peer allow list", "err", err)
+		}
+	}
+}
+
 // JoinPeer attempts to add a new peer to the networking server
 func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
@@ -594,6 +759,10 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	if s.allowlistSub != nil {
+		s.allowlistSub.Close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
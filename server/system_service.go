@@ -84,6 +84,18 @@ func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_Subscrib
 
 // PeersAdd implements the 'peers add' operator service
 func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest) (*proto.PeersAddResponse, error) {
+	if req.Static {
+		if addErr := s.server.network.AddStaticPeer(req.Id); addErr != nil {
+			return &proto.PeersAddResponse{
+				Message: "Unable to successfully add static peer",
+			}, addErr
+		}
+
+		return &proto.PeersAddResponse{
+			Message: "Static peer added, it is kept connected",
+		}, nil
+	}
+
 	if joinErr := s.server.JoinPeer(req.Id); joinErr != nil {
 		return &proto.PeersAddResponse{
 			Message: "Unable to successfully add peer",
@@ -128,18 +140,67 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		Id:        id.String(),
 		Protocols: protocols,
 		Addrs:     addrs,
+		Score:     s.server.network.GetPeerScore(id),
+	}
+
+	if ban := s.server.network.GetPeerBan(id); ban != nil {
+		peer.Ban = &proto.PeerBan{
+			Reason: ban.Reason,
+			Expiry: ban.Expiry.Unix(),
+		}
+	}
+
+	traffic := s.server.network.GetPeerTraffic(id)
+	peer.Traffic = &proto.PeerTraffic{
+		BytesIn:   traffic.TotalIn,
+		BytesOut:  traffic.TotalOut,
+		RateIn:    traffic.RateIn,
+		RateOut:   traffic.RateOut,
+		Protocols: make([]*proto.ProtocolTraffic, len(traffic.Protocols)),
+	}
+
+	for i, protocolTraffic := range traffic.Protocols {
+		peer.Traffic.Protocols[i] = &proto.ProtocolTraffic{
+			Protocol:   protocolTraffic.Protocol,
+			BytesIn:    protocolTraffic.BytesIn,
+			BytesOut:   protocolTraffic.BytesOut,
+			StreamsIn:  protocolTraffic.StreamsIn,
+			StreamsOut: protocolTraffic.StreamsOut,
+		}
 	}
 
 	return peer, nil
 }
 
+// PeersAllowlistReload implements the 'peers allowlist reload' operator service
+func (s *systemService) PeersAllowlistReload(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.PeersAllowlistResponse, error) {
+	peerIDs, err := s.server.network.ReloadPeerAllowlist()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.PeersAllowlistResponse{
+		Peers: make([]string, len(peerIDs)),
+	}
+
+	for i, id := range peerIDs {
+		resp.Peers[i] = id.String()
+	}
+
+	return resp, nil
+}
+
 // PeersList implements the 'peers list' operator service
 func (s *systemService) PeersList(
 	ctx context.Context,
 	req *empty.Empty,
 ) (*proto.PeersListResponse, error) {
 	resp := &proto.PeersListResponse{
-		Peers: []*proto.Peer{},
+		Peers:  []*proto.Peer{},
+		Banned: []*proto.Peer{},
 	}
 
 	peers := s.server.network.Peers()
@@ -152,6 +213,15 @@ func (s *systemService) PeersList(
 		resp.Peers = append(resp.Peers, peer)
 	}
 
+	for _, ban := range s.server.network.BannedPeers() {
+		peer, err := s.getPeer(ban.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Banned = append(resp.Banned, peer)
+	}
+
 	return resp, nil
 }
 
@@ -181,8 +251,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must be greater than or equal to from")
 		}
 
 		to = &req.To
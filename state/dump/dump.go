@@ -0,0 +1,275 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const accountsField = "accounts"
+
+var (
+	errInvalidDump    = errors.New("invalid state dump")
+	errUnresolvedKeys = errors.New("state dump has accounts or storage slots without a known preimage")
+)
+
+// Header describes the state of a dump
+type Header struct {
+	// Block is the number of the block the state is dumped at
+	Block uint64 `json:"block"`
+	// Hash is the hash of the block the state is dumped at
+	Hash types.Hash `json:"hash"`
+	// Root is the state root of the block
+	Root types.Hash `json:"root"`
+	// Params are the chain parameters, including the fork and consensus configuration
+	Params *chain.Params `json:"params"`
+}
+
+// Account is an account of the dump
+type Account struct {
+	// Address is the account address, it's not set if the address preimage is unknown
+	Address *types.Address `json:"address,omitempty"`
+	// Key is the hashed trie key of an account without a known address
+	Key *types.Hash `json:"key,omitempty"`
+
+	Balance string                    `json:"balance"`
+	Nonce   uint64                    `json:"nonce"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+
+	// HashedStorage are the storage slots without a known preimage, by hashed trie key
+	HashedStorage map[types.Hash]types.Hash `json:"hashedStorage,omitempty"`
+}
+
+// IsResolved checks if the address and all the storage slots of the account are known
+func (a *Account) IsResolved() bool {
+	return a.Address != nil && len(a.HashedStorage) == 0
+}
+
+// ToGenesisAccount converts the resolved account to its genesis allocation
+func (a *Account) ToGenesisAccount() (*chain.GenesisAccount, error) {
+	if !a.IsResolved() {
+		return nil, errUnresolvedKeys
+	}
+
+	balance, ok := new(big.Int).SetString(a.Balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %s of account %s", a.Balance, a.Address)
+	}
+
+	account := &chain.GenesisAccount{
+		Balance: balance,
+		Nonce:   a.Nonce,
+	}
+
+	if a.Code != "" {
+		code, err := hex.DecodeHex(a.Code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid code of account %s: %w", a.Address, err)
+		}
+
+		account.Code = code
+	}
+
+	if len(a.Storage) != 0 {
+		account.Storage = a.Storage
+	}
+
+	return account, nil
+}
+
+// Writer writes a state dump as a single JSON document, one account at a time,
+// so the whole state is never held in memory
+type Writer struct {
+	w        *bufio.Writer
+	accounts uint64
+}
+
+// NewWriter writes the header of the dump, followed by the opening of the account list
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	raw, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{
+		w: bufio.NewWriter(w),
+	}
+
+	// the account list is the last field of the header object
+	raw = bytes.TrimSuffix(raw, []byte("}"))
+	if _, err := writer.w.Write(raw); err != nil {
+		return nil, err
+	}
+
+	if _, err := fmt.Fprintf(writer.w, ",%q:[", accountsField); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// WriteAccount appends the account to the dump
+func (w *Writer) WriteAccount(account *Account) error {
+	raw, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	if w.accounts > 0 {
+		if err := w.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+
+	if err := w.w.WriteByte('\n'); err != nil {
+		return err
+	}
+
+	if _, err := w.w.Write(raw); err != nil {
+		return err
+	}
+
+	w.accounts++
+
+	return nil
+}
+
+// Close terminates the dump document and flushes it
+func (w *Writer) Close() error {
+	if _, err := w.w.WriteString("\n]}\n"); err != nil {
+		return err
+	}
+
+	return w.w.Flush()
+}
+
+// Read reads the dump, passing every account to fn as it's decoded,
+// and returns the header of the dump
+func Read(r io.Reader, fn func(*Account) error) (*Header, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected token %v", errInvalidDump, token)
+		}
+
+		if field != accountsField {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+
+			fields[field] = value
+
+			continue
+		}
+
+		if err := readAccounts(dec, fn); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &Header{}
+	if err := json.Unmarshal(raw, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidDump, err)
+	}
+
+	return header, nil
+}
+
+func readAccounts(dec *json.Decoder, fn func(*Account) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		account := &Account{}
+		if err := dec.Decode(account); err != nil {
+			return err
+		}
+
+		if err := fn(account); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("%w: expected %s, got %v", errInvalidDump, delim, token)
+	}
+
+	return nil
+}
+
+// ReadGenesisAlloc reads the dump into a genesis allocation.
+// It fails if any account or storage slot of the dump has no known preimage
+func ReadGenesisAlloc(r io.Reader) (*Header, map[types.Address]*chain.GenesisAccount, error) {
+	var (
+		alloc      = make(map[types.Address]*chain.GenesisAccount)
+		unresolved uint64
+	)
+
+	header, err := Read(r, func(account *Account) error {
+		if !account.IsResolved() {
+			unresolved++
+
+			return nil
+		}
+
+		genesisAccount, err := account.ToGenesisAccount()
+		if err != nil {
+			return err
+		}
+
+		alloc[*account.Address] = genesisAccount
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if unresolved > 0 {
+		return nil, nil, fmt.Errorf("%w: %d accounts", errUnresolvedKeys, unresolved)
+	}
+
+	return header, alloc, nil
+}
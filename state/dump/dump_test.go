@@ -0,0 +1,186 @@
+package dump
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeGenesis(
+	storage itrie.Storage,
+	alloc map[types.Address]*chain.GenesisAccount,
+	recordPreimages bool,
+) types.Hash {
+	st := itrie.NewState(storage)
+	if recordPreimages {
+		st.RecordPreimages()
+	}
+
+	executor := state.NewExecutor(
+		&chain.Params{Forks: chain.AllForksEnabled},
+		st,
+		hclog.NewNullLogger(),
+	)
+
+	return executor.WriteGenesis(alloc)
+}
+
+func TestDumpState_GenesisRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var (
+		eoa      = types.StringToAddress("1")
+		contract = types.StringToAddress("2")
+	)
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		eoa: {
+			Balance: big.NewInt(1000),
+			Nonce:   3,
+		},
+		contract: {
+			Balance: big.NewInt(0),
+			Nonce:   1,
+			Code:    []byte{0x60, 0x00, 0x60, 0x00, 0xf3},
+			Storage: map[types.Hash]types.Hash{
+				types.StringToHash("0"): types.StringToHash("1"),
+				types.StringToHash("5"): types.StringToHash("0xff00"),
+			},
+		},
+	}
+
+	storage := itrie.NewMemoryStorage()
+	root := writeGenesis(storage, alloc, true)
+
+	header := &Header{
+		Block:  10,
+		Root:   root,
+		Params: &chain.Params{ChainID: 100},
+	}
+
+	var buf bytes.Buffer
+
+	stats, err := DumpState(storage, header, nil, false, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, &Stats{Accounts: 2, StorageSlots: 2}, stats)
+
+	readHeader, readAlloc, err := ReadGenesisAlloc(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, header, readHeader)
+	assert.Equal(t, alloc, readAlloc)
+
+	// the dumped state allocated in a new genesis has the same root
+	assert.Equal(t, root, writeGenesis(itrie.NewMemoryStorage(), readAlloc, false))
+}
+
+func TestDumpState_NotRecordedPreimages(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesisAccount = types.StringToAddress("1")
+		createdAccount = types.StringToAddress("2")
+	)
+
+	genesisAlloc := map[types.Address]*chain.GenesisAccount{
+		genesisAccount: {
+			Balance: big.NewInt(1000),
+			Storage: map[types.Hash]types.Hash{
+				types.StringToHash("0"): types.StringToHash("1"),
+			},
+		},
+	}
+
+	// the state of a later block, with an account created after the genesis
+	storage := itrie.NewMemoryStorage()
+	root := writeGenesis(storage, map[types.Address]*chain.GenesisAccount{
+		genesisAccount: genesisAlloc[genesisAccount],
+		createdAccount: {
+			Balance: big.NewInt(1),
+		},
+	}, false)
+
+	header := &Header{Root: root}
+
+	// the dump fails on the accounts without a preimage
+	_, err := DumpState(storage, header, NewGenesisPreimages(genesisAlloc), false, &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrMissingPreimage)
+
+	// the storage slots without a preimage fail the dump too
+	_, err = DumpState(storage, header, Preimages{
+		types.BytesToHash(crypto.Keccak256(genesisAccount.Bytes())): genesisAccount.Bytes(),
+		types.BytesToHash(crypto.Keccak256(createdAccount.Bytes())): createdAccount.Bytes(),
+	}, false, &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrMissingPreimage)
+
+	// nothing is resolved without preimages, if allowed
+	stats, err := DumpState(storage, header, nil, true, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Equal(t, &Stats{Accounts: 2, StorageSlots: 1, UnresolvedAccounts: 2, UnresolvedSlots: 1}, stats)
+
+	// the genesis allocation is resolved, the accounts created later are not
+	var buf bytes.Buffer
+
+	stats, err = DumpState(storage, header, NewGenesisPreimages(genesisAlloc), true, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, &Stats{Accounts: 2, StorageSlots: 1, UnresolvedAccounts: 1}, stats)
+
+	_, _, err = ReadGenesisAlloc(&buf)
+	assert.ErrorIs(t, err, errUnresolvedKeys)
+}
+
+func TestReadGenesisAlloc_Unresolved(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("1")
+	key := types.StringToHash("1")
+
+	testTable := []struct {
+		name    string
+		account *Account
+	}{
+		{
+			"account without address",
+			&Account{
+				Key:     &key,
+				Balance: "1",
+			},
+		},
+		{
+			"storage without slots",
+			&Account{
+				Address: &address,
+				Balance: "1",
+				HashedStorage: map[types.Hash]types.Hash{
+					key: key,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			writer, err := NewWriter(&buf, &Header{})
+			assert.NoError(t, err)
+
+			assert.NoError(t, writer.WriteAccount(testCase.account))
+			assert.NoError(t, writer.Close())
+
+			_, _, err = ReadGenesisAlloc(&buf)
+			assert.ErrorIs(t, err, errUnresolvedKeys)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package dump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var emptyCodeHash = crypto.Keccak256(nil)
+
+var (
+	ErrMissingPreimage = errors.New("no preimage recorded")
+)
+
+// Stats are the counts of a state dump
+type Stats struct {
+	Accounts           uint64
+	StorageSlots       uint64
+	UnresolvedAccounts uint64
+	UnresolvedSlots    uint64
+}
+
+// Preimages are the addresses and storage slots by hashed trie key known besides the
+// preimages recorded by the trie, for the keys committed while the recording was disabled
+type Preimages map[types.Hash][]byte
+
+// NewGenesisPreimages returns the preimages of the addresses and storage slots of the
+// genesis allocation. The accounts and slots created by the blocks are only resolved
+// if the node records the preimages (see the server --record-preimages flag)
+func NewGenesisPreimages(alloc map[types.Address]*chain.GenesisAccount) Preimages {
+	preimages := Preimages{}
+
+	for address, account := range alloc {
+		preimages[types.BytesToHash(crypto.Keccak256(address.Bytes()))] = address.Bytes()
+
+		for slot := range account.Storage {
+			preimages[types.BytesToHash(crypto.Keccak256(slot.Bytes()))] = slot.Bytes()
+		}
+	}
+
+	return preimages
+}
+
+// get returns the preimage of the trie key, recorded by the trie or known
+func (p Preimages) get(storage itrie.Storage, key []byte) ([]byte, bool) {
+	if preimage, ok := itrie.GetPreimage(storage, key); ok {
+		return preimage, true
+	}
+
+	preimage, ok := p[types.BytesToHash(key)]
+
+	return preimage, ok
+}
+
+// DumpState walks the state trie at the root of the header, and writes every account
+// with its code and storage to w. The addresses and storage slots are resolved from the
+// preimages recorded by the trie and the known preimages. A key without a preimage fails
+// the dump with ErrMissingPreimage, unless allowUnresolved is set and it is dumped by hashed key
+func DumpState(
+	storage itrie.Storage,
+	header *Header,
+	preimages Preimages,
+	allowUnresolved bool,
+	w io.Writer,
+) (*Stats, error) {
+	writer, err := NewWriter(w, header)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{}
+
+	if err := itrie.WalkTrie(storage, header.Root, func(key, value []byte) error {
+		account, err := dumpAccount(storage, preimages, allowUnresolved, key, value, stats)
+		if err != nil {
+			return err
+		}
+
+		return writer.WriteAccount(account)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func dumpAccount(
+	storage itrie.Storage,
+	preimages Preimages,
+	allowUnresolved bool,
+	key, value []byte,
+	stats *Stats,
+) (*Account, error) {
+	var trieAccount state.Account
+	if err := trieAccount.UnmarshalRlp(value); err != nil {
+		return nil, fmt.Errorf("unable to decode account %s: %w", hex.EncodeToHex(key), err)
+	}
+
+	account := &Account{
+		Balance: trieAccount.Balance.String(),
+		Nonce:   trieAccount.Nonce,
+	}
+
+	stats.Accounts++
+
+	if preimage, ok := preimages.get(storage, key); ok {
+		address := types.BytesToAddress(preimage)
+		account.Address = &address
+	} else if allowUnresolved {
+		hash := types.BytesToHash(key)
+		account.Key = &hash
+		stats.UnresolvedAccounts++
+	} else {
+		return nil, fmt.Errorf("account %s: %w", hex.EncodeToHex(key), ErrMissingPreimage)
+	}
+
+	if len(trieAccount.CodeHash) != 0 && !bytes.Equal(trieAccount.CodeHash, emptyCodeHash) {
+		code, ok := storage.GetCode(types.BytesToHash(trieAccount.CodeHash))
+		if !ok {
+			return nil, fmt.Errorf("code %s not found", hex.EncodeToHex(trieAccount.CodeHash))
+		}
+
+		account.Code = hex.EncodeToHex(code)
+	}
+
+	if err := dumpStorage(storage, preimages, allowUnresolved, trieAccount.Root, account, stats); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func dumpStorage(
+	storage itrie.Storage,
+	preimages Preimages,
+	allowUnresolved bool,
+	root types.Hash,
+	account *Account,
+	stats *Stats,
+) error {
+	parser := &fastrlp.Parser{}
+
+	return itrie.WalkTrie(storage, root, func(key, value []byte) error {
+		v, err := parser.Parse(value)
+		if err != nil {
+			return fmt.Errorf("unable to decode storage slot %s: %w", hex.EncodeToHex(key), err)
+		}
+
+		raw, err := v.Bytes()
+		if err != nil {
+			return fmt.Errorf("unable to decode storage slot %s: %w", hex.EncodeToHex(key), err)
+		}
+
+		slotValue := types.BytesToHash(raw)
+
+		stats.StorageSlots++
+
+		if preimage, ok := preimages.get(storage, key); ok {
+			if account.Storage == nil {
+				account.Storage = make(map[types.Hash]types.Hash)
+			}
+
+			account.Storage[types.BytesToHash(preimage)] = slotValue
+
+			return nil
+		}
+
+		if !allowUnresolved {
+			return fmt.Errorf("storage slot %s of account %s: %w",
+				hex.EncodeToHex(key), account.Address, ErrMissingPreimage)
+		}
+
+		if account.HashedStorage == nil {
+			account.HashedStorage = make(map[types.Hash]types.Hash)
+		}
+
+		account.HashedStorage[types.BytesToHash(key)] = slotValue
+		stats.UnresolvedSlots++
+
+		return nil
+	})
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -11,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -36,6 +38,9 @@ type Executor struct {
 	state    State
 	GetHash  GetHashByNumberHelper
 
+	// StoreRevertReason keeps the return data of the reverted transactions in their receipts
+	StoreRevertReason bool
+
 	PostHook func(txn *Transition)
 }
 
@@ -71,6 +76,9 @@ func (e *Executor) WriteGenesis(alloc map[types.Address]*chain.GenesisAccount) t
 		}
 	}
 
+	// contracts activated at genesis initialize their state here
+	e.activateRuntimes(0, txn)
+
 	_, root := txn.Commit(false)
 
 	return types.BytesToHash(root)
@@ -81,6 +89,16 @@ func (e *Executor) SetRuntime(r runtime.Runtime) {
 	e.runtimes = append(e.runtimes, r)
 }
 
+// activateRuntimes lets the runtimes initialize the state
+// of the contracts that get activated at the given block
+func (e *Executor) activateRuntimes(block uint64, txn *Txn) {
+	for _, r := range e.runtimes {
+		if activator, ok := r.(runtime.Activator); ok {
+			activator.Activate(block, txn)
+		}
+	}
+}
+
 type BlockResult struct {
 	Root     types.Hash
 	Receipts []*types.Receipt
@@ -93,7 +111,7 @@ func (e *Executor) ProcessBlock(
 	block *types.Block,
 	blockCreator types.Address,
 ) (*Transition, error) {
-	txn, err := e.BeginTxn(parentRoot, block.Header, blockCreator)
+	txn, err := e.BeginBlock(parentRoot, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +145,29 @@ func (e *Executor) StateAt(root types.Hash) (Snapshot, error) {
 	return e.state.NewSnapshotAt(root)
 }
 
-// GetForksInTime returns the active forks at the given block height
-func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
-	return e.config.Forks.At(blockNumber)
+// GetForksInTime returns the active forks at the given block height and timestamp
+func (e *Executor) GetForksInTime(blockNumber, timestamp uint64) chain.ForksInTime {
+	return e.config.Forks.At(blockNumber, timestamp)
+}
+
+// BeginBlock starts the transition of a block being built or processed,
+// the contracts activated at the block are initialized before its transactions.
+// The calls and the queries of the state use BeginTxn, which leaves it untouched
+func (e *Executor) BeginBlock(
+	parentRoot types.Hash,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	txn, err := e.BeginTxn(parentRoot, header, coinbaseReceiver)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Number != 0 {
+		e.activateRuntimes(header.Number, txn.state)
+	}
+
+	return txn, nil
 }
 
 func (e *Executor) BeginTxn(
@@ -137,7 +175,7 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	config := e.config.Forks.At(header.Number)
+	config := e.config.Forks.At(header.Number, header.Timestamp)
 
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
@@ -188,6 +226,9 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// done is closed once the caller gives up on the execution, nil if it can't be cancelled
+	done <-chan struct{}
+
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
@@ -246,6 +287,18 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
+	// the allow lists only restrict the transactions of the blocks,
+	// the calls and the system queries are not checked
+	if err := allowlist.CheckTransaction(
+		t.r.config.Precompiles,
+		uint64(t.ctx.Number),
+		t,
+		txn.From,
+		txn.IsContractCreation(),
+	); err != nil {
+		return NewTransitionApplicationError(err, false)
+	}
+
 	// Make a local copy and apply the transaction
 	msg := txn.Copy()
 
@@ -289,6 +342,10 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		receipt.ContractAddress = crypto.CreateAddress(msg.From, txn.Nonce).Ptr()
 	}
 
+	if t.r.StoreRevertReason && result.Reverted() {
+		receipt.RevertReason = result.ReturnValue
+	}
+
 	// Set the receipt logs and create a bloom for filtering
 	receipt.Logs = logs
 	receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
@@ -297,6 +354,52 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+// WithContext interrupts the executions of the transition once the context is done
+func (t *Transition) WithContext(ctx context.Context) {
+	t.done = ctx.Done()
+}
+
+// Cancelled checks if the context of the transition is done
+func (t *Transition) Cancelled() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithStateOverride applies the overridden accounts to the state of the transition
+func (t *Transition) WithStateOverride(override types.StateOverride) error {
+	for addr, account := range override {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both state and state diff overrides", addr)
+		}
+
+		if account.Nonce != nil {
+			t.state.SetNonce(addr, *account.Nonce)
+		}
+
+		if account.Balance != nil {
+			t.state.SetBalance(addr, account.Balance)
+		}
+
+		if account.Code != nil {
+			t.state.SetCode(addr, account.Code)
+		}
+
+		if account.State != nil {
+			t.state.SetFullState(addr, account.State)
+		}
+
+		for key, value := range account.StateDiff {
+			t.state.SetState(addr, key, value)
+		}
+	}
+
+	return nil
+}
+
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash) {
 	s2, root := t.state.Commit(t.config.EIP155)
@@ -399,6 +502,10 @@ func (e *TransitionApplicationError) Error() string {
 	return e.Err.Error()
 }
 
+func (e *TransitionApplicationError) Unwrap() error {
+	return e.Err
+}
+
 func NewTransitionApplicationError(err error, isRecoverable bool) *TransitionApplicationError {
 	return &TransitionApplicationError{
 		Err:           err,
@@ -518,6 +625,12 @@ func (t *Transition) Call2(
 }
 
 func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+	if t.Cancelled() {
+		return &runtime.ExecutionResult{
+			Err: runtime.ErrExecutionCancelled,
+		}
+	}
+
 	for _, r := range t.r.runtimes {
 		if r.CanRun(contract, host, &t.config) {
 			return r.Run(contract, host, &t.config)
@@ -608,6 +721,19 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
+	// Contracts can only be created on behalf of an allowed deployer
+	if err := allowlist.CheckDeployment(
+		t.r.config.Precompiles,
+		uint64(t.ctx.Number),
+		t,
+		c.Origin,
+	); err != nil {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     err,
+		}
+	}
+
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
@@ -713,6 +839,10 @@ func (t *Transition) GetBalance(addr types.Address) *big.Int {
 	return t.state.GetBalance(addr)
 }
 
+func (t *Transition) AddBalance(addr types.Address, balance *big.Int) {
+	t.state.AddBalance(addr, balance)
+}
+
 func (t *Transition) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	return t.state.GetState(addr, key)
 }
@@ -13,6 +13,9 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// recordPreimages enables the recording of the trie key preimages on commit
+	recordPreimages bool
 }
 
 func NewState(storage Storage) *State {
@@ -26,6 +29,13 @@ func NewState(storage Storage) *State {
 	return s
 }
 
+// RecordPreimages enables the recording of the addresses and storage slots hashed to
+// the trie keys when the tries are committed, so the state can be walked by address.
+// The keys committed before it's enabled have no preimage (see GetPreimage)
+func (s *State) RecordPreimages() {
+	s.recordPreimages = true
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
 	t := NewTrie()
 	t.state = s
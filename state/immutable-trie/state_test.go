@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/hashicorp/go-hclog"
 )
 
 func TestState(t *testing.T) {
@@ -17,3 +18,21 @@ func buildPreState(pre state.PreStates) (state.State, state.Snapshot) {
 
 	return st, snap
 }
+
+func TestState_Pebble(t *testing.T) {
+	state.TestState(t, func(pre state.PreStates) (state.State, state.Snapshot) {
+		storage, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			storage.Close()
+		})
+
+		st := NewState(storage)
+		snap := st.NewSnapshot()
+
+		return st, snap
+	})
+}
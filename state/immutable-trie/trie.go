@@ -163,6 +163,11 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					} else {
 						vv := ar1.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
 						localTxn.Insert(k, vv.MarshalTo(nil))
+
+						if t.state.recordPreimages {
+							// record the slot, so the state can be dumped by slot
+							batch.Put(preimageKey(k), entry.Key)
+						}
 					}
 				}
 
@@ -182,8 +187,14 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			k := hashit(obj.Address.Bytes())
+			tt.Insert(k, data)
 			arena.Reset()
+
+			if t.state.recordPreimages {
+				// record the address, so the state can be dumped by address
+				batch.Put(preimageKey(k), obj.Address.Bytes())
+			}
 		}
 	}
 
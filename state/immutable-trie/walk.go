@@ -0,0 +1,115 @@
+package itrie
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// preimagePrefix is the prefix of the trie key preimages for leveldb
+	preimagePrefix = []byte("preimage")
+)
+
+// WalkFn is called for every key and value of a trie. The key is the hashed trie key
+type WalkFn func(key, value []byte) error
+
+// WalkTrie calls fn for every key and value of the trie with the given root, in key order
+func WalkTrie(storage Storage, root types.Hash, fn WalkFn) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("state not found at hash %s", root)
+	}
+
+	return walkNode(storage, node, nil, fn)
+}
+
+func walkNode(storage Storage, node Node, prefix []byte, fn WalkFn) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %s not found", hex.EncodeToHex(n.buf))
+			}
+
+			return walkNode(storage, nc, prefix, fn)
+		}
+
+		return fn(hexNibblesToBytes(prefix), n.buf)
+
+	case *ShortNode:
+		return walkNode(storage, n.child, appendNibbles(prefix, n.key...), fn)
+
+	case *FullNode:
+		// the value has the shortest key
+		if err := walkNode(storage, n.value, prefix, fn); err != nil {
+			return err
+		}
+
+		for i, child := range n.children {
+			if err := walkNode(storage, child, appendNibbles(prefix, byte(i)), fn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// appendNibbles appends the nibbles to a copy of the prefix,
+// so the sibling nodes don't share the prefix backing array
+func appendNibbles(prefix []byte, nibbles ...byte) []byte {
+	res := make([]byte, 0, len(prefix)+len(nibbles))
+	res = append(res, prefix...)
+
+	return append(res, nibbles...)
+}
+
+// hexNibblesToBytes packs the nibbles into bytes, ignoring the terminator flag
+func hexNibblesToBytes(nibbles []byte) []byte {
+	if hasTerminator(nibbles) {
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	res := make([]byte, len(nibbles)/2)
+	for i := range res {
+		res[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return res
+}
+
+func preimageKey(hash []byte) []byte {
+	return append(append([]byte{}, preimagePrefix...), hash...)
+}
+
+// GetPreimage returns the account address or the storage slot hashed to the trie key.
+// The preimages are recorded when the trie is committed, if the state records them
+func GetPreimage(storage Storage, hash []byte) ([]byte, bool) {
+	preimage, ok := storage.Get(preimageKey(hash))
+	if !ok || len(preimage) == 0 {
+		return nil, false
+	}
+
+	return preimage, true
+}
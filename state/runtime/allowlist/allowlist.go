@@ -0,0 +1,332 @@
+package allowlist
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Names of the allow lists in the genesis configuration
+const (
+	ContractDeployerAllowListName = "contractDeployerAllowList"
+	TransactionAllowListName      = "transactionAllowList"
+)
+
+var (
+	// ContractDeployerAllowListAddr is the address of the contract deployer allow list
+	ContractDeployerAllowListAddr = types.StringToAddress("0x0200000000000000000000000000000000000000")
+
+	// TransactionAllowListAddr is the address of the transaction allow list
+	TransactionAllowListAddr = types.StringToAddress("0x0200000000000000000000000000000000000002")
+)
+
+const (
+	// ReadAllowListCost is the gas cost of reading the role of an address
+	ReadAllowListCost uint64 = 2600
+
+	// ModifyAllowListCost is the gas cost of changing the role of an address
+	ModifyAllowListCost uint64 = 20000
+)
+
+// Role is the permission level of an address in an allow list
+type Role uint64
+
+const (
+	// NoRole is the role of an address which is not in the list
+	NoRole Role = iota
+	// EnabledRole is the role of an address allowed to use the guarded functionality
+	EnabledRole
+	// AdminRole is the role of an address allowed to use the guarded functionality
+	// and to change the role of other addresses
+	AdminRole
+)
+
+// Enabled returns true if the role permits the guarded functionality
+func (r Role) Enabled() bool {
+	return r == EnabledRole || r == AdminRole
+}
+
+func (r Role) String() string {
+	switch r {
+	case NoRole:
+		return "none"
+	case EnabledRole:
+		return "enabled"
+	case AdminRole:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// Hash returns the storage representation of the role
+func (r Role) Hash() types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(uint64(r)).Bytes())
+}
+
+var (
+	// ErrTransactionNotAllowed is returned when the sender is not enabled in the transaction allow list
+	ErrTransactionNotAllowed = errors.New("sender is not allowed to send transactions by the transaction allow list")
+
+	// ErrDeploymentNotAllowed is returned when the sender is not enabled in the contract deployer allow list
+	ErrDeploymentNotAllowed = errors.New("sender is not allowed to deploy contracts by the contract deployer allow list")
+)
+
+var (
+	errNoFunctionSignature = errors.New("input is too short to contain a function signature")
+	errFunctionNotFound    = errors.New("function not found")
+)
+
+var (
+	readAllowListMethod = abis.AllowListABI.GetMethod("readAllowList")
+	setAdminMethod      = abis.AllowListABI.GetMethod("setAdmin")
+	setEnabledMethod    = abis.AllowListABI.GetMethod("setEnabled")
+	setNoneMethod       = abis.AllowListABI.GetMethod("setNone")
+)
+
+// StorageReader is the state an allow list reads the roles from
+type StorageReader interface {
+	GetStorage(addr types.Address, key types.Hash) types.Hash
+}
+
+// AllowList is a stateful precompiled contract keeping a role per address.
+// The roles are stored in the storage of the contract itself, so they
+// can be managed on-chain by the admins through the contract ABI
+type AllowList struct {
+	addr   types.Address
+	config *chain.PrecompileConfig
+}
+
+// NewAllowList creates an allow list living at the given address
+func NewAllowList(addr types.Address, config *chain.PrecompileConfig) *AllowList {
+	return &AllowList{
+		addr:   addr,
+		config: config,
+	}
+}
+
+// Address returns the address of the allow list contract
+func (a *AllowList) Address() types.Address {
+	return a.addr
+}
+
+// Configure writes the roles defined in the genesis configuration
+func (a *AllowList) Configure(state runtime.ActivationState) {
+	// the contract account must not be empty, otherwise it is
+	// cleaned up together with its storage
+	if state.GetNonce(a.addr) == 0 {
+		state.SetNonce(a.addr, 1)
+	}
+
+	for _, addr := range a.config.EnabledAddresses {
+		state.SetState(a.addr, roleKey(addr), EnabledRole.Hash())
+	}
+
+	for _, addr := range a.config.AdminAddresses {
+		state.SetState(a.addr, roleKey(addr), AdminRole.Hash())
+	}
+}
+
+// Run executes a call to the allow list
+func (a *AllowList) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) ([]byte, error) {
+	if len(c.Input) < 4 {
+		return nil, errNoFunctionSignature
+	}
+
+	sig, input := c.Input[:4], c.Input[4:]
+
+	var method *abi.Method
+
+	for _, m := range []*abi.Method{readAllowListMethod, setAdminMethod, setEnabledMethod, setNoneMethod} {
+		if bytes.Equal(sig, m.ID()) {
+			method = m
+
+			break
+		}
+	}
+
+	if method == nil {
+		return nil, errFunctionNotFound
+	}
+
+	addr, err := decodeAddress(method, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if method == readAllowListMethod {
+		if err := UseGas(c, ReadAllowListCost); err != nil {
+			return nil, err
+		}
+
+		return GetRole(host, a.addr, addr).Hash().Bytes(), nil
+	}
+
+	if err := UseGas(c, ModifyAllowListCost); err != nil {
+		return nil, err
+	}
+
+	if c.Static {
+		return nil, runtime.ErrWriteProtection
+	}
+
+	// only admins can change the roles
+	if GetRole(host, a.addr, c.Caller) != AdminRole {
+		return nil, runtime.ErrNotAuth
+	}
+
+	var role Role
+
+	switch method {
+	case setAdminMethod:
+		role = AdminRole
+	case setEnabledMethod:
+		role = EnabledRole
+	case setNoneMethod:
+		role = NoRole
+	}
+
+	host.SetStorage(a.addr, roleKey(addr), role.Hash(), config)
+
+	return nil, nil
+}
+
+// CheckTransaction verifies that the allow lists active at the given block
+// permit the sender to send a transaction, or to deploy a contract
+func CheckTransaction(
+	precompiles map[string]*chain.PrecompileConfig,
+	block uint64,
+	state StorageReader,
+	from types.Address,
+	isContractCreation bool,
+) error {
+	if precompiles[TransactionAllowListName].Active(block) &&
+		!GetRole(state, TransactionAllowListAddr, from).Enabled() {
+		return ErrTransactionNotAllowed
+	}
+
+	if isContractCreation {
+		return CheckDeployment(precompiles, block, state, from)
+	}
+
+	return nil
+}
+
+// CheckDeployment verifies that the contract deployer allow list
+// active at the given block permits the address to deploy contracts
+func CheckDeployment(
+	precompiles map[string]*chain.PrecompileConfig,
+	block uint64,
+	state StorageReader,
+	from types.Address,
+) error {
+	if precompiles[ContractDeployerAllowListName].Active(block) &&
+		!GetRole(state, ContractDeployerAllowListAddr, from).Enabled() {
+		return ErrDeploymentNotAllowed
+	}
+
+	return nil
+}
+
+// parentStateReader reads the allow lists of a block from the state of its parent
+type parentStateReader struct {
+	precompiles map[string]*chain.PrecompileConfig
+	block       uint64
+	parent      StorageReader
+}
+
+// NewParentStateReader returns the reader of the allow lists of the given block,
+// whose state isn't built yet, from the state of its parent.
+// The allow lists activated at the block are only configured when the block
+// is processed, so their roles are read from their configuration
+func NewParentStateReader(
+	precompiles map[string]*chain.PrecompileConfig,
+	block uint64,
+	parent StorageReader,
+) StorageReader {
+	return &parentStateReader{
+		precompiles: precompiles,
+		block:       block,
+		parent:      parent,
+	}
+}
+
+func (r *parentStateReader) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	for name, listAddr := range map[string]types.Address{
+		TransactionAllowListName:      TransactionAllowListAddr,
+		ContractDeployerAllowListName: ContractDeployerAllowListAddr,
+	} {
+		config := r.precompiles[name]
+		if addr != listAddr || !config.Active(r.block) || (r.block > 0 && config.Active(r.block-1)) {
+			continue
+		}
+
+		// the admin role is written last by Configure
+		role := NoRole
+
+		for _, enabled := range config.EnabledAddresses {
+			if roleKey(enabled) == key {
+				role = EnabledRole
+			}
+		}
+
+		for _, admin := range config.AdminAddresses {
+			if roleKey(admin) == key {
+				role = AdminRole
+			}
+		}
+
+		return role.Hash()
+	}
+
+	return r.parent.GetStorage(addr, key)
+}
+
+// GetRole returns the role of the address in the allow list living at listAddr
+func GetRole(state StorageReader, listAddr, addr types.Address) Role {
+	value := state.GetStorage(listAddr, roleKey(addr))
+
+	return Role(new(big.Int).SetBytes(value.Bytes()).Uint64())
+}
+
+// UseGas deducts the given cost from the gas available to the contract
+func UseGas(c *runtime.Contract, cost uint64) error {
+	if c.Gas < cost {
+		return runtime.ErrOutOfGas
+	}
+
+	c.Gas -= cost
+
+	return nil
+}
+
+func roleKey(addr types.Address) types.Hash {
+	return types.BytesToHash(addr.Bytes())
+}
+
+func decodeAddress(method *abi.Method, input []byte) (types.Address, error) {
+	decoded, err := abi.Decode(method.Inputs, input)
+	if err != nil {
+		return types.ZeroAddress, runtime.ErrInvalidInput
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return types.ZeroAddress, runtime.ErrInvalidInput
+	}
+
+	addr, ok := args["addr"].(ethgo.Address)
+	if !ok {
+		return types.ZeroAddress, runtime.ErrInvalidInput
+	}
+
+	return types.Address(addr), nil
+}
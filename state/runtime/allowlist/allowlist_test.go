@@ -0,0 +1,234 @@
+package allowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	adminAddr   = types.StringToAddress("1")
+	enabledAddr = types.StringToAddress("2")
+	otherAddr   = types.StringToAddress("3")
+)
+
+// mockHost keeps the storage in memory, the rest of runtime.Host is not used by the allow list
+type mockHost struct {
+	runtime.Host
+	storage map[types.Address]map[types.Hash]types.Hash
+	nonces  map[types.Address]uint64
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+		nonces:  map[types.Address]uint64{},
+	}
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[addr][key]
+}
+
+func (m *mockHost) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	m.SetState(addr, key, value)
+
+	return runtime.StorageModified
+}
+
+func (m *mockHost) SetState(addr types.Address, key, value types.Hash) {
+	if _, ok := m.storage[addr]; !ok {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][key] = value
+}
+
+func (m *mockHost) SetNonce(addr types.Address, nonce uint64) {
+	m.nonces[addr] = nonce
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func newTestAllowList(host *mockHost) *AllowList {
+	list := NewAllowList(ContractDeployerAllowListAddr, &chain.PrecompileConfig{
+		AdminAddresses:   []types.Address{adminAddr},
+		EnabledAddresses: []types.Address{enabledAddr},
+	})
+	list.Configure(host)
+
+	return list
+}
+
+func encodeCall(t *testing.T, method string, addr types.Address) []byte {
+	t.Helper()
+
+	var (
+		input []byte
+		err   error
+	)
+
+	switch method {
+	case "readAllowList":
+		input, err = readAllowListMethod.Encode([]interface{}{ethgo.Address(addr)})
+	case "setAdmin":
+		input, err = setAdminMethod.Encode([]interface{}{ethgo.Address(addr)})
+	case "setEnabled":
+		input, err = setEnabledMethod.Encode([]interface{}{ethgo.Address(addr)})
+	case "setNone":
+		input, err = setNoneMethod.Encode([]interface{}{ethgo.Address(addr)})
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return input
+}
+
+func TestAllowList_Configure(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	newTestAllowList(host)
+
+	assert.Equal(t, AdminRole, GetRole(host, ContractDeployerAllowListAddr, adminAddr))
+	assert.Equal(t, EnabledRole, GetRole(host, ContractDeployerAllowListAddr, enabledAddr))
+	assert.Equal(t, NoRole, GetRole(host, ContractDeployerAllowListAddr, otherAddr))
+
+	// the contract account is not empty so its storage survives
+	assert.Equal(t, uint64(1), host.GetNonce(ContractDeployerAllowListAddr))
+}
+
+func TestAllowList_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		caller       types.Address
+		method       string
+		target       types.Address
+		gas          uint64
+		static       bool
+		expectedErr  error
+		expectedRole Role
+	}{
+		{
+			name:         "should read the role of an address",
+			caller:       otherAddr,
+			method:       "readAllowList",
+			target:       enabledAddr,
+			gas:          ReadAllowListCost,
+			expectedRole: EnabledRole,
+		},
+		{
+			name:         "should let an admin enable an address",
+			caller:       adminAddr,
+			method:       "setEnabled",
+			target:       otherAddr,
+			gas:          ModifyAllowListCost,
+			expectedRole: EnabledRole,
+		},
+		{
+			name:         "should let an admin add another admin",
+			caller:       adminAddr,
+			method:       "setAdmin",
+			target:       otherAddr,
+			gas:          ModifyAllowListCost,
+			expectedRole: AdminRole,
+		},
+		{
+			name:         "should let an admin remove an address",
+			caller:       adminAddr,
+			method:       "setNone",
+			target:       enabledAddr,
+			gas:          ModifyAllowListCost,
+			expectedRole: NoRole,
+		},
+		{
+			name:         "should not let an enabled address modify the list",
+			caller:       enabledAddr,
+			method:       "setEnabled",
+			target:       otherAddr,
+			gas:          ModifyAllowListCost,
+			expectedErr:  runtime.ErrNotAuth,
+			expectedRole: NoRole,
+		},
+		{
+			name:         "should not modify the list in a static call",
+			caller:       adminAddr,
+			method:       "setEnabled",
+			target:       otherAddr,
+			gas:          ModifyAllowListCost,
+			static:       true,
+			expectedErr:  runtime.ErrWriteProtection,
+			expectedRole: NoRole,
+		},
+		{
+			name:         "should fail without enough gas",
+			caller:       adminAddr,
+			method:       "setEnabled",
+			target:       otherAddr,
+			gas:          ModifyAllowListCost - 1,
+			expectedErr:  runtime.ErrOutOfGas,
+			expectedRole: NoRole,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newMockHost()
+			list := newTestAllowList(host)
+
+			c := runtime.NewContractCall(
+				1,
+				tt.caller,
+				tt.caller,
+				ContractDeployerAllowListAddr,
+				big.NewInt(0),
+				tt.gas,
+				nil,
+				encodeCall(t, tt.method, tt.target),
+			)
+			c.Static = tt.static
+
+			ret, err := list.Run(c, host, &chain.ForksInTime{})
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedRole, GetRole(host, ContractDeployerAllowListAddr, tt.target))
+
+			if tt.method == "readAllowList" {
+				assert.Equal(t, tt.expectedRole.Hash().Bytes(), ret)
+			}
+		})
+	}
+}
+
+func TestAllowList_UnknownFunction(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	list := newTestAllowList(host)
+
+	c := runtime.NewContractCall(1, adminAddr, adminAddr, ContractDeployerAllowListAddr,
+		big.NewInt(0), ModifyAllowListCost, nil, []byte{0x1, 0x2, 0x3, 0x4})
+
+	_, err := list.Run(c, host, &chain.ForksInTime{})
+	assert.ErrorIs(t, err, errFunctionNotFound)
+}
@@ -24,7 +24,9 @@ func newMockContract(value *big.Int, gas uint64, code []byte) *runtime.Contract
 
 // mockHost is a struct which meets the requirements of runtime.Host interface but throws panic in each methods
 // we don't test all opcodes in this test
-type mockHost struct{}
+type mockHost struct {
+	cancelled bool
+}
 
 func (m *mockHost) AccountExists(addr types.Address) bool {
 	panic("Not implemented in tests")
@@ -47,6 +49,10 @@ func (m *mockHost) GetBalance(addr types.Address) *big.Int {
 	panic("Not implemented in tests")
 }
 
+func (m *mockHost) AddBalance(addr types.Address, balance *big.Int) {
+	panic("Not implemented in tests")
+}
+
 func (m *mockHost) GetCodeSize(addr types.Address) int {
 	panic("Not implemented in tests")
 }
@@ -87,6 +93,10 @@ func (m *mockHost) GetNonce(addr types.Address) uint64 {
 	panic("Not implemented in tests")
 }
 
+func (m *mockHost) Cancelled() bool {
+	return m.cancelled
+}
+
 func TestRun(t *testing.T) {
 	t.Parallel()
 
@@ -169,3 +179,21 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRun_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	// the contract loops until it runs out of gas
+	code := []byte{JUMPDEST, PUSH1, 0x00, JUMP}
+
+	res := NewEVM().Run(
+		newMockContract(big.NewInt(0), 1000000, code),
+		&mockHost{cancelled: true},
+		&chain.ForksInTime{},
+	)
+
+	assert.Equal(t, &runtime.ExecutionResult{
+		GasLeft: 0,
+		Err:     errCancelled,
+	}, res)
+}
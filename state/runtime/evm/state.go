@@ -35,11 +35,15 @@ func releaseState(s *state) {
 
 const stackSize = 1024
 
+// cancelCheckInterval is the number of instructions executed between the checks of the cancellation
+const cancelCheckInterval = 1024
+
 var (
 	errOutOfGas              = runtime.ErrOutOfGas
 	errStackUnderflow        = runtime.ErrStackUnderflow
 	errStackOverflow         = runtime.ErrStackOverflow
 	errRevert                = runtime.ErrExecutionReverted
+	errCancelled             = runtime.ErrExecutionCancelled
 	errGasUintOverflow       = errors.New("gas uint64 overflow")
 	errWriteProtection       = errors.New("write protection")
 	errInvalidJump           = errors.New("invalid jump destination")
@@ -213,13 +217,21 @@ func (c *state) Run() ([]byte, error) {
 	var vmerr error
 
 	codeSize := len(c.code)
-	for !c.stop {
+	for steps := 1; !c.stop; steps++ {
 		if c.ip >= codeSize {
 			c.halt()
 
 			break
 		}
 
+		// the cancellation is checked periodically, as the loops of a contract
+		// can take a while before running out of gas
+		if steps%cancelCheckInterval == 0 && c.host.Cancelled() {
+			c.exit(errCancelled)
+
+			break
+		}
+
 		op := OpCode(c.code[c.ip])
 
 		inst := dispatchTable[op]
@@ -0,0 +1,93 @@
+package minter
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// NativeMinterName is the name of the native minter in the genesis configuration
+const NativeMinterName = "nativeMinter"
+
+// NativeMinterAddr is the address of the native minter
+var NativeMinterAddr = types.StringToAddress("0x0200000000000000000000000000000000000001")
+
+// MintCost is the gas cost of minting native coins
+const MintCost uint64 = 30000
+
+var mintNativeCoinMethod = abis.NativeMinterABI.GetMethod("mintNativeCoin")
+
+// NativeMinter is a stateful precompiled contract which mints native coins
+// to any address. Only the addresses enabled in its allow list can mint,
+// and its admins manage the list through the allow list functions
+type NativeMinter struct {
+	*allowlist.AllowList
+}
+
+// NewNativeMinter creates the native minter from its genesis configuration
+func NewNativeMinter(config *chain.PrecompileConfig) *NativeMinter {
+	return &NativeMinter{
+		AllowList: allowlist.NewAllowList(NativeMinterAddr, config),
+	}
+}
+
+// Run executes a call to the native minter
+func (m *NativeMinter) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) ([]byte, error) {
+	if len(c.Input) < 4 || !bytes.Equal(c.Input[:4], mintNativeCoinMethod.ID()) {
+		// not a mint, it is meant for the allow list
+		return m.AllowList.Run(c, host, config)
+	}
+
+	if err := allowlist.UseGas(c, MintCost); err != nil {
+		return nil, err
+	}
+
+	if c.Static {
+		return nil, runtime.ErrWriteProtection
+	}
+
+	if !allowlist.GetRole(host, NativeMinterAddr, c.Caller).Enabled() {
+		return nil, runtime.ErrNotAuth
+	}
+
+	to, amount, err := decodeMint(c.Input[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	host.AddBalance(to, amount)
+
+	return nil, nil
+}
+
+func decodeMint(input []byte) (types.Address, *big.Int, error) {
+	decoded, err := abi.Decode(mintNativeCoinMethod.Inputs, input)
+	if err != nil {
+		return types.ZeroAddress, nil, runtime.ErrInvalidInput
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return types.ZeroAddress, nil, runtime.ErrInvalidInput
+	}
+
+	to, ok := args["addr"].(ethgo.Address)
+	if !ok {
+		return types.ZeroAddress, nil, runtime.ErrInvalidInput
+	}
+
+	amount, ok := args["amount"].(*big.Int)
+	if !ok {
+		return types.ZeroAddress, nil, runtime.ErrInvalidInput
+	}
+
+	return types.Address(to), amount, nil
+}
@@ -0,0 +1,435 @@
+package precompiled
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// Gas schedule of the BLS12-381 precompiles as defined in the EIP-2537 draft
+// implementing nine precompiles at 0x0a - 0x12
+const (
+	blsG1AddGas          uint64 = 600
+	blsG1MulGas          uint64 = 12000
+	blsG2AddGas          uint64 = 4500
+	blsG2MulGas          uint64 = 55000
+	blsPairingBaseGas    uint64 = 115000
+	blsPairingPerPairGas uint64 = 23000
+	blsMapG1Gas          uint64 = 5500
+	blsMapG2Gas          uint64 = 110000
+)
+
+// Sizes of the encoded BLS12-381 values.
+// A field element is padded from 48 to 64 bytes
+const (
+	blsFieldSize   = 64
+	blsFpSize      = 48
+	blsG1PointSize = 2 * blsFieldSize
+	blsG2PointSize = 4 * blsFieldSize
+	blsScalarSize  = 32
+)
+
+// blsMultiExpDiscount is the discount table of the multi exponentiations, per number of pairs.
+// The draft applies the same table to G1 and G2, the last discount is kept above 128 pairs
+var blsMultiExpDiscount = [128]uint64{
+	1200, 888, 764, 641, 594, 547, 500, 453, 438, 423, 408, 394, 379, 364, 349, 334,
+	330, 326, 322, 318, 314, 310, 306, 302, 298, 294, 289, 285, 281, 277, 273, 269,
+	268, 266, 265, 263, 262, 260, 259, 257, 256, 254, 253, 251, 250, 248, 247, 245,
+	244, 242, 241, 239, 238, 236, 235, 233, 232, 231, 229, 228, 226, 225, 223, 222,
+	221, 220, 219, 219, 218, 217, 216, 216, 215, 214, 213, 213, 212, 211, 211, 210,
+	209, 208, 208, 207, 206, 205, 205, 204, 203, 202, 202, 201, 200, 199, 199, 198,
+	197, 196, 196, 195, 194, 193, 193, 192, 191, 191, 190, 189, 188, 188, 187, 186,
+	185, 185, 184, 183, 182, 182, 181, 180, 179, 179, 178, 177, 176, 176, 175, 174,
+}
+
+var (
+	errBLSInvalidInputLength  = errors.New("invalid input length")
+	errBLSInvalidFieldPadding = errors.New("invalid field element top bytes")
+	errBLSG1PointSubgroup     = errors.New("g1 point is not on correct subgroup")
+	errBLSG2PointSubgroup     = errors.New("g2 point is not on correct subgroup")
+)
+
+// blsMultiExpGas returns the gas cost of a multi exponentiation of k pairs
+func blsMultiExpGas(k int, mulGas uint64) uint64 {
+	if k == 0 {
+		return 0
+	}
+
+	discount := blsMultiExpDiscount[len(blsMultiExpDiscount)-1]
+	if k <= len(blsMultiExpDiscount) {
+		discount = blsMultiExpDiscount[k-1]
+	}
+
+	return uint64(k) * mulGas * discount / 1000
+}
+
+type blsG1Add struct{}
+
+func (b *blsG1Add) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsG1AddGas
+}
+
+func (b *blsG1Add) run(input []byte) ([]byte, error) {
+	if len(input) != 2*blsG1PointSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	p0, err := decodeG1Point(input[:blsG1PointSize])
+	if err != nil {
+		return nil, err
+	}
+
+	p1, err := decodeG1Point(input[blsG1PointSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	g := bls12381.NewG1()
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	return encodeG1Point(g, r), nil
+}
+
+type blsG1Mul struct{}
+
+func (b *blsG1Mul) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsG1MulGas
+}
+
+func (b *blsG1Mul) run(input []byte) ([]byte, error) {
+	if len(input) != blsG1PointSize+blsScalarSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	p0, err := decodeG1Point(input[:blsG1PointSize])
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(big.Int).SetBytes(input[blsG1PointSize:])
+
+	g := bls12381.NewG1()
+	r := g.New()
+	g.MulScalarBig(r, p0, e)
+
+	return encodeG1Point(g, r), nil
+}
+
+type blsG1MultiExp struct{}
+
+func (b *blsG1MultiExp) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsMultiExpGas(len(input)/(blsG1PointSize+blsScalarSize), blsG1MulGas)
+}
+
+func (b *blsG1MultiExp) run(input []byte) ([]byte, error) {
+	pairSize := blsG1PointSize + blsScalarSize
+
+	k := len(input) / pairSize
+	if len(input) == 0 || len(input)%pairSize != 0 {
+		return nil, errBLSInvalidInputLength
+	}
+
+	points := make([]*bls12381.PointG1, k)
+	scalars := make([]*big.Int, k)
+
+	for i := 0; i < k; i++ {
+		offset := i * pairSize
+
+		p, err := decodeG1Point(input[offset : offset+blsG1PointSize])
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = p
+		scalars[i] = new(big.Int).SetBytes(input[offset+blsG1PointSize : offset+pairSize])
+	}
+
+	g := bls12381.NewG1()
+	r := g.New()
+
+	if _, err := g.MultiExpBig(r, points, scalars); err != nil {
+		return nil, err
+	}
+
+	return encodeG1Point(g, r), nil
+}
+
+type blsG2Add struct{}
+
+func (b *blsG2Add) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsG2AddGas
+}
+
+func (b *blsG2Add) run(input []byte) ([]byte, error) {
+	if len(input) != 2*blsG2PointSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	p0, err := decodeG2Point(input[:blsG2PointSize])
+	if err != nil {
+		return nil, err
+	}
+
+	p1, err := decodeG2Point(input[blsG2PointSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	g := bls12381.NewG2()
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	return encodeG2Point(g, r), nil
+}
+
+type blsG2Mul struct{}
+
+func (b *blsG2Mul) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsG2MulGas
+}
+
+func (b *blsG2Mul) run(input []byte) ([]byte, error) {
+	if len(input) != blsG2PointSize+blsScalarSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	p0, err := decodeG2Point(input[:blsG2PointSize])
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(big.Int).SetBytes(input[blsG2PointSize:])
+
+	g := bls12381.NewG2()
+	r := g.New()
+	g.MulScalarBig(r, p0, e)
+
+	return encodeG2Point(g, r), nil
+}
+
+type blsG2MultiExp struct{}
+
+func (b *blsG2MultiExp) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsMultiExpGas(len(input)/(blsG2PointSize+blsScalarSize), blsG2MulGas)
+}
+
+func (b *blsG2MultiExp) run(input []byte) ([]byte, error) {
+	pairSize := blsG2PointSize + blsScalarSize
+
+	k := len(input) / pairSize
+	if len(input) == 0 || len(input)%pairSize != 0 {
+		return nil, errBLSInvalidInputLength
+	}
+
+	points := make([]*bls12381.PointG2, k)
+	scalars := make([]*big.Int, k)
+
+	for i := 0; i < k; i++ {
+		offset := i * pairSize
+
+		p, err := decodeG2Point(input[offset : offset+blsG2PointSize])
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = p
+		scalars[i] = new(big.Int).SetBytes(input[offset+blsG2PointSize : offset+pairSize])
+	}
+
+	g := bls12381.NewG2()
+	r := g.New()
+
+	if _, err := g.MultiExpBig(r, points, scalars); err != nil {
+		return nil, err
+	}
+
+	return encodeG2Point(g, r), nil
+}
+
+type blsPairing struct{}
+
+func (b *blsPairing) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsPairingBaseGas + blsPairingPerPairGas*uint64(len(input)/(blsG1PointSize+blsG2PointSize))
+}
+
+func (b *blsPairing) run(input []byte) ([]byte, error) {
+	pairSize := blsG1PointSize + blsG2PointSize
+
+	k := len(input) / pairSize
+	if len(input) == 0 || len(input)%pairSize != 0 {
+		return nil, errBLSInvalidInputLength
+	}
+
+	e := bls12381.NewEngine()
+
+	for i := 0; i < k; i++ {
+		offset := i * pairSize
+
+		p1, err := decodeG1Point(input[offset : offset+blsG1PointSize])
+		if err != nil {
+			return nil, err
+		}
+
+		p2, err := decodeG2Point(input[offset+blsG1PointSize : offset+pairSize])
+		if err != nil {
+			return nil, err
+		}
+
+		// the points must be in the prime order subgroups
+		if !e.G1.InCorrectSubgroup(p1) {
+			return nil, errBLSG1PointSubgroup
+		}
+
+		if !e.G2.InCorrectSubgroup(p2) {
+			return nil, errBLSG2PointSubgroup
+		}
+
+		e.AddPair(p1, p2)
+	}
+
+	if e.Check() {
+		return trueBytes, nil
+	}
+
+	return falseBytes, nil
+}
+
+type blsMapG1 struct{}
+
+func (b *blsMapG1) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsMapG1Gas
+}
+
+func (b *blsMapG1) run(input []byte) ([]byte, error) {
+	if len(input) != blsFieldSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	fe, err := decodeBLSField(input)
+	if err != nil {
+		return nil, err
+	}
+
+	g := bls12381.NewG1()
+
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeG1Point(g, r), nil
+}
+
+type blsMapG2 struct{}
+
+func (b *blsMapG2) gas(input []byte, config *chain.ForksInTime) uint64 {
+	return blsMapG2Gas
+}
+
+func (b *blsMapG2) run(input []byte) ([]byte, error) {
+	if len(input) != 2*blsFieldSize {
+		return nil, errBLSInvalidInputLength
+	}
+
+	fe, err := decodeBLSField2(input)
+	if err != nil {
+		return nil, err
+	}
+
+	g := bls12381.NewG2()
+
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeG2Point(g, r), nil
+}
+
+// decodeBLSField strips the padding of a 64 bytes field element
+func decodeBLSField(in []byte) ([]byte, error) {
+	padding := blsFieldSize - blsFpSize
+
+	for _, b := range in[:padding] {
+		if b != 0 {
+			return nil, errBLSInvalidFieldPadding
+		}
+	}
+
+	return in[padding:], nil
+}
+
+// decodeBLSField2 decodes the c0 || c1 encoding of an extension field element
+// into the c1 || c0 encoding of the library
+func decodeBLSField2(in []byte) ([]byte, error) {
+	c0, err := decodeBLSField(in[:blsFieldSize])
+	if err != nil {
+		return nil, err
+	}
+
+	c1, err := decodeBLSField(in[blsFieldSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 2*blsFpSize)
+	copy(out[:blsFpSize], c1)
+	copy(out[blsFpSize:], c0)
+
+	return out, nil
+}
+
+func decodeG1Point(in []byte) (*bls12381.PointG1, error) {
+	x, err := decodeBLSField(in[:blsFieldSize])
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBLSField(in[blsFieldSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return bls12381.NewG1().FromBytes(append(append([]byte{}, x...), y...))
+}
+
+func decodeG2Point(in []byte) (*bls12381.PointG2, error) {
+	x, err := decodeBLSField2(in[:2*blsFieldSize])
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBLSField2(in[2*blsFieldSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return bls12381.NewG2().FromBytes(append(x, y...))
+}
+
+func encodeG1Point(g *bls12381.G1, p *bls12381.PointG1) []byte {
+	raw := g.ToBytes(p)
+	out := make([]byte, blsG1PointSize)
+
+	// left pad each coordinate to 64 bytes
+	copy(out[blsFieldSize-blsFpSize:blsFieldSize], raw[:blsFpSize])
+	copy(out[2*blsFieldSize-blsFpSize:], raw[blsFpSize:])
+
+	return out
+}
+
+func encodeG2Point(g *bls12381.G2, p *bls12381.PointG2) []byte {
+	raw := g.ToBytes(p)
+	out := make([]byte, blsG2PointSize)
+
+	// the library encodes each extension field element as c1 || c0,
+	// reorder them to c0 || c1 while left padding to 64 bytes
+	for i, j := range []int{1, 0, 3, 2} {
+		copy(out[(i+1)*blsFieldSize-blsFpSize:(i+1)*blsFieldSize], raw[j*blsFpSize:(j+1)*blsFpSize])
+	}
+
+	return out
+}
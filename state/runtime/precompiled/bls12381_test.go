@@ -0,0 +1,131 @@
+package precompiled
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func testBLSFixture(t *testing.T, path string, b contract) {
+	t.Helper()
+
+	ReadTestCase(t, path, func(t *testing.T, c *TestCase) {
+		t.Helper()
+
+		out, err := b.run(c.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(c.Expected, out) {
+			t.Fatal("bad")
+		}
+
+		assert.Equal(t, c.Gas, b.gas(c.Input, &chain.ForksInTime{EIP2537: true}))
+	})
+}
+
+func TestBLSG1Add(t *testing.T) {
+	testBLSFixture(t, "blsG1Add.json", &blsG1Add{})
+}
+
+func TestBLSG1Mul(t *testing.T) {
+	testBLSFixture(t, "blsG1Mul.json", &blsG1Mul{})
+}
+
+func TestBLSG1MultiExp(t *testing.T) {
+	testBLSFixture(t, "blsG1MultiExp.json", &blsG1MultiExp{})
+}
+
+func TestBLSG2Add(t *testing.T) {
+	testBLSFixture(t, "blsG2Add.json", &blsG2Add{})
+}
+
+func TestBLSG2Mul(t *testing.T) {
+	testBLSFixture(t, "blsG2Mul.json", &blsG2Mul{})
+}
+
+func TestBLSG2MultiExp(t *testing.T) {
+	testBLSFixture(t, "blsG2MultiExp.json", &blsG2MultiExp{})
+}
+
+func TestBLSPairing(t *testing.T) {
+	testBLSFixture(t, "blsPairing.json", &blsPairing{})
+}
+
+func TestBLSMapG1(t *testing.T) {
+	testBLSFixture(t, "blsMapG1.json", &blsMapG1{})
+}
+
+func TestBLSMapG2(t *testing.T) {
+	testBLSFixture(t, "blsMapG2.json", &blsMapG2{})
+}
+
+func testBLSFailFixture(t *testing.T, path string, b contract) {
+	t.Helper()
+
+	ReadTestCase(t, path, func(t *testing.T, c *TestCase) {
+		t.Helper()
+
+		out, err := b.run(c.Input)
+		assert.Nil(t, out)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, c.ExpectedError, err.Error())
+		}
+	})
+}
+
+func TestBLSFailures(t *testing.T) {
+	t.Parallel()
+
+	for path, b := range map[string]contract{
+		"fail-blsG1Add.json":      &blsG1Add{},
+		"fail-blsG1Mul.json":      &blsG1Mul{},
+		"fail-blsG1MultiExp.json": &blsG1MultiExp{},
+		"fail-blsG2Add.json":      &blsG2Add{},
+		"fail-blsG2Mul.json":      &blsG2Mul{},
+		"fail-blsG2MultiExp.json": &blsG2MultiExp{},
+		"fail-blsPairing.json":    &blsPairing{},
+		"fail-blsMapG1.json":      &blsMapG1{},
+		"fail-blsMapG2.json":      &blsMapG2{},
+	} {
+		path, b := path, b
+
+		t.Run(path, func(t *testing.T) {
+			testBLSFailFixture(t, path, b)
+		})
+	}
+}
+
+func TestBLSMultiExpGas(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint64(0), blsMultiExpGas(0, blsG1MulGas))
+	assert.Equal(t, uint64(14400), blsMultiExpGas(1, blsG1MulGas))
+	assert.Equal(t, uint64(97680), blsMultiExpGas(2, blsG2MulGas))
+	// the discount is capped after 128 pairs
+	assert.Equal(t, uint64(417600), blsMultiExpGas(200, blsG1MulGas))
+}
+
+func TestBLSForkActivation(t *testing.T) {
+	t.Parallel()
+
+	p := NewPrecompiled()
+
+	for _, addr := range []types.Address{
+		blsG1AddAddr, blsG1MulAddr, blsG1MultiExpAddr,
+		blsG2AddAddr, blsG2MulAddr, blsG2MultiExpAddr,
+		blsPairingAddr, blsMapG1Addr, blsMapG2Addr,
+	} {
+		c := &runtime.Contract{CodeAddress: addr}
+
+		assert.False(t, p.CanRun(c, nil, &chain.ForksInTime{Istanbul: true}))
+		assert.True(t, p.CanRun(c, nil, &chain.ForksInTime{Istanbul: true, EIP2537: true}))
+	}
+}
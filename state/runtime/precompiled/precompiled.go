@@ -8,7 +8,10 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-var _ runtime.Runtime = &Precompiled{}
+var (
+	_ runtime.Runtime   = &Precompiled{}
+	_ runtime.Activator = &Precompiled{}
+)
 
 type contract interface {
 	gas(input []byte, config *chain.ForksInTime) uint64
@@ -19,6 +22,7 @@ type contract interface {
 type Precompiled struct {
 	buf       []byte
 	contracts map[types.Address]contract
+	stateful  map[types.Address]*statefulEntry
 }
 
 // NewPrecompiled creates a new runtime for the precompiled contracts
@@ -43,6 +47,17 @@ func (p *Precompiled) setupContracts() {
 
 	// Istanbul fork
 	p.register("9", &blake2f{p})
+
+	// EIP-2537 fork
+	p.register("a", &blsG1Add{})
+	p.register("b", &blsG1Mul{})
+	p.register("c", &blsG1MultiExp{})
+	p.register("d", &blsG2Add{})
+	p.register("e", &blsG2Mul{})
+	p.register("f", &blsG2MultiExp{})
+	p.register("10", &blsPairing{})
+	p.register("11", &blsMapG1{})
+	p.register("12", &blsMapG2{})
 }
 
 func (p *Precompiled) register(addrStr string, b contract) {
@@ -59,10 +74,24 @@ var (
 	seven = types.StringToAddress("7")
 	eight = types.StringToAddress("8")
 	nine  = types.StringToAddress("9")
+
+	blsG1AddAddr      = types.StringToAddress("a")
+	blsG1MulAddr      = types.StringToAddress("b")
+	blsG1MultiExpAddr = types.StringToAddress("c")
+	blsG2AddAddr      = types.StringToAddress("d")
+	blsG2MulAddr      = types.StringToAddress("e")
+	blsG2MultiExpAddr = types.StringToAddress("f")
+	blsPairingAddr    = types.StringToAddress("10")
+	blsMapG1Addr      = types.StringToAddress("11")
+	blsMapG2Addr      = types.StringToAddress("12")
 )
 
 // CanRun implements the runtime interface
-func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
+func (p *Precompiled) CanRun(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) bool {
+	if entry, ok := p.stateful[c.CodeAddress]; ok {
+		return entry.config.Active(uint64(host.GetTxContext().Number))
+	}
+
 	if _, ok := p.contracts[c.CodeAddress]; !ok {
 		return false
 	}
@@ -85,6 +114,14 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 		return config.Istanbul
 	}
 
+	// EIP-2537 precompiles
+	switch c.CodeAddress {
+	case blsG1AddAddr, blsG1MulAddr, blsG1MultiExpAddr,
+		blsG2AddAddr, blsG2MulAddr, blsG2MultiExpAddr,
+		blsPairingAddr, blsMapG1Addr, blsMapG2Addr:
+		return config.EIP2537
+	}
+
 	return true
 }
 
@@ -94,7 +131,11 @@ func (p *Precompiled) Name() string {
 }
 
 // Run runs an execution
-func (p *Precompiled) Run(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+func (p *Precompiled) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	if entry, ok := p.stateful[c.CodeAddress]; ok {
+		return p.runStateful(entry, c, host, config)
+	}
+
 	contract := p.contracts[c.CodeAddress]
 	gasCost := contract.gas(c.Input, config)
 
@@ -0,0 +1,104 @@
+package precompiled
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/minter"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// StatefulContract is a native contract which, unlike the stateless precompiles,
+// has access to the execution host, the call context and its gas
+type StatefulContract interface {
+	// Address returns the address the contract lives at
+	Address() types.Address
+
+	// Configure initializes the state of the contract on activation
+	Configure(state runtime.ActivationState)
+
+	// Run executes the call. The contract deducts the gas it uses from c.Gas
+	Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) ([]byte, error)
+}
+
+// StatefulFactory creates a stateful contract from its genesis configuration
+type StatefulFactory func(config *chain.PrecompileConfig) StatefulContract
+
+// statefulFactories is the registry of the stateful contracts,
+// keyed by their name in the genesis configuration
+var statefulFactories = map[string]StatefulFactory{
+	allowlist.ContractDeployerAllowListName: func(config *chain.PrecompileConfig) StatefulContract {
+		return allowlist.NewAllowList(allowlist.ContractDeployerAllowListAddr, config)
+	},
+	allowlist.TransactionAllowListName: func(config *chain.PrecompileConfig) StatefulContract {
+		return allowlist.NewAllowList(allowlist.TransactionAllowListAddr, config)
+	},
+	minter.NativeMinterName: func(config *chain.PrecompileConfig) StatefulContract {
+		return minter.NewNativeMinter(config)
+	},
+}
+
+type statefulEntry struct {
+	contract StatefulContract
+	config   *chain.PrecompileConfig
+}
+
+// SetupStateful registers the stateful contracts enabled in the genesis configuration
+func (p *Precompiled) SetupStateful(configs map[string]*chain.PrecompileConfig) error {
+	for name, config := range configs {
+		factory, ok := statefulFactories[name]
+		if !ok {
+			return fmt.Errorf("stateful precompile '%s' not found", name)
+		}
+
+		if config == nil {
+			return fmt.Errorf("stateful precompile '%s' has no configuration", name)
+		}
+
+		contract := factory(config)
+
+		if p.stateful == nil {
+			p.stateful = map[types.Address]*statefulEntry{}
+		}
+
+		p.stateful[contract.Address()] = &statefulEntry{
+			contract: contract,
+			config:   config,
+		}
+	}
+
+	return nil
+}
+
+// Activate implements the runtime.Activator interface
+func (p *Precompiled) Activate(block uint64, state runtime.ActivationState) {
+	for _, entry := range p.stateful {
+		if !entry.config.Block.IsTimestamp() && entry.config.Block.Block == block {
+			entry.contract.Configure(state)
+		}
+	}
+}
+
+func (p *Precompiled) runStateful(
+	entry *statefulEntry,
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) *runtime.ExecutionResult {
+	returnValue, err := entry.contract.Run(c, host, config)
+
+	result := &runtime.ExecutionResult{
+		ReturnValue: returnValue,
+		GasLeft:     c.Gas,
+		Err:         err,
+	}
+
+	if result.Failed() {
+		result.GasLeft = 0
+		result.ReturnValue = nil
+	}
+
+	return result
+}
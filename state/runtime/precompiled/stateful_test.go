@@ -0,0 +1,161 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/minter"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	minterAdmin = types.StringToAddress("100")
+	receiver    = types.StringToAddress("200")
+)
+
+func newStatefulTestExecutor(
+	t *testing.T,
+	configs map[string]*chain.PrecompileConfig,
+) (*state.Executor, types.Hash) {
+	t.Helper()
+
+	p := NewPrecompiled()
+	if err := p.SetupStateful(configs); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := state.NewExecutor(
+		&chain.Params{Forks: chain.AllForksEnabled, ChainID: 100, Precompiles: configs},
+		itrie.NewState(itrie.NewMemoryStorage()),
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(p)
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	root := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		minterAdmin: {Balance: big.NewInt(0)},
+	})
+
+	return executor, root
+}
+
+func mintTx(t *testing.T, nonce uint64, amount int64) *types.Transaction {
+	t.Helper()
+
+	input, err := abis.NativeMinterABI.GetMethod("mintNativeCoin").Encode(
+		[]interface{}{ethgo.Address(receiver), big.NewInt(amount)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &types.Transaction{
+		From:     minterAdmin,
+		To:       &minter.NativeMinterAddr,
+		Value:    big.NewInt(0),
+		Input:    input,
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+		Nonce:    nonce,
+	}
+}
+
+func TestStateful_SetupUnknown(t *testing.T) {
+	t.Parallel()
+
+	err := NewPrecompiled().SetupStateful(map[string]*chain.PrecompileConfig{
+		"unknown": {},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestStateful_ActivatedAtGenesis(t *testing.T) {
+	t.Parallel()
+
+	executor, root := newStatefulTestExecutor(t, map[string]*chain.PrecompileConfig{
+		minter.NativeMinterName: {
+			Block:          *chain.NewFork(0),
+			AdminAddresses: []types.Address{minterAdmin},
+		},
+	})
+
+	transition, err := executor.BeginTxn(root, &types.Header{Number: 1, GasLimit: 1000000}, types.ZeroAddress)
+	assert.NoError(t, err)
+
+	// the admin role is seeded from genesis
+	assert.Equal(t, allowlist.AdminRole, allowlist.GetRole(transition, minter.NativeMinterAddr, minterAdmin))
+
+	result, err := transition.Apply(mintTx(t, 0, 1000))
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(1000), transition.GetBalance(receiver))
+}
+
+func TestStateful_ActivatedAtFork(t *testing.T) {
+	t.Parallel()
+
+	executor, root := newStatefulTestExecutor(t, map[string]*chain.PrecompileConfig{
+		minter.NativeMinterName: {
+			Block:          *chain.NewFork(5),
+			AdminAddresses: []types.Address{minterAdmin},
+		},
+	})
+
+	// before the fork the address holds no contract
+	transition, err := executor.BeginTxn(root, &types.Header{Number: 4, GasLimit: 1000000}, types.ZeroAddress)
+	assert.NoError(t, err)
+
+	result, err := transition.Apply(mintTx(t, 0, 1000))
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(0), transition.GetBalance(receiver))
+
+	// the calls at the fork block leave the state untouched
+	transition, err = executor.BeginTxn(root, &types.Header{Number: 5, GasLimit: 1000000}, types.ZeroAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, allowlist.NoRole, allowlist.GetRole(transition, minter.NativeMinterAddr, minterAdmin))
+
+	// the fork block activates the contract and seeds its roles
+	transition, err = executor.BeginBlock(root, &types.Header{Number: 5, GasLimit: 1000000}, types.ZeroAddress)
+	assert.NoError(t, err)
+
+	result, err = transition.Apply(mintTx(t, 0, 1000))
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(1000), transition.GetBalance(receiver))
+}
+
+func TestStateful_NotAuthorized(t *testing.T) {
+	t.Parallel()
+
+	executor, root := newStatefulTestExecutor(t, map[string]*chain.PrecompileConfig{
+		minter.NativeMinterName: {
+			Block: *chain.NewFork(0),
+		},
+	})
+
+	transition, err := executor.BeginTxn(root, &types.Header{Number: 1, GasLimit: 1000000}, types.ZeroAddress)
+	assert.NoError(t, err)
+
+	result, err := transition.Apply(mintTx(t, 0, 1000))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, result.Err, runtime.ErrNotAuth)
+	assert.Equal(t, big.NewInt(0), transition.GetBalance(receiver))
+}
@@ -59,6 +59,7 @@ type Host interface {
 	GetStorage(addr types.Address, key types.Hash) types.Hash
 	SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) StorageStatus
 	GetBalance(addr types.Address) *big.Int
+	AddBalance(addr types.Address, balance *big.Int)
 	GetCodeSize(addr types.Address) int
 	GetCodeHash(addr types.Address) types.Hash
 	GetCode(addr types.Address) []byte
@@ -69,6 +70,8 @@ type Host interface {
 	Callx(*Contract, Host) *ExecutionResult
 	Empty(addr types.Address) bool
 	GetNonce(addr types.Address) uint64
+	// Cancelled checks if the caller gave up on the execution, so it can be interrupted
+	Cancelled() bool
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -107,6 +110,10 @@ var (
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
 	ErrCodeStoreOutOfGas        = errors.New("contract creation code storage out of gas")
+	ErrWriteProtection          = errors.New("write protection")
+	ErrNotAuth                  = errors.New("not authorized")
+	ErrInvalidInput             = errors.New("invalid input")
+	ErrExecutionCancelled       = errors.New("execution cancelled")
 )
 
 type CallType int
@@ -127,6 +134,19 @@ type Runtime interface {
 	Name() string
 }
 
+// ActivationState is the state a runtime can initialize on activation
+type ActivationState interface {
+	SetState(addr types.Address, key, value types.Hash)
+	SetNonce(addr types.Address, nonce uint64)
+	GetNonce(addr types.Address) uint64
+}
+
+// Activator is implemented by runtimes that have to initialize
+// state at the block in which (some of) their contracts get activated
+type Activator interface {
+	Activate(block uint64, state ActivationState)
+}
+
 // Contract is the instance being called
 type Contract struct {
 	Code        []byte
@@ -4,7 +4,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -159,3 +161,165 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyAllowLists(t *testing.T) {
+	t.Parallel()
+
+	// addr1 is enabled in both lists, addr2 only in the transaction allow list
+	precompiles := map[string]*chain.PrecompileConfig{
+		allowlist.TransactionAllowListName: {
+			EnabledAddresses: []types.Address{addr1, addr2},
+		},
+		allowlist.ContractDeployerAllowListName: {
+			EnabledAddresses: []types.Address{addr1},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		from        types.Address
+		to          *types.Address
+		expectedErr error
+	}{
+		{
+			name:        "should allow an enabled sender to transact",
+			from:        addr2,
+			to:          &addr1,
+			expectedErr: ErrNonceIncorrect,
+		},
+		{
+			name:        "should allow an enabled deployer to deploy",
+			from:        addr1,
+			expectedErr: ErrNonceIncorrect,
+		},
+		{
+			name:        "should reject a sender missing from the transaction allow list",
+			from:        types.StringToAddress("3"),
+			to:          &addr1,
+			expectedErr: allowlist.ErrTransactionNotAllowed,
+		},
+		{
+			name:        "should reject a deployer missing from the contract deployer allow list",
+			from:        addr2,
+			expectedErr: allowlist.ErrDeploymentNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(map[types.Address]*PreState{
+				addr1: {Balance: 1000000},
+				addr2: {Balance: 1000000},
+			})
+			transition.r = &Executor{config: &chain.Params{Precompiles: precompiles}}
+
+			for name, config := range precompiles {
+				addr := allowlist.TransactionAllowListAddr
+				if name == allowlist.ContractDeployerAllowListName {
+					addr = allowlist.ContractDeployerAllowListAddr
+				}
+
+				allowlist.NewAllowList(addr, config).Configure(transition.state)
+			}
+
+			// the wrong nonce stops permitted transactions right after the allow list check
+			err := transition.Write(&types.Transaction{
+				From:     tt.from,
+				To:       tt.to,
+				Nonce:    1,
+				Value:    big.NewInt(0),
+				Gas:      100000,
+				GasPrice: big.NewInt(0),
+			})
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+
+	t.Run("should not check the calls and the system queries", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestTransition(nil)
+		transition.r = &Executor{config: &chain.Params{Precompiles: precompiles}}
+
+		allowlist.NewAllowList(
+			allowlist.TransactionAllowListAddr,
+			precompiles[allowlist.TransactionAllowListName],
+		).Configure(transition.state)
+
+		// the system queries are sent from the zero address
+		_, err := transition.apply(&types.Transaction{
+			From:     types.ZeroAddress,
+			To:       &addr1,
+			Nonce:    1,
+			Value:    big.NewInt(0),
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+		})
+
+		assert.ErrorIs(t, err, ErrNonceIncorrect)
+	})
+}
+
+func TestTransition_WithStateOverride(t *testing.T) {
+	t.Parallel()
+
+	nonce := uint64(10)
+
+	t.Run("should override the account fields", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestTransition(map[types.Address]*PreState{
+			addr1: {Balance: 1000},
+			addr2: {Balance: 1000},
+		})
+
+		for _, addr := range []types.Address{addr1, addr2} {
+			transition.state.SetState(addr, hash1, hash1)
+			transition.state.SetState(addr, hash2, hash2)
+		}
+
+		err := transition.WithStateOverride(types.StateOverride{
+			addr1: {
+				Nonce:   &nonce,
+				Balance: big.NewInt(5),
+				Code:    []byte{0x1},
+				State:   map[types.Hash]types.Hash{hash1: hash2},
+			},
+			addr2: {
+				StateDiff: map[types.Hash]types.Hash{hash1: hash2},
+			},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, nonce, transition.GetNonce(addr1))
+		assert.Equal(t, big.NewInt(5), transition.GetBalance(addr1))
+		assert.Equal(t, []byte{0x1}, transition.GetCode(addr1))
+
+		// state replaces the whole storage
+		assert.Equal(t, hash2, transition.GetStorage(addr1, hash1))
+		assert.Equal(t, types.ZeroHash, transition.GetStorage(addr1, hash2))
+
+		// state diff only replaces the given slots
+		assert.Equal(t, hash2, transition.GetStorage(addr2, hash1))
+		assert.Equal(t, hash2, transition.GetStorage(addr2, hash2))
+		assert.Equal(t, big.NewInt(1000), transition.GetBalance(addr2))
+	})
+
+	t.Run("should fail with both state and state diff", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestTransition(nil)
+
+		err := transition.WithStateOverride(types.StateOverride{
+			addr1: {
+				State:     map[types.Hash]types.Hash{hash1: hash2},
+				StateDiff: map[types.Hash]types.Hash{hash1: hash2},
+			},
+		})
+		assert.Error(t, err)
+	})
+}
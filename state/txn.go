@@ -339,6 +339,21 @@ func (txn *Txn) SetState(
 	})
 }
 
+// SetFullState replaces the whole storage of the address
+func (txn *Txn) SetFullState(addr types.Address, storage map[types.Hash]types.Hash) {
+	txn.upsertAccount(addr, true, func(object *StateObject) {
+		object.Account.Root = emptyStateHash
+		object.Account.Trie = txn.state.NewSnapshot()
+		object.Txn = iradix.New().Txn()
+
+		for key, value := range storage {
+			if value != zeroHash {
+				object.Txn.Insert(key.Bytes(), value.Bytes())
+			}
+		}
+	})
+}
+
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
 	object, exists := txn.getStateObject(addr)
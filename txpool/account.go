@@ -209,6 +209,32 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	return
 }
 
+//	rewind aligns the account with a lower nonce, after the chain was rewound.
+//	The promoted transactions are not executable anymore,
+//	so they are moved back to the enqueued queue.
+func (a *account) rewind(nonce uint64) (demoted []*types.Transaction) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	if nonce >= a.getNonce() {
+		return
+	}
+
+	demoted = a.promoted.clear()
+	for _, tx := range demoted {
+		a.enqueued.push(tx)
+	}
+
+	a.setNonce(nonce)
+
+	return
+}
+
 // enqueue attempts tp push the transaction onto the enqueued queue.
 func (a *account) enqueue(tx *types.Transaction) error {
 	a.enqueued.lock(true)
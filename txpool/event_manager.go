@@ -77,8 +77,9 @@ func (em *eventManager) Close() {
 	em.subscriptionsLock.Lock()
 	defer em.subscriptionsLock.Unlock()
 
-	for _, subscription := range em.subscriptions {
+	for id, subscription := range em.subscriptions {
 		subscription.close()
+		delete(em.subscriptions, id)
 	}
 
 	atomic.StoreInt64(&em.numSubscriptions, 0)
@@ -86,6 +87,11 @@ func (em *eventManager) Close() {
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
 func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+	em.signalBlockEvent(eventType, 0, txHashes...)
+}
+
+// signalBlockEvent alerts the listeners of a new TxPool event related to the block
+func (em *eventManager) signalBlockEvent(eventType proto.EventType, blockNumber uint64, txHashes ...types.Hash) {
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
@@ -98,8 +104,9 @@ func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types
 	for _, txHash := range txHashes {
 		for _, subscription := range em.subscriptions {
 			subscription.pushEvent(&proto.TxPoolEvent{
-				Type:   eventType,
-				TxHash: txHash.String(),
+				Type:        eventType,
+				TxHash:      txHash.String(),
+				BlockNumber: blockNumber,
 			})
 		}
 	}
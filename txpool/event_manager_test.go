@@ -73,6 +73,12 @@ func TestEventManager_SubscribeClose(t *testing.T) {
 			t.Fatalf("Subscription channel not closed for index %d", indx)
 		}
 	}
+
+	// Canceling a subscription of the closed event manager is a no-op
+	assert.Len(t, em.subscriptions, 0)
+	assert.NotPanics(t, func() {
+		em.cancelSubscription(subscriptions[0].subscriptionID)
+	})
 }
 
 func TestEventManager_SignalEvent(t *testing.T) {
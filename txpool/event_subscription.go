@@ -35,15 +35,17 @@ func (es *eventSubscription) eventSupported(eventType proto.EventType) bool {
 	return false
 }
 
-// close stops the event subscription
+// close stops the event subscription, the output channel is closed once the run loop exits
 func (es *eventSubscription) close() {
 	close(es.doneCh)
-	close(es.outputCh)
 	close(es.notifyCh)
 }
 
-// runLoop is the main loop that listens for notifications and handles the event / close signals
+// runLoop is the main loop that listens for notifications and handles the event / close signals.
+// It closes the output channel on exit, as it's the only sender
 func (es *eventSubscription) runLoop() {
+	defer close(es.outputCh)
+
 	for {
 		select {
 		case <-es.doneCh: // Break if a close signal has been received
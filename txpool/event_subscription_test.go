@@ -155,6 +155,43 @@ func TestEventSubscription_ProcessedEvents(t *testing.T) {
 	}
 }
 
+func TestEventSubscription_CloseWhileSending(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 100; i++ {
+		subscription := &eventSubscription{
+			eventTypes: []proto.EventType{proto.EventType_ADDED},
+			outputCh:   make(chan *proto.TxPoolEvent),
+			doneCh:     make(chan struct{}),
+			notifyCh:   make(chan struct{}, 1),
+			eventStore: &eventQueue{
+				events: make([]*proto.TxPoolEvent, 0),
+			},
+		}
+		go subscription.runLoop()
+
+		// the run loop is sending the event when the subscription is closed
+		subscription.pushEvent(&proto.TxPoolEvent{Type: proto.EventType_ADDED})
+		subscription.close()
+
+		// the run loop closes the output channel once it exits
+		closedCh := make(chan struct{})
+
+		go func() {
+			for range subscription.outputCh {
+			}
+
+			close(closedCh)
+		}()
+
+		select {
+		case <-closedCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the output channel isn't closed")
+		}
+	}
+}
+
 func TestEventSubscription_EventSupported(t *testing.T) {
 	t.Parallel()
 
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p-core/peer"
 )
 
 var mockHeader = &types.Header{
@@ -41,6 +43,10 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GetStorage(types.Hash, types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
 type faultyMockStore struct {
 }
 
@@ -60,9 +66,34 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GetStorage(types.Hash, types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
 type mockSigner struct {
 }
 
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+type allowListMockStore struct {
+	defaultMockStore
+	storage map[types.Address]map[types.Hash]types.Hash
+}
+
+func (m allowListMockStore) GetStorage(_ types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	return m.storage[addr][slot]
+}
+
+type mockPeerReporter struct {
+	behaviours map[peer.ID][]network.PeerBehaviour
+}
+
+func (m *mockPeerReporter) ReportPeer(peerID peer.ID, behaviour network.PeerBehaviour) {
+	if m.behaviours == nil {
+		m.behaviours = make(map[peer.ID][]network.PeerBehaviour)
+	}
+
+	m.behaviours[peerID] = append(m.behaviours[peerID], behaviour)
+}
@@ -4,15 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"google.golang.org/grpc"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -41,8 +45,19 @@ var (
 	ErrInvalidAccountState = errors.New("invalid account state")
 	ErrAlreadyKnown        = errors.New("already known")
 	ErrOversizedData       = errors.New("oversized data")
+	ErrUnprotectedTx       = errors.New("only replay-protected (EIP-155) transactions are allowed over RPC")
 )
 
+// invalidGossipTxErrors are the errors of transactions which can't become
+// valid in any state, so the peers gossiping them are penalised
+var invalidGossipTxErrors = []error{
+	ErrIntrinsicGas,
+	ErrNegativeValue,
+	ErrExtractSignature,
+	ErrInvalidSender,
+	ErrOversizedData,
+}
+
 // indicates origin of a transaction
 type txOrigin int
 
@@ -70,17 +85,34 @@ type store interface {
 	Header() *types.Header
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
+	GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 }
 
+// storageAt reads the storage of the store at a fixed state root
+type storageAt struct {
+	store store
+	root  types.Hash
+}
+
+func (s *storageAt) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return s.store.GetStorage(s.root, addr, slot)
+}
+
 type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
 type Config struct {
-	PriceLimit uint64
-	MaxSlots   uint64
-	Sealing    bool
+	PriceLimit  uint64
+	MaxSlots    uint64
+	Sealing     bool
+	Precompiles map[string]*chain.PrecompileConfig
+	ChainID     uint64
+
+	// AllowUnprotectedTxs accepts the transactions without a chain ID (pre EIP-155)
+	// from the json-RPC/gRPC endpoints
+	AllowUnprotectedTxs bool
 }
 
 /* All requests are passed to the main loop
@@ -123,7 +155,7 @@ type promoteRequest struct {
 type TxPool struct {
 	logger hclog.Logger
 	signer signer
-	forks  chain.ForksInTime
+	forks  *chain.Forks
 	store  store
 
 	// map of all accounts registered by the pool
@@ -139,12 +171,26 @@ type TxPool struct {
 	// networking stack
 	topic *network.Topic
 
+	// reporter of the behaviour of the peers gossiping transactions
+	peerReporter network.PeerReporter
+
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// chainID is the chain the transactions are signed for
+	chainID uint64
+
+	// allowUnprotectedTxs indicates if the local transactions
+	// can be signed without a chain ID
+	allowUnprotectedTxs bool
+
+	// stateful precompiles of the chain, the allow lists
+	// among them restrict who can send transactions
+	precompiles map[string]*chain.PrecompileConfig
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -170,7 +216,7 @@ type TxPool struct {
 // NewTxPool returns a new pool for processing incoming transactions.
 func NewTxPool(
 	logger hclog.Logger,
-	forks chain.ForksInTime,
+	forks *chain.Forks,
 	store store,
 	grpcServer *grpc.Server,
 	network *network.Server,
@@ -178,16 +224,19 @@ func NewTxPool(
 	config *Config,
 ) (*TxPool, error) {
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		metrics:     metrics,
-		accounts:    accountsMap{},
-		executables: newPricedQueue(),
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		sealing:     config.Sealing,
+		logger:              logger.Named("txpool"),
+		forks:               forks,
+		store:               store,
+		metrics:             metrics,
+		accounts:            accountsMap{},
+		executables:         newPricedQueue(),
+		index:               lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		gauge:               slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit:          config.PriceLimit,
+		chainID:             config.ChainID,
+		allowUnprotectedTxs: config.AllowUnprotectedTxs,
+		precompiles:         config.Precompiles,
+		sealing:             config.Sealing,
 	}
 
 	// Attach the event manager
@@ -195,7 +244,7 @@ func NewTxPool(
 
 	if network != nil {
 		// subscribe to the gossip protocol
-		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
+		topic, err := network.NewTopic(topicNameV1, &proto.Txn{}, pool.validateGossipTx)
 		if err != nil {
 			return nil, err
 		}
@@ -205,6 +254,7 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+		pool.peerReporter = network
 	}
 
 	if grpcServer != nil {
@@ -246,12 +296,33 @@ func (p *TxPool) Close() {
 	p.shutdownCh <- struct{}{}
 }
 
+// SubscribeTxPoolEvents subscribes for the given TxPool events.
+// The events are received on the returned channel
+// until the returned cancel function is called
+func (p *TxPool) SubscribeTxPoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
 // SetSigner sets the signer the pool will use
-// to validate a transaction's signature.
+// to validate a transaction's signature,
+// in place of the signer of the forks of the next block.
 func (p *TxPool) SetSigner(s signer) {
 	p.signer = s
 }
 
+// getSigner returns the signer set on the pool, or the signer of the forks of the next block
+func (p *TxPool) getSigner(forks chain.ForksInTime) signer {
+	if p.signer != nil {
+		return p.signer
+	}
+
+	return crypto.NewSigner(forks, p.chainID)
+}
+
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
@@ -426,11 +497,21 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 func (p *TxPool) processEvent(event *blockchain.Event) {
 	oldTxs := make(map[types.Hash]*types.Transaction)
 
+	// the chain was rewound, the old blocks are deleted
+	rewound := len(event.RemovedBlocks) > 0
+
 	// Legacy reorg logic //
 	for _, header := range event.OldChain {
-		// transactions to be returned to the pool
+		// transactions to be returned to the pool,
+		// the event carries the blocks that are no longer in the store
 		block, ok := p.store.GetBlockByHash(header.Hash, true)
+		if removed, isRemoved := event.GetRemovedBlock(header.Hash); isRemoved {
+			block, ok = removed.Block, true
+		}
+
 		if !ok {
+			p.logger.Error("could not find block in store", "hash", header.Hash.String())
+
 			continue
 		}
 
@@ -455,6 +536,14 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		// remove mined txs from the lookup map
 		p.index.remove(block.Transactions...)
 
+		if len(block.Transactions) > 0 {
+			p.eventManager.signalBlockEvent(
+				proto.EventType_INCLUDED,
+				block.Number(),
+				toHash(block.Transactions...)...,
+			)
+		}
+
 		// etract latest nonces
 		for _, tx := range block.Transactions {
 			addr := tx.From
@@ -476,6 +565,22 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		}
 	}
 
+	// the senders of the deleted blocks may have later transactions in the pool,
+	// so every account is aligned with the state of the new head
+	// before the transactions of the deleted blocks are returned to the pool
+	if rewound {
+		p.accounts.Range(func(key, _ interface{}) bool {
+			addr, _ := key.(types.Address)
+			if _, processed := stateNonces[addr]; !processed {
+				stateNonces[addr] = p.store.GetNonce(stateRoot, addr)
+			}
+
+			return true
+		})
+
+		p.rewindAccounts(stateNonces)
+	}
+
 	// Legacy reorg logic //
 	for _, tx := range oldTxs {
 		if err := p.addTx(reorg, tx); err != nil {
@@ -491,6 +596,19 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	p.resetAccounts(stateNonces)
 }
 
+// nextForks returns the forks of the next block, the one the pool transactions are validated for.
+// Its timestamp isn't known yet, the current time is the closest estimate
+func (p *TxPool) nextForks() chain.ForksInTime {
+	header := p.store.Header()
+
+	timestamp := uint64(time.Now().Unix())
+	if timestamp < header.Timestamp {
+		timestamp = header.Timestamp
+	}
+
+	return p.forks.At(header.Number+1, timestamp)
+}
+
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
 func (p *TxPool) validateTx(tx *types.Transaction) error {
@@ -504,10 +622,12 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrNegativeValue
 	}
 
+	forks := p.nextForks()
+
 	// Check if the transaction is signed properly
 
 	// Extract the sender
-	from, signerErr := p.signer.Sender(tx)
+	from, signerErr := p.getSigner(forks).Sender(tx)
 	if signerErr != nil {
 		return ErrExtractSignature
 	}
@@ -530,7 +650,22 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Grab the state root for the latest block
-	stateRoot := p.store.Header().StateRoot
+	header := p.store.Header()
+	stateRoot := header.StateRoot
+
+	// Check if the allow lists of the next block, the one the transaction
+	// is included in, permit the sender
+	nextBlock := header.Number + 1
+
+	if err := allowlist.CheckTransaction(
+		p.precompiles,
+		nextBlock,
+		allowlist.NewParentStateReader(p.precompiles, nextBlock, &storageAt{store: p.store, root: stateRoot}),
+		tx.From,
+		tx.IsContractCreation(),
+	); err != nil {
+		return err
+	}
 
 	// Check nonce ordering
 	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
@@ -548,7 +683,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, forks.Homestead, forks.Istanbul)
 	if err != nil {
 		return err
 	}
@@ -577,6 +712,12 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		"hash", tx.Hash.String(),
 	)
 
+	// the transactions signed for any chain can be replayed from other chains,
+	// the node only accepts them from its endpoints if the policy allows it
+	if origin == local && !p.allowUnprotectedTxs && !crypto.IsProtected(tx) {
+		return ErrUnprotectedTx
+	}
+
 	// validate incoming tx
 	if err := p.validateTx(tx); err != nil {
 		return err
@@ -659,7 +800,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.sealing {
 		return
 	}
@@ -674,6 +815,7 @@ func (p *TxPool) addGossipTx(obj interface{}) {
 	// Verify that the gossiped transaction message is not empty
 	if raw == nil || raw.Raw == nil {
 		p.logger.Error("malformed gossip transaction message received")
+		p.reportPeer(from, network.InvalidTransaction)
 
 		return
 	}
@@ -683,6 +825,7 @@ func (p *TxPool) addGossipTx(obj interface{}) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.reportPeer(from, network.InvalidTransaction)
 
 		return
 	}
@@ -696,6 +839,83 @@ func (p *TxPool) addGossipTx(obj interface{}) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		for _, invalidErr := range invalidGossipTxErrors {
+			if errors.Is(err, invalidErr) {
+				p.reportPeer(from, network.InvalidTransaction)
+
+				break
+			}
+		}
+
+		return
+	}
+
+	p.reportPeer(from, network.ValidTransaction)
+}
+
+// validateGossipTx runs the cheap checks on the gossiped transaction
+// before it is relayed to other peers, so the invalid transactions don't spread
+func (p *TxPool) validateGossipTx(obj interface{}, from peer.ID) network.ValidationResult {
+	raw, ok := obj.(*proto.Txn)
+	if !ok || raw.Raw == nil {
+		p.reportPeer(from, network.InvalidTransaction)
+
+		return network.ValidationReject
+	}
+
+	if uint64(len(raw.Raw.Value)) > txMaxSize {
+		p.reportPeer(from, network.InvalidTransaction)
+
+		return network.ValidationReject
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+		p.reportPeer(from, network.InvalidTransaction)
+
+		return network.ValidationReject
+	}
+
+	sender, err := p.getSigner(p.nextForks()).Sender(tx)
+	if err != nil {
+		p.reportPeer(from, network.InvalidTransaction)
+
+		return network.ValidationReject
+	}
+
+	// The transaction might have been valid when the peer relayed it,
+	// so it is dropped without penalizing the peer
+	if p.store.GetNonce(p.store.Header().StateRoot, sender) > tx.Nonce {
+		return network.ValidationIgnore
+	}
+
+	return network.ValidationAccept
+}
+
+// reportPeer reports the behaviour of a peer gossiping transactions, if networking is set up
+func (p *TxPool) reportPeer(from peer.ID, behaviour network.PeerBehaviour) {
+	if p.peerReporter != nil {
+		p.peerReporter.ReportPeer(from, behaviour)
+	}
+}
+
+// rewindAccounts lowers the nonces of the accounts after the chain was rewound.
+// The promoted transactions of the rewound accounts are demoted
+// until the missing nonces are filled
+func (p *TxPool) rewindAccounts(stateNonces map[types.Address]uint64) {
+	for addr, nonce := range stateNonces {
+		if !p.accounts.exists(addr) {
+			continue
+		}
+
+		demoted := p.accounts.get(addr).rewind(nonce)
+		if len(demoted) == 0 {
+			continue
+		}
+
+		p.eventManager.signalEvent(proto.EventType_DEMOTED, toHash(demoted...)...)
+		p.metrics.PendingTxs.Add(float64(-1 * len(demoted)))
 	}
 }
 
@@ -8,13 +8,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/state/runtime/allowlist"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -82,7 +86,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 
 	return NewTxPool(
 		hclog.NewNullLogger(),
-		forks.At(0),
+		forks,
 		storeToUse,
 		nil,
 		nil,
@@ -325,6 +329,209 @@ func TestAddTxErrors(t *testing.T) {
 	})
 }
 
+func TestAddTxAllowLists(t *testing.T) {
+	t.Parallel()
+
+	precompiles := map[string]*chain.PrecompileConfig{
+		allowlist.TransactionAllowListName:      {},
+		allowlist.ContractDeployerAllowListName: {},
+	}
+
+	setupPool := func(roles map[types.Address]map[types.Address]allowlist.Role) *TxPool {
+		storage := map[types.Address]map[types.Hash]types.Hash{}
+
+		for list, entries := range roles {
+			storage[list] = map[types.Hash]types.Hash{}
+
+			for addr, role := range entries {
+				storage[list][types.BytesToHash(addr.Bytes())] = role.Hash()
+			}
+		}
+
+		pool, err := newTestPool(allowListMockStore{
+			defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+			storage:          storage,
+		})
+		if err != nil {
+			t.Fatalf("cannot create txpool - err: %v\n", err)
+		}
+
+		pool.SetSigner(&mockSigner{})
+		pool.precompiles = precompiles
+
+		return pool
+	}
+
+	t.Run("ErrTransactionNotAllowed", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(nil)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newTx(addr1, 0, 1)),
+			allowlist.ErrTransactionNotAllowed,
+		)
+	})
+
+	t.Run("ErrDeploymentNotAllowed", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(map[types.Address]map[types.Address]allowlist.Role{
+			allowlist.TransactionAllowListAddr: {addr1: allowlist.EnabledRole},
+		})
+
+		// newTx creates contract deployments
+		assert.ErrorIs(t,
+			pool.addTx(local, newTx(addr1, 0, 1)),
+			allowlist.ErrDeploymentNotAllowed,
+		)
+	})
+
+	t.Run("allowed sender", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(map[types.Address]map[types.Address]allowlist.Role{
+			allowlist.TransactionAllowListAddr:      {addr1: allowlist.AdminRole},
+			allowlist.ContractDeployerAllowListAddr: {addr1: allowlist.EnabledRole},
+		})
+
+		assert.NoError(t, pool.validateTx(newTx(addr1, 0, 1)))
+	})
+
+	t.Run("allow lists activated at the next block", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(nil)
+
+		// the roles aren't in the state of the head yet
+		pool.precompiles = map[string]*chain.PrecompileConfig{
+			allowlist.TransactionAllowListName: {
+				Block:          *chain.NewFork(mockHeader.Number + 1),
+				AdminAddresses: []types.Address{addr1},
+			},
+			allowlist.ContractDeployerAllowListName: {
+				Block:            *chain.NewFork(mockHeader.Number + 1),
+				EnabledAddresses: []types.Address{addr1},
+			},
+		}
+
+		assert.NoError(t, pool.validateTx(newTx(addr1, 0, 1)))
+		assert.ErrorIs(t,
+			pool.validateTx(newTx(addr2, 0, 1)),
+			allowlist.ErrTransactionNotAllowed,
+		)
+	})
+}
+
+func TestAddTxUnprotected(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+
+	unprotectedTx, err := (&crypto.FrontierSigner{}).SignTx(newTx(types.ZeroAddress, 0, 1), key)
+	assert.NoError(t, err)
+
+	protectedTx, err := crypto.NewEIP155Signer(100).SignTx(newTx(types.ZeroAddress, 0, 1), key)
+	assert.NoError(t, err)
+
+	setupPool := func(allowUnprotected bool) *TxPool {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		pool.SetSigner(crypto.NewEIP155Signer(100))
+		pool.allowUnprotectedTxs = allowUnprotected
+
+		return pool
+	}
+
+	t.Run("unprotected local transaction is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(false)
+
+		assert.ErrorIs(t, pool.addTx(local, unprotectedTx.Copy()), ErrUnprotectedTx)
+	})
+
+	t.Run("unprotected local transaction is accepted when allowed", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(true)
+
+		go func() {
+			<-pool.enqueueReqCh
+		}()
+
+		assert.NoError(t, pool.addTx(local, unprotectedTx.Copy()))
+	})
+
+	t.Run("unprotected gossiped transaction is accepted", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(false)
+
+		go func() {
+			<-pool.enqueueReqCh
+		}()
+
+		assert.NoError(t, pool.addTx(gossip, unprotectedTx.Copy()))
+	})
+
+	t.Run("protected local transaction is accepted", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(false)
+
+		go func() {
+			<-pool.enqueueReqCh
+		}()
+
+		assert.NoError(t, pool.addTx(local, protectedTx.Copy()))
+	})
+}
+
+func TestForkSigner(t *testing.T) {
+	t.Parallel()
+
+	key, sender := tests.GenerateKeyAndAddr(t)
+
+	protectedTx, err := crypto.NewEIP155Signer(100).SignTx(newTx(types.ZeroAddress, 0, 1), key)
+	assert.NoError(t, err)
+
+	setupPool := func(eip155 *chain.Fork) *TxPool {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		pool.chainID = 100
+		pool.forks = &chain.Forks{
+			Homestead: chain.NewFork(0),
+			EIP155:    eip155,
+		}
+
+		return pool
+	}
+
+	t.Run("EIP155 signer after the fork", func(t *testing.T) {
+		t.Parallel()
+
+		// the pool validates the transactions for the block after the mock header
+		pool := setupPool(chain.NewFork(mockHeader.Number + 1))
+
+		tx := protectedTx.Copy()
+		tx.From = types.ZeroAddress
+
+		assert.NoError(t, pool.validateTx(tx))
+		assert.Equal(t, sender, tx.From)
+	})
+
+	t.Run("Frontier signer before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(chain.NewFork(mockHeader.Number + 2))
+
+		tx := protectedTx.Copy()
+		tx.From = types.ZeroAddress
+
+		// the Frontier signer doesn't know the chain ID in the signature
+		assert.ErrorIs(t, pool.validateTx(tx), ErrExtractSignature)
+	})
+}
+
 func TestAddGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -353,7 +560,7 @@ func TestAddGossipTx(t *testing.T) {
 					Value: signedTx.MarshalRLP(),
 				},
 			}
-			pool.addGossipTx(protoTx)
+			pool.addGossipTx(protoTx, "")
 		}()
 		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
 
@@ -382,12 +589,171 @@ func TestAddGossipTx(t *testing.T) {
 				Value: signedTx.MarshalRLP(),
 			},
 		}
-		pool.addGossipTx(protoTx)
+		pool.addGossipTx(protoTx, "")
 
 		assert.Equal(t, uint64(0), pool.accounts.get(sender).enqueued.length())
 	})
 }
 
+func TestAddGossipTx_ReportPeer(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(uint64(100))
+	from := peer.ID("gossiper")
+
+	newReportingPool := func(t *testing.T) (*TxPool, *mockPeerReporter) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(signer)
+
+		pool.sealing = true
+
+		reporter := &mockPeerReporter{}
+		pool.peerReporter = reporter
+
+		return pool, reporter
+	}
+
+	t.Run("malformed message", func(t *testing.T) {
+		t.Parallel()
+
+		pool, reporter := newReportingPool(t)
+
+		pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: []byte{0x1, 0x2}}}, from)
+
+		assert.Equal(t, []network.PeerBehaviour{network.InvalidTransaction}, reporter.behaviours[from])
+	})
+
+	t.Run("oversized transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, reporter := newReportingPool(t)
+
+		tx := newTx(types.ZeroAddress, 0, 1)
+		tx.Input = make([]byte, txMaxSize)
+
+		pool.addGossipTx(&proto.Txn{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}, from)
+
+		assert.Equal(t, []network.PeerBehaviour{network.InvalidTransaction}, reporter.behaviours[from])
+	})
+
+	t.Run("valid transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, reporter := newReportingPool(t)
+
+		// a higher nonce keeps the transaction enqueued
+		signedTx, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key)
+		assert.NoError(t, err)
+
+		done := make(chan struct{})
+
+		go func() {
+			pool.addGossipTx(&proto.Txn{
+				Raw: &any.Any{
+					Value: signedTx.MarshalRLP(),
+				},
+			}, from)
+
+			close(done)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		<-done
+
+		assert.Equal(t, []network.PeerBehaviour{network.ValidTransaction}, reporter.behaviours[from])
+	})
+}
+
+func TestValidateGossipTx(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(uint64(100))
+	from := peer.ID("gossiper")
+
+	toProtoTx := func(tx *types.Transaction) *proto.Txn {
+		return &proto.Txn{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}
+	}
+
+	signedTx, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key)
+	assert.NoError(t, err)
+
+	oversizedTx := newTx(types.ZeroAddress, 0, 1)
+	oversizedTx.Input = make([]byte, txMaxSize)
+
+	testTable := []struct {
+		name           string
+		store          store
+		msg            interface{}
+		expectedResult network.ValidationResult
+		expectedReport []network.PeerBehaviour
+	}{
+		{
+			name:           "valid transaction",
+			msg:            toProtoTx(signedTx),
+			expectedResult: network.ValidationAccept,
+		},
+		{
+			name:           "malformed message",
+			msg:            &proto.Txn{Raw: &any.Any{Value: []byte{0x1, 0x2}}},
+			expectedResult: network.ValidationReject,
+			expectedReport: []network.PeerBehaviour{network.InvalidTransaction},
+		},
+		{
+			name:           "oversized transaction",
+			msg:            toProtoTx(oversizedTx),
+			expectedResult: network.ValidationReject,
+			expectedReport: []network.PeerBehaviour{network.InvalidTransaction},
+		},
+		{
+			name:           "unsigned transaction",
+			msg:            toProtoTx(newTx(types.ZeroAddress, 1, 1)),
+			expectedResult: network.ValidationReject,
+			expectedReport: []network.PeerBehaviour{network.InvalidTransaction},
+		},
+		{
+			name: "nonce too low",
+			// faultyMockStore.GetNonce() == 99999
+			store:          faultyMockStore{},
+			msg:            toProtoTx(signedTx),
+			expectedResult: network.ValidationIgnore,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(signer)
+
+			if testCase.store != nil {
+				pool.store = testCase.store
+			}
+
+			reporter := &mockPeerReporter{}
+			pool.peerReporter = reporter
+
+			assert.Equal(t, testCase.expectedResult, pool.validateGossipTx(testCase.msg, from))
+			assert.Equal(t, testCase.expectedReport, reporter.behaviours[from])
+		})
+	}
+}
+
 func TestDropKnownGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -2169,3 +2535,110 @@ func TestGetTxs(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessEvent_Rewind(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	// the account had the nonce 5 at the rewound head
+	account := pool.accounts.initOnce(addr1, 5)
+	account.promoted.push(newTx(addr1, 5, 1))
+	account.promoted.push(newTx(addr1, 6, 1))
+	account.setNonce(7)
+
+	// the rewound blocks held the transactions 0 to 4
+	removed := &types.Block{Header: &types.Header{Number: 1, Hash: types.StringToHash("1")}}
+
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		tx := newTx(addr1, nonce, 1)
+		tx.ComputeHash()
+
+		removed.Transactions = append(removed.Transactions, tx)
+	}
+
+	promotedSubscription := pool.eventManager.subscribe(
+		[]proto.EventType{
+			proto.EventType_PROMOTED,
+		},
+	)
+
+	// the rewound blocks are deleted from the store, the event carries them
+	pool.processEvent(&blockchain.Event{
+		Type:          blockchain.EventReorg,
+		OldChain:      []*types.Header{removed.Header},
+		NewChain:      []*types.Header{mockHeader},
+		RemovedBlocks: []*blockchain.RemovedBlock{{Block: removed}},
+	})
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	// the store returns the nonce 0 for the new head, the returned transactions
+	// fill the gap so the later ones are promoted again
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, 7), 7)
+	assert.Equal(t, uint64(7), account.getNonce())
+	assert.Equal(t, uint64(7), account.promoted.length())
+	assert.Equal(t, uint64(0), account.enqueued.length())
+}
+
+// blockMockStore returns the block for any hash
+type blockMockStore struct {
+	defaultMockStore
+
+	block *types.Block
+}
+
+func (m blockMockStore) GetBlockByHash(types.Hash, bool) (*types.Block, bool) {
+	return m.block, true
+}
+
+func TestProcessEvent_Included(t *testing.T) {
+	t.Parallel()
+
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1),
+		newTx(addr2, 0, 1),
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+	}
+
+	pool, err := newTestPool(blockMockStore{
+		defaultMockStore: NewDefaultMockStore(mockHeader),
+		block: &types.Block{
+			Header:       &types.Header{Number: 3},
+			Transactions: txs,
+		},
+	})
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	subscription := pool.eventManager.subscribe(
+		[]proto.EventType{
+			proto.EventType_INCLUDED,
+		},
+	)
+
+	pool.processEvent(&blockchain.Event{
+		Type:     blockchain.EventHead,
+		NewChain: []*types.Header{mockHeader},
+	})
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	events := waitForEvents(ctx, subscription, len(txs))
+	assert.Len(t, events, len(txs))
+
+	for i, event := range events {
+		assert.Equal(t, txs[i].Hash.String(), event.TxHash)
+		assert.Equal(t, uint64(3), event.BlockNumber)
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import "math/big"
+
+// StateOverride overrides the accounts of the state a call is simulated on
+type StateOverride map[Address]OverrideAccount
+
+// OverrideAccount are the overridden fields of an account, nil fields are left untouched.
+// State replaces the whole storage of the account, while StateDiff only the given slots
+type OverrideAccount struct {
+	Nonce     *uint64
+	Code      []byte
+	Balance   *big.Int
+	State     map[Hash]Hash
+	StateDiff map[Hash]Hash
+}
+
+// BlockOverride overrides the context of the block a call is simulated in
+type BlockOverride struct {
+	Number    *uint64
+	Timestamp *uint64
+	GasLimit  *uint64
+	Coinbase  *Address
+}
+
+// Apply returns a copy of the header with the overridden number, timestamp and gas limit.
+// The coinbase is not part of the header, it replaces the block creator of the header
+func (o *BlockOverride) Apply(header *Header) *Header {
+	h := header.Copy()
+
+	if o == nil {
+		return h
+	}
+
+	if o.Number != nil {
+		h.Number = *o.Number
+	}
+
+	if o.Timestamp != nil {
+		h.Timestamp = *o.Timestamp
+	}
+
+	if o.GasLimit != nil {
+		h.GasLimit = *o.GasLimit
+	}
+
+	return h
+}
@@ -32,6 +32,10 @@ type Receipt struct {
 	GasUsed         uint64
 	ContractAddress *Address
 	TxHash          Hash
+
+	// RevertReason is the return data of a reverted transaction,
+	// only kept when the node is configured to store it
+	RevertReason []byte
 }
 
 func (r *Receipt) SetStatus(s ReceiptStatus) {
@@ -85,5 +85,10 @@ func (r *Receipt) MarshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// TxHash
 	vv.Set(a.NewBytes(r.TxHash.Bytes()))
 
+	// RevertReason is optional to keep the receipts without it compact
+	if len(r.RevertReason) != 0 {
+		vv.Set(a.NewBytes(r.RevertReason))
+	}
+
 	return vv
 }
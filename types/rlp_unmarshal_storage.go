@@ -141,7 +141,7 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 
 	// tx hash
 	// backwards compatibility, old receipts did not marshal a TxHash
-	if len(elems) == 4 {
+	if len(elems) >= 4 {
 		vv, err := elems[3].Bytes()
 		if err != nil {
 			return err
@@ -150,5 +150,15 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		r.TxHash = BytesToHash(vv)
 	}
 
+	// revert reason
+	if len(elems) == 5 {
+		vv, err := elems[4].Bytes()
+		if err != nil {
+			return err
+		}
+
+		r.RevertReason = append([]byte{}, vv...)
+	}
+
 	return nil
 }